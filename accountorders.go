@@ -0,0 +1,139 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AccountOrdersParams page through the orders of an account.
+type AccountOrdersParams struct {
+	Chain          Chain
+	OrderDirection OrderDirection
+	// Cursor is the Next cursor of the previous page.
+	Cursor string
+	Limit  int
+}
+
+// GetAccountListings returns a page of the active listings made by an
+// address or ENS name, across collections; pass its Next cursor in the
+// params to read the following page. Pages may be short, as the cancelled,
+// filled and expired listings are left out.
+func (o Opensea) GetAccountListings(account Address, params AccountOrdersParams) (*SeaportOrdersResponse, error) {
+	ctx := context.TODO()
+	return o.GetAccountListingsWithContext(ctx, account, params)
+}
+
+func (o Opensea) GetAccountListingsWithContext(ctx context.Context, account Address, params AccountOrdersParams) (*SeaportOrdersResponse, error) {
+	return o.accountOrders(ctx, account, params, false)
+}
+
+// AccountOffersParams page through the offers made by an account.
+type AccountOffersParams struct {
+	Chain          Chain
+	OrderDirection OrderDirection
+	// Cursor is the Next cursor of the previous page.
+	Cursor string
+	Limit  int
+	// Collections are the slugs of the collections whose criteria offers by
+	// the account are added to the first page; the orders endpoints only
+	// return item offers. They are read on Chain.
+	Collections []string
+}
+
+// GetAccountOffers returns a page of the active offers made by an address
+// or ENS name; pass its Next cursor in the params to read the following
+// page. The first page also holds the criteria offers of the account on
+// params.Collections, and is marked Truncated when the offers of a large
+// collection were cut at the page limit of the order book reads. Pages may
+// be short, as the cancelled, filled and expired offers are left out.
+func (o Opensea) GetAccountOffers(account Address, params AccountOffersParams) (*SeaportOrdersResponse, error) {
+	ctx := context.TODO()
+	return o.GetAccountOffersWithContext(ctx, account, params)
+}
+
+func (o Opensea) GetAccountOffersWithContext(ctx context.Context, account Address, params AccountOffersParams) (*SeaportOrdersResponse, error) {
+	if account == "" {
+		return nil, fmt.Errorf("Empty account")
+	}
+	maker, err := o.ResolveAddressWithContext(ctx, account.String())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.accountOrders(ctx, maker, AccountOrdersParams{
+		Chain:          params.Chain,
+		OrderDirection: params.OrderDirection,
+		Cursor:         params.Cursor,
+		Limit:          params.Limit,
+	}, true)
+	if err != nil || params.Cursor != "" {
+		return resp, err
+	}
+
+	now := time.Now()
+	for _, slug := range params.Collections {
+		offers, truncated, err := o.collectionOrders(ctx, params.Chain, slug, false)
+		if err != nil {
+			return nil, err
+		}
+		resp.Truncated = resp.Truncated || truncated
+		for _, offer := range offers {
+			if params.Chain != ChainNone && offer.Chain != "" && offer.Chain != params.Chain {
+				continue
+			}
+			if offer.IsCriteriaOffer() && offer.maker() == strings.ToLower(maker.String()) && offer.Active(now) {
+				resp.Orders = append(resp.Orders, offer)
+			}
+		}
+	}
+	return resp, nil
+}
+
+// IsCriteriaOffer reports whether the order is an offer on any item of a
+// collection, a contract or a trait, rather than on given tokens.
+func (o SeaportOrder) IsCriteriaOffer() bool {
+	if o.Criteria != nil {
+		return true
+	}
+	for _, item := range o.ProtocolData.Parameters.Consideration {
+		if item.ItemType == ItemERC721WithCriteria || item.ItemType == ItemERC1155WithCriteria {
+			return true
+		}
+	}
+	return false
+}
+
+// maker returns the lowercased address of the maker of the order.
+func (o SeaportOrder) maker() string {
+	if o.Maker != nil && o.Maker.Address != "" {
+		return strings.ToLower(o.Maker.Address.String())
+	}
+	return strings.ToLower(o.ProtocolData.Parameters.Offerer.String())
+}
+
+func (o Opensea) accountOrders(ctx context.Context, account Address, params AccountOrdersParams, offers bool) (*SeaportOrdersResponse, error) {
+	if account == "" {
+		return nil, fmt.Errorf("Empty account")
+	}
+	resp, err := o.GetSeaportOrdersWithContext(ctx, GetSeaportOrdersParams{
+		Chain:          params.Chain,
+		Offers:         offers,
+		Maker:          account,
+		OrderDirection: params.OrderDirection,
+		Cursor:         params.Cursor,
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	active := resp.Orders[:0]
+	for _, order := range resp.Orders {
+		if order.Active(now) {
+			active = append(active, order)
+		}
+	}
+	resp.Orders = active
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+package opensea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAccountListings(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/orders/ethereum/seaport/listings", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, testBidder.String(), q.Get("maker"))
+		assert.Equal(t, "", q.Get("asset_contract_address"))
+		assert.Equal(t, "p1", q.Get("cursor"))
+		w.Write([]byte(`{"next":"p2","orders":[
+			{"order_hash":"0x1","side":"ask","expiration_time":4102444800},
+			{"order_hash":"0x2","side":"ask","expiration_time":4102444800,"cancelled":true},
+			{"order_hash":"0x3","side":"ask","expiration_time":1600000000},
+			{"order_hash":"0x4","side":"ask","expiration_time":4102444800,"finalized":true}]}`))
+	})
+
+	resp, err := o.GetAccountListings(testBidder, AccountOrdersParams{Cursor: "p1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "p2", resp.Next)
+	assert.Len(t, resp.Orders, 1)
+	assert.Equal(t, "0x1", resp.Orders[0].OrderHash)
+
+	_, err = o.GetAccountListings("", AccountOrdersParams{})
+	assert.NotNil(t, err)
+	_, err = o.GetAccountListings(testBidder, AccountOrdersParams{Chain: ChainSolana})
+	assert.NotNil(t, err)
+}
+
+func TestGetAccountOffers(t *testing.T) {
+	collectionReads := 0
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/orders/ethereum/seaport/offers":
+			assert.Equal(t, testBidder.String(), r.URL.Query().Get("maker"))
+			w.Write([]byte(`{"next":"p2","orders":[
+				{"order_hash":"0x1","side":"bid","expiration_time":4102444800},
+				{"order_hash":"0x2","side":"bid","expiration_time":4102444800,"cancelled":true}]}`))
+		case "/api/v2/offers/collection/doodles-official/all":
+			collectionReads++
+			w.Write([]byte(`{"offers":[
+				{"order_hash":"0x3","criteria":{"collection":{"slug":"doodles-official"}},
+					"protocol_data":{"parameters":{"offerer":"` + testBidder.String() + `","endTime":"4102444800"}}},
+				{"order_hash":"0x4","criteria":{"collection":{"slug":"doodles-official"}},
+					"protocol_data":{"parameters":{"offerer":"0x4444444444444444444444444444444444444444","endTime":"4102444800"}}},
+				{"order_hash":"0x5","protocol_data":{"parameters":{"offerer":"` + testBidder.String() + `","endTime":"4102444800",
+					"consideration":[{"itemType":2,"token":"` + contract + `","identifierOrCriteria":"1"}]}}},
+				{"order_hash":"0x6","protocol_data":{"parameters":{"offerer":"` + testBidder.String() + `","endTime":"4102444800",
+					"consideration":[{"itemType":4,"token":"` + contract + `","identifierOrCriteria":"0"}]}}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	resp, err := o.GetAccountOffers(testBidder, AccountOffersParams{Collections: []string{"doodles-official"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "p2", resp.Next)
+	assert.False(t, resp.Truncated)
+	hashes := []string{}
+	for _, order := range resp.Orders {
+		hashes = append(hashes, order.OrderHash)
+	}
+	assert.Equal(t, []string{"0x1", "0x3", "0x6"}, hashes)
+	assert.False(t, resp.Orders[0].IsCriteriaOffer())
+	assert.True(t, resp.Orders[2].IsCriteriaOffer())
+
+	resp, err = o.GetAccountOffers(testBidder, AccountOffersParams{Cursor: "p2", Collections: []string{"doodles-official"}})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Orders, 1)
+	assert.Equal(t, 1, collectionReads)
+
+	_, err = o.GetAccountOffers("", AccountOffersParams{})
+	assert.NotNil(t, err)
+}
+
+func TestGetAccountOffersChain(t *testing.T) {
+	chains := map[string]Chain{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/offers/collection/doodles-official/all" {
+			w.Write([]byte(`{"orders":[]}`))
+			return
+		}
+		w.Write([]byte(`{"next":"more","offers":[
+			{"order_hash":"0x1","chain":"matic","criteria":{"collection":{"slug":"doodles-official"}},
+				"protocol_data":{"parameters":{"offerer":"` + testBidder.String() + `","endTime":"4102444800"}}},
+			{"order_hash":"0x2","chain":"ethereum","criteria":{"collection":{"slug":"doodles-official"}},
+				"protocol_data":{"parameters":{"offerer":"` + testBidder.String() + `","endTime":"4102444800"}}}]}`))
+	}, WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := RequestInfoFromContext(req.Context())
+			chains[req.URL.Path] = info.Chain
+			return next.RoundTrip(req)
+		})
+	}))
+
+	resp, err := o.GetAccountOffers(testBidder, AccountOffersParams{Chain: ChainPolygon, Collections: []string{"doodles-official"}})
+	assert.Nil(t, err)
+	assert.Equal(t, ChainPolygon, chains["/api/v2/offers/collection/doodles-official/all"])
+	assert.True(t, resp.Truncated)
+	assert.Len(t, resp.Orders, maxOrderBookPages)
+	for _, order := range resp.Orders {
+		assert.Equal(t, "0x1", order.OrderHash)
+	}
+}
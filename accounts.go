@@ -0,0 +1,280 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAccountCacheTTL is how long resolved accounts are reused.
+	DefaultAccountCacheTTL = time.Hour
+	// DefaultAccountConcurrency is the number of account lookups
+	// ResolveAccounts runs at once.
+	DefaultAccountConcurrency = 4
+)
+
+type cachedAccount struct {
+	account *Account
+	expires time.Time
+}
+
+// AccountCache keeps resolved accounts, including the addresses OpenSea has
+// no account for, for a TTL. It is safe for concurrent use and may be shared
+// between clients.
+type AccountCache struct {
+	ttl      time.Duration
+	mu       sync.Mutex
+	accounts map[Address]cachedAccount
+}
+
+func NewAccountCache(ttl time.Duration) *AccountCache {
+	return &AccountCache{ttl: ttl, accounts: map[Address]cachedAccount{}}
+}
+
+// get returns the cached account of address, nil for addresses without
+// account, and whether it is cached at all.
+func (c *AccountCache) get(address Address, now time.Time) (*Account, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached, ok := c.accounts[address]
+	if !ok || now.After(cached.expires) {
+		delete(c.accounts, address)
+		return nil, false
+	}
+	return cached.account, true
+}
+
+func (c *AccountCache) put(address Address, account *Account, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accounts[address] = cachedAccount{account: account, expires: now.Add(c.ttl)}
+}
+
+// WithAccountCache makes ResolveAccounts reuse the accounts of cache.
+func WithAccountCache(cache *AccountCache) Option {
+	return func(o *Opensea) {
+		o.accounts = cache
+	}
+}
+
+// WithAccountConcurrency sets the number of account lookups ResolveAccounts
+// runs at once.
+func WithAccountConcurrency(n int) Option {
+	return func(o *Opensea) {
+		o.accountConcurrency = n
+	}
+}
+
+// AccountV2 is an account of the v2 accounts endpoint. Account converts it
+// to the v1 model.
+type AccountV2 struct {
+	Address         Address `json:"address" bson:"address"`
+	Username        string  `json:"username" bson:"username"`
+	ProfileImageURL string  `json:"profile_image_url" bson:"profile_image_url"`
+	Config          string  `json:"config" bson:"config"`
+}
+
+func (a AccountV2) Account() *Account {
+	return &Account{
+		Address:       a.Address,
+		User:          User{Username: a.Username},
+		ProfileImgURL: a.ProfileImageURL,
+		Config:        a.Config,
+		APIVersion:    APIv2,
+	}
+}
+
+// AccountV2 converts a to the v2 model.
+func (a Account) AccountV2() *AccountV2 {
+	return &AccountV2{
+		Address:         a.Address,
+		Username:        a.User.Username,
+		ProfileImageURL: a.ProfileImgURL,
+		Config:          a.Config,
+	}
+}
+
+// GetAccount returns the account of an address, an ENS name or an OpenSea
+// username, with its username, profile image and verification status. It
+// returns ErrNotFound when OpenSea has no such account.
+func (o Opensea) GetAccount(addressOrUsername string) (*Account, error) {
+	ctx := context.TODO()
+	return o.GetAccountWithContext(ctx, addressOrUsername)
+}
+
+func (o Opensea) GetAccountWithContext(ctx context.Context, addressOrUsername string) (*Account, error) {
+	if addressOrUsername == "" {
+		return nil, fmt.Errorf("Empty address or username")
+	}
+	key := addressOrUsername
+	if IsENSName(key) || IsHexAddress(key) {
+		address, err := o.ResolveAddressWithContext(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		key = address.String()
+	}
+	now := time.Now()
+	if account, ok := o.accounts.get(Address(key), now); ok {
+		if account == nil {
+			return nil, ErrNotFound
+		}
+		return account, nil
+	}
+
+	account, err := o.getAccountV2(ctx, key)
+	if err == ErrNotFound && IsHexAddress(key) {
+		o.accounts.put(Address(key), nil, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if address, err := ParseAddress(account.Address.String()); err == nil {
+		o.accounts.put(address, account, now)
+	}
+	return account, nil
+}
+
+func (o Opensea) ResolveAccounts(addresses []Address) (map[Address]Account, error) {
+	ctx := context.TODO()
+	return o.ResolveAccountsWithContext(ctx, addresses)
+}
+
+// ResolveAccountsWithContext looks up the usernames and profile images of
+// addresses, e.g. to render an activity feed. Addresses are looked up once,
+// concurrently, and those without an OpenSea account are left out of the
+// result. Keys are lower-case addresses, ENS names being resolved.
+func (o Opensea) ResolveAccountsWithContext(ctx context.Context, addresses []Address) (map[Address]Account, error) {
+	ret := map[Address]Account{}
+	now := time.Now()
+	lookups := []Address{}
+	seen := map[Address]bool{}
+	for _, a := range addresses {
+		address, err := o.ResolveAddressWithContext(ctx, a.String())
+		if err != nil {
+			return nil, err
+		}
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		if account, ok := o.accounts.get(address, now); ok {
+			if account != nil {
+				ret[address] = *account
+			}
+			continue
+		}
+		lookups = append(lookups, address)
+	}
+
+	concurrency := o.accountConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultAccountConcurrency
+	}
+	var mu sync.Mutex
+	jobs := make([]func(ctx context.Context) error, len(lookups))
+	for i, address := range lookups {
+		address := address
+		jobs[i] = func(ctx context.Context) error {
+			account, err := o.getAccountV2(ctx, address.String())
+			if err == ErrNotFound {
+				account, err = nil, nil
+			}
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			o.accounts.put(address, account, now)
+			if account != nil {
+				ret[address] = *account
+			}
+			return nil
+		}
+	}
+	if err := runConcurrently(ctx, concurrency, jobs); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// CollectionHolding summarizes the assets an account holds in a collection.
+type CollectionHolding struct {
+	Slug  string `json:"slug" bson:"slug"`
+	Name  string `json:"name" bson:"name"`
+	Owned int64  `json:"owned" bson:"owned"`
+	// Floor is the current floor price in the native currency, and Value
+	// the owned assets at the floor.
+	Floor float64 `json:"floor" bson:"floor"`
+	Value float64 `json:"value" bson:"value"`
+}
+
+func (o Opensea) GetAccountCollectionSummary(address Address) ([]CollectionHolding, error) {
+	ctx := context.TODO()
+	return o.GetAccountCollectionSummaryWithContext(ctx, address)
+}
+
+// GetAccountCollectionSummaryWithContext returns, for each collection an
+// address or ENS name holds assets of, the owned count and the current
+// floor, without listing the assets. Floors missing from the collections
+// endpoint are read from the collection stats.
+func (o Opensea) GetAccountCollectionSummaryWithContext(ctx context.Context, address Address) ([]CollectionHolding, error) {
+	owner, err := o.ResolveAddressWithContext(ctx, address.String())
+	if err != nil {
+		return nil, err
+	}
+	ret := []CollectionHolding{}
+	missing := []int{}
+	params := GetCollectionsParams{AssetOwner: owner, Limit: 300}
+	for {
+		page, err := o.GetCollectionsWithContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range page {
+			h := CollectionHolding{Slug: c.Slug, Name: c.Name, Owned: c.OwnedAssetCount}
+			if c.Stats != nil {
+				h.Floor = c.Stats.FloorPrice
+			} else {
+				missing = append(missing, len(ret))
+			}
+			ret = append(ret, h)
+		}
+		if len(page) < params.Limit {
+			break
+		}
+		params.Offset += params.Limit
+	}
+
+	concurrency := o.accountConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultAccountConcurrency
+	}
+	jobs := make([]func(ctx context.Context) error, len(missing))
+	for i, idx := range missing {
+		idx := idx
+		jobs[i] = func(ctx context.Context) error {
+			stats, err := o.collectionStats(ctx, ret[idx].Slug)
+			if err != nil {
+				return err
+			}
+			ret[idx].Floor = stats.FloorPrice
+			return nil
+		}
+	}
+	if err := runConcurrently(ctx, concurrency, jobs); err != nil {
+		return nil, err
+	}
+	for i := range ret {
+		ret[i].Value = float64(ret[i].Owned) * ret[i].Floor
+	}
+	return ret, nil
+}
@@ -0,0 +1,105 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAccounts(t *testing.T) {
+	alice := "0xabcdef1111111111111111111111111111111111"
+	bob := "0x2222222222222222222222222222222222222222"
+	nobody := "0x3333333333333333333333333333333333333333"
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		address := strings.TrimPrefix(r.URL.Path, "/api/v2/accounts/")
+		mu.Lock()
+		calls[address]++
+		mu.Unlock()
+		switch address {
+		case alice:
+			w.Write([]byte(`{"address":"` + alice + `","username":"alice","profile_image_url":"https://img/alice.png"}`))
+		case bob:
+			w.Write([]byte(`{"address":"` + bob + `","username":"bob"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}, WithAccountConcurrency(2))
+
+	addresses := []Address{Address("0x" + strings.ToUpper(alice[2:])), Address(alice), Address(bob), Address(nobody)}
+	accounts, err := o.ResolveAccountsWithContext(context.Background(), addresses)
+	assert.Nil(t, err)
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, "alice", accounts[Address(alice)].User.Username)
+	assert.Equal(t, "https://img/alice.png", accounts[Address(alice)].ProfileImgURL)
+	assert.Equal(t, "bob", accounts[Address(bob)].User.Username)
+	assert.Equal(t, map[string]int{alice: 1, bob: 1, nobody: 1}, calls)
+
+	// cached, including the address without account
+	accounts, err = o.ResolveAccountsWithContext(context.Background(), addresses)
+	assert.Nil(t, err)
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, map[string]int{alice: 1, bob: 1, nobody: 1}, calls)
+
+	_, err = o.ResolveAccountsWithContext(context.Background(), []Address{"vitalik"})
+	assert.NotNil(t, err)
+}
+
+func TestGetAccount(t *testing.T) {
+	calls := 0
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/api/v2/accounts/alice", "/api/v2/accounts/0x1111111111111111111111111111111111111111":
+			w.Write([]byte(`{"address":"0x1111111111111111111111111111111111111111","username":"alice","profile_image_url":"https://img/alice.png","config":"verified"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	a, err := o.GetAccountWithContext(context.Background(), "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, Address("0x1111111111111111111111111111111111111111"), a.Address)
+	assert.Equal(t, "https://img/alice.png", a.ProfileImgURL)
+	assert.True(t, a.IsVerified())
+
+	// the lookup by username cached the account of the address
+	a, err = o.GetAccountWithContext(context.Background(), "0x1111111111111111111111111111111111111111")
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", a.User.Username)
+	assert.Equal(t, 1, calls)
+
+	_, err = o.GetAccountWithContext(context.Background(), "0x2222222222222222222222222222222222222222")
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetAccountWithContext(context.Background(), "0x2222222222222222222222222222222222222222")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestGetAccountCollectionSummary(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/collections":
+			assert.Equal(t, "0x000000000000000000000000000000000000000a", r.URL.Query().Get("asset_owner"))
+			w.Write([]byte(`{"collections":[
+				{"slug":"doodles-official","name":"Doodles","owned_asset_count":3,"stats":{"floor_price":2.5}},
+				{"slug":"azuki","name":"Azuki","owned_asset_count":1}]}`))
+		case "/api/v1/collection/azuki/stats":
+			w.Write([]byte(`{"stats":{"floor_price":10}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	summary, err := o.GetAccountCollectionSummaryWithContext(context.Background(), "0x000000000000000000000000000000000000000A")
+	assert.Nil(t, err)
+	assert.Equal(t, []CollectionHolding{
+		{Slug: "doodles-official", Name: "Doodles", Owned: 3, Floor: 2.5, Value: 7.5},
+		{Slug: "azuki", Name: "Azuki", Owned: 1, Floor: 10, Value: 10},
+	}, summary)
+}
@@ -0,0 +1,126 @@
+package opensea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// AssetBatchSize is the number of tokens GetAssetsBatch reads with each
+// call of the assets endpoint, the most it takes.
+const AssetBatchSize = 30
+
+// AssetRef references a token by contract and identifier.
+type AssetRef struct {
+	Contract Address
+	TokenID  string
+}
+
+func (r AssetRef) key() string {
+	return strings.ToLower(r.Contract.String()) + "/" + r.TokenID
+}
+
+// AssetResult is the asset of an AssetRef, or the error reading it.
+type AssetResult struct {
+	Ref   AssetRef
+	Asset *Asset
+	Err   error
+}
+
+// GetAssetsBatch returns the assets of refs, in order, reading them from
+// the assets endpoint AssetBatchSize at a time. The refs the endpoint
+// misses, e.g. burned or migrated tokens, and those of chunks it failed to
+// read, are read one by one with GetSingleAsset, and the errors of those
+// calls, such as ErrNotFound, are set on their results rather than failing
+// the batch. Only ErrAPIKeyRequired, ErrEndpointSunset and the end of ctx
+// fail the batch, as every read would fail the same way.
+func (o Opensea) GetAssetsBatch(refs []AssetRef) ([]AssetResult, error) {
+	ctx := context.TODO()
+	return o.GetAssetsBatchWithContext(ctx, refs)
+}
+
+func (o Opensea) GetAssetsBatchWithContext(ctx context.Context, refs []AssetRef) ([]AssetResult, error) {
+	found := map[string]*Asset{}
+	contracts := []Address{}
+	tokens := map[Address][]string{}
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		if seen[ref.key()] {
+			continue
+		}
+		seen[ref.key()] = true
+		contract := Address(strings.ToLower(ref.Contract.String()))
+		if _, ok := tokens[contract]; !ok {
+			contracts = append(contracts, contract)
+		}
+		tokens[contract] = append(tokens[contract], ref.TokenID)
+	}
+
+	for _, contract := range contracts {
+		ids := tokens[contract]
+		for start := 0; start < len(ids); start += AssetBatchSize {
+			end := start + AssetBatchSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+			resp, err := o.GetAssetsWithContext(ctx, GetAssetsParams{
+				AssetContractAddress: contract,
+				TokenIds:             ids[start:end],
+				Limit:                end - start,
+			})
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if err != nil {
+				if batchFatal(err) {
+					return nil, err
+				}
+				// the refs of the chunk fall back to single reads
+				continue
+			}
+			for i := range resp.Assets {
+				a := &resp.Assets[i]
+				if a.AssetContract == nil {
+					continue
+				}
+				found[AssetRef{a.AssetContract.Address, a.TokenID}.key()] = a
+			}
+		}
+	}
+
+	missed := map[string]AssetResult{}
+	ret := make([]AssetResult, len(refs))
+	for i, ref := range refs {
+		ret[i].Ref = ref
+		if a, ok := found[ref.key()]; ok {
+			ret[i].Asset = a
+			continue
+		}
+		if r, ok := missed[ref.key()]; ok {
+			ret[i].Asset, ret[i].Err = r.Asset, r.Err
+			continue
+		}
+		tokenID, ok := new(big.Int).SetString(ref.TokenID, 10)
+		if ok {
+			ret[i].Asset, ret[i].Err = o.GetSingleAssetWithContext(ctx, ref.Contract.String(), tokenID)
+		} else {
+			ret[i].Err = fmt.Errorf("Invalid token ID %s", ref.TokenID)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if batchFatal(ret[i].Err) {
+			return nil, ret[i].Err
+		}
+		missed[ref.key()] = ret[i]
+	}
+	return ret, nil
+}
+
+// batchFatal reports whether err fails a whole batch rather than a ref.
+func batchFatal(err error) bool {
+	var sunset ErrEndpointSunset
+	return errors.Is(err, ErrAPIKeyRequired) || errors.As(err, &sunset)
+}
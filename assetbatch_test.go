@@ -0,0 +1,91 @@
+package opensea
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAssetsBatch(t *testing.T) {
+	batches := 0
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/assets":
+			batches++
+			assert.Equal(t, contract, r.URL.Query().Get("asset_contract_address"))
+			assets := []string{}
+			for _, id := range r.URL.Query()["token_id"] {
+				if id != "3" && id != "4" && id != "x" {
+					assets = append(assets, fmt.Sprintf(`{"token_id":%q,"asset_contract":{"address":%q}}`, id, contract))
+				}
+			}
+			w.Write([]byte(`{"assets":[` + strings.Join(assets, ",") + `]}`))
+		case r.URL.Path == "/api/v1/asset/"+contract+"/4":
+			w.Write([]byte(`{"token_id":"4","name":"migrated"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	refs := []AssetRef{}
+	for i := 1; i <= 35; i++ {
+		refs = append(refs, AssetRef{Address(contract), fmt.Sprintf("%d", i)})
+	}
+	refs = append(refs, AssetRef{Address(strings.ToUpper(contract[:2]) + contract[2:]), "1"}, AssetRef{Address(contract), "x"})
+	results, err := o.GetAssetsBatch(refs)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, batches)
+	assert.Len(t, results, len(refs))
+
+	assert.Equal(t, "1", results[0].Asset.TokenID)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, ErrNotFound, results[2].Err)
+	assert.Equal(t, refs[2], results[2].Ref)
+	assert.Equal(t, "migrated", results[3].Asset.Name)
+	assert.Equal(t, "35", results[34].Asset.TokenID)
+	assert.Equal(t, "1", results[35].Asset.TokenID)
+	assert.NotNil(t, results[36].Err)
+}
+
+func TestGetAssetsBatchErrors(t *testing.T) {
+	singles := 0
+	status := http.StatusInternalServerError
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/assets" {
+			w.WriteHeader(status)
+			return
+		}
+		singles++
+		if strings.HasSuffix(r.URL.Path, "/2") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"token_id":"1"}`))
+	}, WithRetries(0, 0))
+
+	// the refs of a failed chunk are read one by one
+	refs := []AssetRef{{Address(contract), "1"}, {Address(contract), "2"}}
+	results, err := o.GetAssetsBatch(refs)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, singles)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "1", results[0].Asset.TokenID)
+	assert.Equal(t, ErrNotFound, results[1].Err)
+
+	status = http.StatusGone
+	singles = 0
+	results, err = o.GetAssetsBatch(refs)
+	assert.IsType(t, ErrEndpointSunset{}, err)
+	assert.Nil(t, results)
+	assert.Equal(t, 0, singles)
+
+	keyless := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}, WithRetries(0, 0))
+	keyless.APIKey = ""
+	_, err = keyless.GetAssetsBatch(refs)
+	assert.Equal(t, ErrAPIKeyRequired, err)
+}
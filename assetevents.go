@@ -0,0 +1,203 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type GetEventsParams struct {
+	AssetContractAddress Address
+	// TokenID requires AssetContractAddress.
+	TokenID        string
+	CollectionSlug string
+	// AccountAddress is an address or an ENS name.
+	AccountAddress Address
+	EventType      EventType
+	OccurredBefore time.Time
+	OccurredAfter  time.Time
+	// Cursor is the Next cursor of the previous page.
+	Cursor string
+	Limit  int
+}
+
+func (p GetEventsParams) Encode() string {
+	q := url.Values{}
+	if p.AssetContractAddress != "" {
+		q.Set("asset_contract_address", p.AssetContractAddress.String())
+	}
+	if p.TokenID != "" {
+		q.Set("token_id", p.TokenID)
+	}
+	if p.CollectionSlug != "" {
+		q.Set("collection_slug", p.CollectionSlug)
+	}
+	if p.AccountAddress != "" {
+		q.Set("account_address", p.AccountAddress.String())
+	}
+	if p.EventType != EventTypeNone {
+		q.Set("event_type", string(p.EventType))
+	}
+	if !p.OccurredBefore.IsZero() {
+		q.Set("occurred_before", fmt.Sprintf("%d", p.OccurredBefore.Unix()))
+	}
+	if !p.OccurredAfter.IsZero() {
+		q.Set("occurred_after", fmt.Sprintf("%d", p.OccurredAfter.Unix()))
+	}
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	}
+	if p.Limit > 0 {
+		q.Set("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	return q.Encode()
+}
+
+// EventsResponse is a page of events. Next is empty on the last page.
+type EventsResponse struct {
+	AssetEvents []AssetEvent `json:"asset_events" bson:"asset_events"`
+	Next        string       `json:"next" bson:"next"`
+	Previous    string       `json:"previous" bson:"previous"`
+}
+
+// AssetEvent is an event of the events endpoint. Sale, Transfer, Bid and
+// Cancellation return its typed view by event type.
+type AssetEvent struct {
+	Event `bson:",inline"`
+}
+
+// SaleEvent is a successful sale.
+type SaleEvent struct {
+	Asset        *Asset
+	Seller       Address
+	Buyer        Address
+	TotalPrice   Number
+	PaymentToken *PaymentToken
+	Quantity     string
+	Transaction  *Transaction
+	Time         time.Time
+}
+
+// TransferEvent is a transfer of a token, including mints from the null
+// address.
+type TransferEvent struct {
+	Asset       *Asset
+	From        Address
+	To          Address
+	Quantity    string
+	Transaction *Transaction
+	Time        time.Time
+}
+
+// BidEvent is a bid entered or withdrawn.
+type BidEvent struct {
+	Asset        *Asset
+	Bidder       Address
+	Amount       Number
+	PaymentToken *PaymentToken
+	Withdrawn    bool
+	Time         time.Time
+}
+
+// CancellationEvent is the cancellation of a listing.
+type CancellationEvent struct {
+	Asset       *Asset
+	Seller      Address
+	Transaction *Transaction
+	Time        time.Time
+}
+
+func (e AssetEvent) Sale() (*SaleEvent, bool) {
+	if e.EventType != EventTypeSuccessful {
+		return nil, false
+	}
+	return &SaleEvent{
+		Asset:        e.Asset,
+		Seller:       accountAddress(e.Seller),
+		Buyer:        accountAddress(e.WinnerAccount),
+		TotalPrice:   e.TotalPrice,
+		PaymentToken: e.PaymentToken,
+		Quantity:     e.Quantity,
+		Transaction:  e.Transaction,
+		Time:         e.EventTimestamp.Time(),
+	}, true
+}
+
+func (e AssetEvent) Transfer() (*TransferEvent, bool) {
+	if e.EventType != EventTypeTransfer {
+		return nil, false
+	}
+	return &TransferEvent{
+		Asset:       e.Asset,
+		From:        accountAddress(e.FromAccount),
+		To:          accountAddress(e.ToAccount),
+		Quantity:    e.Quantity,
+		Transaction: e.Transaction,
+		Time:        e.EventTimestamp.Time(),
+	}, true
+}
+
+func (e AssetEvent) Bid() (*BidEvent, bool) {
+	if e.EventType != EventTypeBidEntered && e.EventType != EventTypeBidWithdrawn {
+		return nil, false
+	}
+	return &BidEvent{
+		Asset:        e.Asset,
+		Bidder:       accountAddress(e.FromAccount),
+		Amount:       e.BidAmount,
+		PaymentToken: e.PaymentToken,
+		Withdrawn:    e.EventType == EventTypeBidWithdrawn,
+		Time:         e.EventTimestamp.Time(),
+	}, true
+}
+
+func (e AssetEvent) Cancellation() (*CancellationEvent, bool) {
+	if e.EventType != EventTypeCancelled {
+		return nil, false
+	}
+	return &CancellationEvent{
+		Asset:       e.Asset,
+		Seller:      accountAddress(e.Seller),
+		Transaction: e.Transaction,
+		Time:        e.EventTimestamp.Time(),
+	}, true
+}
+
+func accountAddress(a *Account) Address {
+	if a == nil {
+		return ""
+	}
+	return a.Address
+}
+
+// GetEvents returns a page of events; pass its Next cursor in the params to
+// read the following page.
+func (o Opensea) GetEvents(params GetEventsParams) (*EventsResponse, error) {
+	ctx := context.TODO()
+	return o.GetEventsWithContext(ctx, params)
+}
+
+func (o Opensea) GetEventsWithContext(ctx context.Context, params GetEventsParams) (*EventsResponse, error) {
+	o.deprecated("GetEvents")
+	if params.TokenID != "" && params.AssetContractAddress == "" {
+		return nil, fmt.Errorf("Filtering by token ID requires an asset contract address")
+	}
+	if params.AccountAddress != "" {
+		account, err := o.ResolveAddressWithContext(ctx, params.AccountAddress.String())
+		if err != nil {
+			return nil, err
+		}
+		params.AccountAddress = account
+	}
+	b, err := o.GetPath(ctx, "/api/v1/events/?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	resp := &EventsResponse{AssetEvents: []AssetEvent{}}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
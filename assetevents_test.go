@@ -0,0 +1,68 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEvents(t *testing.T) {
+	var queries []string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/events/", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"next":"page2","asset_events":[
+				{"event_type":"successful","total_price":"1000","quantity":"1","event_timestamp":"2023-01-02T03:04:05",
+					"seller":{"address":"0x1111111111111111111111111111111111111111"},"winner_account":{"address":"0x2222222222222222222222222222222222222222"}},
+				{"event_type":"transfer","event_timestamp":"2023-01-02T03:04:05",
+					"from_account":{"address":"0x0000000000000000000000000000000000000000"},"to_account":{"address":"0x2222222222222222222222222222222222222222"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"next":"","asset_events":[
+			{"event_type":"bid_withdrawn","bid_amount":"500","event_timestamp":"2023-01-02T03:04:05","from_account":{"address":"0x3333333333333333333333333333333333333333"}},
+			{"event_type":"cancelled","event_timestamp":"2023-01-02T03:04:05","seller":{"address":"0x1111111111111111111111111111111111111111"}}]}`))
+	})
+
+	params := GetEventsParams{
+		AssetContractAddress: "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e",
+		TokenID:              "42",
+		EventType:            EventTypeSuccessful,
+		OccurredAfter:        time.Unix(1600000000, 0),
+		Limit:                2,
+	}
+	page, err := o.GetEventsWithContext(context.Background(), params)
+	assert.Nil(t, err)
+	assert.Equal(t, "asset_contract_address=0x8a90cab2b38dba80c64b7734e58ee1db38b8992e&event_type=successful&limit=2&occurred_after=1600000000&token_id=42", queries[0])
+	assert.Equal(t, "page2", page.Next)
+
+	sale, ok := page.AssetEvents[0].Sale()
+	assert.True(t, ok)
+	assert.Equal(t, Address("0x2222222222222222222222222222222222222222"), sale.Buyer)
+	assert.Equal(t, Number("1000"), sale.TotalPrice)
+	_, ok = page.AssetEvents[0].Transfer()
+	assert.False(t, ok)
+	transfer, ok := page.AssetEvents[1].Transfer()
+	assert.True(t, ok)
+	assert.True(t, transfer.From.IsNullAddress())
+
+	params.Cursor = page.Next
+	page, err = o.GetEventsWithContext(context.Background(), params)
+	assert.Nil(t, err)
+	assert.Contains(t, queries[1], "cursor=page2")
+	bid, ok := page.AssetEvents[0].Bid()
+	assert.True(t, ok)
+	assert.True(t, bid.Withdrawn)
+	assert.Equal(t, Number("500"), bid.Amount)
+	cancel, ok := page.AssetEvents[1].Cancellation()
+	assert.True(t, ok)
+	assert.Equal(t, Address("0x1111111111111111111111111111111111111111"), cancel.Seller)
+
+	_, err = o.GetEventsWithContext(context.Background(), GetEventsParams{TokenID: "1"})
+	assert.NotNil(t, err)
+	_, err = o.GetEventsWithContext(context.Background(), GetEventsParams{AccountAddress: "not an address"})
+	assert.NotNil(t, err)
+}
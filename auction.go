@@ -0,0 +1,109 @@
+package opensea
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// MinAuctionDuration and MaxAuctionDuration bound the duration of the
+	// auctions OpenSea accepts.
+	MinAuctionDuration = 15 * time.Minute
+	MaxAuctionDuration = 180 * 24 * time.Hour
+)
+
+// DutchAuctionParams describe a declining-price listing. Price is the start
+// price; the price declines linearly to EndPrice at EndTime, when the
+// listing expires.
+type DutchAuctionParams struct {
+	ListingParams
+	EndPrice Number
+	// Duration sets EndTime from StartTime when set.
+	Duration time.Duration
+}
+
+// BuildDutchAuction builds the parameters of a declining-price listing,
+// ready for SignOrder. OpenSea only accepts Dutch auctions in the native
+// currency of the chain.
+func BuildDutchAuction(p DutchAuctionParams) (*OrderParameters, error) {
+	if p.PaymentToken != "" && !p.PaymentToken.IsNullAddress() {
+		return nil, fmt.Errorf("Dutch auctions are paid in the native currency, not %s", p.PaymentToken)
+	}
+	start, ok := new(big.Int).SetString(string(p.Price), 10)
+	if !ok {
+		return nil, fmt.Errorf("Invalid price: %s", p.Price)
+	}
+	end, ok := new(big.Int).SetString(string(p.EndPrice), 10)
+	if !ok || end.Sign() <= 0 {
+		return nil, fmt.Errorf("Invalid end price: %s", p.EndPrice)
+	}
+	if end.Cmp(start) >= 0 {
+		return nil, fmt.Errorf("End price %s is not below start price %s", p.EndPrice, p.Price)
+	}
+
+	if p.StartTime.IsZero() {
+		p.StartTime = time.Now()
+	}
+	if p.Duration > 0 {
+		p.EndTime = p.StartTime.Add(p.Duration)
+	}
+	if p.EndTime.IsZero() {
+		return nil, fmt.Errorf("Dutch auctions need a duration or an end time")
+	}
+	if d := p.EndTime.Sub(p.StartTime); d < MinAuctionDuration || d > MaxAuctionDuration {
+		return nil, fmt.Errorf("Auction duration %s out of [%s, %s]", d, MinAuctionDuration, MaxAuctionDuration)
+	}
+
+	order, err := BuildListing(p.ListingParams)
+	if err != nil {
+		return nil, err
+	}
+	endParams := p.ListingParams
+	endParams.Price = p.EndPrice
+	endConsideration, err := listingConsideration(endParams)
+	if err != nil {
+		return nil, err
+	}
+	for i := range order.Consideration {
+		order.Consideration[i].EndAmount = endConsideration[i].EndAmount
+	}
+	return order, nil
+}
+
+// CurrentPrice returns the total price of a listing at t, as Seaport
+// computes it: amounts move linearly from their start to their end amount,
+// and are rounded up.
+func (p OrderParameters) CurrentPrice(t time.Time) (Number, error) {
+	startTime, ok1 := new(big.Int).SetString(string(p.StartTime), 10)
+	endTime, ok2 := new(big.Int).SetString(string(p.EndTime), 10)
+	if !ok1 || !ok2 || endTime.Cmp(startTime) <= 0 {
+		return "", fmt.Errorf("Invalid order period: %s to %s", p.StartTime, p.EndTime)
+	}
+	now := big.NewInt(t.Unix())
+	if now.Cmp(startTime) < 0 {
+		now = startTime
+	}
+	if now.Cmp(endTime) > 0 {
+		now = endTime
+	}
+	duration := new(big.Int).Sub(endTime, startTime)
+	elapsed := new(big.Int).Sub(now, startTime)
+	remaining := new(big.Int).Sub(endTime, now)
+
+	total := new(big.Int)
+	for _, item := range p.Consideration {
+		start, ok1 := new(big.Int).SetString(string(item.StartAmount), 10)
+		end, ok2 := new(big.Int).SetString(string(item.EndAmount), 10)
+		if !ok1 || !ok2 {
+			return "", fmt.Errorf("Invalid amounts: %s to %s", item.StartAmount, item.EndAmount)
+		}
+		amount := new(big.Int).Mul(start, remaining)
+		amount.Add(amount, new(big.Int).Mul(end, elapsed))
+		// round up
+		amount.Add(amount, new(big.Int).Sub(duration, big.NewInt(1)))
+		amount.Quo(amount, duration)
+		total.Add(total, amount)
+	}
+	return Number(total.String()), nil
+}
@@ -0,0 +1,57 @@
+package opensea
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDutchAuction(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	p := DutchAuctionParams{
+		ListingParams: ListingParams{
+			Offerer:   "0x1111111111111111111111111111111111111111",
+			Items:     []ListingItem{ERC721Item("0x2222222222222222222222222222222222222222", "1")},
+			Price:     "2000000000000000000",
+			Fees:      []Fee{{Recipient: OpenSeaFeeRecipient, BasisPoints: 250}},
+			StartTime: start,
+		},
+		EndPrice: "1000000000000000000",
+		Duration: 24 * time.Hour,
+	}
+	order, err := BuildDutchAuction(p)
+	assert.Nil(t, err)
+	assert.Equal(t, Number("1700086400"), order.EndTime)
+	assert.Equal(t, Number("1950000000000000000"), order.Consideration[0].StartAmount)
+	assert.Equal(t, Number("975000000000000000"), order.Consideration[0].EndAmount)
+	assert.Equal(t, Number("50000000000000000"), order.Consideration[1].StartAmount)
+	assert.Equal(t, Number("25000000000000000"), order.Consideration[1].EndAmount)
+
+	price, err := order.CurrentPrice(start.Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, Number("2000000000000000000"), price)
+	price, err = order.CurrentPrice(start.Add(12 * time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, Number("1500000000000000000"), price)
+	price, err = order.CurrentPrice(start.Add(48 * time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, Number("1000000000000000000"), price)
+
+	invalid := []func(p *DutchAuctionParams){
+		func(p *DutchAuctionParams) { p.EndPrice = p.Price },
+		func(p *DutchAuctionParams) { p.EndPrice = "0" },
+		func(p *DutchAuctionParams) { p.PaymentToken = "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" },
+		func(p *DutchAuctionParams) { p.Duration = time.Minute },
+		func(p *DutchAuctionParams) { p.Duration = 365 * 24 * time.Hour },
+		func(p *DutchAuctionParams) { p.Duration, p.EndTime = 0, time.Time{} },
+		// the fee is below one wei at the end price
+		func(p *DutchAuctionParams) { p.Price, p.EndPrice = "100", "1" },
+	}
+	for i, f := range invalid {
+		q := p
+		f(&q)
+		_, err := BuildDutchAuction(q)
+		assert.NotNil(t, err, i)
+	}
+}
@@ -0,0 +1,164 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultBackfillWindow is the time window BackfillEvents reads at once.
+const DefaultBackfillWindow = 24 * time.Hour
+
+// TimeWindow is the period [From, To).
+type TimeWindow struct {
+	From time.Time `json:"from" bson:"from"`
+	To   time.Time `json:"to" bson:"to"`
+}
+
+// Checkpointer records the windows of a backfill already read, so that a
+// restarted backfill skips them.
+type Checkpointer interface {
+	Completed(ctx context.Context) ([]TimeWindow, error)
+	Complete(ctx context.Context, w TimeWindow) error
+}
+
+// FileCheckpointer is a Checkpointer keeping the completed windows in a JSON
+// file, rewritten atomically on each completion.
+type FileCheckpointer struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func NewFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{Path: path}
+}
+
+func (c *FileCheckpointer) Completed(ctx context.Context) ([]TimeWindow, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.read()
+}
+
+func (c *FileCheckpointer) read() ([]TimeWindow, error) {
+	b, err := ioutil.ReadFile(c.Path)
+	if os.IsNotExist(err) {
+		return []TimeWindow{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	windows := []TimeWindow{}
+	if err := json.Unmarshal(b, &windows); err != nil {
+		return nil, fmt.Errorf("Invalid checkpoint file %s: %v", c.Path, err)
+	}
+	return windows, nil
+}
+
+func (c *FileCheckpointer) Complete(ctx context.Context, w TimeWindow) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	windows, err := c.read()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(append(windows, w))
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(c.Path), filepath.Base(c.Path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.Path)
+}
+
+// BackfillParams describe the events to backfill: those of Filter that
+// occurred in [From, To), read Window by Window.
+type BackfillParams struct {
+	Filter GetEventsParams
+	From   time.Time
+	To     time.Time
+	// Window defaults to DefaultBackfillWindow.
+	Window time.Duration
+	// Checkpointer, if set, skips the windows completed by a previous run.
+	Checkpointer Checkpointer
+}
+
+// BackfillEvents reads the events of p, page by page, handing each page to
+// fn with its window. A window is checkpointed once all its pages were
+// handled, so a failed backfill resumes at the start of the window it was
+// reading; fn should tolerate seeing the events of that window again.
+func (o Opensea) BackfillEvents(ctx context.Context, p BackfillParams, fn func(w TimeWindow, events []AssetEvent) error) error {
+	if p.From.IsZero() || !p.From.Before(p.To) {
+		return fmt.Errorf("Invalid backfill period: %s to %s", p.From, p.To)
+	}
+	window := p.Window
+	if window <= 0 {
+		window = DefaultBackfillWindow
+	}
+	completed := []TimeWindow{}
+	if p.Checkpointer != nil {
+		var err error
+		if completed, err = p.Checkpointer.Completed(ctx); err != nil {
+			return err
+		}
+	}
+
+	for from := p.From; from.Before(p.To); from = from.Add(window) {
+		w := TimeWindow{From: from, To: from.Add(window)}
+		if w.To.After(p.To) {
+			w.To = p.To
+		}
+		if windowCompleted(completed, w) {
+			continue
+		}
+		params := p.Filter
+		params.OccurredAfter, params.OccurredBefore = w.From, w.To
+		params.Cursor = ""
+		for {
+			resp, err := o.GetEventsWithContext(ctx, params)
+			if err != nil {
+				return err
+			}
+			if err := fn(w, resp.AssetEvents); err != nil {
+				return err
+			}
+			reportProgress(ctx, len(resp.AssetEvents))
+			if resp.Next == "" {
+				break
+			}
+			params.Cursor = resp.Next
+		}
+		if p.Checkpointer != nil {
+			if err := p.Checkpointer.Complete(ctx, w); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// windowCompleted reports whether w lies within one of the completed
+// windows.
+func windowCompleted(completed []TimeWindow, w TimeWindow) bool {
+	for _, c := range completed {
+		if !w.From.Before(c.From) && !w.To.After(c.To) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package opensea
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillEvents(t *testing.T) {
+	from := time.Unix(1700000000, 0).UTC()
+	requests := map[string]int{}
+	failAfter := ""
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		window := r.URL.Query().Get("occurred_after")
+		requests[window]++
+		if window == failAfter {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":["boom"]}`))
+			return
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"asset_events":[{"event_type":"transfer"}],"next":"page2"}`))
+			return
+		}
+		w.Write([]byte(`{"asset_events":[{"event_type":"transfer"}]}`))
+	})
+	checkpoints := NewFileCheckpointer(filepath.Join(t.TempDir(), "checkpoints.json"))
+	p := BackfillParams{
+		Filter:       GetEventsParams{CollectionSlug: "doodles-official"},
+		From:         from,
+		To:           from.Add(3 * time.Hour),
+		Window:       time.Hour,
+		Checkpointer: checkpoints,
+	}
+
+	// the third window fails
+	failAfter = "1700007200"
+	events := 0
+	err := o.BackfillEvents(context.Background(), p, func(w TimeWindow, page []AssetEvent) error {
+		events += len(page)
+		return nil
+	})
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, 4, events)
+	done, err := checkpoints.Completed(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, done, 2)
+
+	// the restart only reads the third window
+	failAfter = ""
+	windows := []TimeWindow{}
+	err = o.BackfillEvents(context.Background(), p, func(w TimeWindow, page []AssetEvent) error {
+		windows = append(windows, w)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []TimeWindow{{From: from.Add(2 * time.Hour), To: from.Add(3 * time.Hour)}, {From: from.Add(2 * time.Hour), To: from.Add(3 * time.Hour)}}, windows)
+	assert.Equal(t, map[string]int{"1700000000": 2, "1700003600": 2, "1700007200": 3}, requests)
+
+	p.To = p.From
+	assert.NotNil(t, o.BackfillEvents(context.Background(), p, nil))
+}
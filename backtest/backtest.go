@@ -0,0 +1,336 @@
+// Package backtest replays recorded events and order book snapshots through
+// trading strategies on a simulated clock, to evaluate them offline.
+//
+// Replay is an opensea.EventStream, so the stream consumers of the module
+// run unchanged against recorded events; Backtest drives a Strategy and
+// keeps the books of its simulated trades.
+package backtest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// Clock is a simulated clock. It only moves forward.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AdvanceTo moves the clock to t, unless t is in its past.
+func (c *Clock) AdvanceTo(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.AdvanceTo(c.Now().Add(d))
+}
+
+// ReadEvents reads events from JSON lines, or from a JSON array.
+func ReadEvents(r io.Reader) ([]*opensea.Event, error) {
+	br := bufio.NewReader(r)
+	b, err := br.Peek(1)
+	for err == nil && strings.TrimSpace(string(b)) == "" {
+		br.ReadByte()
+		b, err = br.Peek(1)
+	}
+	if err == io.EOF {
+		return []*opensea.Event{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(br)
+	ret := []*opensea.Event{}
+	if b[0] == '[' {
+		if err := dec.Decode(&ret); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+	for {
+		e := new(opensea.Event)
+		err := dec.Decode(e)
+		if err == io.EOF {
+			return ret, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, e)
+	}
+}
+
+// sortEvents returns events sorted by timestamp, keeping the recorded order
+// of simultaneous events.
+func sortEvents(events []*opensea.Event) []*opensea.Event {
+	ret := append([]*opensea.Event{}, events...)
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].EventTimestamp.Time().Before(ret[j].EventTimestamp.Time())
+	})
+	return ret
+}
+
+// Replay is an EventStream of recorded events, in timestamp order. The
+// clock is advanced to each event as it is sent, which may be before a
+// consumer is done with the previous one; Backtest keeps an exact clock of
+// its own.
+type Replay struct {
+	clock  *Clock
+	events []*opensea.Event
+	ch     chan *opensea.Event
+
+	mu  sync.Mutex
+	err error
+}
+
+func NewReplay(clock *Clock, events []*opensea.Event) *Replay {
+	return &Replay{clock: clock, events: sortEvents(events), ch: make(chan *opensea.Event)}
+}
+
+// Start replays the events in the background until they run out or ctx is
+// done.
+func (r *Replay) Start(ctx context.Context) {
+	go func() {
+		defer close(r.ch)
+		for _, e := range r.events {
+			r.clock.AdvanceTo(e.EventTimestamp.Time())
+			select {
+			case r.ch <- e:
+			case <-ctx.Done():
+				r.mu.Lock()
+				r.err = ctx.Err()
+				r.mu.Unlock()
+				return
+			}
+		}
+	}()
+}
+
+func (r *Replay) Events() <-chan *opensea.Event {
+	return r.ch
+}
+
+func (r *Replay) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Dataset is what a backtest replays.
+type Dataset struct {
+	Events []*opensea.Event
+	Books  []opensea.OrderBookSnapshot
+}
+
+// Strategy is the set of callbacks under test. Nil callbacks are skipped; a
+// callback error stops the backtest.
+type Strategy struct {
+	OnEvent     func(sim *Simulation, e *opensea.Event) error
+	OnOrderBook func(sim *Simulation, book *opensea.OrderBookSnapshot) error
+	OnTick      func(sim *Simulation) error
+}
+
+// Side is the side of a simulated trade.
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+// Trade is a simulated trade, in the native currency.
+type Trade struct {
+	Time  time.Time `json:"time"`
+	Side  Side      `json:"side"`
+	Token string    `json:"token"`
+	Price float64   `json:"price"`
+}
+
+// Simulation is the state a strategy sees and trades against.
+type Simulation struct {
+	Clock *Clock
+	// Books are the latest order book snapshots delivered, by slug.
+	Books map[string]*opensea.OrderBookSnapshot
+
+	trades   []Trade
+	holdings map[string]int
+}
+
+// TokenKey identifies the token of an event as "<contract>/<token id>". It
+// is empty for events without asset contract.
+func TokenKey(e *opensea.Event) string {
+	if e.Asset == nil || e.Asset.AssetContract == nil {
+		return ""
+	}
+	return strings.ToLower(e.Asset.AssetContract.Address.String()) + "/" + e.Asset.TokenID
+}
+
+// Buy records the purchase of token at price, e.g. when sniping a listing.
+func (s *Simulation) Buy(token string, price float64) {
+	s.trades = append(s.trades, Trade{Time: s.Clock.Now(), Side: Buy, Token: token, Price: price})
+	s.holdings[token]++
+}
+
+// Sell records the sale of a held token at price.
+func (s *Simulation) Sell(token string, price float64) error {
+	if s.holdings[token] == 0 {
+		return fmt.Errorf("Token %s is not held", token)
+	}
+	s.trades = append(s.trades, Trade{Time: s.Clock.Now(), Side: Sell, Token: token, Price: price})
+	s.holdings[token]--
+	if s.holdings[token] == 0 {
+		delete(s.holdings, token)
+	}
+	return nil
+}
+
+// Holds returns how many of token are held.
+func (s *Simulation) Holds(token string) int {
+	return s.holdings[token]
+}
+
+// Result summarizes a backtest.
+type Result struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Events   int       `json:"events"`
+	Trades   []Trade   `json:"trades"`
+	Spent    float64   `json:"spent"`
+	Received float64   `json:"received"`
+	// Profit is Received - Spent; the tokens still held are not valued.
+	Profit   float64        `json:"profit"`
+	Holdings map[string]int `json:"holdings"`
+}
+
+// Backtest replays a Dataset through a Strategy.
+type Backtest struct {
+	Data     Dataset
+	Strategy Strategy
+	// Start defaults to the first timestamp of the dataset.
+	Start time.Time
+	// Tick calls OnTick every Tick of simulated time when set.
+	Tick time.Duration
+}
+
+// Run replays the dataset: each event, order book and tick is delivered
+// once the clock reaches it, order books and ticks before the events of the
+// same instant.
+func (b Backtest) Run(ctx context.Context) (*Result, error) {
+	books := append([]opensea.OrderBookSnapshot{}, b.Data.Books...)
+	sort.SliceStable(books, func(i, j int) bool { return books[i].Time.Before(books[j].Time) })
+	events := sortEvents(b.Data.Events)
+
+	start := b.Start
+	if start.IsZero() {
+		if len(events) > 0 {
+			start = events[0].EventTimestamp.Time()
+		}
+		if len(books) > 0 && (start.IsZero() || books[0].Time.Before(start)) {
+			start = books[0].Time
+		}
+	}
+	clock := NewClock(start)
+	sim := &Simulation{Clock: clock, Books: map[string]*opensea.OrderBookSnapshot{}, holdings: map[string]int{}}
+	nextTick := start
+	if b.Tick > 0 {
+		nextTick = start.Add(b.Tick)
+	}
+
+	// until delivers the books and ticks up to t
+	until := func(t time.Time) error {
+		for {
+			bookDue := len(books) > 0 && !books[0].Time.After(t)
+			tickDue := b.Tick > 0 && !nextTick.After(t)
+			switch {
+			case bookDue && (!tickDue || !nextTick.Before(books[0].Time)):
+				book := books[0]
+				books = books[1:]
+				clock.AdvanceTo(book.Time)
+				sim.Books[book.Slug] = &book
+				if b.Strategy.OnOrderBook != nil {
+					if err := b.Strategy.OnOrderBook(sim, &book); err != nil {
+						return err
+					}
+				}
+			case tickDue:
+				clock.AdvanceTo(nextTick)
+				nextTick = nextTick.Add(b.Tick)
+				if b.Strategy.OnTick != nil {
+					if err := b.Strategy.OnTick(sim); err != nil {
+						return err
+					}
+				}
+			default:
+				return nil
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// the replay runs on a clock of its own, for the books and ticks due
+	// before an event to see their own time
+	stream := NewReplay(NewClock(start), events)
+	stream.Start(ctx)
+	result := &Result{Start: start}
+	for e := range stream.Events() {
+		if err := until(e.EventTimestamp.Time()); err != nil {
+			return nil, err
+		}
+		clock.AdvanceTo(e.EventTimestamp.Time())
+		result.Events++
+		if b.Strategy.OnEvent != nil {
+			if err := b.Strategy.OnEvent(sim, e); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	end := clock.Now()
+	if len(books) > 0 {
+		end = books[len(books)-1].Time
+	}
+	if err := until(end); err != nil {
+		return nil, err
+	}
+
+	result.End = clock.Now()
+	result.Trades = sim.trades
+	result.Holdings = sim.holdings
+	for _, t := range sim.trades {
+		if t.Side == Buy {
+			result.Spent += t.Price
+		} else {
+			result.Received += t.Price
+		}
+	}
+	result.Profit = result.Received - result.Spent
+	return result, nil
+}
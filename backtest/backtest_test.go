@@ -0,0 +1,111 @@
+package backtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func event(id uint64, at time.Time, t opensea.EventType, token string) *opensea.Event {
+	e := &opensea.Event{ID: id, EventType: t, EventTimestamp: opensea.TimeNano(at)}
+	e.Asset = &opensea.Asset{TokenID: token, AssetContract: &opensea.AssetContract{Address: "0x00000000000000000000000000000000000000aa"}}
+	return e
+}
+
+func TestReadEvents(t *testing.T) {
+	lines := `{"id":1,"event_type":"created","event_timestamp":"2022-01-01T00:00:00"}
+{"id":2,"event_type":"successful","event_timestamp":"2022-01-01T00:01:00"}
+`
+	events, err := ReadEvents(strings.NewReader(lines))
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, opensea.EventTypeSuccessful, events[1].EventType)
+
+	events, err = ReadEvents(strings.NewReader(` [{"id":3}]`))
+	assert.Nil(t, err)
+	assert.Len(t, events, 1)
+
+	events, err = ReadEvents(strings.NewReader(""))
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+}
+
+func TestReplay(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewClock(start)
+	var stream opensea.EventStream = NewReplay(clock, []*opensea.Event{
+		event(2, start.Add(2*time.Minute), opensea.EventTypeSuccessful, "1"),
+		event(1, start.Add(time.Minute), opensea.EventTypeCreated, "1"),
+	})
+	stream.(*Replay).Start(context.Background())
+
+	ids := []uint64{}
+	for e := range stream.Events() {
+		assert.False(t, clock.Now().Before(e.EventTimestamp.Time()))
+		ids = append(ids, e.ID)
+	}
+	assert.Nil(t, stream.Err())
+	assert.Equal(t, start.Add(2*time.Minute), clock.Now())
+	assert.Equal(t, []uint64{1, 2}, ids)
+}
+
+func TestBacktest(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	listing := func(id uint64, at time.Duration, token string, wei string) *opensea.Event {
+		e := event(id, start.Add(at), opensea.EventTypeCreated, token)
+		e.StartingPrice = wei
+		return e
+	}
+	book := opensea.OrderBookSnapshot{Slug: "doodles", Time: start}
+	book.Listings = []opensea.SeaportOrder{{}}
+	book.Listings[0].Price.Current = &opensea.OrderPrice{Decimals: 18, Value: "2000000000000000000"}
+
+	var ticks []time.Time
+	bt := Backtest{
+		Data: Dataset{
+			Events: []*opensea.Event{
+				listing(2, 30*time.Minute, "2", "3000000000000000000"),
+				listing(1, 10*time.Minute, "1", "1000000000000000000"),
+			},
+			Books: []opensea.OrderBookSnapshot{book},
+		},
+		Tick: time.Hour,
+		Strategy: Strategy{
+			// snipe listings under 80% of the floor
+			OnEvent: func(sim *Simulation, e *opensea.Event) error {
+				floor := sim.Books["doodles"].Liquidity(0).BestAsk
+				price := opensea.OrderPrice{Decimals: 18, Value: opensea.Number(e.StartingPrice)}.Float()
+				if price < 0.8*floor {
+					sim.Buy(TokenKey(e), price)
+				}
+				return nil
+			},
+			// and list them at the floor
+			OnTick: func(sim *Simulation) error {
+				ticks = append(ticks, sim.Clock.Now())
+				key := "0x00000000000000000000000000000000000000aa/1"
+				if sim.Holds(key) > 0 {
+					return sim.Sell(key, 2)
+				}
+				return nil
+			},
+		},
+	}
+	// a tick after the last event
+	bt.Data.Books = append(bt.Data.Books, opensea.OrderBookSnapshot{Slug: "doodles", Time: start.Add(time.Hour), Listings: book.Listings})
+
+	result, err := bt.Run(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Events)
+	assert.Equal(t, []time.Time{start.Add(time.Hour)}, ticks)
+	assert.Len(t, result.Trades, 2)
+	assert.Equal(t, Buy, result.Trades[0].Side)
+	assert.Equal(t, start.Add(10*time.Minute), result.Trades[0].Time)
+	assert.Equal(t, 1.0, result.Profit)
+	assert.Empty(t, result.Holdings)
+	assert.Equal(t, start.Add(time.Hour), result.End)
+}
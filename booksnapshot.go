@@ -0,0 +1,166 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OrderBookSnapshot is the order book of a collection at a point in time.
+// Truncated is set when the listings or offers of a large collection were
+// cut at the page limit of the order book reads.
+type OrderBookSnapshot struct {
+	Slug      string         `json:"slug" bson:"slug"`
+	Time      time.Time      `json:"time" bson:"time"`
+	Listings  []SeaportOrder `json:"listings" bson:"listings"`
+	Offers    []SeaportOrder `json:"offers" bson:"offers"`
+	Truncated bool           `json:"truncated,omitempty" bson:"truncated,omitempty"`
+}
+
+func (o Opensea) SnapshotOrderBook(slug string) (*OrderBookSnapshot, error) {
+	ctx := context.TODO()
+	return o.SnapshotOrderBookWithContext(ctx, slug)
+}
+
+// SnapshotOrderBookWithContext reads the listings and offers of a
+// collection. The orders of large collections are cut after
+// maxOrderBookPages pages of each, and the snapshot marked Truncated.
+func (o Opensea) SnapshotOrderBookWithContext(ctx context.Context, slug string) (*OrderBookSnapshot, error) {
+	now := time.Now()
+	listings, listingsTruncated, err := o.collectionOrders(ctx, ChainNone, slug, true)
+	if err != nil {
+		return nil, err
+	}
+	offers, offersTruncated, err := o.collectionOrders(ctx, ChainNone, slug, false)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderBookSnapshot{
+		Slug:      slug,
+		Time:      now,
+		Listings:  listings,
+		Offers:    offers,
+		Truncated: listingsTruncated || offersTruncated,
+	}, nil
+}
+
+// Liquidity computes the liquidity metrics of the snapshot, with the
+// defaults of LiquidityParams, given the supply of the collection.
+func (s OrderBookSnapshot) Liquidity(supply float64) *LiquidityMetrics {
+	m := computeLiquidity(s.Listings, s.Offers, supply, LiquidityParams{})
+	m.Slug = s.Slug
+	return m
+}
+
+// WriteOrderBookSnapshot writes s as JSON.
+func WriteOrderBookSnapshot(w io.Writer, s *OrderBookSnapshot) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// ReadOrderBookSnapshot reads a snapshot written by WriteOrderBookSnapshot.
+func ReadOrderBookSnapshot(r io.Reader) (*OrderBookSnapshot, error) {
+	s := new(OrderBookSnapshot)
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// OrderBookStore persists order book snapshots.
+type OrderBookStore interface {
+	SaveOrderBook(ctx context.Context, s *OrderBookSnapshot) error
+	// OrderBooks returns the snapshots of slug taken in [from, to), oldest
+	// first.
+	OrderBooks(ctx context.Context, slug string, from, to time.Time) ([]OrderBookSnapshot, error)
+}
+
+// DirOrderBookStore is an OrderBookStore keeping each snapshot in a JSON
+// file, under a directory per collection. Slugs naming a path, such as
+// "../x", are refused.
+type DirOrderBookStore struct {
+	Dir string
+}
+
+func NewDirOrderBookStore(dir string) *DirOrderBookStore {
+	return &DirOrderBookStore{Dir: dir}
+}
+
+// collectionDir returns the directory of the snapshots of slug.
+func (d *DirOrderBookStore) collectionDir(slug string) (string, error) {
+	if slug == "" || slug == "." || slug == ".." || strings.ContainsAny(slug, `/\`) {
+		return "", fmt.Errorf("Invalid collection slug: %q", slug)
+	}
+	return filepath.Join(d.Dir, slug), nil
+}
+
+func (d *DirOrderBookStore) SaveOrderBook(ctx context.Context, s *OrderBookSnapshot) error {
+	dir, err := d.collectionDir(s.Slug)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(dir, strconv.FormatInt(s.Time.UnixNano(), 10)+".json"))
+	if err != nil {
+		return err
+	}
+	if err := WriteOrderBookSnapshot(f, s); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (d *DirOrderBookStore) OrderBooks(ctx context.Context, slug string, from, to time.Time) ([]OrderBookSnapshot, error) {
+	dir, err := d.collectionDir(slug)
+	if err != nil {
+		return nil, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []OrderBookSnapshot{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	times := []int64{}
+	for _, f := range files {
+		ns, err := strconv.ParseInt(strings.TrimSuffix(f.Name(), ".json"), 10, 64)
+		if err != nil || f.IsDir() {
+			continue
+		}
+		t := time.Unix(0, ns)
+		if !t.Before(from) && t.Before(to) {
+			times = append(times, ns)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	ret := []OrderBookSnapshot{}
+	for _, ns := range times {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		f, err := os.Open(filepath.Join(dir, strconv.FormatInt(ns, 10)+".json"))
+		if err != nil {
+			return nil, err
+		}
+		s, err := ReadOrderBookSnapshot(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *s)
+	}
+	return ret, nil
+}
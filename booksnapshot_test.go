@@ -0,0 +1,94 @@
+package opensea
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotOrderBook(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/listings/collection/doodles/all":
+			w.Write([]byte(`{"listings":[{"order_hash":"0x1","chain":"ethereum","price":{"current":{"currency":"ETH","decimals":18,"value":"2000000000000000000"}},
+				"protocol_data":{"parameters":{"offerer":"0x00000000000000000000000000000000000000bb","offer":[{"itemType":2,"token":"0x00000000000000000000000000000000000000aa","identifierOrCriteria":"7","startAmount":"1","endAmount":"1"}]},"signature":"0xsig"},
+				"protocol_address":"0x0000000000000068f116a894984e2db1123eb395"}]}`))
+		case "/api/v2/offers/collection/doodles/all":
+			w.Write([]byte(`{"offers":[{"order_hash":"0x2","price":{"currency":"WETH","decimals":18,"value":"1000000000000000000"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	s, err := o.SnapshotOrderBookWithContext(context.Background(), "doodles")
+	assert.Nil(t, err)
+	assert.Len(t, s.Listings, 1)
+	assert.Len(t, s.Offers, 1)
+	assert.False(t, s.Truncated)
+	assert.Equal(t, 2.0, s.Listings[0].Price.Current.Float())
+	assert.Equal(t, "0xsig", s.Listings[0].ProtocolData.Signature)
+
+	var buf bytes.Buffer
+	assert.Nil(t, WriteOrderBookSnapshot(&buf, s))
+	restored, err := ReadOrderBookSnapshot(&buf)
+	assert.Nil(t, err)
+	assert.True(t, s.Time.Equal(restored.Time))
+	restored.Time = s.Time
+	assert.Equal(t, s, restored)
+
+	m := restored.Liquidity(10)
+	assert.Equal(t, 2.0, m.BestAsk)
+	assert.Equal(t, 1.0, m.BestBid)
+	assert.Equal(t, 1, m.Listed)
+}
+
+func TestDirOrderBookStore(t *testing.T) {
+	store := NewDirOrderBookStore(t.TempDir())
+	ctx := context.Background()
+	now := time.Now()
+
+	snaps, err := store.OrderBooks(ctx, "doodles", now.Add(-time.Hour), now)
+	assert.Nil(t, err)
+	assert.Empty(t, snaps)
+
+	for _, ago := range []time.Duration{3 * time.Hour, time.Hour, 2 * time.Hour} {
+		s := &OrderBookSnapshot{Slug: "doodles", Time: now.Add(-ago), Listings: []SeaportOrder{{OrderHash: ago.String()}}}
+		assert.Nil(t, store.SaveOrderBook(ctx, s))
+	}
+	assert.Nil(t, store.SaveOrderBook(ctx, &OrderBookSnapshot{Slug: "azuki", Time: now.Add(-time.Hour)}))
+
+	snaps, err = store.OrderBooks(ctx, "doodles", now.Add(-150*time.Minute), now)
+	assert.Nil(t, err)
+	assert.Len(t, snaps, 2)
+	assert.Equal(t, "2h0m0s", snaps[0].Listings[0].OrderHash)
+	assert.Equal(t, "1h0m0s", snaps[1].Listings[0].OrderHash)
+
+	for _, slug := range []string{"../../x", "..", "a/b", `a\b`, ""} {
+		assert.NotNil(t, store.SaveOrderBook(ctx, &OrderBookSnapshot{Slug: slug, Time: now}), slug)
+		_, err := store.OrderBooks(ctx, slug, now.Add(-time.Hour), now)
+		assert.NotNil(t, err, slug)
+	}
+	_, err = os.Stat(filepath.Join(store.Dir, "..", "x"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSnapshotOrderBookTruncated(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/listings/collection/doodles/all" {
+			w.Write([]byte(`{"listings":[{"order_hash":"0x1"}],"next":"more"}`))
+			return
+		}
+		w.Write([]byte(`{"offers":[]}`))
+	})
+
+	s, err := o.SnapshotOrderBookWithContext(context.Background(), "doodles")
+	assert.Nil(t, err)
+	assert.True(t, s.Truncated)
+	assert.Len(t, s.Listings, maxOrderBookPages)
+}
@@ -0,0 +1,93 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Bundle is a group of assets sold together.
+type Bundle struct {
+	Maker         *Account       `json:"maker" bson:"maker"`
+	Slug          string         `json:"slug" bson:"slug"`
+	Name          string         `json:"name" bson:"name"`
+	Description   string         `json:"description" bson:"description"`
+	ExternalLink  string         `json:"external_link" bson:"external_link"`
+	Permalink     string         `json:"permalink" bson:"permalink"`
+	Assets        []*Asset       `json:"assets" bson:"assets"`
+	AssetContract *AssetContract `json:"asset_contract" bson:"asset_contract"`
+	SellOrders    []*Order       `json:"sell_orders" bson:"sell_orders"`
+}
+
+type BundlesResponse struct {
+	Bundles []Bundle `json:"bundles" bson:"bundles"`
+}
+
+type GetBundlesParams struct {
+	// OnSale limits the bundles to those with a sell order.
+	OnSale bool
+	// Owner is an address or an ENS name.
+	Owner                  Address
+	AssetContractAddress   Address
+	AssetContractAddresses []Address
+	// TokenIDs require AssetContractAddress.
+	TokenIDs []string
+	Offset   int
+	// Limit defaults to 20, the maximum.
+	Limit int
+}
+
+func (p GetBundlesParams) Encode() string {
+	q := url.Values{}
+	if p.OnSale {
+		q.Set("on_sale", "true")
+	}
+	if p.Owner != "" {
+		q.Set("owner", p.Owner.String())
+	}
+	if p.AssetContractAddress != "" {
+		q.Set("asset_contract_address", p.AssetContractAddress.String())
+	}
+	for _, a := range p.AssetContractAddresses {
+		q.Add("asset_contract_addresses", a.String())
+	}
+	for _, id := range p.TokenIDs {
+		q.Add("token_ids", id)
+	}
+	limit := p.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	q.Set("offset", fmt.Sprintf("%d", p.Offset))
+	return q.Encode()
+}
+
+func (o Opensea) GetBundles(params GetBundlesParams) ([]Bundle, error) {
+	ctx := context.TODO()
+	return o.GetBundlesWithContext(ctx, params)
+}
+
+func (o Opensea) GetBundlesWithContext(ctx context.Context, params GetBundlesParams) ([]Bundle, error) {
+	o.deprecated("GetBundles")
+	if len(params.TokenIDs) > 0 && params.AssetContractAddress == "" {
+		return nil, fmt.Errorf("Filtering by token IDs requires an asset contract address")
+	}
+	if params.Owner != "" {
+		owner, err := o.ResolveAddressWithContext(ctx, params.Owner.String())
+		if err != nil {
+			return nil, err
+		}
+		params.Owner = owner
+	}
+	b, err := o.GetPath(ctx, "/api/v1/bundles?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	resp := &BundlesResponse{Bundles: []Bundle{}}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return resp.Bundles, nil
+}
@@ -0,0 +1,38 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBundles(t *testing.T) {
+	var query string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/bundles", r.URL.Path)
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"bundles":[{"slug":"pair","name":"Pair",
+			"maker":{"address":"0x1111111111111111111111111111111111111111"},
+			"assets":[{"token_id":"1"},{"token_id":"2"}],
+			"sell_orders":[{"current_price":"5000","side":1,"maker":{"address":"0x1111111111111111111111111111111111111111"}}]}]}`))
+	})
+
+	bundles, err := o.GetBundlesWithContext(context.Background(), GetBundlesParams{
+		OnSale:               true,
+		AssetContractAddress: "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e",
+		TokenIDs:             []string{"1", "2"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "asset_contract_address=0x8a90cab2b38dba80c64b7734e58ee1db38b8992e&limit=20&offset=0&on_sale=true&token_ids=1&token_ids=2", query)
+	assert.Len(t, bundles, 1)
+	b := bundles[0]
+	assert.Equal(t, "pair", b.Slug)
+	assert.Equal(t, Address("0x1111111111111111111111111111111111111111"), b.Maker.Address)
+	assert.Len(t, b.Assets, 2)
+	assert.Equal(t, Number("5000"), b.SellOrders[0].CurrentPrice)
+
+	_, err = o.GetBundlesWithContext(context.Background(), GetBundlesParams{TokenIDs: []string{"1"}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,104 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CancellationStatus is the state of an off-chain cancellation.
+type CancellationStatus string
+
+const (
+	// CancellationPending orders may still be filled with a fulfillment
+	// signature issued before the cancellation, until it expires.
+	CancellationPending CancellationStatus = "pending"
+	// CancellationFinal orders can no longer be filled.
+	CancellationFinal CancellationStatus = "final"
+	// CancellationDryRun cancellations were logged by a client created
+	// WithDryRun, and not sent: the order is untouched.
+	CancellationDryRun CancellationStatus = "dry_run"
+)
+
+// OrderCancellation is the response of the cancellation of an order.
+type OrderCancellation struct {
+	OrderHash string `json:"order_hash" bson:"order_hash"`
+	Chain     Chain  `json:"chain" bson:"chain"`
+	// LastSignatureIssuedValidUntil is the expiry of the last fulfillment
+	// signature issued for the order, zero if none was.
+	LastSignatureIssuedValidUntil time.Time `json:"last_signature_issued_valid_until" bson:"last_signature_issued_valid_until"`
+	// DryRun is set when the cancellation was not sent.
+	DryRun bool `json:"dry_run,omitempty" bson:"dry_run,omitempty"`
+}
+
+// Status returns the status of the cancellation at now.
+func (c OrderCancellation) Status(now time.Time) CancellationStatus {
+	if c.DryRun {
+		return CancellationDryRun
+	}
+	if now.Before(c.LastSignatureIssuedValidUntil) {
+		return CancellationPending
+	}
+	return CancellationFinal
+}
+
+// CancelOrder cancels an order of DefaultProtocol off-chain, without gas.
+// Only orders of the OpenSea signed zone can be cancelled this way, and the
+// client's API key must belong to the offerer.
+func (o Opensea) CancelOrder(orderHash string, chain Chain) (*OrderCancellation, error) {
+	ctx := context.TODO()
+	return o.CancelOrderWithContext(ctx, orderHash, chain)
+}
+
+func (o Opensea) CancelOrderWithContext(ctx context.Context, orderHash string, chain Chain) (*OrderCancellation, error) {
+	chain, err := o.resolveChain(chain)
+	if err != nil {
+		return nil, err
+	}
+	if orderHash == "" {
+		return nil, fmt.Errorf("Empty order hash")
+	}
+	if idempotencyKeyFromContext(ctx) == "" {
+		ctx = ContextWithIdempotencyKey(ctx, "cancel-"+orderHash)
+	}
+	ctx = contextWithChain(ctx, chain)
+	path := fmt.Sprintf("/api/v2/orders/chain/%s/protocol/%s/%s/cancel", chain, o.protocolAddress(DefaultProtocol), orderHash)
+	if o.dryRun {
+		o.logDryRun("POST", path, []byte("{}"))
+		return &OrderCancellation{OrderHash: orderHash, Chain: chain, DryRun: true}, nil
+	}
+
+	// Cancelling twice is harmless, so failed attempts are retried as is.
+	backoff := o.backoff
+	var b []byte
+	for attempt := 0; ; attempt++ {
+		resp := new(Response)
+		b, err = o.PostPath(ContextWithResponse(ctx, resp), path, struct{}{})
+		if r := responseFromContext(ctx); r != nil {
+			*r = *resp
+		}
+		if err == nil {
+			break
+		}
+		if attempt >= o.retries || ctx.Err() != nil || !retryableWrite(resp, err) {
+			return nil, err
+		}
+		if err := sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+
+	resp := &struct {
+		LastSignatureIssuedValidUntil *time.Time `json:"last_signature_issued_valid_until"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	c := &OrderCancellation{OrderHash: orderHash, Chain: chain}
+	if resp.LastSignatureIssuedValidUntil != nil {
+		c.LastSignatureIssuedValidUntil = *resp.LastSignatureIssuedValidUntil
+	}
+	return c, nil
+}
@@ -0,0 +1,52 @@
+package opensea
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelOrder(t *testing.T) {
+	const hash = "0x1234"
+	calls := 0
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, fmt.Sprintf("/api/v2/orders/chain/ethereum/protocol/%s/%s/cancel", DefaultProtocol.Address(), hash), r.URL.Path)
+		assert.Equal(t, "cancel-"+hash, r.Header.Get(IdempotencyKeyHeader))
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"last_signature_issued_valid_until":"2024-01-02T03:04:05Z"}`))
+	}, WithRetries(1, time.Millisecond))
+
+	c, err := o.CancelOrder(hash, ChainEthereum)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, hash, c.OrderHash)
+	assert.Equal(t, ChainEthereum, c.Chain)
+	until := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.True(t, until.Equal(c.LastSignatureIssuedValidUntil))
+	assert.Equal(t, CancellationPending, c.Status(until.Add(-time.Minute)))
+	assert.Equal(t, CancellationFinal, c.Status(until))
+	assert.False(t, c.DryRun)
+
+	_, err = o.CancelOrder("", ChainEthereum)
+	assert.NotNil(t, err)
+}
+
+func TestCancelOrderDryRun(t *testing.T) {
+	logger := &testLogger{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected %s %s", r.Method, r.URL.Path)
+	}, WithDryRun(), WithLogger(logger))
+	c, err := o.CancelOrder("0x1234", ChainEthereum)
+	assert.Nil(t, err)
+	assert.True(t, c.DryRun)
+	assert.Equal(t, CancellationDryRun, c.Status(time.Now()))
+	assert.Len(t, logger.lines, 1)
+}
@@ -0,0 +1,89 @@
+package opensea
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Capability is a group of endpoints that may or may not be available on a
+// chain.
+type Capability string
+
+const (
+	// CapabilityAssets covers the v1 assets, contract and events endpoints,
+	// which only index Ethereum.
+	CapabilityAssets           Capability = "assets"
+	CapabilityNFTs             Capability = "nfts"
+	CapabilityCollections      Capability = "collections"
+	CapabilityEvents           Capability = "events"
+	CapabilityListings         Capability = "listings"
+	CapabilityOffers           Capability = "offers"
+	CapabilityCollectionOffers Capability = "collection_offers"
+	CapabilityFulfillment      Capability = "fulfillment"
+	CapabilityStream           Capability = "stream"
+)
+
+// ErrUnsupportedOnChain is returned before sending a request that OpenSea
+// would reject because the chain does not support the capability.
+type ErrUnsupportedOnChain struct {
+	Chain      Chain
+	Capability Capability
+}
+
+func (e ErrUnsupportedOnChain) Error() string {
+	return fmt.Sprintf("%s not supported on chain %s", e.Capability, e.Chain)
+}
+
+var evmCapabilities = []Capability{
+	CapabilityNFTs,
+	CapabilityCollections,
+	CapabilityEvents,
+	CapabilityListings,
+	CapabilityOffers,
+	CapabilityCollectionOffers,
+	CapabilityFulfillment,
+	CapabilityStream,
+}
+
+var capabilityOverrides = map[Chain][]Capability{
+	ChainEthereum:     append([]Capability{CapabilityAssets}, evmCapabilities...),
+	ChainSepolia:      append([]Capability{CapabilityAssets}, evmCapabilities...),
+	ChainSolana:       {CapabilityNFTs, CapabilityCollections},
+	ChainSolanaDevnet: {CapabilityNFTs, CapabilityCollections},
+}
+
+// ChainCapabilities returns the capabilities available on the chain.
+func ChainCapabilities(chain Chain) []Capability {
+	if !chain.IsValid() {
+		return nil
+	}
+	caps, ok := capabilityOverrides[chain]
+	if !ok {
+		caps = evmCapabilities
+	}
+	ret := append([]Capability{}, caps...)
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+func SupportsCapability(chain Chain, capability Capability) bool {
+	for _, c := range ChainCapabilities(chain) {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// chainFor resolves the chain of a call like resolveChain and checks that it
+// supports the capability.
+func (o Opensea) chainFor(chain Chain, capability Capability) (Chain, error) {
+	chain, err := o.resolveChain(chain)
+	if err != nil {
+		return ChainNone, err
+	}
+	if !SupportsCapability(chain, capability) {
+		return ChainNone, ErrUnsupportedOnChain{Chain: chain, Capability: capability}
+	}
+	return chain, nil
+}
@@ -0,0 +1,27 @@
+package opensea
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainCapabilities(t *testing.T) {
+	assert.True(t, SupportsCapability(ChainEthereum, CapabilityAssets))
+	assert.True(t, SupportsCapability(ChainBase, CapabilityListings))
+	assert.False(t, SupportsCapability(ChainBase, CapabilityAssets))
+	assert.False(t, SupportsCapability(ChainSolana, CapabilityOffers))
+	assert.Nil(t, ChainCapabilities(Chain("unknown")))
+}
+
+func TestChainFor(t *testing.T) {
+	client, err := NewOpensea("", WithChain(ChainSolana))
+	assert.Nil(t, err)
+
+	c, err := client.chainFor(ChainNone, CapabilityNFTs)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainSolana, c)
+
+	_, err = client.chainFor(ChainNone, CapabilityListings)
+	assert.Equal(t, ErrUnsupportedOnChain{Chain: ChainSolana, Capability: CapabilityListings}, err)
+}
@@ -0,0 +1,170 @@
+package opensea
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Chain is the chain identifier used by the OpenSea v2 APIs.
+type Chain string
+
+const (
+	ChainNone      Chain = ""
+	ChainEthereum  Chain = "ethereum"
+	ChainPolygon   Chain = "matic"
+	ChainBase      Chain = "base"
+	ChainArbitrum  Chain = "arbitrum"
+	ChainOptimism  Chain = "optimism"
+	ChainAvalanche Chain = "avalanche"
+	ChainKlaytn    Chain = "klaytn"
+	ChainZora      Chain = "zora"
+	ChainBlast     Chain = "blast"
+	ChainSolana    Chain = "solana"
+
+	ChainSepolia         Chain = "sepolia"
+	ChainAmoy            Chain = "amoy"
+	ChainBaseSepolia     Chain = "base_sepolia"
+	ChainArbitrumSepolia Chain = "arbitrum_sepolia"
+	ChainOptimismSepolia Chain = "optimism_sepolia"
+	ChainAvalancheFuji   Chain = "avalanche_fuji"
+	ChainBaobab          Chain = "baobab"
+	ChainZoraSepolia     Chain = "zora_sepolia"
+	ChainBlastSepolia    Chain = "blast_sepolia"
+	ChainSolanaDevnet    Chain = "soldev"
+)
+
+// DefaultChain is used when neither the call nor the client specify a chain.
+const DefaultChain = ChainEthereum
+
+// DefaultTestnetChain is the default chain of clients created with
+// NewOpenseaTestnet.
+const DefaultTestnetChain = ChainSepolia
+
+var mainnetChains = map[Chain]bool{
+	ChainEthereum:  true,
+	ChainPolygon:   true,
+	ChainBase:      true,
+	ChainArbitrum:  true,
+	ChainOptimism:  true,
+	ChainAvalanche: true,
+	ChainKlaytn:    true,
+	ChainZora:      true,
+	ChainBlast:     true,
+	ChainSolana:    true,
+}
+
+var testnetChains = map[Chain]bool{
+	ChainSepolia:         true,
+	ChainAmoy:            true,
+	ChainBaseSepolia:     true,
+	ChainArbitrumSepolia: true,
+	ChainOptimismSepolia: true,
+	ChainAvalancheFuji:   true,
+	ChainBaobab:          true,
+	ChainZoraSepolia:     true,
+	ChainBlastSepolia:    true,
+	ChainSolanaDevnet:    true,
+}
+
+// chainIDs are the EIP-155 chain IDs of the EVM chains, used in the
+// signing domain of orders.
+var chainIDs = map[Chain]int64{
+	ChainEthereum:  1,
+	ChainPolygon:   137,
+	ChainBase:      8453,
+	ChainArbitrum:  42161,
+	ChainOptimism:  10,
+	ChainAvalanche: 43114,
+	ChainKlaytn:    8217,
+	ChainZora:      7777777,
+	ChainBlast:     81457,
+
+	ChainSepolia:         11155111,
+	ChainAmoy:            80002,
+	ChainBaseSepolia:     84532,
+	ChainArbitrumSepolia: 421614,
+	ChainOptimismSepolia: 11155420,
+	ChainAvalancheFuji:   43113,
+	ChainBaobab:          1001,
+	ChainZoraSepolia:     999999999,
+	ChainBlastSepolia:    168587773,
+}
+
+var chainAliases = map[string]Chain{
+	"eth":     ChainEthereum,
+	"mainnet": ChainEthereum,
+	"polygon": ChainPolygon,
+	"sol":     ChainSolana,
+}
+
+// ParseChain parses a chain identifier, accepting a few common aliases
+// such as "polygon" for "matic".
+func ParseChain(s string) (Chain, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if c, ok := chainAliases[s]; ok {
+		return c, nil
+	}
+	c := Chain(s)
+	if !c.IsValid() {
+		return ChainNone, fmt.Errorf("Invalid chain: %s", s)
+	}
+	return c, nil
+}
+
+func (c Chain) String() string {
+	return string(c)
+}
+
+func (c Chain) IsValid() bool {
+	return mainnetChains[c] || testnetChains[c]
+}
+
+func (c Chain) IsTestnet() bool {
+	return testnetChains[c]
+}
+
+// IsEVM reports whether addresses on the chain are 20-byte hex addresses.
+func (c Chain) IsEVM() bool {
+	return c != ChainSolana && c != ChainSolanaDevnet
+}
+
+// ChainID returns the EIP-155 chain ID of an EVM chain, or 0 for other
+// chains.
+func (c Chain) ChainID() int64 {
+	return chainIDs[c]
+}
+
+// chainsFor returns the chains served by the given base URL, or nil when the
+// base URL is not a known OpenSea endpoint (e.g. a proxy or a test server).
+func chainsFor(api string) map[Chain]bool {
+	switch api {
+	case mainnetAPI:
+		return mainnetChains
+	case testnetsAPI:
+		return testnetChains
+	}
+	return nil
+}
+
+// resolveChain returns the chain to use for a call: the given chain, falling
+// back to the client default and then DefaultChain. It fails when the chain
+// is not served by the client's endpoint.
+func (o Opensea) resolveChain(chain Chain) (Chain, error) {
+	if chain == ChainNone {
+		chain = o.Chain
+	}
+	if chain == ChainNone {
+		chain = DefaultChain
+		if o.API == testnetsAPI {
+			chain = DefaultTestnetChain
+		}
+	}
+	if supported := chainsFor(o.API); supported != nil {
+		if !supported[chain] {
+			return ChainNone, fmt.Errorf("Chain not supported by %s: %s", o.API, chain)
+		}
+	} else if !chain.IsValid() {
+		return ChainNone, fmt.Errorf("Invalid chain: %s", chain)
+	}
+	return chain, nil
+}
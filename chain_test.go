@@ -0,0 +1,54 @@
+package opensea
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChain(t *testing.T) {
+	c, err := ParseChain("Polygon")
+	assert.Nil(t, err)
+	assert.Equal(t, ChainPolygon, c)
+
+	c, err = ParseChain("base")
+	assert.Nil(t, err)
+	assert.Equal(t, ChainBase, c)
+
+	_, err = ParseChain("dogechain")
+	assert.NotNil(t, err)
+}
+
+func TestResolveChain(t *testing.T) {
+	client, err := NewOpensea("", WithChain(ChainBase))
+	assert.Nil(t, err)
+
+	c, err := client.resolveChain(ChainNone)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainBase, c)
+
+	c, err = client.resolveChain(ChainPolygon)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainPolygon, c)
+
+	_, err = client.resolveChain(Chain("sepolia"))
+	assert.NotNil(t, err)
+
+	_, err = NewOpensea("", WithChain(Chain("unknown")))
+	assert.NotNil(t, err)
+}
+
+func TestTestnetClient(t *testing.T) {
+	client, err := NewOpenseaTestnet("")
+	assert.Nil(t, err)
+
+	c, err := client.resolveChain(ChainNone)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainSepolia, c)
+
+	_, err = client.resolveChain(ChainEthereum)
+	assert.NotNil(t, err)
+
+	_, err = NewOpenseaRinkeby("")
+	assert.Equal(t, ErrRinkebyDeprecated, err)
+}
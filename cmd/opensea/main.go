@@ -0,0 +1,315 @@
+// Command opensea is a small command line client for the OpenSea API.
+//
+// It is configured through the environment variables read by
+// opensea.LoadConfigFromEnv, most importantly OPENSEA_API_KEY.
+//
+// Usage:
+//
+//	opensea assets [flags]
+//	opensea collection [flags] <slug>
+//	opensea stats [flags] <slug>
+//	opensea events [flags]
+//	opensea orders [flags]
+//	opensea stream tail [flags]
+//	opensea replay [flags] <dump>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/quintics-io/go-opensea/export"
+)
+
+type command func(ctx context.Context, o *opensea.Opensea, args []string) error
+
+var commands = map[string]command{
+	"assets":     runAssets,
+	"collection": runCollection,
+	"stats":      runStats,
+	"events":     runEvents,
+	"orders":     runOrders,
+	"stream":     runStream,
+	"replay":     runReplay,
+}
+
+var usages = []struct{ name, usage string }{
+	{"assets", "assets [-owner addr] [-contract addr] [-collection slug] [-limit n] [-cursor c] [-all]"},
+	{"collection", "collection <slug>"},
+	{"stats", "stats <slug>"},
+	{"events", "events [-contract addr] [-account addr] [-type t] [-since d] [-offset n]"},
+	{"orders", "orders -contract addr [-chain c] [-offers] [-since d]"},
+	{"stream", "stream tail [-contract addr] [-type t] [-interval d]"},
+	{"replay", "replay [-api url] <dump>"},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	o, err := opensea.NewClientFromEnv()
+	if err != nil {
+		fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := cmd(ctx, o, os.Args[2:]); err != nil {
+		fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: opensea <command> [flags]")
+	for _, u := range usages {
+		fmt.Fprintf(os.Stderr, "  opensea %s\n", u.usage)
+	}
+	fmt.Fprintln(os.Stderr, "every command accepts -output json|jsonl|csv|table (default json)")
+}
+
+func usageError(name string) error {
+	for _, u := range usages {
+		if u.name == name {
+			return fmt.Errorf("usage: opensea %s", u.usage)
+		}
+	}
+	return fmt.Errorf("unknown command: %s", name)
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "opensea:", err)
+	os.Exit(1)
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	output := fs.String("output", "json", "output format: json, jsonl, csv or table")
+	return fs, output
+}
+
+func runAssets(ctx context.Context, o *opensea.Opensea, args []string) error {
+	fs, output := newFlagSet("assets")
+	owner := fs.String("owner", "", "owner address")
+	contract := fs.String("contract", "", "asset contract address")
+	collection := fs.String("collection", "", "collection slug")
+	limit := fs.Int("limit", 50, "page size")
+	cursor := fs.String("cursor", "", "cursor to resume from")
+	all := fs.Bool("all", false, "follow the cursor until the last page")
+	fs.Parse(args)
+
+	params := opensea.GetAssetsParams{
+		Owner:                opensea.Address(*owner),
+		AssetContractAddress: opensea.Address(*contract),
+		Collection:           *collection,
+		Limit:                *limit,
+		Cursor:               *cursor,
+	}
+	w, err := newWriter(*output, export.AssetColumns)
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := o.GetAssetsWithContext(ctx, params)
+		if err != nil {
+			return err
+		}
+		for i := range resp.Assets {
+			if err := w.Write(&resp.Assets[i], export.AssetRecord(&resp.Assets[i])); err != nil {
+				return err
+			}
+		}
+		if resp.Next == "" {
+			break
+		}
+		// Printed so that an interrupted run can be resumed with -cursor.
+		fmt.Fprintf(os.Stderr, "next cursor: %s\n", resp.Next)
+		if !*all {
+			break
+		}
+		params.Cursor = resp.Next
+	}
+	return w.Flush()
+}
+
+func runCollection(ctx context.Context, o *opensea.Opensea, args []string) error {
+	fs, output := newFlagSet("collection")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return usageError("collection")
+	}
+
+	c, err := o.GetCollectionWithContext(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return writeObject(*output, c)
+}
+
+func runStats(ctx context.Context, o *opensea.Opensea, args []string) error {
+	fs, output := newFlagSet("stats")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return usageError("stats")
+	}
+
+	stats, err := o.GetCollectionStatsWithContext(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return writeObject(*output, stats)
+}
+
+func runEvents(ctx context.Context, o *opensea.Opensea, args []string) error {
+	fs, output := newFlagSet("events")
+	contract := fs.String("contract", "", "asset contract address")
+	account := fs.String("account", "", "account address")
+	eventType := fs.String("type", "", "event type, e.g. successful")
+	since := fs.Duration("since", time.Hour, "how far back to look")
+	offset := fs.Int("offset", 0, "offset to resume from")
+	fs.Parse(args)
+
+	params := opensea.NewRetrievingEventsParams()
+	if *contract != "" {
+		if err := params.SetAssetContractAddress(*contract); err != nil {
+			return err
+		}
+	}
+	if *account != "" {
+		if err := params.SetAccountAddress(*account); err != nil {
+			return err
+		}
+	}
+	params.EventType = opensea.EventType(*eventType)
+	params.OccurredAfter = time.Now().Add(-*since).Unix()
+	params.Offset = *offset
+
+	w, err := newWriter(*output, export.EventColumns)
+	if err != nil {
+		return err
+	}
+	err = o.RetrievingEventsPagesWithContext(ctx, params, func(events []*opensea.Event) error {
+		for _, e := range events {
+			if err := w.Write(e, export.EventRecord(e)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "resume with -offset %d\n", params.Offset)
+		return err
+	}
+	return w.Flush()
+}
+
+func runOrders(ctx context.Context, o *opensea.Opensea, args []string) error {
+	fs, output := newFlagSet("orders")
+	contract := fs.String("contract", "", "asset contract address")
+	chain := fs.String("chain", "", "chain of the orders, e.g. ethereum")
+	offers := fs.Bool("offers", false, "read offers instead of listings")
+	since := fs.Duration("since", 24*time.Hour, "how far back to look")
+	fs.Parse(args)
+	if *contract == "" {
+		return usageError("orders")
+	}
+
+	w, err := newWriter(*output, export.OrderColumns)
+	if err != nil {
+		return err
+	}
+	params := opensea.GetSeaportOrdersParams{
+		Chain:                opensea.Chain(*chain),
+		Offers:               *offers,
+		AssetContractAddress: opensea.Address(*contract),
+		ListedAfter:          time.Now().Add(-*since),
+	}
+	err = o.SeaportOrdersIterator(params)(ctx, func(item interface{}) error {
+		order := item.(opensea.SeaportOrder)
+		return w.Write(&order, export.OrderRecord(&order))
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// runStream tails events through an EventPoller, as the client has no
+// websocket Stream API support.
+func runStream(ctx context.Context, o *opensea.Opensea, args []string) error {
+	if len(args) == 0 || args[0] != "tail" {
+		return usageError("stream")
+	}
+	fs, output := newFlagSet("stream tail")
+	contract := fs.String("contract", "", "asset contract address")
+	eventType := fs.String("type", "", "event type, e.g. successful")
+	interval := fs.Duration("interval", 10*time.Second, "polling interval")
+	fs.Parse(args[1:])
+
+	params := opensea.NewRetrievingEventsParams()
+	if *contract != "" {
+		if err := params.SetAssetContractAddress(*contract); err != nil {
+			return err
+		}
+	}
+	params.EventType = opensea.EventType(*eventType)
+	params.OccurredAfter = time.Now().Unix()
+
+	w, err := newWriter(*output, export.EventColumns)
+	if err != nil {
+		return err
+	}
+	p := o.NewEventPoller(params, *interval)
+	p.OnError = func(err error) {
+		fmt.Fprintln(os.Stderr, "opensea:", err)
+	}
+	p.Start(ctx)
+	for e := range p.Events() {
+		if err := w.Write(e, export.EventRecord(e)); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	if err := p.Err(); err != context.Canceled {
+		return err
+	}
+	return nil
+}
+
+// runReplay sends a request dumped by opensea.WithFailureDumps again, to
+// the API of the environment or to -api, e.g. a fake server, and prints the
+// dump of the replay.
+func runReplay(ctx context.Context, o *opensea.Opensea, args []string) error {
+	fs, output := newFlagSet("replay")
+	api := fs.String("api", "", "base URL to replay against, defaults to the API of the environment")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return usageError("replay")
+	}
+
+	d, err := opensea.ReadRequestDump(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *api != "" {
+		o.API = *api
+	}
+	replay, err := o.ReplayRequestDump(ctx, d)
+	if err != nil {
+		return err
+	}
+	return writeObject(*output, replay)
+}
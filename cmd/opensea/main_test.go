@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *opensea.Opensea {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	o, err := opensea.NewOpensea("test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.API = srv.URL
+	return o
+}
+
+// captureStdout returns what f printed.
+func captureStdout(t *testing.T, f func() error) string {
+	buf := new(bytes.Buffer)
+	saved := stdout
+	stdout = buf
+	defer func() { stdout = saved }()
+	assert.Nil(t, f())
+	return buf.String()
+}
+
+// csvObject reads the header and the single row printed for an object.
+func csvObject(t *testing.T, out string) map[string]string {
+	rows, err := csv.NewReader(bytes.NewBufferString(out)).ReadAll()
+	assert.Nil(t, err)
+	if !assert.Len(t, rows, 2) {
+		return nil
+	}
+	assert.Len(t, rows[1], len(rows[0]))
+	ret := map[string]string{}
+	for i, column := range rows[0] {
+		ret[column] = rows[1][i]
+	}
+	return ret
+}
+
+func TestCSVOutput(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/collection/doodles-official":
+			w.Write([]byte(`{"collection":{"slug":"doodles-official","name":"Doodles","stats":{"floor_price":2.5},"payment_tokens":[{"symbol":"ETH"}]}}`))
+		case "/api/v1/collection/doodles-official/stats":
+			w.Write([]byte(`{"stats":{"floor_price":2.5,"num_owners":5000,"total_volume":123.25}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	c := csvObject(t, captureStdout(t, func() error {
+		return runCollection(ctx, o, []string{"-output", "csv", "doodles-official"})
+	}))
+	assert.Equal(t, "doodles-official", c["slug"])
+	assert.Equal(t, "Doodles", c["name"])
+	assert.Contains(t, c["stats"], `"floor_price":2.5`)
+	assert.Contains(t, c["payment_tokens"], `"symbol":"ETH"`)
+
+	s := csvObject(t, captureStdout(t, func() error {
+		return runStats(ctx, o, []string{"-output", "csv", "doodles-official"})
+	}))
+	assert.Equal(t, "doodles-official", s["slug"])
+	assert.Equal(t, "2.5", s["floor_price"])
+	assert.Equal(t, "5000", s["num_owners"])
+	assert.Equal(t, "123.25", s["total_volume"])
+}
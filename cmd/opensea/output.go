@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/quintics-io/go-opensea/export"
+)
+
+// stdout is where the commands print, swapped by the tests.
+var stdout io.Writer = os.Stdout
+
+// newWriter returns a writer printing items as indented JSON documents, JSON
+// lines or rows of a table sharing the column schemas of the export package.
+func newWriter(format string, columns []string) (export.Writer, error) {
+	var w export.Writer
+	switch format {
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		w = jsonWriter{enc}
+	case "jsonl":
+		w = export.NewJSONLinesWriter(stdout)
+	case "table":
+		w = tableWriter{tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)}
+	case "csv":
+		w = export.NewCSVWriter(stdout)
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", format)
+	}
+	return w, w.Begin(columns)
+}
+
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (w jsonWriter) Begin(columns []string) error {
+	return nil
+}
+
+func (w jsonWriter) Write(item interface{}, record []string) error {
+	return w.enc.Encode(item)
+}
+
+func (w jsonWriter) Flush() error {
+	return nil
+}
+
+type tableWriter struct {
+	tw *tabwriter.Writer
+}
+
+func (w tableWriter) Begin(columns []string) error {
+	_, err := fmt.Fprintln(w.tw, strings.Join(columns, "\t"))
+	return err
+}
+
+func (w tableWriter) Write(item interface{}, record []string) error {
+	_, err := fmt.Fprintln(w.tw, strings.Join(record, "\t"))
+	return err
+}
+
+func (w tableWriter) Flush() error {
+	return w.tw.Flush()
+}
+
+// writeObject prints a single struct, as a two column key/value table in
+// table mode and as a header and a row of its fields in csv mode.
+func writeObject(format string, v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		for _, f := range objectFields(rv) {
+			fmt.Fprintf(tw, "%s\t%s\n", f.name, f.value)
+		}
+		return tw.Flush()
+	case "csv":
+		columns, record := []string{}, []string{}
+		for _, f := range objectFields(rv) {
+			columns = append(columns, f.name)
+			record = append(record, f.value)
+		}
+		w, err := newWriter(format, columns)
+		if err != nil {
+			return err
+		}
+		if err := w.Write(v, record); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+
+	w, err := newWriter(format, nil)
+	if err != nil {
+		return err
+	}
+	if err := w.Write(v, nil); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+type objectField struct {
+	name, value string
+}
+
+// objectFields lists the fields of a struct by JSON name, flattening the
+// embedded structs. Composite values are JSON encoded.
+func objectFields(rv reflect.Value) []objectField {
+	ret := []objectField{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			ret = append(ret, objectFields(rv.Field(i))...)
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fv := rv.Field(i)
+		switch fv.Kind() {
+		case reflect.Struct, reflect.Slice, reflect.Map, reflect.Interface, reflect.Ptr:
+			b, _ := json.Marshal(fv.Interface())
+			ret = append(ret, objectField{name, string(b)})
+		default:
+			ret = append(ret, objectField{name, fmt.Sprintf("%v", fv.Interface())})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,205 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+type GetCollectionsParams struct {
+	// AssetOwner, an address or an ENS name, limits the collections to those
+	// the account owns assets of.
+	AssetOwner Address
+	Offset     int
+	// Limit defaults to 300, the maximum.
+	Limit int
+}
+
+type CollectionsResponse struct {
+	Collections []CollectionSingle `json:"collections" bson:"collections"`
+}
+
+func (o Opensea) GetCollections(params GetCollectionsParams) ([]CollectionSingle, error) {
+	ctx := context.TODO()
+	return o.GetCollectionsWithContext(ctx, params)
+}
+
+func (o Opensea) GetCollectionsWithContext(ctx context.Context, params GetCollectionsParams) ([]CollectionSingle, error) {
+	o.deprecated("GetCollections")
+	values := url.Values{}
+	if params.AssetOwner != "" {
+		owner, err := o.ownerAddress(ctx, params.AssetOwner)
+		if err != nil {
+			return nil, err
+		}
+		values.Set("asset_owner", owner.String())
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 300
+	}
+	values.Set("offset", fmt.Sprintf("%d", params.Offset))
+	values.Set("limit", fmt.Sprintf("%d", limit))
+
+	b, err := o.GetPath(ctx, "/api/v1/collections?"+values.Encode())
+	if err != nil {
+		return nil, err
+	}
+	resp := &CollectionsResponse{Collections: []CollectionSingle{}}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return resp.Collections, nil
+}
+
+// GetCollection returns a collection by slug, with its stats. It returns
+// ErrNotFound for unknown slugs.
+func (o Opensea) GetCollection(slug string) (*CollectionSingle, error) {
+	ctx := context.TODO()
+	return o.GetCollectionWithContext(ctx, slug)
+}
+
+func (o Opensea) GetCollectionWithContext(ctx context.Context, slug string) (*CollectionSingle, error) {
+	o.deprecated("GetCollection")
+	if slug == "" {
+		return nil, fmt.Errorf("Empty collection slug")
+	}
+	b, err := o.GetPath(ctx, "/api/v1/collection/"+url.PathEscape(slug))
+	if err != nil {
+		return nil, err
+	}
+	resp := new(CollectionSingleResponse)
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return &resp.Collection, nil
+}
+
+// GetEditableCollections returns the collections an address, or an ENS
+// name, may edit. The assets endpoint filters by editor, so every asset of
+// those collections is paged through; the collections are listed once each,
+// in the order met.
+func (o Opensea) GetEditableCollections(editor Address) ([]Collection, error) {
+	ctx := context.TODO()
+	return o.GetEditableCollectionsWithContext(ctx, editor)
+}
+
+func (o Opensea) GetEditableCollectionsWithContext(ctx context.Context, editor Address) ([]Collection, error) {
+	address, err := o.ResolveAddressWithContext(ctx, editor.String())
+	if err != nil {
+		return nil, err
+	}
+	ret := []Collection{}
+	seen := map[string]bool{}
+	params := GetAssetsParams{
+		CollectionEditor: address.String(),
+		Limit:            50,
+		Fields:           []string{"collection"},
+	}
+	for {
+		resp, err := o.GetAssetsWithContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Assets {
+			if a.Collection == nil || seen[a.Collection.Slug] {
+				continue
+			}
+			seen[a.Collection.Slug] = true
+			ret = append(ret, *a.Collection)
+		}
+		if resp.Next == "" {
+			break
+		}
+		params.Cursor = resp.Next
+	}
+	return ret, nil
+}
+
+// CollectionStats are the stats of a collection: floor price, volumes,
+// sales and owners.
+type CollectionStats struct {
+	Slug string `json:"slug" bson:"slug"`
+	Stat `bson:",inline"`
+}
+
+// GetCollectionStats returns the stats of a collection, much cheaper to
+// poll than GetCollection.
+func (o Opensea) GetCollectionStats(slug string) (*CollectionStats, error) {
+	ctx := context.TODO()
+	return o.GetCollectionStatsWithContext(ctx, slug)
+}
+
+func (o Opensea) GetCollectionStatsWithContext(ctx context.Context, slug string) (*CollectionStats, error) {
+	o.deprecated("GetCollectionStats")
+	if slug == "" {
+		return nil, fmt.Errorf("Empty collection slug")
+	}
+	stats, err := o.collectionStats(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return &CollectionStats{Slug: slug, Stat: *stats}, nil
+}
+
+func (o Opensea) collectionStats(ctx context.Context, slug string) (*Stat, error) {
+	b, err := o.GetPath(ctx, fmt.Sprintf("/api/v1/collection/%s/stats", url.PathEscape(slug)))
+	if err != nil {
+		return nil, err
+	}
+	resp := new(StatResponse)
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return &resp.Stats, nil
+}
+
+// collectionStatsV2 reads the stats of a collection from the v2 endpoint,
+// which covers its contracts on every chain, in the v1 model.
+func (o Opensea) collectionStatsV2(ctx context.Context, slug string) (*Stat, error) {
+	b, err := o.GetPath(ctx, fmt.Sprintf("/api/v2/collections/%s/stats", url.PathEscape(slug)))
+	if err != nil {
+		return nil, err
+	}
+	type interval struct {
+		Interval     string  `json:"interval"`
+		Volume       float64 `json:"volume"`
+		VolumeChange float64 `json:"volume_change"`
+		Sales        float64 `json:"sales"`
+		AveragePrice float64 `json:"average_price"`
+	}
+	resp := &struct {
+		Total struct {
+			Volume       float64 `json:"volume"`
+			Sales        float64 `json:"sales"`
+			AveragePrice float64 `json:"average_price"`
+			NumOwners    float64 `json:"num_owners"`
+			MarketCap    float64 `json:"market_cap"`
+			FloorPrice   float64 `json:"floor_price"`
+		} `json:"total"`
+		Intervals []interval `json:"intervals"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	s := &Stat{
+		TotalVolume:  resp.Total.Volume,
+		TotalSales:   resp.Total.Sales,
+		AveragePrice: resp.Total.AveragePrice,
+		NumOwners:    resp.Total.NumOwners,
+		MarketCap:    resp.Total.MarketCap,
+		FloorPrice:   resp.Total.FloorPrice,
+	}
+	for _, i := range resp.Intervals {
+		switch i.Interval {
+		case "one_day":
+			s.OneDayVolume, s.OneDayChange, s.OneDaySales, s.OneDayAveragePrice = i.Volume, i.VolumeChange, i.Sales, i.AveragePrice
+		case "seven_day":
+			s.SevenDayVolume, s.SevenDayChange, s.SevenDaySales, s.SevenDayAveragePrice = i.Volume, i.VolumeChange, i.Sales, i.AveragePrice
+		case "thirty_day":
+			s.ThirtyDayVolume, s.ThirtyDayChange, s.ThirtyDaySales, s.ThirtyDayAveragePrice = i.Volume, i.VolumeChange, i.Sales, i.AveragePrice
+		}
+	}
+	return s, nil
+}
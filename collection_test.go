@@ -0,0 +1,99 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCollections(t *testing.T) {
+	var query string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/collections", r.URL.Path)
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"collections":[{"slug":"doodles-official","name":"Doodles","banner_image_url":"https://img/banner.png","image_url":"https://img/logo.png",
+			"primary_asset_contracts":[{"address":"0x8a90cab2b38dba80c64b7734e58ee1db38b8992e","schema_name":"ERC721"}],
+			"stats":{"floor_price":2.5,"num_owners":5000,"total_volume":100000},
+			"fees":{"seller_fees":{"0xdcfe18bc46f5a0cd0d3af0c2155d2bcb5ade2fc5":500},"opensea_fees":{"0x0000a26b00c1f0df003000390027140000faa719":250}}}]}`))
+	})
+
+	collections, err := o.GetCollectionsWithContext(context.Background(), GetCollectionsParams{
+		AssetOwner: "0x1111111111111111111111111111111111111111",
+		Offset:     10,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "asset_owner=0x1111111111111111111111111111111111111111&limit=300&offset=10", query)
+	assert.Len(t, collections, 1)
+	c := collections[0]
+	assert.Equal(t, "doodles-official", c.Slug)
+	assert.Equal(t, "https://img/banner.png", c.BannerImageUrl)
+	assert.Equal(t, "ERC721", c.PrimaryAssetContracts[0].SchemaName)
+	assert.Equal(t, 2.5, c.Stats.FloorPrice)
+	assert.Equal(t, int64(500), c.Fees.SellerFees["0xdcfe18bc46f5a0cd0d3af0c2155d2bcb5ade2fc5"])
+	assert.Equal(t, int64(250), c.Fees.OpenseaFees[OpenSeaFeeRecipient.String()])
+}
+
+func TestGetCollection(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/collection/doodles-official" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"collection":{"slug":"doodles-official","name":"Doodles","editors":["0x000000000000000000000000000000000000000A"],
+			"stats":{"floor_price":2.5,"total_volume":100000,"num_owners":5000,"one_day_change":0.1,"seven_day_change":-0.2,"thirty_day_change":0.3}}}`))
+	})
+
+	c, err := o.GetCollectionWithContext(context.Background(), "doodles-official")
+	assert.Nil(t, err)
+	assert.Equal(t, "Doodles", c.Name)
+	assert.True(t, c.IsEditor("0x000000000000000000000000000000000000000a"))
+	assert.False(t, c.IsEditor(NullAddress))
+	assert.Equal(t, 2.5, c.Stats.FloorPrice)
+	assert.Equal(t, 100000.0, c.Stats.TotalVolume)
+	assert.Equal(t, 5000.0, c.Stats.NumOwners)
+	assert.Equal(t, []float64{0.1, -0.2, 0.3}, []float64{c.Stats.OneDayChange, c.Stats.SevenDayChange, c.Stats.ThirtyDayChange})
+
+	_, err = o.GetCollectionWithContext(context.Background(), "unknown")
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetCollectionWithContext(context.Background(), "")
+	assert.NotNil(t, err)
+}
+
+func TestGetCollectionStats(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/collection/doodles-official/stats", r.URL.Path)
+		w.Write([]byte(`{"stats":{"floor_price":2.5,"one_day_volume":120.5,"total_volume":100000,"num_owners":5000}}`))
+	})
+
+	stats, err := o.GetCollectionStatsWithContext(context.Background(), "doodles-official")
+	assert.Nil(t, err)
+	assert.Equal(t, "doodles-official", stats.Slug)
+	assert.Equal(t, 2.5, stats.FloorPrice)
+	assert.Equal(t, 120.5, stats.OneDayVolume)
+	assert.Equal(t, 5000.0, stats.NumOwners)
+
+	_, err = o.GetCollectionStatsWithContext(context.Background(), "")
+	assert.NotNil(t, err)
+}
+
+func TestGetEditableCollections(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/assets", r.URL.Path)
+		assert.Equal(t, "0x000000000000000000000000000000000000000a", r.URL.Query().Get("collection_editor"))
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"assets":[{"collection":{"slug":"a"}},{"collection":{"slug":"a"}}],"next":"page2"}`))
+			return
+		}
+		w.Write([]byte(`{"assets":[{"collection":{"slug":"b"}},{"collection":{"slug":"a"}}]}`))
+	})
+	collections, err := o.GetEditableCollectionsWithContext(context.Background(), "0x000000000000000000000000000000000000000A")
+	assert.Nil(t, err)
+	assert.Len(t, collections, 2)
+	assert.Equal(t, "a", collections[0].Slug)
+	assert.Equal(t, "b", collections[1].Slug)
+
+	_, err = o.GetEditableCollectionsWithContext(context.Background(), "me")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,127 @@
+package opensea
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+type Environment string
+
+const (
+	EnvironmentMainnet Environment = "mainnet"
+	EnvironmentTestnet Environment = "testnet"
+)
+
+// Config gathers everything needed to build a client.
+type Config struct {
+	APIKey      string
+	Environment Environment
+	// API overrides the base URL selected by Environment, e.g. for a proxy.
+	API       string
+	Chain     Chain
+	Timeout   time.Duration
+	RateLimit RateLimit
+	// Headers and Query are added to every request, e.g. for an API gateway.
+	Headers http.Header
+	Query   url.Values
+}
+
+// LoadConfigFromEnv reads a Config from the following variables:
+//
+//	OPENSEA_API_KEY     API key
+//	OPENSEA_ENV         "mainnet" (default) or "testnet"
+//	OPENSEA_API_URL     base URL override
+//	OPENSEA_CHAIN       default chain, e.g. "ethereum" or "polygon"
+//	OPENSEA_TIMEOUT     HTTP timeout as a Go duration, e.g. "30s"
+//	OPENSEA_RATE_LIMIT  requests per second
+//	OPENSEA_RATE_BURST  rate limiter burst
+func LoadConfigFromEnv() (Config, error) {
+	cfg := Config{
+		APIKey:      os.Getenv("OPENSEA_API_KEY"),
+		Environment: Environment(os.Getenv("OPENSEA_ENV")),
+		API:         os.Getenv("OPENSEA_API_URL"),
+	}
+
+	if s := os.Getenv("OPENSEA_CHAIN"); s != "" {
+		chain, err := ParseChain(s)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Chain = chain
+	}
+	if s := os.Getenv("OPENSEA_TIMEOUT"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return cfg, fmt.Errorf("Invalid OPENSEA_TIMEOUT: %s", s)
+		}
+		cfg.Timeout = d
+	}
+	if s := os.Getenv("OPENSEA_RATE_LIMIT"); s != "" {
+		rps, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return cfg, fmt.Errorf("Invalid OPENSEA_RATE_LIMIT: %s", s)
+		}
+		cfg.RateLimit.RequestsPerSecond = rps
+	}
+	if s := os.Getenv("OPENSEA_RATE_BURST"); s != "" {
+		burst, err := strconv.Atoi(s)
+		if err != nil {
+			return cfg, fmt.Errorf("Invalid OPENSEA_RATE_BURST: %s", s)
+		}
+		cfg.RateLimit.Burst = burst
+	}
+	return cfg, nil
+}
+
+// NewClient builds a client from cfg. Extra options are applied after the
+// ones derived from cfg.
+func NewClient(cfg Config, opts ...Option) (*Opensea, error) {
+	var cfgOpts []Option
+	if cfg.API != "" {
+		cfgOpts = append(cfgOpts, WithAPI(cfg.API))
+	}
+	if cfg.Chain != ChainNone {
+		cfgOpts = append(cfgOpts, WithChain(cfg.Chain))
+	}
+	if cfg.Timeout > 0 {
+		cfgOpts = append(cfgOpts, WithTimeout(cfg.Timeout))
+	}
+	if !cfg.RateLimit.IsZero() {
+		cfgOpts = append(cfgOpts, WithRateLimit(cfg.RateLimit))
+	}
+	if len(cfg.Headers) > 0 {
+		cfgOpts = append(cfgOpts, WithHeaders(cfg.Headers))
+	}
+	if len(cfg.Query) > 0 {
+		cfgOpts = append(cfgOpts, WithQueryParams(cfg.Query))
+	}
+	opts = append(cfgOpts, opts...)
+
+	var o *Opensea
+	var err error
+	switch cfg.Environment {
+	case "", EnvironmentMainnet:
+		o, err = NewOpensea(cfg.APIKey, opts...)
+	case EnvironmentTestnet:
+		o, err = NewOpenseaTestnet(cfg.APIKey, opts...)
+	default:
+		return nil, fmt.Errorf("Invalid environment: %s", cfg.Environment)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// NewClientFromEnv is a shortcut for LoadConfigFromEnv followed by NewClient.
+func NewClientFromEnv(opts ...Option) (*Opensea, error) {
+	cfg, err := LoadConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(cfg, opts...)
+}
@@ -0,0 +1,51 @@
+package opensea
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv("OPENSEA_API_KEY", "secret")
+	t.Setenv("OPENSEA_ENV", "testnet")
+	t.Setenv("OPENSEA_CHAIN", "base_sepolia")
+	t.Setenv("OPENSEA_TIMEOUT", "5s")
+	t.Setenv("OPENSEA_RATE_LIMIT", "2")
+
+	client, err := NewClientFromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", client.APIKey)
+	assert.Equal(t, testnetsAPI, client.API)
+	assert.Equal(t, ChainBaseSepolia, client.Chain)
+	assert.Equal(t, 5*time.Second, client.httpClient.Timeout)
+	assert.NotNil(t, client.limiter)
+}
+
+func TestNewClientAPI(t *testing.T) {
+	// the chain is validated against the configured endpoint
+	client, err := NewClient(Config{Environment: EnvironmentTestnet, API: mainnetAPI, Chain: ChainEthereum})
+	assert.Nil(t, err)
+	assert.Equal(t, mainnetAPI, client.API)
+	assert.Equal(t, ChainEthereum, client.Chain)
+
+	_, err = NewClient(Config{API: testnetsAPI, Chain: ChainEthereum})
+	assert.NotNil(t, err)
+
+	client, err = NewClient(Config{API: "https://proxy.example.com", Chain: ChainPolygon})
+	assert.Nil(t, err)
+	assert.Equal(t, "https://proxy.example.com", client.API)
+}
+
+func TestNewClientInvalidConfig(t *testing.T) {
+	_, err := NewClient(Config{Environment: "staging"})
+	assert.NotNil(t, err)
+
+	_, err = NewClient(Config{Chain: ChainSepolia})
+	assert.NotNil(t, err)
+
+	t.Setenv("OPENSEA_TIMEOUT", "soon")
+	_, err = LoadConfigFromEnv()
+	assert.NotNil(t, err)
+}
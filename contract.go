@@ -3,6 +3,7 @@ package opensea
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 )
 
 type Contract struct {
@@ -37,6 +38,10 @@ func (o Opensea) GetSingleContract(assetContractAddress string) (*Contract, erro
 }
 
 func (o Opensea) GetSingleContractWithContext(ctx context.Context, assetContractAddress string) (contract *Contract, err error) {
+	o.deprecated("GetSingleContract")
+	if o.v2Compat {
+		return o.getSingleContractV2(ctx, assetContractAddress)
+	}
 	path := "/api/v1/asset_contract/" + assetContractAddress
 	b, err := o.GetPath(ctx, path)
 	if err != nil {
@@ -47,3 +52,32 @@ func (o Opensea) GetSingleContractWithContext(ctx context.Context, assetContract
 	err = json.Unmarshal(b, contract)
 	return
 }
+
+// Supply returns the total supply of the contract, when reported.
+func (c Contract) Supply() (int64, bool) {
+	switch v := c.TotalSupply.(type) {
+	case float64:
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// GetAssetContract returns an asset contract, with its schema, symbol,
+// supply, collection and fees. Unlike GetSingleContract, it takes an Address
+// and checks it with ParseAddress, so an invalid address fails before any
+// request is sent.
+func (o Opensea) GetAssetContract(address Address) (*Contract, error) {
+	ctx := context.TODO()
+	return o.GetAssetContractWithContext(ctx, address)
+}
+
+func (o Opensea) GetAssetContractWithContext(ctx context.Context, address Address) (*Contract, error) {
+	a, err := ParseAddress(address.String())
+	if err != nil {
+		return nil, err
+	}
+	return o.GetSingleContractWithContext(ctx, a.String())
+}
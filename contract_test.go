@@ -1,7 +1,12 @@
 package opensea
 
 import (
+	"context"
+	"io/ioutil"
+	"net/http"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetSingleContract(t *testing.T) {
@@ -12,3 +17,35 @@ func TestGetSingleContract(t *testing.T) {
 
 	print(*ret)
 }
+
+func TestGetAssetContract(t *testing.T) {
+	fixture, err := ioutil.ReadFile("test-files/opeansea-contract.json")
+	assert.Nil(t, err)
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/asset_contract/0xdceaf1652a131f32a821468dc03a92df0edd86ea" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(fixture)
+	})
+
+	c, err := o.GetAssetContractWithContext(context.Background(), "0xDCEAF1652A131F32A821468DC03A92DF0EDD86EA")
+	assert.Nil(t, err)
+	assert.Equal(t, "ERC721", c.SchemaName)
+	assert.Equal(t, "MCHE", c.Symbol)
+	assert.Equal(t, "mycryptoheroes", c.Collection.Slug)
+	assert.Equal(t, int64(1000), c.SellerFeeBasisPoints)
+	assert.Equal(t, Address("0xe7af11370c3bab51230d8307454350bdf6d68f4a"), c.PayoutAddress)
+	_, ok := c.Supply()
+	assert.False(t, ok)
+
+	c.TotalSupply = "10000"
+	supply, ok := c.Supply()
+	assert.True(t, ok)
+	assert.Equal(t, int64(10000), supply)
+
+	_, err = o.GetAssetContractWithContext(context.Background(), "0x1111111111111111111111111111111111111111")
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetAssetContractWithContext(context.Background(), "contract")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,137 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChainContract identifies a contract deployment on a chain.
+type ChainContract struct {
+	Chain   Chain
+	Address Address
+}
+
+// ChainAsset is an asset labeled with the chain it was fetched from.
+type ChainAsset struct {
+	Chain Chain `json:"chain" bson:"chain"`
+	Asset
+}
+
+type CrossChainCollection struct {
+	Slug   string       `json:"slug" bson:"slug"`
+	Assets []ChainAsset `json:"assets" bson:"assets"`
+	Stats  *Stat        `json:"stats" bson:"stats"`
+}
+
+// GetCrossChainCollection fetches the NFTs of every deployment of a
+// collection and merges them, together with the collection stats. Each
+// contract is fetched through the client of its chain, from the v2 NFT and
+// contract endpoints.
+//
+// Deployments listed by OpenSea under another collection than slug add the
+// stats of that collection: counts and volumes are summed, the floor is the
+// lowest one. Prices are summed in the units each collection reports them
+// in, so only merge deployments priced in the same currency.
+func (m *MultiChainClient) GetCrossChainCollection(ctx context.Context, slug string, contracts []ChainContract) (*CrossChainCollection, error) {
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("No contract given for collection %s", slug)
+	}
+
+	ret := &CrossChainCollection{
+		Slug:   slug,
+		Assets: []ChainAsset{},
+	}
+	// the stats of a collection are read once, by the client of its first
+	// deployment
+	first, err := m.Client(contracts[0].Chain)
+	if err != nil {
+		return nil, err
+	}
+	slugs := []string{slug}
+	clients := map[string]*Opensea{slug: first}
+	for _, c := range contracts {
+		o, err := m.Client(c.Chain)
+		if err != nil {
+			return nil, err
+		}
+		params := GetNFTsParams{}
+		for {
+			resp, err := o.GetNFTsByContractWithContext(ctx, c.Chain, c.Address, params)
+			if err != nil {
+				return nil, err
+			}
+			for _, n := range resp.NFTs {
+				ret.Assets = append(ret.Assets, ChainAsset{Chain: c.Chain, Asset: *n.Asset()})
+			}
+			if resp.Next == "" {
+				break
+			}
+			params.Cursor = resp.Next
+		}
+
+		contract, err := o.contractV2(ctx, c.Chain, c.Address.String())
+		if err != nil {
+			return nil, err
+		}
+		if s := contract.Collection.Slug; s != "" && clients[s] == nil {
+			slugs = append(slugs, s)
+			clients[s] = o
+		}
+	}
+
+	stats := []*Stat{}
+	for _, s := range slugs {
+		stat, err := clients[s].collectionStatsV2(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	ret.Stats = mergeStats(stats)
+	return ret, nil
+}
+
+// mergeStats sums the counts and volumes of stats, averages the prices
+// over the sales and changes over the volumes, and keeps the lowest floor.
+func mergeStats(stats []*Stat) *Stat {
+	if len(stats) == 1 {
+		return stats[0]
+	}
+	ret := &Stat{}
+	var oneDay, sevenDay, thirtyDay float64
+	for _, s := range stats {
+		ret.OneDayVolume += s.OneDayVolume
+		ret.OneDaySales += s.OneDaySales
+		ret.SevenDayVolume += s.SevenDayVolume
+		ret.SevenDaySales += s.SevenDaySales
+		ret.ThirtyDayVolume += s.ThirtyDayVolume
+		ret.ThirtyDaySales += s.ThirtyDaySales
+		ret.TotalVolume += s.TotalVolume
+		ret.TotalSales += s.TotalSales
+		ret.TotalSupply += s.TotalSupply
+		ret.Count += s.Count
+		ret.NumOwners += s.NumOwners
+		ret.NumReports += s.NumReports
+		ret.MarketCap += s.MarketCap
+		oneDay += s.OneDayChange * s.OneDayVolume
+		sevenDay += s.SevenDayChange * s.SevenDayVolume
+		thirtyDay += s.ThirtyDayChange * s.ThirtyDayVolume
+		if s.FloorPrice > 0 && (ret.FloorPrice == 0 || s.FloorPrice < ret.FloorPrice) {
+			ret.FloorPrice = s.FloorPrice
+		}
+	}
+	ratio := func(a, b float64) float64 {
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	}
+	ret.OneDayChange = ratio(oneDay, ret.OneDayVolume)
+	ret.SevenDayChange = ratio(sevenDay, ret.SevenDayVolume)
+	ret.ThirtyDayChange = ratio(thirtyDay, ret.ThirtyDayVolume)
+	ret.OneDayAveragePrice = ratio(ret.OneDayVolume, ret.OneDaySales)
+	ret.SevenDayAveragePrice = ratio(ret.SevenDayVolume, ret.SevenDaySales)
+	ret.ThirtyDayAveragePrice = ratio(ret.ThirtyDayVolume, ret.ThirtyDaySales)
+	ret.AveragePrice = ratio(ret.TotalVolume, ret.TotalSales)
+	return ret
+}
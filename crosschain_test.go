@@ -0,0 +1,68 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCrossChainCollection(t *testing.T) {
+	const polygonContract = "0x2222222222222222222222222222222222222222"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/chain/ethereum/contract/" + contract + "/nfts":
+			if r.URL.Query().Get("next") == "" {
+				w.Write([]byte(`{"nfts":[{"identifier":"1"}],"next":"page2"}`))
+				return
+			}
+			w.Write([]byte(`{"nfts":[{"identifier":"2"}]}`))
+		case "/api/v2/chain/matic/contract/" + polygonContract + "/nfts":
+			w.Write([]byte(`{"nfts":[{"identifier":"7"}]}`))
+		case "/api/v2/chain/ethereum/contract/" + contract:
+			w.Write([]byte(`{"address":"` + contract + `","collection":"doodles-official"}`))
+		case "/api/v2/chain/matic/contract/" + polygonContract:
+			w.Write([]byte(`{"address":"` + polygonContract + `","collection":"doodles-polygon"}`))
+		case "/api/v2/collections/doodles-official/stats":
+			w.Write([]byte(`{"total":{"volume":100,"sales":10,"num_owners":5,"floor_price":1.5},
+				"intervals":[{"interval":"one_day","volume":6,"volume_change":0.5,"sales":2}]}`))
+		case "/api/v2/collections/doodles-polygon/stats":
+			w.Write([]byte(`{"total":{"volume":20,"sales":10,"num_owners":3,"floor_price":0.5},
+				"intervals":[{"interval":"one_day","volume":2,"volume_change":-0.5,"sales":2}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	m, err := NewMultiChainClient("key", map[Chain]ChainConfig{
+		ChainEthereum: {API: srv.URL},
+		ChainPolygon:  {API: srv.URL},
+	})
+	assert.Nil(t, err)
+
+	ret, err := m.GetCrossChainCollection(context.Background(), "doodles-official", []ChainContract{
+		{Chain: ChainEthereum, Address: Address(contract)},
+		{Chain: ChainPolygon, Address: polygonContract},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, ret.Assets, 3)
+	assert.Equal(t, ChainEthereum, ret.Assets[0].Chain)
+	assert.Equal(t, "2", ret.Assets[1].TokenID)
+	assert.Equal(t, ChainPolygon, ret.Assets[2].Chain)
+	assert.Equal(t, "7", ret.Assets[2].TokenID)
+
+	assert.Equal(t, 120.0, ret.Stats.TotalVolume)
+	assert.Equal(t, 20.0, ret.Stats.TotalSales)
+	assert.Equal(t, 6.0, ret.Stats.AveragePrice)
+	assert.Equal(t, 8.0, ret.Stats.NumOwners)
+	assert.Equal(t, 0.5, ret.Stats.FloorPrice)
+	assert.Equal(t, 8.0, ret.Stats.OneDayVolume)
+	assert.Equal(t, 0.25, ret.Stats.OneDayChange)
+	assert.Equal(t, 2.0, ret.Stats.OneDayAveragePrice)
+
+	_, err = m.GetCrossChainCollection(context.Background(), "doodles-official", nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,109 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// Deprecation describes a method calling a v1 endpoint that OpenSea is
+// sunsetting.
+type Deprecation struct {
+	Method      string
+	Replacement string
+	// Compat is true when WithV2Compat routes the method to v2.
+	Compat bool
+}
+
+var deprecations = map[string]Deprecation{
+	"GetAssets":          {Method: "GetAssets", Replacement: "GetNFTsByAccount, GetNFTsByContract or GetNFTsByCollection"},
+	"GetSingleAsset":     {Method: "GetSingleAsset", Replacement: "GetNFT", Compat: true},
+	"GetSingleContract":  {Method: "GetSingleContract", Replacement: "/api/v2/chain/{chain}/contract/{address}", Compat: true},
+	"RetrievingEvents":   {Method: "RetrievingEvents", Replacement: "the v2 events endpoints"},
+	"GetEvents":          {Method: "GetEvents", Replacement: "the v2 events endpoints"},
+	"GetBundles":         {Method: "GetBundles", Replacement: "bundle listings of the v2 listings endpoints"},
+	"GetOrders":          {Method: "GetOrders", Replacement: "GetSeaportOrders"},
+	"GetCollections":     {Method: "GetCollections", Replacement: "/api/v2/collections"},
+	"GetCollection":      {Method: "GetCollection", Replacement: "/api/v2/collections/{slug}"},
+	"GetCollectionStats": {Method: "GetCollectionStats", Replacement: "/api/v2/collections/{slug}/stats"},
+}
+
+// Deprecations lists the deprecated methods and their replacements.
+func Deprecations() []Deprecation {
+	ret := make([]Deprecation, 0, len(deprecations))
+	for _, d := range deprecations {
+		ret = append(ret, d)
+	}
+	return ret
+}
+
+var warned sync.Map
+
+// deprecated warns once per process through the logger of the client.
+func (o Opensea) deprecated(method string) {
+	d, ok := deprecations[method]
+	if !ok || o.logger == nil {
+		return
+	}
+	if _, loaded := warned.LoadOrStore(method, true); loaded {
+		return
+	}
+	hint := ""
+	if d.Compat && !o.v2Compat {
+		hint = ", or enable WithV2Compat"
+	}
+	o.logf("opensea: %s calls a deprecated v1 endpoint, use %s instead%s", d.Method, d.Replacement, hint)
+}
+
+// WithV2Compat routes deprecated methods that have a v2 equivalent to it,
+// converting the v2 response to the v1 model. Fields without a v2
+// counterpart are left empty. Calls use the default chain of the client.
+func WithV2Compat() Option {
+	return func(o *Opensea) {
+		o.v2Compat = true
+	}
+}
+
+func (o Opensea) getSingleAssetV2(ctx context.Context, assetContractAddress string, tokenID *big.Int) (*Asset, error) {
+	n, err := o.GetNFTWithContext(ctx, ChainNone, Address(assetContractAddress), tokenID.String())
+	if err != nil {
+		return nil, err
+	}
+	return n.Asset(), nil
+}
+
+func (o Opensea) getSingleContractV2(ctx context.Context, assetContractAddress string) (*Contract, error) {
+	return o.contractV2(ctx, ChainNone, assetContractAddress)
+}
+
+// contractV2 reads a contract of chain from the v2 endpoint, in the v1
+// model.
+func (o Opensea) contractV2(ctx context.Context, chain Chain, assetContractAddress string) (*Contract, error) {
+	chain, err := o.chainFor(chain, CapabilityNFTs)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/api/v2/chain/%s/contract/%s", chain, assetContractAddress)
+	b, err := o.GetPath(contextWithChain(ctx, chain), path)
+	if err != nil {
+		return nil, err
+	}
+	ret := &struct {
+		Address          Address `json:"address"`
+		Collection       string  `json:"collection"`
+		ContractStandard string  `json:"contract_standard"`
+		Name             string  `json:"name"`
+	}{}
+	if err := json.Unmarshal(b, ret); err != nil {
+		return nil, err
+	}
+	return &Contract{
+		Address:    ret.Address,
+		Name:       ret.Name,
+		SchemaName: strings.ToUpper(ret.ContractStandard),
+		Collection: Collection{Slug: ret.Collection},
+	}, nil
+}
@@ -0,0 +1,58 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestDeprecationWarning(t *testing.T) {
+	warned.Delete("GetSingleContract")
+	logger := &testLogger{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}, WithLogger(logger))
+
+	o.GetSingleContractWithContext(context.Background(), "0x1")
+	o.GetSingleContractWithContext(context.Background(), "0x1")
+	assert.Equal(t, []string{
+		"opensea: GetSingleContract calls a deprecated v1 endpoint, use /api/v2/chain/{chain}/contract/{address} instead, or enable WithV2Compat",
+	}, logger.lines)
+}
+
+func TestV2Compat(t *testing.T) {
+	var paths []string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v2/chain/ethereum/contract/0x8a90cab2b38dba80c64b7734e58ee1db38b8992e/nfts/1":
+			w.Write([]byte(`{"nft":{"identifier":"1","collection":"doodles-official","contract":"0x8a90cab2b38dba80c64b7734e58ee1db38b8992e","name":"Doodle #1","opensea_url":"https://opensea.io/assets/ethereum/0x8a90cab2b38dba80c64b7734e58ee1db38b8992e/1","owners":[{"address":"0x0000000000000000000000000000000000000001","quantity":1}]}}`))
+		case "/api/v2/chain/ethereum/contract/0x8a90cab2b38dba80c64b7734e58ee1db38b8992e":
+			w.Write([]byte(`{"address":"0x8a90cab2b38dba80c64b7734e58ee1db38b8992e","chain":"ethereum","collection":"doodles-official","contract_standard":"erc721","name":"Doodles"}`))
+		}
+	}, WithV2Compat())
+
+	a, err := o.GetSingleAssetWithContext(context.Background(), "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e", big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, "Doodle #1", a.Name)
+	assert.Equal(t, "doodles-official", a.Collection.Slug)
+	assert.Equal(t, Address("0x0000000000000000000000000000000000000001"), a.Owner.Address)
+
+	c, err := o.GetSingleContractWithContext(context.Background(), "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e")
+	assert.Nil(t, err)
+	assert.Equal(t, "ERC721", c.SchemaName)
+	assert.Equal(t, "doodles-official", c.Collection.Slug)
+	assert.Len(t, paths, 2)
+}
@@ -0,0 +1,103 @@
+package opensea
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// ChangeKind groups the fields of a Change.
+type ChangeKind string
+
+const (
+	ChangePrice    ChangeKind = "price"
+	ChangeOwner    ChangeKind = "owner"
+	ChangeMetadata ChangeKind = "metadata"
+	ChangeImage    ChangeKind = "image"
+)
+
+// Change is a field that differs between two versions of an asset. Field is
+// the JSON name of the field, dotted for nested fields.
+type Change struct {
+	Field string      `json:"field" bson:"field"`
+	Kind  ChangeKind  `json:"kind" bson:"kind"`
+	Old   interface{} `json:"old" bson:"old"`
+	New   interface{} `json:"new" bson:"new"`
+}
+
+type assetField struct {
+	name  string
+	kind  ChangeKind
+	value func(a *Asset) interface{}
+}
+
+var assetFields = []assetField{
+	{"last_sale.total_price", ChangePrice, func(a *Asset) interface{} {
+		if a.LastSale == nil {
+			return ""
+		}
+		return a.LastSale.TotalPrice
+	}},
+	{"last_sale.payment_token", ChangePrice, func(a *Asset) interface{} {
+		if a.LastSale == nil || a.LastSale.PaymentToken == nil {
+			return ""
+		}
+		return a.LastSale.PaymentToken.Symbol
+	}},
+	{"num_sales", ChangePrice, func(a *Asset) interface{} { return a.NumSales }},
+	{"owner.address", ChangeOwner, func(a *Asset) interface{} {
+		if a.Owner == nil {
+			return Address("")
+		}
+		return a.Owner.Address
+	}},
+	{"name", ChangeMetadata, func(a *Asset) interface{} { return a.Name }},
+	{"description", ChangeMetadata, func(a *Asset) interface{} { return a.Description }},
+	{"external_link", ChangeMetadata, func(a *Asset) interface{} { return a.ExternalLink }},
+	{"token_metadata", ChangeMetadata, func(a *Asset) interface{} { return a.TokenMetadata }},
+	{"traits", ChangeMetadata, func(a *Asset) interface{} { return a.Traits }},
+	{"background_color", ChangeImage, func(a *Asset) interface{} { return a.BackgroundColor }},
+	{"image_url", ChangeImage, func(a *Asset) interface{} { return a.ImageURL }},
+	{"image_preview_url", ChangeImage, func(a *Asset) interface{} { return a.ImagePreviewURL }},
+	{"image_thumbnail_url", ChangeImage, func(a *Asset) interface{} { return a.ImageThumbnailURL }},
+	{"image_original_url", ChangeImage, func(a *Asset) interface{} { return a.ImageOriginalURL }},
+	{"animation_url", ChangeImage, func(a *Asset) interface{} { return a.AnimationURL }},
+	{"animation_original_url", ChangeImage, func(a *Asset) interface{} { return a.AnimationOriginalURL }},
+}
+
+// DiffAssets lists the fields that changed from old to new, in a stable
+// order. A nil asset compares as an empty one.
+func DiffAssets(old *Asset, new *Asset) []Change {
+	if old == nil {
+		old = &Asset{}
+	}
+	if new == nil {
+		new = &Asset{}
+	}
+
+	changes := []Change{}
+	for _, f := range assetFields {
+		a, b := f.value(old), f.value(new)
+		if equalValues(a, b) {
+			continue
+		}
+		changes = append(changes, Change{Field: f.name, Kind: f.kind, Old: a, New: b})
+	}
+	return changes
+}
+
+// equalValues compares decoded JSON values, such as traits, by their
+// encoding so that map ordering does not matter.
+func equalValues(a interface{}, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(ja) == string(jb)
+}
@@ -0,0 +1,40 @@
+package opensea
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffAssets(t *testing.T) {
+	var oldTraits, newTraits interface{}
+	json.Unmarshal([]byte(`[{"trait_type":"hat","value":"cap"}]`), &oldTraits)
+	json.Unmarshal([]byte(`[{"value":"cap","trait_type":"hat"}]`), &newTraits)
+
+	old := &Asset{
+		Name:     "Doodle #1",
+		ImageURL: "https://example.com/1.png",
+		Owner:    &Account{Address: "0x0000000000000000000000000000000000000001"},
+		Traits:   oldTraits,
+	}
+	new := &Asset{
+		Name:     "Doodle #1",
+		ImageURL: "https://example.com/1-v2.png",
+		Owner:    &Account{Address: "0x0000000000000000000000000000000000000002"},
+		Traits:   newTraits,
+		NumSales: 1,
+		LastSale: &Sale{TotalPrice: "1000000000000000000"},
+	}
+
+	changes := DiffAssets(old, new)
+	assert.Equal(t, []Change{
+		{Field: "last_sale.total_price", Kind: ChangePrice, Old: "", New: "1000000000000000000"},
+		{Field: "num_sales", Kind: ChangePrice, Old: int64(0), New: int64(1)},
+		{Field: "owner.address", Kind: ChangeOwner, Old: Address("0x0000000000000000000000000000000000000001"), New: Address("0x0000000000000000000000000000000000000002")},
+		{Field: "image_url", Kind: ChangeImage, Old: "https://example.com/1.png", New: "https://example.com/1-v2.png"},
+	}, changes)
+
+	assert.Empty(t, DiffAssets(new, new))
+	assert.Len(t, DiffAssets(nil, &Asset{Name: "a"}), 1)
+}
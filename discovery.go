@@ -0,0 +1,145 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// CollectionOrderBy ranks the collections of BrowseCollections.
+type CollectionOrderBy string
+
+const (
+	CollectionsByCreatedDate    CollectionOrderBy = "created_date"
+	CollectionsByOneDayChange   CollectionOrderBy = "one_day_change"
+	CollectionsBySevenDayVolume CollectionOrderBy = "seven_day_volume"
+	CollectionsBySevenDayChange CollectionOrderBy = "seven_day_change"
+	CollectionsByNumOwners      CollectionOrderBy = "num_owners"
+	CollectionsByMarketCap      CollectionOrderBy = "market_cap"
+)
+
+type BrowseCollectionsParams struct {
+	// Chain limits the collections to those of a chain; all chains if
+	// empty.
+	Chain   Chain
+	OrderBy CollectionOrderBy
+	// CreatorUsername limits the collections to those of a creator.
+	CreatorUsername string
+	IncludeHidden   bool
+	Cursor          string
+	// Limit defaults to 100, the maximum.
+	Limit int
+}
+
+// maxCollectionsPage is the largest page of the collections endpoint.
+const maxCollectionsPage = 100
+
+func (p BrowseCollectionsParams) Encode() string {
+	values := url.Values{}
+	if p.Chain != ChainNone {
+		values.Set("chain", p.Chain.String())
+	}
+	if p.OrderBy != "" {
+		values.Set("order_by", string(p.OrderBy))
+	}
+	if p.CreatorUsername != "" {
+		values.Set("creator_username", p.CreatorUsername)
+	}
+	if p.IncludeHidden {
+		values.Set("include_hidden", "true")
+	}
+	if p.Cursor != "" {
+		values.Set("next", p.Cursor)
+	}
+	limit := p.Limit
+	if limit <= 0 {
+		limit = maxCollectionsPage
+	}
+	values.Set("limit", fmt.Sprintf("%d", limit))
+	return values.Encode()
+}
+
+type BrowseCollectionsResponse struct {
+	Collections []Collection `json:"collections" bson:"collections"`
+	Next        string       `json:"next" bson:"next"`
+}
+
+// BrowseCollections returns a page of the collections ranked by
+// params.OrderBy, the listing behind the rankings of the website.
+func (o Opensea) BrowseCollections(params BrowseCollectionsParams) (*BrowseCollectionsResponse, error) {
+	ctx := context.TODO()
+	return o.BrowseCollectionsWithContext(ctx, params)
+}
+
+func (o Opensea) BrowseCollectionsWithContext(ctx context.Context, params BrowseCollectionsParams) (*BrowseCollectionsResponse, error) {
+	if params.Chain != ChainNone {
+		chain, err := o.chainFor(params.Chain, CapabilityCollections)
+		if err != nil {
+			return nil, err
+		}
+		params.Chain = chain
+		ctx = contextWithChain(ctx, chain)
+	}
+	b, err := o.GetPath(ctx, "/api/v2/collections?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	resp := &struct {
+		Collections []collectionV2 `json:"collections"`
+		Next        string         `json:"next"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	ret := &BrowseCollectionsResponse{Collections: []Collection{}, Next: resp.Next}
+	for _, c := range resp.Collections {
+		ret.Collections = append(ret.Collections, *c.collection())
+	}
+	return ret, nil
+}
+
+// TrendingCollections returns the limit collections of chain with the
+// largest one day change; all chains if chain is empty.
+func (o Opensea) TrendingCollections(chain Chain, limit int) ([]Collection, error) {
+	ctx := context.TODO()
+	return o.TrendingCollectionsWithContext(ctx, chain, limit)
+}
+
+func (o Opensea) TrendingCollectionsWithContext(ctx context.Context, chain Chain, limit int) ([]Collection, error) {
+	return o.TopCollectionsWithContext(ctx, chain, CollectionsByOneDayChange, limit)
+}
+
+// TopCollections returns the limit first collections of chain ranked by
+// orderBy.
+func (o Opensea) TopCollections(chain Chain, orderBy CollectionOrderBy, limit int) ([]Collection, error) {
+	ctx := context.TODO()
+	return o.TopCollectionsWithContext(ctx, chain, orderBy, limit)
+}
+
+func (o Opensea) TopCollectionsWithContext(ctx context.Context, chain Chain, orderBy CollectionOrderBy, limit int) ([]Collection, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("Invalid limit: %d", limit)
+	}
+	params := BrowseCollectionsParams{Chain: chain, OrderBy: orderBy}
+	ret := []Collection{}
+	for len(ret) < limit {
+		params.Limit = limit - len(ret)
+		if params.Limit > maxCollectionsPage {
+			params.Limit = maxCollectionsPage
+		}
+		resp, err := o.BrowseCollectionsWithContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, resp.Collections...)
+		if resp.Next == "" || len(resp.Collections) == 0 {
+			break
+		}
+		params.Cursor = resp.Next
+	}
+	if len(ret) > limit {
+		ret = ret[:limit]
+	}
+	return ret, nil
+}
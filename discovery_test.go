@@ -0,0 +1,57 @@
+package opensea
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopCollections(t *testing.T) {
+	limits := []string{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/collections", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "base", q.Get("chain"))
+		assert.Equal(t, "one_day_change", q.Get("order_by"))
+		limits = append(limits, q.Get("limit"))
+		if q.Get("next") == "" {
+			w.Write([]byte(`{"collections":[{"collection":"a","category":"art"},{"collection":"b","category":"pfps"}],"next":"p2"}`))
+			return
+		}
+		w.Write([]byte(`{"collections":[{"collection":"c","category":"art"}]}`))
+	})
+
+	collections, err := o.TrendingCollections(ChainBase, 3)
+	assert.Nil(t, err)
+	assert.Len(t, collections, 3)
+	assert.Equal(t, "a", collections[0].Slug)
+	assert.Equal(t, "art", collections[0].Category)
+	assert.Equal(t, "c", collections[2].Slug)
+	assert.Equal(t, []string{"3", "1"}, limits)
+
+	_, err = o.TopCollections(ChainBase, CollectionsByMarketCap, 0)
+	assert.NotNil(t, err)
+}
+
+func TestTopCollectionsPageLimit(t *testing.T) {
+	limits := []string{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limits = append(limits, q.Get("limit"))
+		n, _ := strconv.Atoi(q.Get("limit"))
+		page := make([]string, n)
+		for i := range page {
+			page[i] = fmt.Sprintf(`{"collection":"c%d-%d"}`, len(limits), i)
+		}
+		w.Write([]byte(`{"collections":[` + strings.Join(page, ",") + `],"next":"p"}`))
+	})
+
+	collections, err := o.TopCollections(ChainEthereum, CollectionsByMarketCap, 250)
+	assert.Nil(t, err)
+	assert.Len(t, collections, 250)
+	assert.Equal(t, []string{"100", "100", "50"}, limits)
+}
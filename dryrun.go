@@ -0,0 +1,34 @@
+package opensea
+
+// WithDryRun makes write methods, such as CreateOffer, validate and build
+// their requests, log them and return a synthesized response, without
+// sending them. Reads are still sent.
+func WithDryRun() Option {
+	return func(o *Opensea) {
+		o.dryRun = true
+	}
+}
+
+// DryRun reports whether the client was created WithDryRun.
+func (o Opensea) DryRun() bool {
+	return o.dryRun
+}
+
+// logDryRun logs the write request the client would have sent.
+func (o Opensea) logDryRun(method, path string, body []byte) {
+	o.logf("opensea: dry run: %s %s %s", method, path, body)
+}
+
+// dryRunOrder synthesizes the response of the orders endpoints to a post
+// of order.
+func dryRunOrder(chain Chain, orderHash string, order *SignedOrder) *SeaportOrder {
+	return &SeaportOrder{
+		OrderHash: orderHash,
+		Chain:     chain,
+		ProtocolData: ProtocolData{
+			Parameters: order.Parameters,
+			Signature:  order.Signature,
+		},
+		ProtocolAddress: order.ProtocolAddress,
+	}
+}
@@ -0,0 +1,36 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	logger := &testLogger{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected %s %s", r.Method, r.URL.Path)
+	}, WithDryRun(), WithLogger(logger))
+	assert.True(t, o.DryRun())
+
+	order, hash := testSignedOffer(t)
+	ret, err := o.CreateOfferWithContext(context.Background(), ChainEthereum, order)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, ret.OrderHash)
+	assert.Equal(t, order.Parameters, ret.ProtocolData.Parameters)
+	assert.Len(t, logger.lines, 1)
+	assert.True(t, strings.HasPrefix(logger.lines[0], "opensea: dry run: POST /api/v2/orders/ethereum/seaport/offers {"))
+
+	b, err := o.PostPath(context.Background(), "/api/v2/anything", map[string]string{"a": "b"})
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", string(b))
+
+	// orders are still validated
+	unsigned := *order
+	unsigned.Signature = ""
+	_, err = o.CreateOfferWithContext(context.Background(), ChainEthereum, &unsigned)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,201 @@
+package opensea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestDump is a failed request and its response, as written by
+// WithFailureDumps. Credentials are not recorded: the API key, the
+// Authorization and Cookie headers, and the default headers and query
+// parameters of the client are left out, for the replaying client to add
+// its own.
+type RequestDump struct {
+	Time     time.Time   `json:"time"`
+	Endpoint string      `json:"endpoint,omitempty"`
+	Chain    Chain       `json:"chain,omitempty"`
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Header   http.Header `json:"header,omitempty"`
+	Body     string      `json:"body,omitempty"`
+	// Status is 0 when no response was received, and Error says why.
+	Status         int         `json:"status,omitempty"`
+	ResponseHeader http.Header `json:"response_header,omitempty"`
+	ResponseBody   string      `json:"response_body,omitempty"`
+	Error          string      `json:"error,omitempty"`
+}
+
+// Failed reports whether the request of d failed: it got no response, or a
+// status other than 2xx.
+func (d RequestDump) Failed() bool {
+	return d.Status < 200 || d.Status > 299
+}
+
+// WithFailureDumps writes a RequestDump of every failed request, retries
+// included, as a JSON file in dir, for ReplayRequestDump to reproduce it.
+// Failing to write a dump is logged and leaves the request unaffected.
+func WithFailureDumps(dir string) Option {
+	return func(o *Opensea) {
+		client := o
+		WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				d, err := client.newRequestDump(req)
+				if err != nil {
+					return nil, err
+				}
+				resp, err := next.RoundTrip(req)
+				if err != nil {
+					d.Error = err.Error()
+				} else {
+					var body []byte
+					if body, err = ioutil.ReadAll(resp.Body); err != nil {
+						resp.Body.Close()
+						return nil, err
+					}
+					resp.Body.Close()
+					resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+					d.Status, d.ResponseHeader, d.ResponseBody = resp.StatusCode, resp.Header, string(body)
+				}
+				if d.Failed() {
+					if path, werr := d.write(dir); werr != nil {
+						client.logf("Failed to dump request %s %s: %v", d.Method, d.URL, werr)
+					} else {
+						client.logf("Dumped failed request %s %s to %s", d.Method, d.URL, path)
+					}
+				}
+				return resp, err
+			})
+		})(o)
+	}
+}
+
+// dumpSecretHeaders are the headers never written to dumps.
+var dumpSecretHeaders = []string{"X-API-KEY", "Authorization", "Proxy-Authorization", "Cookie"}
+
+func (o Opensea) newRequestDump(req *http.Request) (*RequestDump, error) {
+	info, _ := RequestInfoFromContext(req.Context())
+	u := *req.URL
+	if len(o.query) > 0 {
+		q := u.Query()
+		for key := range o.query {
+			q.Del(key)
+		}
+		u.RawQuery = q.Encode()
+	}
+	d := &RequestDump{
+		Time:     time.Now().UTC(),
+		Endpoint: info.Endpoint,
+		Chain:    info.Chain,
+		Method:   req.Method,
+		URL:      u.String(),
+		Header:   req.Header.Clone(),
+	}
+	for _, key := range dumpSecretHeaders {
+		d.Header.Del(key)
+	}
+	for key := range o.headers {
+		d.Header.Del(key)
+	}
+	if req.GetBody != nil {
+		r, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		d.Body = string(b)
+	}
+	return d, nil
+}
+
+func (d RequestDump) write(dir string) (string, error) {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile(dir, "request-"+d.Time.Format("20060102T150405")+"-*.json")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// ReadRequestDump reads a dump written by WithFailureDumps.
+func ReadRequestDump(path string) (*RequestDump, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d := new(RequestDump)
+	if err := json.Unmarshal(b, d); err != nil {
+		return nil, fmt.Errorf("Invalid request dump %s: %v", path, err)
+	}
+	return d, nil
+}
+
+// ReplayRequestDump sends the request of d again, with the API key and
+// headers of the client, and returns the dump of the replay. The request is
+// sent to the API of the client, so a client of NewOpenseaTestnet or of a
+// fake server replays it there. It is sent once, even in dry runs, and
+// whatever its status.
+func (o Opensea) ReplayRequestDump(ctx context.Context, d *RequestDump) (*RequestDump, error) {
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid request dump URL %s: %v", d.URL, err)
+	}
+	u.Scheme, u.Host = "", ""
+	target := o.API + u.String()
+
+	if d.Endpoint != "" {
+		ctx = ContextWithEndpoint(ctx, d.Endpoint)
+	}
+	if d.Chain != ChainNone {
+		ctx = contextWithChain(ctx, d.Chain)
+	}
+	ctx = contextWithAttempt(ctx, target, 0)
+	if err := o.wait(ctx); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, d.Method, target, bytes.NewReader([]byte(d.Body)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = d.Header.Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Header.Del("X-API-KEY")
+	if !o.Keyless() {
+		req.Header.Set("X-API-KEY", o.APIKey)
+	}
+	o.decorate(req)
+
+	replay, err := o.newRequestDump(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	replay.Status, replay.ResponseHeader, replay.ResponseBody = resp.StatusCode, resp.Header, string(b)
+	return replay, nil
+}
@@ -0,0 +1,70 @@
+package opensea
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureDumps(t *testing.T) {
+	dir := t.TempDir()
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/ok" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"success":false}`))
+	}, WithFailureDumps(dir), WithHeader("Authorization", "Bearer secret-auth"),
+		WithHeader("X-Gateway-Token", "secret-gateway"), WithQueryParam("gateway_key", "secret-query"))
+
+	_, err := o.GetPath(context.Background(), "/api/v2/ok")
+	assert.Nil(t, err)
+	_, err = o.PostPath(ContextWithEndpoint(context.Background(), "poster"), "/api/v2/orders?x=1", map[string]string{"a": "b"})
+	assert.NotNil(t, err)
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Nil(t, err)
+	assert.Len(t, paths, 1)
+	b, err := ioutil.ReadFile(paths[0])
+	assert.Nil(t, err)
+	assert.NotContains(t, string(b), "test-key")
+	assert.NotContains(t, string(b), "secret")
+
+	d, err := ReadRequestDump(paths[0])
+	assert.Nil(t, err)
+	assert.True(t, d.Failed())
+	assert.Equal(t, "poster", d.Endpoint)
+	assert.Equal(t, "POST", d.Method)
+	assert.Equal(t, `{"a":"b"}`, d.Body)
+	assert.Equal(t, http.StatusInternalServerError, d.Status)
+	assert.Equal(t, `{"success":false}`, d.ResponseBody)
+	assert.Equal(t, "application/json", d.Header.Get("Content-Type"))
+	assert.Contains(t, d.URL, "x=1")
+
+	var got *http.Request
+	var body []byte
+	replayer := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"ok":true}`))
+	}, WithHeader("X-Trace", "replay"))
+	replay, err := replayer.ReplayRequestDump(context.Background(), d)
+	assert.Nil(t, err)
+	assert.False(t, replay.Failed())
+	assert.Equal(t, `{"ok":true}`, replay.ResponseBody)
+	assert.Equal(t, "/api/v2/orders", got.URL.Path)
+	assert.Equal(t, "1", got.URL.Query().Get("x"))
+	assert.Equal(t, "POST", got.Method)
+	assert.Equal(t, `{"a":"b"}`, string(body))
+	assert.Equal(t, "test-key", got.Header.Get("X-API-KEY"))
+	assert.Equal(t, "replay", got.Header.Get("X-Trace"))
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+
+	_, err = ReadRequestDump(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,399 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBidDuration is the lifetime of bids built without an end time.
+const DefaultBidDuration = 7 * 24 * time.Hour
+
+// wrappedNativeTokens are the ERC20 wrappers of the native currencies, which
+// offers and bids are paid in.
+var wrappedNativeTokens = map[Chain]Address{
+	ChainEthereum: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+	ChainPolygon:  "0x7ceb23fd6bc0add59e62ac25578270cff1b9f619",
+	ChainBase:     "0x4200000000000000000000000000000000000006",
+	ChainArbitrum: "0x82af49447d8a07e3bd95bd0d56f35241523fbab1",
+	ChainOptimism: "0x4200000000000000000000000000000000000006",
+	ChainZora:     "0x4200000000000000000000000000000000000006",
+	ChainBlast:    "0x4300000000000000000000000000000000000004",
+	ChainSepolia:  "0xfff9976782d46cc05630d1f6ebab18b2324d6b14",
+}
+
+// WrappedNativeToken returns the wrapped native currency of chain, WETH on
+// Ethereum.
+func WrappedNativeToken(chain Chain) (Address, error) {
+	token, ok := wrappedNativeTokens[chain]
+	if !ok {
+		return "", fmt.Errorf("No wrapped native token known on %s", chain)
+	}
+	return token, nil
+}
+
+type AuctionStatus string
+
+const (
+	AuctionOpen      AuctionStatus = "open"
+	AuctionCancelled AuctionStatus = "cancelled"
+	AuctionSettled   AuctionStatus = "settled"
+)
+
+// AuctionState is the state of an English auction, the highest bid winning
+// when the auction ends. Amounts are in the smallest unit of the payment
+// token of the auction.
+type AuctionState struct {
+	Contract Address
+	TokenID  string
+	Seller   Address
+	Status   AuctionStatus
+	// MinimumBid is the starting price of the auction.
+	MinimumBid Number
+	// Reserve is the price below which the seller does not have to accept
+	// the highest bid, empty when the auction has none.
+	Reserve       Number
+	HighestBid    Number
+	HighestBidder Address
+	// Bids counts the bids standing.
+	Bids      int
+	StartTime time.Time
+	// EndTime is zero when the duration of the auction is unknown.
+	EndTime time.Time
+
+	bids map[string]Number
+}
+
+// ReserveMet reports whether the highest bid reaches the reserve.
+func (s AuctionState) ReserveMet() bool {
+	if s.HighestBid == "" {
+		return false
+	}
+	if s.Reserve == "" {
+		return true
+	}
+	return compareAmounts(s.HighestBid, s.Reserve) >= 0
+}
+
+// Ended reports whether the auction no longer takes bids at t.
+func (s AuctionState) Ended(t time.Time) bool {
+	return s.Status != AuctionOpen || (!s.EndTime.IsZero() && !t.Before(s.EndTime))
+}
+
+// CheckBid fails unless amount is a valid bid at t: at least the minimum bid
+// and above the highest bid, before the auction ends.
+func (s AuctionState) CheckBid(amount Number, t time.Time) error {
+	n := amount.Big()
+	if n == nil || n.Sign() <= 0 {
+		return fmt.Errorf("Invalid bid: %s", amount)
+	}
+	if s.Ended(t) {
+		return fmt.Errorf("Auction of %s/%s has ended", s.Contract, s.TokenID)
+	}
+	if s.MinimumBid != "" && compareAmounts(amount, s.MinimumBid) < 0 {
+		return fmt.Errorf("Bid %s is below the minimum bid %s", amount, s.MinimumBid)
+	}
+	if s.HighestBid != "" && compareAmounts(amount, s.HighestBid) <= 0 {
+		return fmt.Errorf("Bid %s does not exceed the highest bid %s", amount, s.HighestBid)
+	}
+	return nil
+}
+
+// AuctionStateFromEvents returns the state of the last English auction of
+// the events of a token: its created event, the bids entered and withdrawn
+// since, and the sale or cancellation ending it.
+func AuctionStateFromEvents(events []*Event) (*AuctionState, error) {
+	sorted := make([]*Event, 0, len(events))
+	for _, e := range events {
+		if e != nil {
+			sorted = append(sorted, e)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EventTimestamp.Time().Before(sorted[j].EventTimestamp.Time())
+	})
+
+	start := -1
+	for i, e := range sorted {
+		if e.EventType == EventTypeCreated && AuctionType(e.AuctionType) == AuctionTypeEnglish {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("No English auction in %d events", len(events))
+	}
+
+	created := sorted[start]
+	s := &AuctionState{
+		Status:     AuctionOpen,
+		MinimumBid: Number(created.StartingPrice),
+		Reserve:    created.MinPrice,
+		StartTime:  created.EventTimestamp.Time(),
+		bids:       map[string]Number{},
+	}
+	if created.Asset != nil {
+		s.TokenID = created.Asset.TokenID
+		if created.Asset.AssetContract != nil {
+			s.Contract = created.Asset.AssetContract.Address
+		}
+	}
+	if created.Seller != nil {
+		s.Seller = created.Seller.Address
+	}
+	if d, ok := eventDuration(created.Duration); ok {
+		s.EndTime = s.StartTime.Add(d)
+	}
+
+	for _, e := range sorted[start+1:] {
+		if s.Status != AuctionOpen {
+			break
+		}
+		switch e.EventType {
+		case EventTypeBidEntered:
+			if e.FromAccount != nil {
+				s.bid(e.FromAccount.Address, e.BidAmount)
+			}
+		case EventTypeBidWithdrawn:
+			if e.FromAccount != nil {
+				delete(s.bids, strings.ToLower(e.FromAccount.Address.String()))
+			}
+		case EventTypeCancelled:
+			s.Status = AuctionCancelled
+		case EventTypeSuccessful:
+			s.Status = AuctionSettled
+		}
+	}
+	s.update()
+	return s, nil
+}
+
+// ApplyOffers records the offers on the token of the auction as bids, e.g.
+// offers read from the v2 order book. Offers on other tokens are ignored.
+func (s *AuctionState) ApplyOffers(offers []SeaportOrder) {
+	if s.bids == nil {
+		s.bids = map[string]Number{}
+	}
+	for _, o := range offers {
+		if !o.considers(s.Contract, s.TokenID) {
+			continue
+		}
+		s.bid(o.ProtocolData.Parameters.Offerer, o.price().Value)
+	}
+	s.update()
+}
+
+// bid records the bid of bidder, keeping their highest.
+func (s *AuctionState) bid(bidder Address, amount Number) {
+	if n := amount.Big(); n == nil || n.Sign() <= 0 {
+		return
+	}
+	key := strings.ToLower(bidder.String())
+	if current, ok := s.bids[key]; ok && compareAmounts(current, amount) >= 0 {
+		return
+	}
+	s.bids[key] = amount
+}
+
+// update sets the highest bid from the bids standing.
+func (s *AuctionState) update() {
+	s.HighestBid, s.HighestBidder, s.Bids = "", "", len(s.bids)
+	for bidder, amount := range s.bids {
+		c := 1
+		if s.HighestBid != "" {
+			c = compareAmounts(amount, s.HighestBid)
+		}
+		if c > 0 || (c == 0 && bidder < s.HighestBidder.String()) {
+			s.HighestBid, s.HighestBidder = amount, Address(bidder)
+		}
+	}
+}
+
+// considers reports whether the order asks for the token contract/tokenID.
+func (o SeaportOrder) considers(contract Address, tokenID string) bool {
+	for _, item := range o.ProtocolData.Parameters.Consideration {
+		if item.ItemType >= ItemERC721 && strings.EqualFold(item.Token.String(), contract.String()) &&
+			string(item.IdentifierOrCriteria) == tokenID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAuctionState reads the state of the English auction of a token from
+// its events. tokenID is the decimal uint256 identifier of the token.
+func (o Opensea) GetAuctionState(contract Address, tokenID string) (*AuctionState, error) {
+	ctx := context.TODO()
+	return o.GetAuctionStateWithContext(ctx, contract, tokenID)
+}
+
+func (o Opensea) GetAuctionStateWithContext(ctx context.Context, contract Address, tokenID string) (*AuctionState, error) {
+	if tokenID == "" {
+		return nil, fmt.Errorf("Empty token ID")
+	}
+	params := GetEventsParams{
+		AssetContractAddress: contract,
+		TokenID:              tokenID,
+		OccurredAfter:        time.Now().Add(-MaxAuctionDuration),
+	}
+	events := []*Event{}
+	for {
+		resp, err := o.GetEventsWithContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.AssetEvents {
+			events = append(events, &resp.AssetEvents[i].Event)
+		}
+		if resp.Next == "" {
+			break
+		}
+		params.Cursor = resp.Next
+	}
+	return AuctionStateFromEvents(events)
+}
+
+// BidParams describe a bid, an offer on a token paid in an ERC20.
+type BidParams struct {
+	Bidder Address
+	Item   ListingItem
+	// Amount is the bid, in the smallest unit of PaymentToken. Fees are
+	// paid out of it; the seller receives the rest.
+	Amount Number
+	// PaymentToken defaults to the wrapped native token of Chain, itself
+	// defaulting to DefaultChain. The native currency cannot be offered.
+	PaymentToken Address
+	Chain        Chain
+	Fees         []Fee
+	// StartTime defaults to now and EndTime to DefaultBidDuration later.
+	StartTime time.Time
+	EndTime   time.Time
+	Salt      Number
+	Counter   Number
+}
+
+// BuildBid builds the parameters of a bid, ready for SignOrder: the bidder
+// offers Amount of the payment token, and asks for the item and for the
+// fees to be paid, in the payment token, out of the amount.
+func BuildBid(p BidParams) (*OrderParameters, error) {
+	bidder, err := ParseAddress(p.Bidder.String())
+	if err != nil {
+		return nil, err
+	}
+	chain := p.Chain
+	if chain == ChainNone {
+		chain = DefaultChain
+	}
+	token := p.PaymentToken
+	if token == "" {
+		if token, err = WrappedNativeToken(chain); err != nil {
+			return nil, err
+		}
+	}
+	if token.IsNullAddress() {
+		return nil, fmt.Errorf("Bids are paid in an ERC20, not in the native currency")
+	}
+	if token, err = ParseAddress(token.String()); err != nil {
+		return nil, err
+	}
+	item, err := p.Item.offerItem()
+	if err != nil {
+		return nil, err
+	}
+	proceeds, err := CalculateProceeds(p.Amount, p.Fees)
+	if err != nil {
+		return nil, err
+	}
+
+	offer := []OfferItem{{
+		ItemType:             ItemERC20,
+		Token:                token,
+		IdentifierOrCriteria: "0",
+		StartAmount:          p.Amount,
+		EndAmount:            p.Amount,
+	}}
+	consideration := []ConsiderationItem{{
+		ItemType:             item.ItemType,
+		Token:                item.Token,
+		IdentifierOrCriteria: item.IdentifierOrCriteria,
+		StartAmount:          item.StartAmount,
+		EndAmount:            item.EndAmount,
+		Recipient:            bidder,
+	}}
+	for _, fee := range proceeds.Fees {
+		consideration = append(consideration, ConsiderationItem{
+			ItemType:             ItemERC20,
+			Token:                token,
+			IdentifierOrCriteria: "0",
+			StartAmount:          fee.Amount,
+			EndAmount:            fee.Amount,
+			Recipient:            fee.Recipient,
+		})
+	}
+	return newOrderParameters(bidder, offer, consideration, p.StartTime, p.EndTime, DefaultBidDuration, p.Salt, p.Counter)
+}
+
+// PlaceBid checks a bid against the state of the auction, then builds,
+// signs and posts it.
+func (o Opensea) PlaceBid(state *AuctionState, p BidParams, signer Signer) (*SeaportOrder, error) {
+	ctx := context.TODO()
+	return o.PlaceBidWithContext(ctx, state, p, signer)
+}
+
+func (o Opensea) PlaceBidWithContext(ctx context.Context, state *AuctionState, p BidParams, signer Signer) (*SeaportOrder, error) {
+	if err := state.CheckBid(p.Amount, time.Now()); err != nil {
+		return nil, err
+	}
+	chain, err := o.chainFor(p.Chain, CapabilityOffers)
+	if err != nil {
+		return nil, err
+	}
+	p.Chain = chain
+	params, err := BuildBid(p)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := o.SignOrder(ctx, chain, DefaultProtocol, params, signer)
+	if err != nil {
+		return nil, err
+	}
+	return o.CreateOfferWithContext(ctx, chain, signed)
+}
+
+// compareAmounts compares two integer amounts; invalid amounts compare as
+// zero.
+func compareAmounts(a, b Number) int {
+	x, y := a.Big(), b.Big()
+	if x == nil {
+		x = new(big.Int)
+	}
+	if y == nil {
+		y = new(big.Int)
+	}
+	return x.Cmp(y)
+}
+
+// eventDuration decodes the duration of an event, in seconds, sent as a
+// number or a string.
+func eventDuration(v interface{}) (time.Duration, bool) {
+	var seconds float64
+	switch d := v.(type) {
+	case float64:
+		seconds = d
+	case string:
+		f, err := strconv.ParseFloat(d, 64)
+		if err != nil {
+			return 0, false
+		}
+		seconds = f
+	default:
+		return 0, false
+	}
+	if seconds <= 0 {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
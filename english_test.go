@@ -0,0 +1,170 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAuctionContract Address = "0x2222222222222222222222222222222222222222"
+	testBidder          Address = "0x3333333333333333333333333333333333333333"
+	testOtherBidder     Address = "0x4444444444444444444444444444444444444444"
+)
+
+func auctionEvent(t EventType, at time.Time) *Event {
+	return &Event{
+		EventType:      t,
+		EventTimestamp: TimeNano(at),
+		Asset:          &Asset{TokenID: "7", AssetContract: &AssetContract{Address: testAuctionContract}},
+	}
+}
+
+func TestAuctionStateFromEvents(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	created := auctionEvent(EventTypeCreated, start)
+	created.AuctionType = string(AuctionTypeEnglish)
+	created.StartingPrice, created.MinPrice = "1000", "5000"
+	created.Duration = "86400"
+	created.Seller = &Account{Address: "0x1111111111111111111111111111111111111111"}
+
+	bid1 := auctionEvent(EventTypeBidEntered, start.Add(time.Minute))
+	bid1.FromAccount, bid1.BidAmount = &Account{Address: testBidder}, "2000"
+	bid2 := auctionEvent(EventTypeBidEntered, start.Add(2*time.Minute))
+	bid2.FromAccount, bid2.BidAmount = &Account{Address: testOtherBidder}, "3000"
+	withdrawn := auctionEvent(EventTypeBidWithdrawn, start.Add(3*time.Minute))
+	withdrawn.FromAccount = &Account{Address: testOtherBidder}
+
+	// out of order, as pages of events come newest first
+	s, err := AuctionStateFromEvents([]*Event{withdrawn, bid2, bid1, created})
+	assert.Nil(t, err)
+	assert.Equal(t, AuctionOpen, s.Status)
+	assert.Equal(t, "7", s.TokenID)
+	assert.Equal(t, Number("2000"), s.HighestBid)
+	assert.Equal(t, testBidder, s.HighestBidder)
+	assert.Equal(t, 1, s.Bids)
+	assert.Equal(t, start.Add(24*time.Hour).Unix(), s.EndTime.Unix())
+	assert.False(t, s.ReserveMet())
+
+	assert.NotNil(t, s.CheckBid("2000", time.Now()))
+	assert.NotNil(t, s.CheckBid("500", time.Now()))
+	assert.NotNil(t, s.CheckBid("3000", start.Add(25*time.Hour)))
+	assert.Nil(t, s.CheckBid("2001", time.Now()))
+
+	s.ApplyOffers([]SeaportOrder{
+		{
+			Price: SeaportOrderPrice{OrderPrice: OrderPrice{Currency: "WETH", Decimals: 18, Value: "6000"}},
+			ProtocolData: ProtocolData{Parameters: OrderParameters{
+				Offerer:       testOtherBidder,
+				Consideration: []ConsiderationItem{{ItemType: ItemERC721, Token: testAuctionContract, IdentifierOrCriteria: "7"}},
+			}},
+		},
+		{
+			Price: SeaportOrderPrice{OrderPrice: OrderPrice{Currency: "WETH", Decimals: 18, Value: "9000"}},
+			ProtocolData: ProtocolData{Parameters: OrderParameters{
+				Offerer:       testOtherBidder,
+				Consideration: []ConsiderationItem{{ItemType: ItemERC721, Token: testAuctionContract, IdentifierOrCriteria: "8"}},
+			}},
+		},
+	})
+	assert.Equal(t, Number("6000"), s.HighestBid)
+	assert.Equal(t, testOtherBidder, s.HighestBidder)
+	assert.True(t, s.ReserveMet())
+
+	sold := auctionEvent(EventTypeSuccessful, start.Add(4*time.Minute))
+	s, err = AuctionStateFromEvents([]*Event{created, bid1, sold})
+	assert.Nil(t, err)
+	assert.Equal(t, AuctionSettled, s.Status)
+	assert.True(t, s.Ended(time.Now()))
+
+	_, err = AuctionStateFromEvents([]*Event{bid1})
+	assert.NotNil(t, err)
+}
+
+func TestGetAuctionState(t *testing.T) {
+	tokenID := "57896044618658097711785492504343953926634992332820282019728792003956564819968"
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tokenID, r.URL.Query().Get("token_id"))
+		assert.Equal(t, testAuctionContract.String(), r.URL.Query().Get("asset_contract_address"))
+		asset := `{"token_id":"` + tokenID + `","asset_contract":{"address":"` + testAuctionContract.String() + `"}}`
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"asset_events":[{"event_type":"bid_entered","event_timestamp":"2022-01-01T01:00:00","asset":` + asset + `,"bid_amount":"2000","from_account":{"address":"` + testBidder.String() + `"}}],"next":"abc"}`))
+			return
+		}
+		w.Write([]byte(`{"asset_events":[{"event_type":"created","auction_type":"english","event_timestamp":"2022-01-01T00:00:00","asset":` + asset + `,"starting_price":"1000"}]}`))
+	})
+
+	s, err := o.GetAuctionState(testAuctionContract, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, tokenID, s.TokenID)
+	assert.Equal(t, Number("2000"), s.HighestBid)
+	assert.Equal(t, testBidder, s.HighestBidder)
+
+	_, err = o.GetAuctionState(testAuctionContract, "")
+	assert.NotNil(t, err)
+}
+
+func TestBuildBid(t *testing.T) {
+	p := BidParams{
+		Bidder: testBidder,
+		Item:   ERC721Item(testAuctionContract, "7"),
+		Amount: "1000000000000000000",
+		Fees:   []Fee{{Recipient: OpenSeaFeeRecipient, BasisPoints: 250}},
+	}
+	order, err := BuildBid(p)
+	assert.Nil(t, err)
+	weth := wrappedNativeTokens[ChainEthereum]
+	assert.Equal(t, []OfferItem{{ItemType: ItemERC20, Token: weth, IdentifierOrCriteria: "0", StartAmount: p.Amount, EndAmount: p.Amount}}, order.Offer)
+	assert.Len(t, order.Consideration, 2)
+	assert.Equal(t, ItemERC721, order.Consideration[0].ItemType)
+	assert.Equal(t, testBidder, order.Consideration[0].Recipient)
+	assert.Equal(t, ConsiderationItem{
+		ItemType: ItemERC20, Token: weth, IdentifierOrCriteria: "0",
+		StartAmount: "25000000000000000", EndAmount: "25000000000000000", Recipient: OpenSeaFeeRecipient,
+	}, order.Consideration[1])
+	assert.Equal(t, 2, order.TotalOriginalConsiderationItems)
+
+	invalid := []func(p *BidParams){
+		func(p *BidParams) { p.PaymentToken = NullAddress },
+		func(p *BidParams) { p.Chain = ChainSolana },
+		func(p *BidParams) { p.Amount = "-1" },
+		func(p *BidParams) { p.Bidder = "bidder" },
+	}
+	for i, f := range invalid {
+		q := p
+		f(&q)
+		_, err := BuildBid(q)
+		assert.NotNil(t, err, i)
+	}
+}
+
+func TestPlaceBid(t *testing.T) {
+	var posted SignedOrder
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v2/orders/ethereum/seaport/offers", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Nil(t, json.Unmarshal(b, &posted))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"order":{"order_hash":"0xabc","protocol_address":"0x0000000000000068f116a894984e2db1123eb395"}}`))
+	})
+	state := &AuctionState{Contract: testAuctionContract, TokenID: "7", Status: AuctionOpen, MinimumBid: "1000", HighestBid: "2000"}
+	p := BidParams{Bidder: testBidder, Item: ERC721Item(testAuctionContract, "7"), Amount: "1500"}
+	signer := &testSigner{address: testBidder}
+
+	_, err := o.PlaceBidWithContext(context.Background(), state, p, signer)
+	assert.NotNil(t, err)
+
+	p.Amount = "2500"
+	order, err := o.PlaceBidWithContext(context.Background(), state, p, signer)
+	assert.Nil(t, err)
+	assert.Equal(t, "0xabc", order.OrderHash)
+	assert.Equal(t, Number("2500"), posted.Parameters.Offer[0].StartAmount)
+	assert.Len(t, signer.digests, 1)
+}
@@ -0,0 +1,188 @@
+package opensea
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/quintics-io/go-opensea/internal/keccak"
+)
+
+// DefaultENSAPI is the public API ENSAPIResolver resolves names through by
+// default.
+const DefaultENSAPI = "https://api.ensideas.com/ens/resolve/"
+
+// ENSRegistry is the address of the ENS registry on Ethereum.
+const ENSRegistry Address = "0x00000000000c2e074ec69a0dfb2997ba6c7d2e1e"
+
+var (
+	// resolver(bytes32) of the registry and addr(bytes32) of resolvers
+	selectorResolver = []byte{0x01, 0x78, 0xb8, 0xbf}
+	selectorAddr     = []byte{0x3b, 0x3b, 0x57, 0xde}
+)
+
+// ENSResolver resolves ENS names, e.g. "vitalik.eth", to addresses. It
+// returns ErrNotFound for names without address.
+type ENSResolver interface {
+	ResolveENS(ctx context.Context, name string) (Address, error)
+}
+
+// WithENSResolver sets the resolver of the ENS names passed as owner or
+// account addresses. It defaults to an ENSAPIResolver.
+func WithENSResolver(r ENSResolver) Option {
+	return func(o *Opensea) {
+		o.ens = r
+	}
+}
+
+// IsENSName reports whether s is an ENS name rather than an address.
+func IsENSName(s string) bool {
+	if IsHexAddress(s) || strings.ContainsAny(s, " /?#") {
+		return false
+	}
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, l := range labels {
+		if l == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// NameHash returns the ENS namehash of name.
+func NameHash(name string) [32]byte {
+	var node [32]byte
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := keccak.Sum256([]byte(labels[i]))
+		node = keccak.Sum256(node[:], label[:])
+	}
+	return node
+}
+
+func (o Opensea) ResolveAddress(nameOrAddress string) (Address, error) {
+	ctx := context.TODO()
+	return o.ResolveAddressWithContext(ctx, nameOrAddress)
+}
+
+// ResolveAddressWithContext parses an address or resolves an ENS name with
+// the ENS resolver of the client.
+func (o Opensea) ResolveAddressWithContext(ctx context.Context, nameOrAddress string) (Address, error) {
+	if !IsENSName(nameOrAddress) {
+		return ParseAddress(nameOrAddress)
+	}
+	r := o.ens
+	if r == nil {
+		r = NewENSAPIResolver()
+	}
+	a, err := r.ResolveENS(ctx, strings.ToLower(nameOrAddress))
+	if err != nil {
+		return "", err
+	}
+	return ParseAddress(a.String())
+}
+
+// ownerAddress resolves the owner or account of a request, which may be an
+// ENS name.
+func (o Opensea) ownerAddress(ctx context.Context, a Address) (Address, error) {
+	if !IsENSName(a.String()) {
+		return a, nil
+	}
+	return o.ResolveAddressWithContext(ctx, a.String())
+}
+
+// ENSAPIResolver resolves names through an HTTP API answering
+// {"address": "0x..."} at URL followed by the name.
+type ENSAPIResolver struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewENSAPIResolver() *ENSAPIResolver {
+	return &ENSAPIResolver{URL: DefaultENSAPI, Client: http.DefaultClient}
+}
+
+func (r *ENSAPIResolver) ResolveENS(ctx context.Context, name string) (Address, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.URL+url.PathEscape(name), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Backend returns status %d msg: %s", resp.StatusCode, string(b))
+	}
+	ret := &struct {
+		Address string `json:"address"`
+	}{}
+	if err := json.Unmarshal(b, ret); err != nil {
+		return "", err
+	}
+	if ret.Address == "" || Address(strings.ToLower(ret.Address)).IsNullAddress() {
+		return "", ErrNotFound
+	}
+	return ParseAddress(strings.ToLower(ret.Address))
+}
+
+// EthCaller makes read-only calls to Ethereum contracts, e.g. eth_call
+// through an RPC client.
+type EthCaller interface {
+	CallContract(ctx context.Context, to Address, data []byte) ([]byte, error)
+}
+
+// ENSRegistryResolver resolves names on chain, through the ENS registry and
+// the resolver of each name.
+type ENSRegistryResolver struct {
+	Caller   EthCaller
+	Registry Address
+}
+
+func NewENSRegistryResolver(caller EthCaller) *ENSRegistryResolver {
+	return &ENSRegistryResolver{Caller: caller, Registry: ENSRegistry}
+}
+
+func (r *ENSRegistryResolver) ResolveENS(ctx context.Context, name string) (Address, error) {
+	node := NameHash(name)
+	resolver, err := r.callAddress(ctx, r.Registry, selectorResolver, node)
+	if err != nil {
+		return "", err
+	}
+	return r.callAddress(ctx, resolver, selectorAddr, node)
+}
+
+// callAddress calls a function taking a node and returning an address.
+func (r *ENSRegistryResolver) callAddress(ctx context.Context, to Address, selector []byte, node [32]byte) (Address, error) {
+	out, err := r.Caller.CallContract(ctx, to, append(append([]byte{}, selector...), node[:]...))
+	if err != nil {
+		return "", err
+	}
+	if len(out) < 32 {
+		return "", fmt.Errorf("Invalid ENS response from %s: %x", to, out)
+	}
+	a := Address("0x" + hex.EncodeToString(out[12:32]))
+	if a.IsNullAddress() {
+		return "", ErrNotFound
+	}
+	return a, nil
+}
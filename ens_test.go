@@ -0,0 +1,121 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/quintics-io/go-opensea/internal/keccak"
+	"github.com/stretchr/testify/assert"
+)
+
+type testENSResolver map[string]Address
+
+func (r testENSResolver) ResolveENS(ctx context.Context, name string) (Address, error) {
+	a, ok := r[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return a, nil
+}
+
+type testEthCaller map[Address]map[string][]byte
+
+func (c testEthCaller) CallContract(ctx context.Context, to Address, data []byte) ([]byte, error) {
+	out, ok := c[to][fmt.Sprintf("%x", data)]
+	if !ok {
+		return make([]byte, 32), nil
+	}
+	return out, nil
+}
+
+func TestIsENSName(t *testing.T) {
+	assert.True(t, IsENSName("vitalik.eth"))
+	assert.True(t, IsENSName("pay.vitalik.eth"))
+	assert.False(t, IsENSName("vitalik"))
+	assert.False(t, IsENSName(".eth"))
+	assert.False(t, IsENSName("0xd8da6bf26964af9d7eed9e03e53415d37aa96045"))
+}
+
+func TestNameHash(t *testing.T) {
+	assert.Equal(t, [32]byte{}, NameHash(""))
+	assert.Equal(t, "93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae", fmt.Sprintf("%x", NameHash("eth")))
+	assert.Equal(t, "de9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f", fmt.Sprintf("%x", NameHash("foo.eth")))
+
+	resolver := keccak.Sum256([]byte("resolver(bytes32)"))
+	addr := keccak.Sum256([]byte("addr(bytes32)"))
+	assert.Equal(t, selectorResolver, resolver[:4])
+	assert.Equal(t, selectorAddr, addr[:4])
+}
+
+func TestENSRegistryResolver(t *testing.T) {
+	node := NameHash("vitalik.eth")
+	word := func(a string) []byte {
+		b, err := hexBytes(a, 20)
+		assert.Nil(t, err)
+		return append(make([]byte, 12), b...)
+	}
+	resolver := Address("0x4976fb03c32e5b8cfe2b6ccb31c09ba78ebaba41")
+	caller := testEthCaller{
+		ENSRegistry: {fmt.Sprintf("%x%x", selectorResolver, node[:]): word(resolver.String())},
+		resolver:    {fmt.Sprintf("%x%x", selectorAddr, node[:]): word("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")},
+	}
+
+	r := NewENSRegistryResolver(caller)
+	a, err := r.ResolveENS(context.Background(), "vitalik.eth")
+	assert.Nil(t, err)
+	assert.Equal(t, Address("0xd8da6bf26964af9d7eed9e03e53415d37aa96045"), a)
+
+	_, err = r.ResolveENS(context.Background(), "nobody.eth")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestENSAPIResolver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/vitalik.eth" {
+			w.Write([]byte(`{"address":"0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045","name":"vitalik.eth"}`))
+			return
+		}
+		w.Write([]byte(`{"address":null}`))
+	}))
+	defer srv.Close()
+
+	r := &ENSAPIResolver{URL: srv.URL + "/", Client: http.DefaultClient}
+	a, err := r.ResolveENS(context.Background(), "vitalik.eth")
+	assert.Nil(t, err)
+	assert.Equal(t, Address("0xd8da6bf26964af9d7eed9e03e53415d37aa96045"), a)
+
+	_, err = r.ResolveENS(context.Background(), "nobody.eth")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestENSOwnerParams(t *testing.T) {
+	vitalik := "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"
+	var queries []string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/v1/assets"):
+			w.Write([]byte(`{"assets":[]}`))
+		default:
+			w.Write([]byte(`{"asset_events":[]}`))
+		}
+	}, WithENSResolver(testENSResolver{"vitalik.eth": Address(vitalik)}))
+
+	_, err := o.GetAssetsWithContext(context.Background(), GetAssetsParams{Owner: "Vitalik.eth"})
+	assert.Nil(t, err)
+	assert.Contains(t, queries[0], "owner="+vitalik)
+
+	params := NewRetrievingEventsParams()
+	assert.Nil(t, params.SetAccountAddress("vitalik.eth"))
+	_, err = o.RetrievingEventsWithContext(context.Background(), params)
+	assert.Nil(t, err)
+	assert.Contains(t, queries[1], "account_address="+vitalik)
+	assert.Equal(t, Address("vitalik.eth"), params.AccountAddress)
+
+	_, err = o.GetAssetsWithContext(context.Background(), GetAssetsParams{Owner: "nobody.eth"})
+	assert.Equal(t, ErrNotFound, err)
+}
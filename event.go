@@ -56,6 +56,14 @@ func (e Event) IsBundle() bool {
 	return e.AssetBundle != nil
 }
 
+// SalePrice returns the unit price of a sale in the native currency, using
+// the ETH price of the payment token. Bundles are not priced.
+func (e Event) SalePrice() (float64, bool) {
+	return salePrice(&e)
+}
+
+// PaymentToken is a currency of orders and events. Its prices are numbers
+// or strings; read them with ETHPrice and USDPrice.
 type PaymentToken struct {
 	Symbol   string      `json:"symbol" bson:"symbol"`
 	Address  Address     `json:"address" bson:"address"`
@@ -64,6 +72,8 @@ type PaymentToken struct {
 	Decimals int64       `json:"decimals" bson:"decimals"`
 	EthPrice interface{} `json:"eth_price" bson:"eth_price"`
 	UsdPrice interface{} `json:"usd_price" bson:"usd_price"`
+	// Chain is only set by GetPaymentToken.
+	Chain Chain `json:"chain,omitempty" bson:"chain,omitempty"`
 }
 
 type Transaction struct {
@@ -128,6 +138,7 @@ type RetrievingEventsParams struct {
 	AssetContractAddress Address
 	TokenID              int32
 	AccountAddress       Address
+	CollectionSlug       string
 	EventType            EventType
 	OnlyOpensea          bool
 	AuctionType          AuctionType
@@ -135,6 +146,9 @@ type RetrievingEventsParams struct {
 	Limit                int
 	OccurredBefore       int64
 	OccurredAfter        int64
+	// Fields limits the returned events to the given top-level JSON fields.
+	// Filtering by asset contract still sees the whole event.
+	Fields []string
 }
 
 func NewRetrievingEventsParams() *RetrievingEventsParams {
@@ -157,7 +171,13 @@ func (p *RetrievingEventsParams) SetAssetContractAddress(addr string) (err error
 	return
 }
 
+// SetAccountAddress sets the account of the events, an address or an ENS
+// name resolved when the events are retrieved.
 func (p *RetrievingEventsParams) SetAccountAddress(addr string) (err error) {
+	if IsENSName(addr) {
+		p.AccountAddress = Address(addr)
+		return nil
+	}
 	p.AccountAddress, err = ParseAddress(addr)
 	return
 }
@@ -174,6 +194,9 @@ func (p RetrievingEventsParams) Encode() string {
 	if p.AccountAddress != NullAddress {
 		q.Set("account_address", p.AccountAddress.String())
 	}
+	if p.CollectionSlug != "" {
+		q.Set("collection_slug", p.CollectionSlug)
+	}
 	if p.EventType != EventTypeNone {
 		q.Set("event_type", string(p.EventType))
 	}
@@ -199,16 +222,40 @@ func (o Opensea) RetrievingEvents(params *RetrievingEventsParams) ([]*Event, err
 }
 
 func (o Opensea) RetrievingEventsWithContext(ctx context.Context, params *RetrievingEventsParams) (events []*Event, err error) {
+	events = []*Event{}
+	err = o.RetrievingEventsPagesWithContext(ctx, params, func(page []*Event) error {
+		events = append(events, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// RetrievingEventsPagesWithContext is like RetrievingEventsWithContext but
+// hands each filtered page to fn as soon as it is fetched instead of
+// accumulating the results. Iteration stops at the first error returned by fn.
+func (o Opensea) RetrievingEventsPagesWithContext(ctx context.Context, params *RetrievingEventsParams, fn func(events []*Event) error) error {
+	o.deprecated("RetrievingEvents")
 	if params == nil {
 		params = NewRetrievingEventsParams()
 	}
+	if IsENSName(params.AccountAddress.String()) {
+		account, err := o.ownerAddress(ctx, params.AccountAddress)
+		if err != nil {
+			return err
+		}
+		resolved := *params
+		resolved.AccountAddress = account
+		params = &resolved
+	}
 
-	events = []*Event{}
 	for true {
 		path := "/api/v1/events/?" + params.Encode()
 		b, err := o.GetPath(ctx, path)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		eventsResp := &AssetEventsResponse{
@@ -216,7 +263,7 @@ func (o Opensea) RetrievingEventsWithContext(ctx context.Context, params *Retrie
 		}
 		err = json.Unmarshal(b, eventsResp)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		// Filters
@@ -243,10 +290,18 @@ func (o Opensea) RetrievingEventsWithContext(ctx context.Context, params *Retrie
 				}
 			}
 
+			if len(params.Fields) > 0 {
+				if err := Prune(&eventsResp.AssetEvents[i], params.Fields...); err != nil {
+					return err
+				}
+			}
 			tmp[cnt] = &eventsResp.AssetEvents[i]
 			cnt++
 		}
-		events = append(events, tmp[0:cnt]...)
+		if err := fn(tmp[0:cnt]); err != nil {
+			return err
+		}
+		reportProgress(ctx, len(eventsResp.AssetEvents))
 
 		if len(eventsResp.AssetEvents) < params.Limit {
 			break
@@ -254,5 +309,5 @@ func (o Opensea) RetrievingEventsWithContext(ctx context.Context, params *Retrie
 		params.Offset += params.Limit
 	}
 
-	return
+	return nil
 }
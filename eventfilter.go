@@ -0,0 +1,140 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// EventFilter builds the filter of an events query, e.g.
+//
+//	NewEventFilter().Collection("doodles-official").
+//		Types(EventTypeSuccessful, EventTypeTransfer).
+//		Between(from, to).MinPriceEth(1)
+//
+// Params compiles the criteria the API supports into GetEventsParams, and
+// Match applies the others to the events it returns.
+type EventFilter struct {
+	params      GetEventsParams
+	types       []EventType
+	minPriceEth float64
+	maxPriceEth float64
+	err         error
+}
+
+func NewEventFilter() *EventFilter {
+	return &EventFilter{}
+}
+
+func (f *EventFilter) Collection(slug string) *EventFilter {
+	f.params.CollectionSlug = slug
+	return f
+}
+
+func (f *EventFilter) Contract(assetContractAddress Address) *EventFilter {
+	f.params.AssetContractAddress = assetContractAddress
+	return f
+}
+
+func (f *EventFilter) Token(assetContractAddress Address, tokenID string) *EventFilter {
+	f.params.AssetContractAddress = assetContractAddress
+	f.params.TokenID = tokenID
+	return f
+}
+
+// Account keeps the events of an address or an ENS name.
+func (f *EventFilter) Account(account Address) *EventFilter {
+	f.params.AccountAddress = account
+	return f
+}
+
+// Types keeps the events of the given types. The API filters on a single
+// type; more are filtered by Match.
+func (f *EventFilter) Types(types ...EventType) *EventFilter {
+	f.types = types
+	return f
+}
+
+// Between keeps the events that occurred after from and before to. Either
+// may be zero.
+func (f *EventFilter) Between(from, to time.Time) *EventFilter {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		f.err = fmt.Errorf("Invalid period: %s to %s", from, to)
+	}
+	f.params.OccurredAfter = from
+	f.params.OccurredBefore = to
+	return f
+}
+
+// MinPriceEth keeps the sales with a unit price of at least p in the native
+// currency. Unpriced events, e.g. transfers and bundles, are dropped.
+func (f *EventFilter) MinPriceEth(p float64) *EventFilter {
+	f.minPriceEth = p
+	return f
+}
+
+// MaxPriceEth keeps the sales with a unit price of at most p in the native
+// currency. Unpriced events are dropped.
+func (f *EventFilter) MaxPriceEth(p float64) *EventFilter {
+	f.maxPriceEth = p
+	return f
+}
+
+func (f *EventFilter) Limit(limit int) *EventFilter {
+	f.params.Limit = limit
+	return f
+}
+
+// Params returns the API parameters of the filter.
+func (f *EventFilter) Params() (GetEventsParams, error) {
+	if f.err != nil {
+		return GetEventsParams{}, f.err
+	}
+	if f.minPriceEth > 0 && f.maxPriceEth > 0 && f.minPriceEth > f.maxPriceEth {
+		return GetEventsParams{}, fmt.Errorf("Invalid price range: %g to %g", f.minPriceEth, f.maxPriceEth)
+	}
+	p := f.params
+	if len(f.types) == 1 {
+		p.EventType = f.types[0]
+	}
+	return p, nil
+}
+
+// Match reports whether e passes the criteria the API does not filter on.
+func (f *EventFilter) Match(e AssetEvent) bool {
+	if len(f.types) > 1 && !EventOfType(f.types...)(e) {
+		return false
+	}
+	if f.minPriceEth > 0 || f.maxPriceEth > 0 {
+		return EventPriceBetween(f.minPriceEth, f.maxPriceEth)(e)
+	}
+	return true
+}
+
+// GetFilteredEvents returns a page of the events matching f; pass its Next
+// cursor to read the following page. Pages may be short, or empty, as Match
+// drops events after the fetch.
+func (o Opensea) GetFilteredEvents(f *EventFilter, cursor string) (*EventsResponse, error) {
+	ctx := context.TODO()
+	return o.GetFilteredEventsWithContext(ctx, f, cursor)
+}
+
+func (o Opensea) GetFilteredEventsWithContext(ctx context.Context, f *EventFilter, cursor string) (*EventsResponse, error) {
+	params, err := f.Params()
+	if err != nil {
+		return nil, err
+	}
+	params.Cursor = cursor
+	resp, err := o.GetEventsWithContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	matched := resp.AssetEvents[:0]
+	for _, e := range resp.AssetEvents {
+		if f.Match(e) {
+			matched = append(matched, e)
+		}
+	}
+	resp.AssetEvents = matched
+	return resp, nil
+}
@@ -0,0 +1,52 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilter(t *testing.T) {
+	from, to := time.Unix(1700000000, 0), time.Unix(1700086400, 0)
+	f := NewEventFilter().Collection("doodles-official").Types(EventTypeSuccessful).Between(from, to)
+	p, err := f.Params()
+	assert.Nil(t, err)
+	assert.Equal(t, "doodles-official", p.CollectionSlug)
+	assert.Equal(t, EventTypeSuccessful, p.EventType)
+	assert.Equal(t, from, p.OccurredAfter)
+	assert.Equal(t, to, p.OccurredBefore)
+
+	p, err = NewEventFilter().Types(EventTypeSuccessful, EventTypeTransfer).Params()
+	assert.Nil(t, err)
+	assert.Equal(t, EventTypeNone, p.EventType)
+
+	_, err = NewEventFilter().Between(to, from).Params()
+	assert.NotNil(t, err)
+	_, err = NewEventFilter().MinPriceEth(2).MaxPriceEth(1).Params()
+	assert.NotNil(t, err)
+}
+
+func TestGetFilteredEvents(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "doodles-official", r.URL.Query().Get("collection_slug"))
+		assert.Equal(t, "", r.URL.Query().Get("event_type"))
+		assert.Equal(t, "page2", r.URL.Query().Get("cursor"))
+		eth := `"payment_token":{"symbol":"ETH","decimals":18,"eth_price":"1.0"}`
+		w.Write([]byte(`{"next":"page3","asset_events":[
+			{"event_type":"successful","total_price":"2000000000000000000",` + eth + `},
+			{"event_type":"successful","total_price":"500000000000000000",` + eth + `},
+			{"event_type":"transfer"},
+			{"event_type":"bid_entered","bid_amount":"3000000000000000000",` + eth + `}]}`))
+	})
+	f := NewEventFilter().Collection("doodles-official").
+		Types(EventTypeSuccessful, EventTypeTransfer).
+		MinPriceEth(1)
+	resp, err := o.GetFilteredEventsWithContext(context.Background(), f, "page2")
+	assert.Nil(t, err)
+	assert.Equal(t, "page3", resp.Next)
+	assert.Len(t, resp.AssetEvents, 1)
+	assert.Equal(t, Number("2000000000000000000"), resp.AssetEvents[0].TotalPrice)
+}
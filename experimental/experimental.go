@@ -0,0 +1,144 @@
+// Package experimental gives typed access to endpoints OpenSea exposes but
+// does not document or stabilize. They may change or go away without
+// notice, so they are kept out of the opensea package and used by opting
+// in with New.
+//
+// Every endpoint is listed by Endpoints with its Stability. Version is
+// bumped whenever a method changes in a way that breaks callers; this
+// package does not follow the compatibility promise of the opensea one.
+package experimental
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// Version is the version of the experimental API.
+const Version = "v0"
+
+// Stability is how much an endpoint can be relied on.
+type Stability string
+
+const (
+	// Undocumented endpoints are used by the website but not documented.
+	Undocumented Stability = "undocumented"
+	// Emulated endpoints are built from documented ones, filling in what
+	// they lack on the client side, and may turn into a documented
+	// endpoint.
+	Emulated Stability = "emulated"
+)
+
+// Endpoint annotates a method of Client.
+type Endpoint struct {
+	Method    string
+	Path      string
+	Stability Stability
+}
+
+var endpoints = []Endpoint{
+	{Method: "Search", Path: "/api/v2/search", Stability: Undocumented},
+	{Method: "CategoryCollections", Path: "/api/v2/collections", Stability: Emulated},
+}
+
+// Endpoints lists the endpoints of the package and their stability.
+func Endpoints() []Endpoint {
+	return append([]Endpoint{}, endpoints...)
+}
+
+// Client calls the experimental endpoints with an opensea client.
+type Client struct {
+	o *opensea.Opensea
+}
+
+func New(o *opensea.Opensea) *Client {
+	return &Client{o: o}
+}
+
+// SearchResult is a result of Search. Type tells which of Collection, NFT
+// and Account is set.
+type SearchResult struct {
+	Type       string            `json:"type" bson:"type"`
+	Collection *CollectionResult `json:"collection,omitempty" bson:"collection,omitempty"`
+	NFT        *opensea.NFT      `json:"nft,omitempty" bson:"nft,omitempty"`
+	Account    *AccountResult    `json:"account,omitempty" bson:"account,omitempty"`
+}
+
+type CollectionResult struct {
+	Collection string `json:"collection" bson:"collection"`
+	Name       string `json:"name" bson:"name"`
+	ImageURL   string `json:"image_url" bson:"image_url"`
+	IsDisabled bool   `json:"is_disabled" bson:"is_disabled"`
+	IsNSFW     bool   `json:"is_nsfw" bson:"is_nsfw"`
+	OpenseaURL string `json:"opensea_url" bson:"opensea_url"`
+}
+
+type AccountResult struct {
+	Address         opensea.Address `json:"address" bson:"address"`
+	Username        string          `json:"username" bson:"username"`
+	ProfileImageURL string          `json:"profile_image_url" bson:"profile_image_url"`
+	OpenseaURL      string          `json:"opensea_url" bson:"opensea_url"`
+}
+
+// Search runs a full text search of collections, NFTs and accounts, the
+// search box of the website. Limit defaults to 20.
+func (c *Client) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("Empty search query")
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	values := url.Values{}
+	values.Set("query", query)
+	values.Set("limit", fmt.Sprintf("%d", limit))
+	b, err := c.o.GetPath(ctx, "/api/v2/search?"+values.Encode())
+	if err != nil {
+		return nil, err
+	}
+	resp := &struct {
+		Results []SearchResult `json:"results"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	if resp.Results == nil {
+		resp.Results = []SearchResult{}
+	}
+	return resp.Results, nil
+}
+
+// CategoryCollections returns the limit first collections of a category,
+// e.g. "art", ranked by orderBy. The API documents the category of
+// collections but has no filter on it, so the ranking is read page by page
+// until limit collections of the category are found or maxPages were read.
+func (c *Client) CategoryCollections(ctx context.Context, category string, chain opensea.Chain, orderBy opensea.CollectionOrderBy, limit, maxPages int) ([]opensea.Collection, error) {
+	if category == "" {
+		return nil, fmt.Errorf("Empty category")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("Invalid limit: %d", limit)
+	}
+	params := opensea.BrowseCollectionsParams{Chain: chain, OrderBy: orderBy}
+	ret := []opensea.Collection{}
+	for page := 0; page < maxPages && len(ret) < limit; page++ {
+		resp, err := c.o.BrowseCollectionsWithContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, collection := range resp.Collections {
+			if strings.EqualFold(collection.Category, category) && len(ret) < limit {
+				ret = append(ret, collection)
+			}
+		}
+		if resp.Next == "" {
+			break
+		}
+		params.Cursor = resp.Next
+	}
+	return ret, nil
+}
@@ -0,0 +1,68 @@
+package experimental
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	o, err := opensea.NewOpensea("test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.API = srv.URL
+	return New(o)
+}
+
+func TestEndpoints(t *testing.T) {
+	for _, e := range Endpoints() {
+		assert.NotEmpty(t, e.Method)
+		assert.Contains(t, []Stability{Undocumented, Emulated}, e.Stability)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/search", r.URL.Path)
+		assert.Equal(t, "doodles", r.URL.Query().Get("query"))
+		assert.Equal(t, "20", r.URL.Query().Get("limit"))
+		w.Write([]byte(`{"results":[{"type":"collection","collection":{"collection":"doodles-official","name":"Doodles"}},{"type":"nft","nft":{"identifier":"1","collection":"doodles-official"}}]}`))
+	})
+
+	results, err := c.Search(context.Background(), "doodles", 0)
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "doodles-official", results[0].Collection.Collection)
+	assert.Equal(t, "1", results[1].NFT.Identifier)
+
+	_, err = c.Search(context.Background(), " ", 0)
+	assert.NotNil(t, err)
+}
+
+func TestCategoryCollections(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/collections", r.URL.Path)
+		if r.URL.Query().Get("next") == "" {
+			w.Write([]byte(`{"collections":[{"collection":"a","category":"art"},{"collection":"b","category":"pfps"}],"next":"p2"}`))
+			return
+		}
+		w.Write([]byte(`{"collections":[{"collection":"c","category":"art"}]}`))
+	})
+
+	art, err := c.CategoryCollections(context.Background(), "Art", opensea.ChainBase, opensea.CollectionsByOneDayChange, 5, 10)
+	assert.Nil(t, err)
+	assert.Len(t, art, 2)
+	assert.Equal(t, "c", art[1].Slug)
+
+	art, err = c.CategoryCollections(context.Background(), "art", opensea.ChainBase, opensea.CollectionsByOneDayChange, 5, 1)
+	assert.Nil(t, err)
+	assert.Len(t, art, 1)
+}
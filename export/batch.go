@@ -0,0 +1,201 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+)
+
+// ColumnType is the logical type of an exported column. The types map to
+// Arrow as utf8, int64, bool and timestamp[s, UTC]. Prices stay strings as
+// they do not fit 64 bits.
+type ColumnType int
+
+const (
+	TypeString ColumnType = iota
+	TypeInt64
+	TypeBool
+	TypeTimestamp
+)
+
+// ColumnTypes gives the type of every non-string column of the schemas.
+var ColumnTypes = map[string]ColumnType{
+	"id":                     TypeInt64,
+	"num_sales":              TypeInt64,
+	"event_timestamp":        TypeTimestamp,
+	"payment_token_decimals": TypeInt64,
+	"listing_time":           TypeTimestamp,
+	"expiration_time":        TypeTimestamp,
+	"cancelled":              TypeBool,
+	"finalized":              TypeBool,
+}
+
+type Field struct {
+	Name string
+	Type ColumnType
+}
+
+// value is a parsed cell, appended to its column once the whole row parsed.
+type value struct {
+	s     string
+	i     int64
+	b     bool
+	valid bool
+}
+
+func (f Field) parse(s string) (value, error) {
+	v := value{s: s, valid: s != ""}
+	if !v.valid {
+		return v, nil
+	}
+
+	var err error
+	switch f.Type {
+	case TypeInt64:
+		v.i, err = strconv.ParseInt(s, 10, 64)
+	case TypeBool:
+		v.b, err = strconv.ParseBool(s)
+	case TypeTimestamp:
+		if t, perr := time.Parse(time.RFC3339, s); perr == nil {
+			v.i = t.Unix()
+		} else {
+			v.i, err = strconv.ParseInt(s, 10, 64)
+		}
+	}
+	if err != nil {
+		return v, fmt.Errorf("Invalid %s: %s", f.Name, s)
+	}
+	return v, nil
+}
+
+// arrowType returns the Arrow type of the column.
+func (f Field) arrowType() arrow.DataType {
+	switch f.Type {
+	case TypeInt64:
+		return arrow.PrimitiveTypes.Int64
+	case TypeBool:
+		return arrow.FixedWidthTypes.Boolean
+	case TypeTimestamp:
+		return arrow.FixedWidthTypes.Timestamp_s
+	}
+	return arrow.BinaryTypes.String
+}
+
+// Schema returns the Arrow schema of the records of fields, with nullable
+// columns.
+func Schema(fields []Field) *arrow.Schema {
+	ret := make([]arrow.Field, len(fields))
+	for i, f := range fields {
+		ret[i] = arrow.Field{Name: f.Name, Type: f.arrowType(), Nullable: true}
+	}
+	return arrow.NewSchema(ret, nil)
+}
+
+func appendValue(b array.Builder, f Field, v value) {
+	if !v.valid {
+		b.AppendNull()
+		return
+	}
+	switch f.Type {
+	case TypeInt64:
+		b.(*array.Int64Builder).Append(v.i)
+	case TypeBool:
+		b.(*array.BooleanBuilder).Append(v.b)
+	case TypeTimestamp:
+		b.(*array.TimestampBuilder).Append(arrow.Timestamp(v.i))
+	default:
+		b.(*array.StringBuilder).Append(v.s)
+	}
+}
+
+// BatchWriter is a Writer collecting rows into Arrow record batches of Size
+// rows, each passed to Handle as soon as it is full. The last, possibly
+// shorter, record is passed on Flush. Records are released once Handle
+// returns: a Handle keeping one, e.g. to export it through the Arrow C data
+// interface to DuckDB or DataFusion, must Retain it.
+type BatchWriter struct {
+	Size   int
+	Handle func(rec arrow.Record) error
+	// Mem allocates the records, defaulting to the Go allocator.
+	Mem memory.Allocator
+
+	fields  []Field
+	builder *array.RecordBuilder
+	rows    int
+}
+
+func NewBatchWriter(size int, handle func(rec arrow.Record) error) *BatchWriter {
+	if size < 1 {
+		size = 1024
+	}
+	return &BatchWriter{Size: size, Handle: handle}
+}
+
+func (w *BatchWriter) Begin(columns []string) error {
+	if w.builder != nil {
+		return fmt.Errorf("Batch export already begun")
+	}
+	w.fields = make([]Field, len(columns))
+	for i, name := range columns {
+		w.fields[i] = Field{Name: name, Type: ColumnTypes[name]}
+	}
+	mem := w.Mem
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	w.builder = array.NewRecordBuilder(mem, Schema(w.fields))
+	w.builder.Reserve(w.Size)
+	return nil
+}
+
+func (w *BatchWriter) Write(item interface{}, record []string) error {
+	if w.builder == nil {
+		return fmt.Errorf("Batch export not begun")
+	}
+	if len(record) != len(w.fields) {
+		return fmt.Errorf("Record has %d values for %d columns", len(record), len(w.fields))
+	}
+	values := make([]value, len(record))
+	for i, s := range record {
+		v, err := w.fields[i].parse(s)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+	for i, v := range values {
+		appendValue(w.builder.Field(i), w.fields[i], v)
+	}
+	w.rows++
+	if w.rows >= w.Size {
+		return w.emit()
+	}
+	return nil
+}
+
+// Flush passes the last record to Handle and releases the builder.
+func (w *BatchWriter) Flush() error {
+	if w.builder == nil {
+		return nil
+	}
+	defer func() {
+		w.builder.Release()
+		w.builder = nil
+	}()
+	if w.rows == 0 {
+		return nil
+	}
+	return w.emit()
+}
+
+func (w *BatchWriter) emit() error {
+	rec := w.builder.NewRecord()
+	defer rec.Release()
+	w.rows = 0
+	w.builder.Reserve(w.Size)
+	return w.Handle(rec)
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchWriter(t *testing.T) {
+	fixture, err := ioutil.ReadFile("../test-files/opensea-events.json")
+	assert.Nil(t, err)
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	var records []arrow.Record
+	bw := NewBatchWriter(5, func(rec arrow.Record) error {
+		rec.Retain()
+		records = append(records, rec)
+		return nil
+	})
+	bw.Mem = mem
+	n, err := ExportEvents(context.Background(), o, opensea.NewRetrievingEventsParams(), bw)
+	assert.Nil(t, err)
+	assert.Equal(t, 8, n)
+	defer func() {
+		for _, rec := range records {
+			rec.Release()
+		}
+	}()
+
+	assert.Len(t, records, 2)
+	assert.Equal(t, int64(5), records[0].NumRows())
+	assert.Equal(t, int64(3), records[1].NumRows())
+
+	schema := records[0].Schema()
+	i := schema.FieldIndices("event_timestamp")[0]
+	assert.Equal(t, arrow.FixedWidthTypes.Timestamp_s, schema.Field(i).Type)
+	ts := records[0].Column(i).(*array.Timestamp)
+	assert.Equal(t, 5, ts.Len())
+	// the fixture predates event_timestamp
+	assert.True(t, ts.IsNull(0))
+
+	i = schema.FieldIndices("event_type")[0]
+	assert.Equal(t, arrow.BinaryTypes.String, schema.Field(i).Type)
+	assert.Equal(t, "successful", records[0].Column(i).(*array.String).Value(0))
+	assert.False(t, schema.HasField("missing"))
+}
+
+func TestBatchWriterNulls(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	var got arrow.Record
+	bw := NewBatchWriter(0, func(rec arrow.Record) error {
+		rec.Retain()
+		got = rec
+		return nil
+	})
+	bw.Mem = mem
+	assert.Nil(t, bw.Begin([]string{"id", "cancelled", "listing_time", "name"}))
+	assert.Nil(t, bw.Write(nil, []string{"1", "", "2022-03-01T00:00:00Z", "a"}))
+	assert.Nil(t, bw.Write(nil, []string{"", "true", "1646092800", ""}))
+	assert.NotNil(t, bw.Write(nil, []string{"1", "x", "", ""}))
+	assert.NotNil(t, bw.Write(nil, []string{"1"}))
+	assert.Nil(t, bw.Flush())
+	defer got.Release()
+
+	// rejected rows leave no partial values behind
+	assert.Equal(t, int64(2), got.NumRows())
+	for _, c := range got.Columns() {
+		assert.Equal(t, 2, c.Len())
+	}
+	id := got.Column(0).(*array.Int64)
+	assert.Equal(t, int64(1), id.Value(0))
+	assert.True(t, id.IsNull(1))
+	cancelled := got.Column(1).(*array.Boolean)
+	assert.True(t, cancelled.IsNull(0))
+	assert.True(t, cancelled.Value(1))
+	listing := got.Column(2).(*array.Timestamp)
+	assert.Equal(t, arrow.Timestamp(1646092800), listing.Value(0))
+	assert.Equal(t, arrow.Timestamp(1646092800), listing.Value(1))
+	assert.True(t, got.Column(3).(*array.String).IsNull(1))
+}
+
+func TestBatchWriterOrders(t *testing.T) {
+	orders := []opensea.SeaportOrder{
+		{OrderHash: "0x1", Side: "ask", ListingTime: 1641092645},
+		{OrderHash: "0x2", Side: "bid", Finalized: true},
+	}
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	var got arrow.Record
+	bw := NewBatchWriter(0, func(rec arrow.Record) error {
+		rec.Retain()
+		got = rec
+		return nil
+	})
+	bw.Mem = mem
+	assert.Nil(t, bw.Begin(OrderColumns))
+	for i := range orders {
+		assert.Nil(t, bw.Write(&orders[i], OrderRecord(&orders[i])))
+	}
+	assert.Nil(t, bw.Flush())
+	if !assert.NotNil(t, got) {
+		return
+	}
+	defer got.Release()
+
+	assert.Equal(t, int64(2), got.NumRows())
+	schema := got.Schema()
+	i := schema.FieldIndices("side")[0]
+	assert.Equal(t, arrow.BinaryTypes.String, schema.Field(i).Type)
+	side := got.Column(i).(*array.String)
+	assert.Equal(t, "ask", side.Value(0))
+	assert.Equal(t, "bid", side.Value(1))
+	i = schema.FieldIndices("listing_time")[0]
+	listing := got.Column(i).(*array.Timestamp)
+	assert.Equal(t, arrow.Timestamp(1641092645), listing.Value(0))
+	assert.True(t, listing.IsNull(1))
+	i = schema.FieldIndices("finalized")[0]
+	assert.True(t, got.Column(i).(*array.Boolean).Value(1))
+	assert.False(t, schema.HasField("sale_kind"))
+}
@@ -0,0 +1,142 @@
+// Package export streams paginated OpenSea results into tabular files with
+// stable column schemas.
+//
+// CSV, JSON Lines and Parquet are implemented here, and BatchWriter hands
+// rows to analytics engines as Apache Arrow records. Other formats can be
+// plugged in by implementing Writer on top of the encoder of choice.
+// Sink streams exports to S3 or GCS, optionally compressed, under paths
+// partitioned by collection and date.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// Writer receives every exported item both as the decoded model and as a
+// row matching the columns given to Begin. Tabular writers use the record,
+// document writers the item.
+type Writer interface {
+	Begin(columns []string) error
+	Write(item interface{}, record []string) error
+	Flush() error
+}
+
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+func (c *CSVWriter) Begin(columns []string) error {
+	return c.w.Write(columns)
+}
+
+func (c *CSVWriter) Write(item interface{}, record []string) error {
+	return c.w.Write(record)
+}
+
+func (c *CSVWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// JSONLinesWriter writes each item as one JSON document per line, suitable
+// for jq or bulk loaders.
+type JSONLinesWriter struct {
+	enc *json.Encoder
+}
+
+func NewJSONLinesWriter(w io.Writer) *JSONLinesWriter {
+	return &JSONLinesWriter{enc: json.NewEncoder(w)}
+}
+
+func (j *JSONLinesWriter) Begin(columns []string) error {
+	return nil
+}
+
+func (j *JSONLinesWriter) Write(item interface{}, record []string) error {
+	return j.enc.Encode(item)
+}
+
+func (j *JSONLinesWriter) Flush() error {
+	return nil
+}
+
+// ExportAssets follows the assets cursor until exhaustion, writing each page
+// as soon as it is fetched. It returns the number of rows written.
+func ExportAssets(ctx context.Context, o *opensea.Opensea, params opensea.GetAssetsParams, w Writer) (int, error) {
+	if err := w.Begin(AssetColumns); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for {
+		resp, err := o.GetAssetsWithContext(ctx, params)
+		if err != nil {
+			return n, err
+		}
+		for i := range resp.Assets {
+			if err := w.Write(&resp.Assets[i], AssetRecord(&resp.Assets[i])); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if resp.Next == "" {
+			break
+		}
+		params.Cursor = resp.Next
+	}
+	return n, w.Flush()
+}
+
+// ExportEvents writes every event matching params, e.g. the sales history of
+// a collection when params.EventType is opensea.EventTypeSuccessful.
+func ExportEvents(ctx context.Context, o *opensea.Opensea, params *opensea.RetrievingEventsParams, w Writer) (int, error) {
+	if err := w.Begin(EventColumns); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	err := o.RetrievingEventsPagesWithContext(ctx, params, func(events []*opensea.Event) error {
+		for _, e := range events {
+			if err := w.Write(e, EventRecord(e)); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, w.Flush()
+}
+
+// ExportOrders writes every Seaport listing, or offer when params.Offers,
+// matching params.
+func ExportOrders(ctx context.Context, o *opensea.Opensea, params opensea.GetSeaportOrdersParams, w Writer) (int, error) {
+	if err := w.Begin(OrderColumns); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	err := o.SeaportOrdersIterator(params)(ctx, func(item interface{}) error {
+		order := item.(opensea.SeaportOrder)
+		if err := w.Write(&order, OrderRecord(&order)); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, w.Flush()
+}
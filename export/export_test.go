@@ -0,0 +1,109 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *opensea.Opensea {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	o, err := opensea.NewOpensea("test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.API = srv.URL
+	return o
+}
+
+func TestExportEvents(t *testing.T) {
+	fixture, err := ioutil.ReadFile("../test-files/opensea-events.json")
+	assert.Nil(t, err)
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+
+	buf := new(bytes.Buffer)
+	params := opensea.NewRetrievingEventsParams()
+	n, err := ExportEvents(context.Background(), o, params, NewCSVWriter(buf))
+	assert.Nil(t, err)
+	assert.Equal(t, 8, n)
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 9)
+	assert.Equal(t, EventColumns, rows[0])
+	assert.Equal(t, "successful", rows[1][1])
+	for _, row := range rows {
+		assert.Len(t, row, len(EventColumns))
+	}
+}
+
+func TestExportAssets(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"assets":[{"id":1,"token_id":"1","collection":{"slug":"doodles"}}],"next":"abc"}`))
+			return
+		}
+		w.Write([]byte(`{"assets":[{"id":2,"token_id":"2"}]}`))
+	})
+
+	buf := new(bytes.Buffer)
+	n, err := ExportAssets(context.Background(), o, opensea.GetAssetsParams{}, NewCSVWriter(buf))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "1", "", "", "doodles", "", "0", "", "", "", "", ""}, rows[1])
+}
+
+func TestJSONLinesWriter(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"assets":[{"id":1,"token_id":"1"},{"id":2,"token_id":"2"}]}`))
+	})
+
+	buf := new(bytes.Buffer)
+	n, err := ExportAssets(context.Background(), o, opensea.GetAssetsParams{}, NewJSONLinesWriter(buf))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	asset := new(opensea.Asset)
+	assert.Nil(t, json.Unmarshal(lines[1], asset))
+	assert.Equal(t, "2", asset.TokenID)
+}
+
+func TestExportOrders(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/orders/ethereum/seaport/listings", r.URL.Path)
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"orders":[{"order_hash":"0x1","side":"ask","current_price":"1000000000000000000","maker":{"address":"0x00000000000000000000000000000000000000ab"},"protocol_data":{"parameters":{"offer":[{"itemType":2,"token":"0x00000000000000000000000000000000000000de","identifierOrCriteria":"7","startAmount":"1"}]}}}],"next":"abc"}`))
+			return
+		}
+		w.Write([]byte(`{"orders":[{"order_hash":"0x2","side":"ask"}]}`))
+	})
+
+	buf := new(bytes.Buffer)
+	params := opensea.GetSeaportOrdersParams{AssetContractAddress: "0x00000000000000000000000000000000000000de"}
+	n, err := ExportOrders(context.Background(), o, params, NewCSVWriter(buf))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, OrderColumns, rows[0])
+	assert.Equal(t, []string{"0x1", "ask", "", "", "0x00000000000000000000000000000000000000de", "7", "0x00000000000000000000000000000000000000ab", "", "1000000000000000000", "", "1", "", "", "false", "false"}, rows[1])
+}
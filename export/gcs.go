@@ -0,0 +1,149 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// GCSStore writes to a Google Cloud Storage bucket with resumable uploads.
+// Token returns an OAuth2 access token, e.g. from the metadata server or
+// golang.org/x/oauth2/google. Part sizes must be multiples of 256 KiB.
+type GCSStore struct {
+	Bucket     string
+	Token      func(ctx context.Context) (string, error)
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+func NewGCSStore(bucket string, token func(ctx context.Context) (string, error)) *GCSStore {
+	return &GCSStore{
+		Bucket:     bucket,
+		Token:      token,
+		Endpoint:   gcsEndpoint,
+		HTTPClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+func (s *GCSStore) do(ctx context.Context, method string, u string, header http.Header, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if s.Token != nil {
+		token, err := s.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp, nil
+}
+
+func (s *GCSStore) CreateUpload(ctx context.Context, key string, contentType string) (Upload, error) {
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = gcsEndpoint
+	}
+	u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=resumable&name=%s", endpoint, url.PathEscape(s.Bucket), url.QueryEscape(key))
+	body, err := json.Marshal(map[string]string{"contentType": contentType})
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json; charset=UTF-8")
+	header.Set("X-Upload-Content-Type", contentType)
+
+	resp, err := s.do(ctx, "POST", u, header, body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GCS upload of %s returns status %d", key, resp.StatusCode)
+	}
+	session := resp.Header.Get("Location")
+	if session == "" {
+		return nil, fmt.Errorf("GCS upload of %s returns no session", key)
+	}
+	return &gcsUpload{store: s, key: key, session: session}, nil
+}
+
+type gcsUpload struct {
+	store   *GCSStore
+	key     string
+	session string
+	offset  int64
+}
+
+func (u *gcsUpload) put(ctx context.Context, data []byte, total string) (int, error) {
+	header := http.Header{}
+	if len(data) == 0 {
+		header.Set("Content-Range", "bytes */"+total)
+	} else {
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%s", u.offset, u.offset+int64(len(data))-1, total))
+	}
+	resp, err := u.store.do(ctx, "PUT", u.session, header, data)
+	if err != nil {
+		return 0, err
+	}
+	u.offset += int64(len(data))
+	return resp.StatusCode, nil
+}
+
+func (u *gcsUpload) Part(ctx context.Context, data []byte) error {
+	status, err := u.put(ctx, data, "*")
+	if err != nil {
+		return err
+	}
+	// 308 acknowledges an intermediate chunk
+	if status != http.StatusPermanentRedirect {
+		return fmt.Errorf("GCS upload of %s returns status %d", u.key, status)
+	}
+	return nil
+}
+
+func (u *gcsUpload) Complete(ctx context.Context, last []byte) error {
+	total := fmt.Sprint(u.offset + int64(len(last)))
+	status, err := u.put(ctx, last, total)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return fmt.Errorf("GCS upload of %s returns status %d", u.key, status)
+	}
+	return nil
+}
+
+func (u *gcsUpload) Abort(ctx context.Context) error {
+	resp, err := u.store.do(ctx, "DELETE", u.session, nil, nil)
+	if err != nil {
+		return err
+	}
+	// GCS answers 499 to a cancelled upload
+	if resp.StatusCode != 499 && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("GCS abort of %s returns status %d", u.key, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCSUpload(t *testing.T) {
+	var ranges []string
+	var body []byte
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.Method {
+		case "POST":
+			assert.Equal(t, "/upload/storage/v1/b/bucket/o", r.URL.Path)
+			assert.Equal(t, "lake/a.csv", r.URL.Query().Get("name"))
+			assert.Equal(t, "text/csv", r.Header.Get("X-Upload-Content-Type"))
+			w.Header().Set("Location", srv.URL+"/session/1")
+		case "PUT":
+			assert.Equal(t, "/session/1", r.URL.Path)
+			ranges = append(ranges, r.Header.Get("Content-Range"))
+			b, _ := ioutil.ReadAll(r.Body)
+			body = append(body, b...)
+			if r.Header.Get("Content-Range") == "bytes 0-3/*" {
+				w.WriteHeader(http.StatusPermanentRedirect)
+			}
+		case "DELETE":
+			w.WriteHeader(499)
+		}
+	}))
+	defer srv.Close()
+
+	store := NewGCSStore("bucket", func(ctx context.Context) (string, error) {
+		return "token", nil
+	})
+	store.Endpoint = srv.URL
+	w, err := NewObjectWriter(context.Background(), store, "lake/a.csv", "text/csv", 4)
+	assert.Nil(t, err)
+	w.Write([]byte("abcdef"))
+	assert.Nil(t, w.Close())
+
+	assert.Equal(t, []string{"bytes 0-3/*", "bytes 4-5/6"}, ranges)
+	assert.Equal(t, "abcdef", string(body))
+
+	w, _ = NewObjectWriter(context.Background(), store, "lake/a.csv", "text/csv", 4)
+	assert.Nil(t, w.Abort())
+}
@@ -0,0 +1,106 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+)
+
+// parquetColumn is the pattern of the column names a Parquet schema takes.
+var parquetColumn = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParquetWriter writes the rows as a Snappy compressed Parquet file, with
+// the columns typed as the fields of a BatchWriter: strings, int64, boolean
+// and UTC timestamps in milliseconds. Empty values are nulls. The file is
+// only complete once Flush wrote its footer, so a ParquetWriter writes a
+// single export.
+type ParquetWriter struct {
+	w      io.Writer
+	fields []Field
+	fw     *goparquet.FileWriter
+}
+
+func NewParquetWriter(w io.Writer) *ParquetWriter {
+	return &ParquetWriter{w: w}
+}
+
+func (p *ParquetWriter) Begin(columns []string) error {
+	if p.fw != nil {
+		return fmt.Errorf("Parquet export already begun")
+	}
+	var schema strings.Builder
+	schema.WriteString("message export {\n")
+	p.fields = make([]Field, len(columns))
+	for i, name := range columns {
+		if !parquetColumn.MatchString(name) {
+			return fmt.Errorf("Invalid Parquet column %q", name)
+		}
+		p.fields[i] = Field{Name: name, Type: ColumnTypes[name]}
+		switch p.fields[i].Type {
+		case TypeInt64:
+			fmt.Fprintf(&schema, "  optional int64 %s;\n", name)
+		case TypeBool:
+			fmt.Fprintf(&schema, "  optional boolean %s;\n", name)
+		case TypeTimestamp:
+			fmt.Fprintf(&schema, "  optional int64 %s (TIMESTAMP(MILLIS, true));\n", name)
+		default:
+			fmt.Fprintf(&schema, "  optional binary %s (STRING);\n", name)
+		}
+	}
+	schema.WriteString("}\n")
+
+	sd, err := parquetschema.ParseSchemaDefinition(schema.String())
+	if err != nil {
+		return err
+	}
+	p.fw = goparquet.NewFileWriter(p.w,
+		goparquet.WithSchemaDefinition(sd),
+		goparquet.WithCompressionCodec(parquet.CompressionCodec_SNAPPY),
+		goparquet.WithCreator("go-opensea"),
+	)
+	return nil
+}
+
+func (p *ParquetWriter) Write(item interface{}, record []string) error {
+	if p.fw == nil {
+		return fmt.Errorf("Parquet export not begun")
+	}
+	if len(record) != len(p.fields) {
+		return fmt.Errorf("Record has %d values for %d columns", len(record), len(p.fields))
+	}
+	row := make(map[string]interface{}, len(record))
+	for i, s := range record {
+		v, err := p.fields[i].parse(s)
+		if err != nil {
+			return err
+		}
+		if !v.valid {
+			continue
+		}
+		name := p.fields[i].Name
+		switch p.fields[i].Type {
+		case TypeInt64:
+			row[name] = v.i
+		case TypeBool:
+			row[name] = v.b
+		case TypeTimestamp:
+			row[name] = v.i * 1000
+		default:
+			row[name] = []byte(v.s)
+		}
+	}
+	return p.fw.AddData(row)
+}
+
+// Flush writes the last row group and the footer, ending the file.
+func (p *ParquetWriter) Flush() error {
+	if p.fw == nil {
+		return nil
+	}
+	return p.fw.Close()
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	goparquet "github.com/fraugster/parquet-go"
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParquetWriter(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"assets":[{"id":1,"token_id":"1","name":"One","num_sales":3,"collection":{"slug":"doodles"}}],"next":"abc"}`))
+			return
+		}
+		w.Write([]byte(`{"assets":[{"id":2,"token_id":"2"}]}`))
+	})
+
+	buf := new(bytes.Buffer)
+	n, err := ExportAssets(context.Background(), o, opensea.GetAssetsParams{}, NewParquetWriter(buf))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	fr, err := goparquet.NewFileReader(bytes.NewReader(buf.Bytes()))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, int64(2), fr.NumRows())
+
+	row, err := fr.NextRow()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), row["id"])
+	assert.Equal(t, []byte("One"), row["name"])
+	assert.Equal(t, []byte("doodles"), row["collection_slug"])
+	assert.Equal(t, int64(3), row["num_sales"])
+	assert.NotContains(t, row, "owner")
+
+	row, err = fr.NextRow()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), row["id"])
+	assert.NotContains(t, row, "name")
+
+	_, err = fr.NextRow()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestParquetWriterTypes(t *testing.T) {
+	buf := new(bytes.Buffer)
+	p := NewParquetWriter(buf)
+	assert.Nil(t, p.Begin([]string{"id", "cancelled", "listing_time", "maker"}))
+	assert.Nil(t, p.Write(nil, []string{"7", "true", "2022-01-02T03:04:05Z", "0xabc"}))
+	assert.NotNil(t, p.Write(nil, []string{"7", "true"}))
+	assert.NotNil(t, p.Write(nil, []string{"x", "", "", ""}))
+	assert.Nil(t, p.Flush())
+
+	fr, err := goparquet.NewFileReader(bytes.NewReader(buf.Bytes()))
+	if !assert.Nil(t, err) {
+		return
+	}
+	row, err := fr.NextRow()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), row["id"])
+	assert.Equal(t, true, row["cancelled"])
+	assert.Equal(t, int64(1641092645000), row["listing_time"])
+	assert.Equal(t, []byte("0xabc"), row["maker"])
+}
+
+func TestParquetWriterColumnNames(t *testing.T) {
+	p := NewParquetWriter(new(bytes.Buffer))
+	assert.NotNil(t, p.Begin([]string{"id", "bad name"}))
+}
+
+func TestParquetWriterOrders(t *testing.T) {
+	order := opensea.SeaportOrder{
+		OrderHash:      "0x1",
+		Side:           "bid",
+		CurrentPrice:   "1000000000000000000",
+		ListingTime:    1641092645,
+		ExpirationTime: 1643771045,
+		Cancelled:      true,
+	}
+
+	buf := new(bytes.Buffer)
+	p := NewParquetWriter(buf)
+	assert.Nil(t, p.Begin(OrderColumns))
+	assert.Nil(t, p.Write(&order, OrderRecord(&order)))
+	assert.Nil(t, p.Flush())
+
+	fr, err := goparquet.NewFileReader(bytes.NewReader(buf.Bytes()))
+	if !assert.Nil(t, err) {
+		return
+	}
+	row, err := fr.NextRow()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("0x1"), row["order_hash"])
+	assert.Equal(t, []byte("bid"), row["side"])
+	assert.Equal(t, []byte("1000000000000000000"), row["current_price"])
+	assert.Equal(t, []byte("1"), row["quantity"])
+	assert.Equal(t, int64(1641092645000), row["listing_time"])
+	assert.Equal(t, int64(1643771045000), row["expiration_time"])
+	assert.Equal(t, true, row["cancelled"])
+	assert.Equal(t, false, row["finalized"])
+	assert.NotContains(t, row, "maker")
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// OrderSchemaVersion is the version of OrderColumns. Version 1 held the
+// Wyvern orders of the v1 API, with a numeric side and sale_kind; version 2
+// holds Seaport orders, with side "ask" or "bid".
+const OrderSchemaVersion = 2
+
+// Column schemas. New columns are only ever appended so that existing
+// consumers keep working, other changes bump the schema version.
+var (
+	AssetColumns = []string{
+		"id",
+		"token_id",
+		"name",
+		"contract_address",
+		"collection_slug",
+		"owner",
+		"num_sales",
+		"last_sale_total_price",
+		"last_sale_payment_token",
+		"permalink",
+		"image_url",
+		"rarity_rank",
+	}
+
+	EventColumns = []string{
+		"id",
+		"event_type",
+		"event_timestamp",
+		"collection_slug",
+		"contract_address",
+		"token_id",
+		"quantity",
+		"total_price",
+		"bid_amount",
+		"payment_token",
+		"payment_token_decimals",
+		"seller",
+		"winner",
+		"from",
+		"to",
+		"transaction_hash",
+	}
+
+	OrderColumns = []string{
+		"order_hash",
+		"side",
+		"order_type",
+		"chain",
+		"contract_address",
+		"token_id",
+		"maker",
+		"taker",
+		"current_price",
+		"payment_token",
+		"quantity",
+		"listing_time",
+		"expiration_time",
+		"cancelled",
+		"finalized",
+	}
+)
+
+func AssetRecord(a *opensea.Asset) []string {
+	var contract, slug, owner, salePrice, saleToken, rank string
+	if a.AssetContract != nil {
+		contract = a.AssetContract.Address.String()
+	}
+	if a.Collection != nil {
+		slug = a.Collection.Slug
+	}
+	if a.Owner != nil {
+		owner = a.Owner.Address.String()
+	}
+	if a.LastSale != nil {
+		salePrice = a.LastSale.TotalPrice
+		if a.LastSale.PaymentToken != nil {
+			saleToken = a.LastSale.PaymentToken.Symbol
+		}
+	}
+	if a.Rarity != nil {
+		rank = strconv.FormatInt(a.Rarity.Rank, 10)
+	}
+	return []string{
+		strconv.FormatInt(a.ID, 10),
+		a.TokenID,
+		a.Name,
+		contract,
+		slug,
+		owner,
+		strconv.FormatInt(a.NumSales, 10),
+		salePrice,
+		saleToken,
+		a.Permalink,
+		a.ImageURL,
+		rank,
+	}
+}
+
+func EventRecord(e *opensea.Event) []string {
+	var tokenID, token, decimals, txHash string
+	if e.Asset != nil {
+		tokenID = e.Asset.TokenID
+	}
+	if e.PaymentToken != nil {
+		token = e.PaymentToken.Symbol
+		decimals = strconv.FormatInt(e.PaymentToken.Decimals, 10)
+	}
+	if e.Transaction != nil {
+		txHash = e.Transaction.TransactionHash
+	}
+	return []string{
+		strconv.FormatUint(e.ID, 10),
+		string(e.EventType),
+		formatTime(e.EventTimestamp.Time()),
+		e.CollectionSlug,
+		e.ContractAddress.String(),
+		tokenID,
+		e.Quantity,
+		string(e.TotalPrice),
+		string(e.BidAmount),
+		token,
+		decimals,
+		accountAddress(e.Seller),
+		accountAddress(e.WinnerAccount),
+		accountAddress(e.FromAccount),
+		accountAddress(e.ToAccount),
+		txHash,
+	}
+}
+
+func OrderRecord(o *opensea.SeaportOrder) []string {
+	q := o.Quote()
+	side := o.Side
+	if side == "" {
+		side = "bid"
+		if o.IsListing() {
+			side = "ask"
+		}
+	}
+	maker := accountAddress(o.Maker)
+	if maker == "" {
+		maker = o.ProtocolData.Parameters.Offerer.String()
+	}
+	price := o.CurrentPrice
+	if price == "" {
+		price = o.Price.Value
+		if o.Price.Current != nil {
+			price = o.Price.Current.Value
+		}
+	}
+	return []string{
+		o.OrderHash,
+		side,
+		o.OrderType,
+		string(o.Chain),
+		q.Contract.String(),
+		q.TokenID,
+		maker,
+		accountAddress(o.Taker),
+		string(price),
+		q.Currency,
+		strconv.FormatInt(q.Quantity, 10),
+		formatTime(o.StartTime()),
+		formatTime(o.ExpiresAt()),
+		strconv.FormatBool(o.Cancelled),
+		strconv.FormatBool(o.Finalized),
+	}
+}
+
+func accountAddress(a *opensea.Account) string {
+	if a == nil {
+		return ""
+	}
+	return a.Address.String()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// accountColumns are the columns of the schemas holding account addresses.
+var accountColumns = map[string]bool{
+	"owner":  true,
+	"seller": true,
+	"winner": true,
+	"from":   true,
+	"to":     true,
+	"maker":  true,
+	"taker":  true,
+}
+
+// RedactingWriter redacts the items and records it passes to a Writer with
+// a policy: items through the policy, and the account columns of records
+// with its Addresses action.
+type RedactingWriter struct {
+	w        Writer
+	policy   opensea.RedactionPolicy
+	accounts []int
+}
+
+func NewRedactingWriter(w Writer, policy opensea.RedactionPolicy) *RedactingWriter {
+	return &RedactingWriter{w: w, policy: policy}
+}
+
+func (r *RedactingWriter) Begin(columns []string) error {
+	r.accounts = r.accounts[:0]
+	for i, c := range columns {
+		if accountColumns[c] {
+			r.accounts = append(r.accounts, i)
+		}
+	}
+	return r.w.Begin(columns)
+}
+
+func (r *RedactingWriter) Write(item interface{}, record []string) error {
+	redacted, err := r.policy.Redact(item)
+	if err != nil {
+		return err
+	}
+	record = append([]string{}, record...)
+	for _, i := range r.accounts {
+		if i < len(record) {
+			record[i] = r.policy.Apply(r.policy.Addresses, record[i])
+		}
+	}
+	return r.w.Write(redacted, record)
+}
+
+func (r *RedactingWriter) Flush() error {
+	return r.w.Flush()
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingWriter struct {
+	items   []interface{}
+	records [][]string
+}
+
+func (w *recordingWriter) Begin(columns []string) error { return nil }
+
+func (w *recordingWriter) Write(item interface{}, record []string) error {
+	w.items = append(w.items, item)
+	w.records = append(w.records, record)
+	return nil
+}
+
+func (w *recordingWriter) Flush() error { return nil }
+
+func TestRedactingWriter(t *testing.T) {
+	const owner = "0x00000000000000000000000000000000000000aa"
+	asset := &opensea.Asset{TokenID: "1", Owner: &opensea.Account{Address: owner, User: opensea.User{Username: "alice"}}}
+	record := []string{"1", "1", "", "0xcontract", "doodles", owner}
+
+	rec := &recordingWriter{}
+	w := NewRedactingWriter(rec, opensea.DefaultRedactionPolicy())
+	assert.Nil(t, w.Begin(AssetColumns))
+	assert.Nil(t, w.Write(asset, record))
+	assert.Equal(t, "0x0000…00aa", rec.records[0][5])
+	assert.Equal(t, owner, record[5])
+	assert.Equal(t, "0x0000…00aa", rec.items[0].(*opensea.Asset).Owner.Address.String())
+	assert.Equal(t, owner, asset.Owner.Address.String())
+
+	buf := new(bytes.Buffer)
+	jw := NewRedactingWriter(NewJSONLinesWriter(buf), opensea.DefaultRedactionPolicy())
+	assert.Nil(t, jw.Begin(AssetColumns))
+	assert.Nil(t, jw.Write(asset, record))
+	doc := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.NotContains(t, buf.String(), "alice")
+}
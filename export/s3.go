@@ -0,0 +1,261 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Store writes to an S3 bucket, or to any S3 compatible service when
+// Endpoint is set, in which case path-style URLs are used.
+type S3Store struct {
+	Bucket          string
+	Region          string
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	HTTPClient      *http.Client
+}
+
+func NewS3Store(bucket string, region string, accessKeyID string, secretAccessKey string) *S3Store {
+	return &S3Store{
+		Bucket:          bucket,
+		Region:          region,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		HTTPClient:      &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+func (s *S3Store) objectURL(key string, query url.Values) string {
+	var u string
+	if s.Endpoint == "" {
+		u = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.Bucket, s.Region, escapePath(key))
+	} else {
+		u = fmt.Sprintf("%s/%s/%s", strings.TrimRight(s.Endpoint, "/"), s.Bucket, escapePath(key))
+	}
+	if len(query) > 0 {
+		u += "?" + canonicalQuery(query)
+	}
+	return u
+}
+
+func (s *S3Store) do(ctx context.Context, method string, key string, query url.Values, header http.Header, body []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key, query), bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	signV4(req, body, s.Region, "s3", s.AccessKeyID, s.SecretAccessKey, s.SessionToken, time.Now())
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf("S3 %s %s returns status %d: %s", method, key, resp.StatusCode, b)
+	}
+	return resp, b, nil
+}
+
+func (s *S3Store) CreateUpload(ctx context.Context, key string, contentType string) (Upload, error) {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	_, b, err := s.do(ctx, "POST", key, url.Values{"uploads": {""}}, header, nil)
+	if err != nil {
+		return nil, err
+	}
+	var ret struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(b, &ret); err != nil {
+		return nil, err
+	}
+	return &s3Upload{store: s, key: key, id: ret.UploadID}, nil
+}
+
+type s3Part struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3Upload struct {
+	store *S3Store
+	key   string
+	id    string
+	parts []s3Part
+}
+
+func (u *s3Upload) Part(ctx context.Context, data []byte) error {
+	n := len(u.parts) + 1
+	query := url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {u.id}}
+	resp, _, err := u.store.do(ctx, "PUT", u.key, query, nil, data)
+	if err != nil {
+		return err
+	}
+	u.parts = append(u.parts, s3Part{PartNumber: n, ETag: resp.Header.Get("ETag")})
+	return nil
+}
+
+func (u *s3Upload) Complete(ctx context.Context, last []byte) error {
+	if len(last) > 0 || len(u.parts) == 0 {
+		if err := u.Part(ctx, last); err != nil {
+			return err
+		}
+	}
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []s3Part `xml:"Part"`
+	}{Parts: u.parts})
+	if err != nil {
+		return err
+	}
+	_, b, err := u.store.do(ctx, "POST", u.key, url.Values{"uploadId": {u.id}}, nil, body)
+	if err != nil {
+		return err
+	}
+	// S3 may report a failed completion in a 200 response
+	if bytes.Contains(b, []byte("<Error>")) {
+		return fmt.Errorf("S3 completes %s with an error: %s", u.key, b)
+	}
+	return nil
+}
+
+func (u *s3Upload) Abort(ctx context.Context) error {
+	_, _, err := u.store.do(ctx, "DELETE", u.key, url.Values{"uploadId": {u.id}}, nil, nil)
+	return err
+}
+
+// signV4 signs req with AWS Signature Version 4. The host, content type,
+// range and x-amz-* headers are signed.
+func signV4(req *http.Request, body []byte, region string, service string, accessKeyID string, secretAccessKey string, sessionToken string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		if strings.HasPrefix(k, "x-amz-") || k == "content-type" || k == "range" || k == "content-md5" {
+			headers[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	uri := req.URL.EscapedPath()
+	if uri == "" {
+		uri = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uri,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	for _, s := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, s string) []byte {
+	mac := hmac.New(sha256.New, key)
+	io.WriteString(mac, s)
+	return mac.Sum(nil)
+}
+
+// escapePath escapes each segment of an object key like SigV4 expects.
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = uriEncode(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, k := range keys {
+		values := append([]string{}, query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything but the unreserved characters of
+// RFC 3986.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"path"
+	"time"
+)
+
+// ObjectStore creates objects through multipart uploads, so that exports
+// of any size are streamed without being held in memory.
+type ObjectStore interface {
+	CreateUpload(ctx context.Context, key string, contentType string) (Upload, error)
+}
+
+// Upload is an object being written. Every part passed to Part has the part
+// size of the writer; Complete receives the remainder, possibly empty.
+type Upload interface {
+	Part(ctx context.Context, data []byte) error
+	Complete(ctx context.Context, last []byte) error
+	Abort(ctx context.Context) error
+}
+
+// DefaultPartSize satisfies the 5 MiB minimum of S3 and the 256 KiB
+// granularity of GCS.
+const DefaultPartSize = 8 << 20
+
+// ObjectWriter buffers writes into parts of an Upload.
+type ObjectWriter struct {
+	ctx      context.Context
+	upload   Upload
+	partSize int
+	buf      []byte
+	err      error
+}
+
+func NewObjectWriter(ctx context.Context, store ObjectStore, key string, contentType string, partSize int) (*ObjectWriter, error) {
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+	u, err := store.CreateUpload(ctx, key, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectWriter{ctx: ctx, upload: u, partSize: partSize}, nil
+}
+
+func (w *ObjectWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := len(p)
+	for len(p) > 0 {
+		room := w.partSize - len(w.buf)
+		if room > len(p) {
+			room = len(p)
+		}
+		w.buf = append(w.buf, p[:room]...)
+		p = p[room:]
+		// a full part is only sent once more data follows, so that
+		// Complete always gets the last bytes
+		if len(w.buf) == w.partSize && len(p) > 0 {
+			if w.err = w.upload.Part(w.ctx, w.buf); w.err != nil {
+				return n - len(p), w.err
+			}
+			w.buf = w.buf[:0]
+		}
+	}
+	return n, nil
+}
+
+// Close commits the object.
+func (w *ObjectWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.upload.Complete(w.ctx, w.buf)
+	if w.err == nil {
+		w.err = fmt.Errorf("Object writer closed")
+		return nil
+	}
+	return w.err
+}
+
+// Abort discards the parts uploaded so far.
+func (w *ObjectWriter) Abort() error {
+	w.err = fmt.Errorf("Object writer aborted")
+	return w.upload.Abort(w.ctx)
+}
+
+// PartitionPath returns a Hive-style object key such as
+// "<prefix>/events/collection=doodles/dt=2022-03-01/20220301T120000Z.csv.gz"
+// so that data lake engines can prune by collection and date. The
+// collection partition is left out when empty.
+func PartitionPath(prefix string, dataset string, collection string, t time.Time, ext string) string {
+	t = t.UTC()
+	parts := []string{prefix, dataset}
+	if collection != "" {
+		parts = append(parts, "collection="+collection)
+	}
+	parts = append(parts, "dt="+t.Format("2006-01-02"), t.Format("20060102T150405Z")+"."+ext)
+	return path.Join(parts...)
+}
+
+// Sink writes exports as objects, optionally gzip compressed, under
+// partitioned paths. Parquet objects are never gzipped, their columns
+// being compressed already.
+type Sink struct {
+	Store    ObjectStore
+	Prefix   string
+	Gzip     bool
+	PartSize int
+}
+
+// SinkWriter is the destination of a single export, passed to NewCSVWriter,
+// NewJSONLinesWriter or NewParquetWriter. Close commits the object; call Abort instead when
+// the export fails.
+type SinkWriter struct {
+	Key string
+
+	object *ObjectWriter
+	gz     *gzip.Writer
+}
+
+var contentTypes = map[string]string{
+	"csv":     "text/csv",
+	"jsonl":   "application/x-ndjson",
+	"parquet": "application/vnd.apache.parquet",
+}
+
+// compressedFormats compress their own content.
+var compressedFormats = map[string]bool{
+	"parquet": true,
+}
+
+// Open starts the object of a dataset such as "events" or "listings" in the
+// given format, "csv", "jsonl" or "parquet".
+func (s *Sink) Open(ctx context.Context, dataset string, collection string, t time.Time, format string) (*SinkWriter, error) {
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return nil, fmt.Errorf("Invalid format: %s", format)
+	}
+	ext := format
+	gz := s.Gzip && !compressedFormats[format]
+	if gz {
+		ext += ".gz"
+		contentType = "application/gzip"
+	}
+
+	key := PartitionPath(s.Prefix, dataset, collection, t, ext)
+	object, err := NewObjectWriter(ctx, s.Store, key, contentType, s.PartSize)
+	if err != nil {
+		return nil, err
+	}
+	w := &SinkWriter{Key: key, object: object}
+	if gz {
+		w.gz = gzip.NewWriter(object)
+	}
+	return w, nil
+}
+
+func (w *SinkWriter) Write(p []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	return w.object.Write(p)
+}
+
+func (w *SinkWriter) Close() error {
+	if w.gz != nil {
+		if err := w.gz.Close(); err != nil {
+			w.object.Abort()
+			return err
+		}
+	}
+	return w.object.Close()
+}
+
+func (w *SinkWriter) Abort() error {
+	return w.object.Abort()
+}
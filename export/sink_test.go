@@ -0,0 +1,150 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryUpload struct {
+	parts     [][]byte
+	completed bool
+	aborted   bool
+}
+
+func (u *memoryUpload) Part(ctx context.Context, data []byte) error {
+	u.parts = append(u.parts, append([]byte{}, data...))
+	return nil
+}
+
+func (u *memoryUpload) Complete(ctx context.Context, last []byte) error {
+	u.parts = append(u.parts, append([]byte{}, last...))
+	u.completed = true
+	return nil
+}
+
+func (u *memoryUpload) Abort(ctx context.Context) error {
+	u.aborted = true
+	return nil
+}
+
+func (u *memoryUpload) bytes() []byte {
+	return bytes.Join(u.parts, nil)
+}
+
+type memoryStore struct {
+	uploads map[string]*memoryUpload
+	types   map[string]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{uploads: map[string]*memoryUpload{}, types: map[string]string{}}
+}
+
+func (s *memoryStore) CreateUpload(ctx context.Context, key string, contentType string) (Upload, error) {
+	u := &memoryUpload{}
+	s.uploads[key] = u
+	s.types[key] = contentType
+	return u, nil
+}
+
+func TestObjectWriter(t *testing.T) {
+	store := newMemoryStore()
+	w, err := NewObjectWriter(context.Background(), store, "a", "text/csv", 4)
+	assert.Nil(t, err)
+
+	w.Write([]byte("abc"))
+	w.Write([]byte("defgh"))
+	w.Write([]byte("ijk"))
+	assert.Nil(t, w.Close())
+
+	u := store.uploads["a"]
+	assert.True(t, u.completed)
+	assert.Equal(t, [][]byte{[]byte("abcd"), []byte("efgh"), []byte("ijk")}, u.parts)
+
+	_, err = w.Write([]byte("x"))
+	assert.NotNil(t, err)
+
+	// an exact multiple of the part size leaves the last part to Complete
+	w, _ = NewObjectWriter(context.Background(), store, "b", "text/csv", 4)
+	w.Write([]byte("abcdefgh"))
+	w.Close()
+	assert.Equal(t, [][]byte{[]byte("abcd"), []byte("efgh")}, store.uploads["b"].parts)
+}
+
+func TestPartitionPath(t *testing.T) {
+	ts := time.Date(2022, 3, 1, 12, 30, 0, 0, time.UTC)
+	assert.Equal(t, "lake/events/collection=doodles/dt=2022-03-01/20220301T123000Z.csv.gz", PartitionPath("lake", "events", "doodles", ts, "csv.gz"))
+	assert.Equal(t, "listings/dt=2022-03-01/20220301T123000Z.jsonl", PartitionPath("", "listings", "", ts, "jsonl"))
+}
+
+func TestSink(t *testing.T) {
+	fixture, err := ioutil.ReadFile("../test-files/opensea-events.json")
+	assert.Nil(t, err)
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+
+	store := newMemoryStore()
+	sink := &Sink{Store: store, Prefix: "lake", Gzip: true}
+	ts := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	w, err := sink.Open(context.Background(), "events", "doodles", ts, "csv")
+	assert.Nil(t, err)
+	assert.Equal(t, "lake/events/collection=doodles/dt=2022-03-01/20220301T000000Z.csv.gz", w.Key)
+
+	n, err := ExportEvents(context.Background(), o, opensea.NewRetrievingEventsParams(), NewCSVWriter(w))
+	assert.Nil(t, err)
+	assert.Equal(t, 8, n)
+	assert.Nil(t, w.Close())
+	assert.Equal(t, "application/gzip", store.types[w.Key])
+
+	gz, err := gzip.NewReader(bytes.NewReader(store.uploads[w.Key].bytes()))
+	assert.Nil(t, err)
+	rows, err := csv.NewReader(gz).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 9)
+
+	_, err = sink.Open(context.Background(), "events", "", ts, "xml")
+	assert.NotNil(t, err)
+
+	w, _ = sink.Open(context.Background(), "events", "", ts, "jsonl")
+	assert.Nil(t, w.Abort())
+	assert.True(t, store.uploads[w.Key].aborted)
+}
+
+func TestSinkParquet(t *testing.T) {
+	fixture, err := ioutil.ReadFile("../test-files/opensea-events.json")
+	assert.Nil(t, err)
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+
+	store := newMemoryStore()
+	sink := &Sink{Store: store, Prefix: "lake", Gzip: true}
+	ts := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	w, err := sink.Open(context.Background(), "events", "doodles", ts, "parquet")
+	assert.Nil(t, err)
+	// parquet is not gzipped again
+	assert.Equal(t, "lake/events/collection=doodles/dt=2022-03-01/20220301T000000Z.parquet", w.Key)
+
+	n, err := ExportEvents(context.Background(), o, opensea.NewRetrievingEventsParams(), NewParquetWriter(w))
+	assert.Nil(t, err)
+	assert.Equal(t, 8, n)
+	assert.Nil(t, w.Close())
+	assert.Equal(t, "application/vnd.apache.parquet", store.types[w.Key])
+
+	fr, err := goparquet.NewFileReader(bytes.NewReader(store.uploads[w.Key].bytes()))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, int64(8), fr.NumRows())
+}
@@ -0,0 +1,134 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// CollectionFee is a fee on the sales of a collection, either the OpenSea
+// fee or a creator fee.
+type CollectionFee struct {
+	Fee
+	// Required fees are enforced on every sale; creator fees that are not
+	// required are paid at the discretion of the seller.
+	Required bool `json:"required" bson:"required"`
+}
+
+// IsOpenSeaFee reports whether the fee goes to OpenSea.
+func (f CollectionFee) IsOpenSeaFee() bool {
+	return strings.EqualFold(f.Recipient.String(), OpenSeaFeeRecipient.String())
+}
+
+// CollectionFees are the fees on the sales of a collection.
+type CollectionFees struct {
+	Slug string          `json:"slug" bson:"slug"`
+	Fees []CollectionFee `json:"fees" bson:"fees"`
+	// Enforced is the is_creator_fees_enforced flag of the collection, when
+	// the API serves it.
+	Enforced *bool `json:"enforced,omitempty" bson:"enforced,omitempty"`
+}
+
+// CreatorFeesEnforced reports whether a creator fee is required, e.g.
+// through an operator filter or enforced royalties. The flag of the API is
+// used when served; otherwise a creator fee must be marked required.
+func (c CollectionFees) CreatorFeesEnforced() bool {
+	if c.Enforced != nil {
+		return *c.Enforced
+	}
+	for _, f := range c.Fees {
+		if f.Required && !f.IsOpenSeaFee() {
+			return true
+		}
+	}
+	return false
+}
+
+// ListingFees returns the fees a listing of the collection pays: the
+// required ones, and the optional creator fees too when payOptional is set.
+func (c CollectionFees) ListingFees(payOptional bool) []Fee {
+	ret := []Fee{}
+	for _, f := range c.Fees {
+		if f.Required || payOptional {
+			ret = append(ret, f.Fee)
+		}
+	}
+	return ret
+}
+
+func (o Opensea) GetCollectionFees(slug string) (*CollectionFees, error) {
+	ctx := context.TODO()
+	return o.GetCollectionFeesWithContext(ctx, slug)
+}
+
+// GetCollectionFeesWithContext reads the fees of a collection and whether
+// they are enforced.
+func (o Opensea) GetCollectionFeesWithContext(ctx context.Context, slug string) (*CollectionFees, error) {
+	c, err := o.getCollectionV2(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	ret := &CollectionFees{Slug: c.Collection, Fees: []CollectionFee{}, Enforced: c.CreatorFeesEnforced}
+	for _, f := range c.Fees {
+		ret.Fees = append(ret.Fees, CollectionFee{
+			// fees are served as percents
+			Fee:      Fee{Recipient: f.Recipient, BasisPoints: int(math.Round(f.Fee * 100))},
+			Required: f.Required,
+		})
+	}
+	return ret, nil
+}
+
+// FeeAmount is the amount of a fee on a sale.
+type FeeAmount struct {
+	Fee
+	Amount Number `json:"amount" bson:"amount"`
+}
+
+// Proceeds splits a sale price between the fees and the seller.
+type Proceeds struct {
+	Price Number      `json:"price" bson:"price"`
+	Fees  []FeeAmount `json:"fees" bson:"fees"`
+	Net   Number      `json:"net" bson:"net"`
+}
+
+// CalculateProceeds computes the fees taken from price, in the smallest
+// unit of the payment token, and what the seller nets. Fees are rounded
+// down, in favor of the seller, and must each amount to at least one unit.
+func CalculateProceeds(price Number, fees []Fee) (*Proceeds, error) {
+	total, ok := new(big.Int).SetString(string(price), 10)
+	if !ok || total.Sign() <= 0 {
+		return nil, fmt.Errorf("Invalid price: %s", price)
+	}
+
+	ret := &Proceeds{Price: price, Fees: []FeeAmount{}}
+	bps := 0
+	net := new(big.Int).Set(total)
+	for _, fee := range fees {
+		recipient, err := ParseAddress(fee.Recipient.String())
+		if err != nil {
+			return nil, err
+		}
+		if fee.BasisPoints <= 0 {
+			return nil, fmt.Errorf("Invalid fee for %s: %d basis points", recipient, fee.BasisPoints)
+		}
+		bps += fee.BasisPoints
+		amount := new(big.Int).Mul(total, big.NewInt(int64(fee.BasisPoints)))
+		amount.Quo(amount, big.NewInt(10000))
+		if amount.Sign() == 0 {
+			return nil, fmt.Errorf("Price %s too low for the fee of %s", price, recipient)
+		}
+		net.Sub(net, amount)
+		ret.Fees = append(ret.Fees, FeeAmount{
+			Fee:    Fee{Recipient: recipient, BasisPoints: fee.BasisPoints},
+			Amount: Number(amount.String()),
+		})
+	}
+	if bps >= 10000 {
+		return nil, fmt.Errorf("Fees of %d basis points leave nothing to the seller", bps)
+	}
+	ret.Net = Number(net.String())
+	return ret, nil
+}
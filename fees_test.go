@@ -0,0 +1,72 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCollectionFees(t *testing.T) {
+	creator := Address("0x00000000000000000000000000000000000000cc")
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/collections/enforced":
+			w.Write([]byte(`{"collection":"enforced","fees":[
+				{"fee":2.5,"recipient":"0x0000a26b00c1f0df003000390027140000faa719","required":true},
+				{"fee":5,"recipient":"0x00000000000000000000000000000000000000cc","required":true}
+			]}`))
+		case "/api/v2/collections/flagged":
+			w.Write([]byte(`{"collection":"flagged","is_creator_fees_enforced":false,"fees":[
+				{"fee":5,"recipient":"0x00000000000000000000000000000000000000cc","required":true}
+			]}`))
+		case "/api/v2/collections/optional":
+			w.Write([]byte(`{"collection":"optional","fees":[
+				{"fee":2.5,"recipient":"0x0000a26b00c1f0df003000390027140000faa719","required":true},
+				{"fee":5,"recipient":"0x00000000000000000000000000000000000000cc","required":false}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	fees, err := o.GetCollectionFeesWithContext(context.Background(), "enforced")
+	assert.Nil(t, err)
+	assert.True(t, fees.CreatorFeesEnforced())
+	assert.Equal(t, []Fee{{OpenSeaFeeRecipient, 250}, {creator, 500}}, fees.ListingFees(false))
+
+	fees, err = o.GetCollectionFeesWithContext(context.Background(), "optional")
+	assert.Nil(t, err)
+	assert.False(t, fees.CreatorFeesEnforced())
+	assert.True(t, fees.Fees[0].IsOpenSeaFee())
+	assert.Equal(t, []Fee{{OpenSeaFeeRecipient, 250}}, fees.ListingFees(false))
+	assert.Len(t, fees.ListingFees(true), 2)
+
+	// the flag of the API wins over the required fees
+	fees, err = o.GetCollectionFeesWithContext(context.Background(), "flagged")
+	assert.Nil(t, err)
+	assert.NotNil(t, fees.Enforced)
+	assert.False(t, fees.CreatorFeesEnforced())
+
+	_, err = o.GetCollectionFeesWithContext(context.Background(), "missing")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestCalculateProceeds(t *testing.T) {
+	p, err := CalculateProceeds("1000000000000000001", []Fee{{OpenSeaFeeRecipient, 250}, {"0x00000000000000000000000000000000000000cc", 500}})
+	assert.Nil(t, err)
+	assert.Equal(t, Number("25000000000000000"), p.Fees[0].Amount)
+	assert.Equal(t, Number("50000000000000000"), p.Fees[1].Amount)
+	// rounding favors the seller
+	assert.Equal(t, Number("925000000000000001"), p.Net)
+
+	p, err = CalculateProceeds("100", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, Number("100"), p.Net)
+
+	_, err = CalculateProceeds("abc", nil)
+	assert.NotNil(t, err)
+	_, err = CalculateProceeds("10", []Fee{{OpenSeaFeeRecipient, 250}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,214 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FloorPoint is the floor price of a collection over one bucket of a floor
+// history, in the native currency of its chain.
+type FloorPoint struct {
+	Time  time.Time `json:"time" bson:"time"`
+	Floor float64   `json:"floor" bson:"floor"`
+	// Sales is the number of sales the point was derived from; it is zero
+	// for points taken from snapshots.
+	Sales    int  `json:"sales" bson:"sales"`
+	Snapshot bool `json:"snapshot" bson:"snapshot"`
+}
+
+// FloorSnapshot is a floor price observed at a point in time.
+type FloorSnapshot struct {
+	Slug  string    `json:"slug" bson:"slug"`
+	Time  time.Time `json:"time" bson:"time"`
+	Floor float64   `json:"floor" bson:"floor"`
+}
+
+// FloorSnapshotStore persists floor snapshots, so that the floor history
+// of collections can be served from observations rather than estimated.
+type FloorSnapshotStore interface {
+	SaveFloorSnapshot(ctx context.Context, snapshot FloorSnapshot) error
+	// FloorSnapshots returns the snapshots of slug taken in [from, to).
+	FloorSnapshots(ctx context.Context, slug string, from, to time.Time) ([]FloorSnapshot, error)
+}
+
+// WithFloorStore makes SnapshotFloor save its snapshots to store and
+// GetFloorHistory read them back.
+func WithFloorStore(store FloorSnapshotStore) Option {
+	return func(o *Opensea) {
+		o.floors = store
+	}
+}
+
+// MemoryFloorStore is a FloorSnapshotStore kept in memory.
+type MemoryFloorStore struct {
+	mu        sync.Mutex
+	snapshots map[string][]FloorSnapshot
+}
+
+func NewMemoryFloorStore() *MemoryFloorStore {
+	return &MemoryFloorStore{snapshots: map[string][]FloorSnapshot{}}
+}
+
+func (s *MemoryFloorStore) SaveFloorSnapshot(ctx context.Context, snapshot FloorSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[snapshot.Slug] = append(s.snapshots[snapshot.Slug], snapshot)
+	return nil
+}
+
+func (s *MemoryFloorStore) FloorSnapshots(ctx context.Context, slug string, from, to time.Time) ([]FloorSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := []FloorSnapshot{}
+	for _, snap := range s.snapshots[slug] {
+		if !snap.Time.Before(from) && snap.Time.Before(to) {
+			ret = append(ret, snap)
+		}
+	}
+	return ret, nil
+}
+
+// SnapshotFloor reads the current floor of a collection from its stats and
+// saves it to the store of the client, if any. A snapshot younger than the
+// cache TTL of the collection profile is returned instead of fetching again.
+func (o Opensea) SnapshotFloor(ctx context.Context, slug string) (*FloorSnapshot, error) {
+	ctx = o.contextWithProfile(ctx, slug)
+	if ttl := o.profile(slug).CacheTTL; ttl > 0 && o.floors != nil {
+		now := time.Now()
+		snaps, err := o.floors.FloorSnapshots(ctx, slug, now.Add(-ttl), now.Add(time.Nanosecond))
+		if err != nil {
+			return nil, err
+		}
+		if len(snaps) > 0 {
+			latest := snaps[0]
+			for _, snap := range snaps[1:] {
+				if snap.Time.After(latest.Time) {
+					latest = snap
+				}
+			}
+			return &latest, nil
+		}
+	}
+
+	stats, err := o.collectionStats(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	snap := &FloorSnapshot{Slug: slug, Time: time.Now(), Floor: stats.FloorPrice}
+	if o.floors != nil {
+		if err := o.floors.SaveFloorSnapshot(ctx, *snap); err != nil {
+			return nil, err
+		}
+	}
+	return snap, nil
+}
+
+func (o Opensea) GetFloorHistory(slug string, window, granularity time.Duration) ([]FloorPoint, error) {
+	ctx := context.TODO()
+	return o.GetFloorHistoryWithContext(ctx, slug, window, granularity)
+}
+
+// GetFloorHistoryWithContext returns the floor of a collection over the last
+// window, in buckets of granularity, oldest first. OpenSea does not serve
+// floor history, so a bucket holds the last snapshot taken in it when the
+// client has a floor store, and otherwise the cheapest sale in it, an upper
+// bound of the floor at the time. Bundle sales are ignored, and buckets
+// without data are left out.
+func (o Opensea) GetFloorHistoryWithContext(ctx context.Context, slug string, window, granularity time.Duration) ([]FloorPoint, error) {
+	if window <= 0 || granularity <= 0 || granularity > window {
+		return nil, fmt.Errorf("Invalid floor history window %s with granularity %s", window, granularity)
+	}
+	to := time.Now()
+	from := to.Add(-window)
+	bucket := func(t time.Time) time.Time {
+		return from.Add(t.Sub(from) / granularity * granularity)
+	}
+
+	points := map[time.Time]*FloorPoint{}
+	params := NewRetrievingEventsParams()
+	params.CollectionSlug = slug
+	params.EventType = EventTypeSuccessful
+	params.OccurredAfter = from.Unix()
+	params.OccurredBefore = to.Unix()
+	err := o.RetrievingEventsPagesWithContext(ctx, params, func(events []*Event) error {
+		for _, e := range events {
+			price, ok := salePrice(e)
+			if !ok {
+				continue
+			}
+			t := bucket(e.EventTimestamp.Time())
+			p, ok := points[t]
+			if !ok {
+				p = &FloorPoint{Time: t, Floor: price}
+				points[t] = p
+			}
+			if price < p.Floor {
+				p.Floor = price
+			}
+			p.Sales++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if o.floors != nil {
+		snaps, err := o.floors.FloorSnapshots(ctx, slug, from, to)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(snaps, func(i, j int) bool { return snaps[i].Time.Before(snaps[j].Time) })
+		for _, snap := range snaps {
+			t := bucket(snap.Time)
+			points[t] = &FloorPoint{Time: t, Floor: snap.Floor, Snapshot: true}
+		}
+	}
+
+	ret := make([]FloorPoint, 0, len(points))
+	for _, p := range points {
+		ret = append(ret, *p)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Time.Before(ret[j].Time) })
+	return ret, nil
+}
+
+// salePrice returns the unit price of a sale in the native currency, using
+// the ETH price of the payment token.
+func salePrice(e *Event) (float64, bool) {
+	if e.IsBundle() || e.PaymentToken == nil || e.TotalPrice == "" {
+		return 0, false
+	}
+	total, ok := new(big.Float).SetString(string(e.TotalPrice))
+	if !ok {
+		return 0, false
+	}
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(e.PaymentToken.Decimals), nil))
+	price, _ := new(big.Float).Quo(total, scale).Float64()
+
+	rate := 1.0
+	switch v := e.PaymentToken.EthPrice.(type) {
+	case float64:
+		rate = v
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		rate = f
+	}
+	quantity := 1.0
+	if e.Quantity != "" {
+		q, err := strconv.ParseFloat(e.Quantity, 64)
+		if err != nil || q <= 0 {
+			return 0, false
+		}
+		quantity = q
+	}
+	return price * rate / quantity, true
+}
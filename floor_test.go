@@ -0,0 +1,69 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFloorHistory(t *testing.T) {
+	sale := func(ago time.Duration, price string, token string) string {
+		ts := time.Now().Add(-ago).UTC().Format("2006-01-02T15:04:05.999999")
+		return fmt.Sprintf(`{"id":%d,"event_type":"successful","total_price":%q,"quantity":"1","event_timestamp":%q,"payment_token":%s}`,
+			ago/time.Minute, price, ts, token)
+	}
+	eth := `{"symbol":"ETH","decimals":18,"eth_price":"1.000000000000000"}`
+	usdc := `{"symbol":"USDC","decimals":6,"eth_price":"0.0005"}`
+
+	var query string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/events/":
+			query = r.URL.RawQuery
+			events := []string{
+				sale(150*time.Minute, "2000000000000000000", eth),
+				sale(90*time.Minute, "1200000000000000000", eth),
+				sale(70*time.Minute, "2000000000", usdc),
+				`{"id":1,"event_type":"successful","total_price":"1","asset_bundle":{"slug":"b"},"payment_token":` + eth + `}`,
+			}
+			w.Write([]byte(`{"asset_events":[` + strings.Join(events, ",") + `]}`))
+		case "/api/v1/collection/doodles/stats":
+			w.Write([]byte(`{"stats":{"floor_price":1.5}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	points, err := o.GetFloorHistoryWithContext(context.Background(), "doodles", 3*time.Hour, time.Hour)
+	assert.Nil(t, err)
+	assert.Contains(t, query, "collection_slug=doodles")
+	assert.Contains(t, query, "event_type=successful")
+	assert.Len(t, points, 2)
+	assert.Equal(t, 2.0, points[0].Floor)
+	assert.Equal(t, 1, points[0].Sales)
+	// the USDC sale is worth 1 ETH
+	assert.InDelta(t, 1.0, points[1].Floor, 1e-9)
+	assert.Equal(t, 2, points[1].Sales)
+	assert.Equal(t, time.Hour, points[1].Time.Sub(points[0].Time))
+
+	store := NewMemoryFloorStore()
+	o2 := *o
+	WithFloorStore(store)(&o2)
+	snap, err := o2.SnapshotFloor(context.Background(), "doodles")
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, snap.Floor)
+
+	points, err = o2.GetFloorHistoryWithContext(context.Background(), "doodles", 3*time.Hour, time.Hour)
+	assert.Nil(t, err)
+	assert.Len(t, points, 3)
+	assert.True(t, points[2].Snapshot)
+	assert.Equal(t, 1.5, points[2].Floor)
+
+	_, err = o.GetFloorHistoryWithContext(context.Background(), "doodles", time.Hour, 2*time.Hour)
+	assert.NotNil(t, err)
+}
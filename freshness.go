@@ -0,0 +1,72 @@
+package opensea
+
+import "time"
+
+// WithDropExpiredOrders makes the order book reads leave out the orders
+// already expired when fetched. Best listing and offer lookups return
+// ErrNotFound instead.
+func WithDropExpiredOrders() Option {
+	return func(o *Opensea) {
+		o.dropExpired = true
+	}
+}
+
+// ExpiresAt returns the end time of the order, or the zero time when
+// unknown.
+func (o SeaportOrder) ExpiresAt() time.Time {
+	if o.ExpirationTime > 0 {
+		return time.Unix(o.ExpirationTime, 0)
+	}
+	if n := o.ProtocolData.Parameters.EndTime.Big(); n != nil && n.IsInt64() && n.Sign() > 0 {
+		return time.Unix(n.Int64(), 0)
+	}
+	return time.Time{}
+}
+
+// TimeToExpiry returns the time left before the order expires, negative
+// once expired. It is 0 when the end time is unknown.
+func (o SeaportOrder) TimeToExpiry(now time.Time) time.Duration {
+	end := o.ExpiresAt()
+	if end.IsZero() {
+		return 0
+	}
+	return end.Sub(now)
+}
+
+// Expired reports whether the order has expired at now.
+func (o SeaportOrder) Expired(now time.Time) bool {
+	end := o.ExpiresAt()
+	return !end.IsZero() && !now.Before(end)
+}
+
+// Active reports whether the order can still be filled at now: neither
+// cancelled, filled, invalid nor expired.
+func (o SeaportOrder) Active(now time.Time) bool {
+	return !o.Cancelled && !o.Finalized && !o.MarkedInvalid && !o.Expired(now)
+}
+
+// annotateOrders sets the FetchedAt time of orders, leaving out the
+// expired ones under WithDropExpiredOrders.
+func (o Opensea) annotateOrders(orders []SeaportOrder) []SeaportOrder {
+	// in UTC, without the monotonic reading, to survive persistence as is
+	now := time.Now().UTC()
+	ret := orders[:0]
+	for _, order := range orders {
+		if o.dropExpired && order.Expired(now) {
+			continue
+		}
+		order.FetchedAt = now
+		ret = append(ret, order)
+	}
+	return ret
+}
+
+// annotateOrder is annotateOrders for a single order, which is ErrNotFound
+// once dropped.
+func (o Opensea) annotateOrder(order *SeaportOrder) (*SeaportOrder, error) {
+	orders := o.annotateOrders([]SeaportOrder{*order})
+	if len(orders) == 0 {
+		return nil, ErrNotFound
+	}
+	return &orders[0], nil
+}
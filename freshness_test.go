@@ -0,0 +1,60 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	order := SeaportOrder{}
+	order.ProtocolData.Parameters.EndTime = "1700003600"
+	assert.Equal(t, time.Hour, order.TimeToExpiry(now))
+	assert.False(t, order.Expired(now))
+	assert.True(t, order.Expired(now.Add(time.Hour)))
+
+	order.ExpirationTime = 1699999000
+	assert.True(t, order.Expired(now))
+	assert.True(t, order.TimeToExpiry(now) < 0)
+
+	assert.Equal(t, time.Duration(0), SeaportOrder{}.TimeToExpiry(now))
+	assert.False(t, SeaportOrder{}.Expired(now))
+}
+
+func TestDropExpiredOrders(t *testing.T) {
+	live := time.Now().Add(time.Hour).Unix()
+	expired := time.Now().Add(-time.Hour).Unix()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/listings/collection/doodles-official/all":
+			fmt.Fprintf(w, `{"listings":[
+				{"order_hash":"0x1","protocol_data":{"parameters":{"endTime":"%d"}}},
+				{"order_hash":"0x2","protocol_data":{"parameters":{"endTime":"%d"}}}]}`, live, expired)
+		case "/api/v2/listings/collection/doodles-official/nfts/7/best":
+			fmt.Fprintf(w, `{"order_hash":"0x2","protocol_data":{"parameters":{"endTime":"%d"}}}`, expired)
+		}
+	}
+	ctx := context.Background()
+	before := time.Now()
+
+	o := newTestClient(t, handler)
+	resp, err := o.GetCollectionListingsWithContext(ctx, "doodles-official", CollectionOrdersParams{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Listings, 2)
+	assert.False(t, resp.Listings[0].FetchedAt.Before(before.Truncate(time.Second)))
+	_, err = o.GetBestListingWithContext(ctx, "doodles-official", "7")
+	assert.Nil(t, err)
+
+	o = newTestClient(t, handler, WithDropExpiredOrders())
+	resp, err = o.GetCollectionListingsWithContext(ctx, "doodles-official", CollectionOrdersParams{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Listings, 1)
+	assert.Equal(t, "0x1", resp.Listings[0].OrderHash)
+	_, err = o.GetBestListingWithContext(ctx, "doodles-official", "7")
+	assert.Equal(t, ErrNotFound, err)
+}
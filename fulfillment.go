@@ -0,0 +1,121 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// FulfillmentParams select the order to fulfill and its fulfiller.
+type FulfillmentParams struct {
+	Chain     Chain
+	OrderHash string
+	// ProtocolAddress defaults to the deployment of the client.
+	ProtocolAddress Address
+	Fulfiller       Address
+	// Token and TokenID pick the item sold to a criteria offer, such as a
+	// collection or trait offer.
+	Token   Address
+	TokenID string
+}
+
+// FulfillmentTransaction is the transaction fulfilling an order: the call
+// of Function on To with InputData as arguments, sending Value wei.
+type FulfillmentTransaction struct {
+	Function  string          `json:"function" bson:"function"`
+	ChainID   int64           `json:"chain" bson:"chain"`
+	To        Address         `json:"to" bson:"to"`
+	Value     json.Number     `json:"value" bson:"value"`
+	InputData json.RawMessage `json:"input_data" bson:"input_data"`
+}
+
+// FulfillmentData is what fulfilling an order on chain takes: the
+// transaction to send, and the signed orders it fulfills.
+type FulfillmentData struct {
+	Protocol    string                 `json:"protocol" bson:"protocol"`
+	Transaction FulfillmentTransaction `json:"transaction" bson:"transaction"`
+	Orders      []ProtocolData         `json:"orders" bson:"orders"`
+}
+
+// GenerateListingFulfillmentData returns the transaction buying a listing.
+func (o Opensea) GenerateListingFulfillmentData(p FulfillmentParams) (*FulfillmentData, error) {
+	ctx := context.TODO()
+	return o.GenerateListingFulfillmentDataWithContext(ctx, p)
+}
+
+func (o Opensea) GenerateListingFulfillmentDataWithContext(ctx context.Context, p FulfillmentParams) (*FulfillmentData, error) {
+	return o.fulfillmentData(ctx, "listing", p)
+}
+
+// GenerateOfferFulfillmentData returns the transaction accepting an offer.
+// Criteria offers need the Token and TokenID of the item sold.
+func (o Opensea) GenerateOfferFulfillmentData(p FulfillmentParams) (*FulfillmentData, error) {
+	ctx := context.TODO()
+	return o.GenerateOfferFulfillmentDataWithContext(ctx, p)
+}
+
+func (o Opensea) GenerateOfferFulfillmentDataWithContext(ctx context.Context, p FulfillmentParams) (*FulfillmentData, error) {
+	return o.fulfillmentData(ctx, "offer", p)
+}
+
+// fulfillmentData asks the fulfillment data endpoint of the listing or
+// offer kind. It writes nothing, so it is sent in dry runs too.
+func (o Opensea) fulfillmentData(ctx context.Context, kind string, p FulfillmentParams) (*FulfillmentData, error) {
+	chain, err := o.chainFor(p.Chain, CapabilityFulfillment)
+	if err != nil {
+		return nil, err
+	}
+	if p.OrderHash == "" {
+		return nil, fmt.Errorf("Empty order hash")
+	}
+	fulfiller, err := o.ResolveAddressWithContext(ctx, p.Fulfiller.String())
+	if err != nil {
+		return nil, err
+	}
+	protocol := p.ProtocolAddress
+	if protocol == "" {
+		protocol = o.protocolAddress(DefaultProtocol)
+	}
+
+	type orderRef struct {
+		Hash            string  `json:"hash"`
+		Chain           Chain   `json:"chain"`
+		ProtocolAddress Address `json:"protocol_address"`
+	}
+	type consideration struct {
+		AssetContractAddress Address `json:"asset_contract_address"`
+		TokenID              string  `json:"token_id"`
+	}
+	req := map[string]interface{}{
+		kind:        orderRef{p.OrderHash, chain, protocol},
+		"fulfiller": map[string]Address{"address": fulfiller},
+	}
+	if p.Token != "" {
+		if kind != "offer" {
+			return nil, fmt.Errorf("Only offers take the item to sell")
+		}
+		token, err := ParseAddress(p.Token.String())
+		if err != nil {
+			return nil, err
+		}
+		req["consideration"] = consideration{token, p.TokenID}
+	}
+
+	b, err := o.postQuery(contextWithChain(ctx, chain), "/api/v2/"+kind+"s/fulfillment_data", req)
+	if err != nil {
+		return nil, err
+	}
+	resp := &struct {
+		Protocol        string          `json:"protocol"`
+		FulfillmentData FulfillmentData `json:"fulfillment_data"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	data := resp.FulfillmentData
+	data.Protocol = resp.Protocol
+	if data.Orders == nil {
+		data.Orders = []ProtocolData{}
+	}
+	return &data, nil
+}
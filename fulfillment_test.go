@@ -0,0 +1,55 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateFulfillmentData(t *testing.T) {
+	const fulfiller = "0x000000000000000000000000000000000000000a"
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		req := map[string]map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal(body, &req))
+		assert.Equal(t, fulfiller, req["fulfiller"]["address"])
+		switch r.URL.Path {
+		case "/api/v2/listings/fulfillment_data":
+			assert.Equal(t, "0xabc", req["listing"]["hash"])
+			assert.Equal(t, "ethereum", req["listing"]["chain"])
+			assert.Equal(t, DefaultProtocol.Address().String(), req["listing"]["protocol_address"])
+			assert.Nil(t, req["consideration"])
+		case "/api/v2/offers/fulfillment_data":
+			assert.Equal(t, "0xdef", req["offer"]["hash"])
+			assert.Equal(t, contract, req["consideration"]["asset_contract_address"])
+			assert.Equal(t, "7", req["consideration"]["token_id"])
+		}
+		w.Write([]byte(`{"protocol":"seaport1.6","fulfillment_data":{
+			"transaction":{"function":"fulfillBasicOrder_efficient_6GL6yc((address,uint256,uint256,address,address,address,uint256,uint256,uint8,uint256,uint256,bytes32,uint256,bytes32,bytes32,uint256,(uint256,address)[],bytes))",
+				"chain":1,"to":"0x0000000000000068f116a894984e2db1123eb395","value":1000000000000000000000,"input_data":{"parameters":{"considerationToken":"0x0000000000000000000000000000000000000000"}}},
+			"orders":[{"parameters":{"offerer":"0x00000000000000000000000000000000000000bb"},"signature":"0xsig"}]}}`))
+	})
+	ctx := context.Background()
+	data, err := o.GenerateListingFulfillmentDataWithContext(ctx, FulfillmentParams{OrderHash: "0xabc", Fulfiller: fulfiller})
+	assert.Nil(t, err)
+	assert.Equal(t, "seaport1.6", data.Protocol)
+	assert.Equal(t, int64(1), data.Transaction.ChainID)
+	assert.Equal(t, json.Number("1000000000000000000000"), data.Transaction.Value)
+	assert.Contains(t, string(data.Transaction.InputData), "considerationToken")
+	assert.Equal(t, "0xsig", data.Orders[0].Signature)
+
+	_, err = o.GenerateOfferFulfillmentDataWithContext(ctx, FulfillmentParams{OrderHash: "0xdef", Fulfiller: fulfiller, Token: Address(contract), TokenID: "7"})
+	assert.Nil(t, err)
+
+	_, err = o.GenerateListingFulfillmentDataWithContext(ctx, FulfillmentParams{OrderHash: "0xabc", Fulfiller: fulfiller, Token: Address(contract)})
+	assert.NotNil(t, err)
+	_, err = o.GenerateListingFulfillmentDataWithContext(ctx, FulfillmentParams{Fulfiller: fulfiller})
+	assert.NotNil(t, err)
+	_, err = o.GenerateListingFulfillmentDataWithContext(ctx, FulfillmentParams{OrderHash: "0xabc"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,13 @@
+package opensea
+
+// Endpoints without a hand-written method are generated from OpenSea's
+// OpenAPI specification, committed as openapi/opensea.json. openapifetch
+// checks it against the pinned openapi/opensea.json.sha256, and downloads
+// it from $OPENSEA_OPENAPI_URL when missing, see "Development" in the
+// README.
+//
+// Only the operations of -ops are generated, and the models of -skip are
+// the hand-written ones; add to both when moving an endpoint in or out of
+// the generated file.
+//go:generate go run ./internal/cmd/openapifetch -out openapi/opensea.json
+//go:generate go run ./internal/cmd/openapigen -spec openapi/opensea.json -out zz_generated_endpoints.go -ops get_collection_offers_v2,list_events,list_events_by_account,list_events_by_collection,list_events_by_nft -skip Account,Asset,AssetContract,AssetEvent,AssetEventsResponse,Collection,CollectionStats,Contract,Event,EventsResponse,Fee,NFT,NFTOwner,NFTRarity,OfferCriteria,Order,OrderParameters,PaymentToken,ProtocolData,SeaportOrder,SeaportOrdersResponse,Stat,Trait,Transaction
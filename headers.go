@@ -0,0 +1,71 @@
+package opensea
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// WithHeader adds a header to every request, e.g. the token of an API
+// gateway in front of OpenSea. Default headers are set after the headers of
+// the client and replace them.
+func WithHeader(key, value string) Option {
+	return func(o *Opensea) {
+		if o.headers == nil {
+			o.headers = http.Header{}
+		}
+		o.headers.Add(key, value)
+	}
+}
+
+// WithHeaders adds headers to every request, like WithHeader.
+func WithHeaders(headers http.Header) Option {
+	return func(o *Opensea) {
+		for key, values := range headers {
+			for _, value := range values {
+				WithHeader(key, value)(o)
+			}
+		}
+	}
+}
+
+// WithQueryParam appends a query parameter to every request. Parameters set
+// by the call take precedence.
+func WithQueryParam(key, value string) Option {
+	return func(o *Opensea) {
+		if o.query == nil {
+			o.query = url.Values{}
+		}
+		o.query.Add(key, value)
+	}
+}
+
+// WithQueryParams appends query parameters to every request, like
+// WithQueryParam.
+func WithQueryParams(values url.Values) Option {
+	return func(o *Opensea) {
+		for key, vs := range values {
+			for _, value := range vs {
+				WithQueryParam(key, value)(o)
+			}
+		}
+	}
+}
+
+// decorate adds the default headers and query parameters to req.
+func (o Opensea) decorate(req *http.Request) {
+	for key, values := range o.headers {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if len(o.query) > 0 {
+		q := req.URL.Query()
+		for key, values := range o.query {
+			if _, ok := q[key]; !ok {
+				q[key] = values
+			}
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+}
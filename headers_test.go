@@ -0,0 +1,43 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultHeadersAndQuery(t *testing.T) {
+	var req *http.Request
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		w.Write([]byte(`{}`))
+	},
+		WithHeader("X-Gateway-Token", "secret"),
+		WithHeaders(http.Header{"Accept": {"application/vnd.gateway+json"}}),
+		WithQueryParam("tenant", "acme"),
+		WithQueryParams(url.Values{"limit": {"1"}}),
+	)
+
+	_, err := o.GetPath(context.Background(), "/api/v1/collections?limit=300")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", req.Header.Get("X-Gateway-Token"))
+	assert.Equal(t, []string{"application/vnd.gateway+json"}, req.Header.Values("Accept"))
+	assert.Equal(t, "test-key", req.Header.Get("X-API-KEY"))
+	assert.Equal(t, "acme", req.URL.Query().Get("tenant"))
+	// parameters of the call take precedence
+	assert.Equal(t, []string{"300"}, req.URL.Query()["limit"])
+}
+
+func TestNewClientHeaders(t *testing.T) {
+	client, err := NewClient(Config{
+		APIKey:  "secret",
+		Headers: http.Header{"X-Gateway-Token": {"token"}},
+		Query:   url.Values{"tenant": {"acme"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "token", client.headers.Get("X-Gateway-Token"))
+	assert.Equal(t, "acme", client.query.Get("tenant"))
+}
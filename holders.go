@@ -0,0 +1,165 @@
+package opensea
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holding is a quantity of a token held by an account.
+type Holding struct {
+	Owner    Address `json:"owner" bson:"owner"`
+	TokenID  string  `json:"token_id" bson:"token_id"`
+	Quantity int64   `json:"quantity" bson:"quantity"`
+}
+
+// HolderSnapshot lists the holdings of a collection at a point in time.
+type HolderSnapshot struct {
+	Collection string    `json:"collection" bson:"collection"`
+	Time       time.Time `json:"time" bson:"time"`
+	Holdings   []Holding `json:"holdings" bson:"holdings"`
+}
+
+// TokenAmount is a quantity of a token moved in or out of an account.
+type TokenAmount struct {
+	TokenID  string `json:"token_id" bson:"token_id"`
+	Quantity int64  `json:"quantity" bson:"quantity"`
+}
+
+// HolderChange lists the tokens an account received and sent between two
+// snapshots.
+type HolderChange struct {
+	Owner Address       `json:"owner" bson:"owner"`
+	In    []TokenAmount `json:"in" bson:"in"`
+	Out   []TokenAmount `json:"out" bson:"out"`
+}
+
+// SnapshotDiff is the difference between two holder snapshots. Every list
+// is sorted by address and token ID.
+type SnapshotDiff struct {
+	Changes []HolderChange `json:"changes" bson:"changes"`
+	// NewHolders held nothing in the first snapshot.
+	NewHolders []Address `json:"new_holders" bson:"new_holders"`
+	// ExitedHolders hold nothing in the second snapshot.
+	ExitedHolders []Address `json:"exited_holders" bson:"exited_holders"`
+}
+
+// DiffSnapshots reports the tokens moved in and out of each account, and
+// the holders entering and leaving the collection, from a to b.
+func DiffSnapshots(a, b HolderSnapshot) SnapshotDiff {
+	before, after := a.balances(), b.balances()
+	diff := SnapshotDiff{Changes: []HolderChange{}, NewHolders: []Address{}, ExitedHolders: []Address{}}
+
+	owners := map[Address]bool{}
+	for owner := range before {
+		owners[owner] = true
+	}
+	for owner := range after {
+		owners[owner] = true
+	}
+	sorted := make([]Address, 0, len(owners))
+	for owner := range owners {
+		sorted = append(sorted, owner)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	for _, owner := range sorted {
+		was, is := before[owner], after[owner]
+		if len(was) == 0 && len(is) > 0 {
+			diff.NewHolders = append(diff.NewHolders, owner)
+		}
+		if len(was) > 0 && len(is) == 0 {
+			diff.ExitedHolders = append(diff.ExitedHolders, owner)
+		}
+
+		tokens := map[string]bool{}
+		for id := range was {
+			tokens[id] = true
+		}
+		for id := range is {
+			tokens[id] = true
+		}
+		ids := make([]string, 0, len(tokens))
+		for id := range tokens {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return lessTokenID(ids[i], ids[j]) })
+
+		change := HolderChange{Owner: owner}
+		for _, id := range ids {
+			switch d := is[id] - was[id]; {
+			case d > 0:
+				change.In = append(change.In, TokenAmount{TokenID: id, Quantity: d})
+			case d < 0:
+				change.Out = append(change.Out, TokenAmount{TokenID: id, Quantity: -d})
+			}
+		}
+		if len(change.In) > 0 || len(change.Out) > 0 {
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+	return diff
+}
+
+// balances sums the holdings of the snapshot per owner and token.
+func (s HolderSnapshot) balances() map[Address]map[string]int64 {
+	ret := map[Address]map[string]int64{}
+	for _, h := range s.Holdings {
+		if h.Quantity <= 0 {
+			continue
+		}
+		owner := Address(strings.ToLower(h.Owner.String()))
+		if ret[owner] == nil {
+			ret[owner] = map[string]int64{}
+		}
+		ret[owner][h.TokenID] += h.Quantity
+	}
+	return ret
+}
+
+// lessTokenID orders numeric token IDs by value.
+func lessTokenID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func (o Opensea) TakeHolderSnapshot(assetContractAddress Address) (*HolderSnapshot, error) {
+	ctx := context.TODO()
+	return o.TakeHolderSnapshotWithContext(ctx, assetContractAddress)
+}
+
+// TakeHolderSnapshotWithContext lists the owner of every asset of a
+// contract. Each asset counts as one token held by its owner.
+func (o Opensea) TakeHolderSnapshotWithContext(ctx context.Context, assetContractAddress Address) (*HolderSnapshot, error) {
+	snap := &HolderSnapshot{
+		Collection: assetContractAddress.String(),
+		Time:       time.Now(),
+		Holdings:   []Holding{},
+	}
+	params := GetAssetsParams{
+		AssetContractAddress: assetContractAddress,
+		Limit:                50,
+		Fields:               []string{"token_id", "owner"},
+	}
+	for {
+		resp, err := o.GetAssetsWithContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Assets {
+			if a.Owner == nil {
+				continue
+			}
+			snap.Holdings = append(snap.Holdings, Holding{Owner: a.Owner.Address, TokenID: a.TokenID, Quantity: 1})
+		}
+		reportProgress(ctx, len(resp.Assets))
+		if resp.Next == "" {
+			break
+		}
+		params.Cursor = resp.Next
+	}
+	return snap, nil
+}
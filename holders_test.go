@@ -0,0 +1,58 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	alice := Address("0x000000000000000000000000000000000000000a")
+	bob := Address("0x000000000000000000000000000000000000000b")
+	carol := Address("0x000000000000000000000000000000000000000c")
+
+	a := HolderSnapshot{Holdings: []Holding{
+		{Owner: alice, TokenID: "10", Quantity: 1},
+		{Owner: alice, TokenID: "9", Quantity: 1},
+		{Owner: bob, TokenID: "3", Quantity: 5},
+	}}
+	b := HolderSnapshot{Holdings: []Holding{
+		{Owner: alice, TokenID: "9", Quantity: 1},
+		{Owner: "0x000000000000000000000000000000000000000B", TokenID: "3", Quantity: 2},
+		{Owner: carol, TokenID: "10", Quantity: 1},
+		{Owner: carol, TokenID: "3", Quantity: 3},
+	}}
+
+	diff := DiffSnapshots(a, b)
+	assert.Equal(t, []HolderChange{
+		{Owner: alice, Out: []TokenAmount{{TokenID: "10", Quantity: 1}}},
+		{Owner: bob, Out: []TokenAmount{{TokenID: "3", Quantity: 3}}},
+		{Owner: carol, In: []TokenAmount{{TokenID: "3", Quantity: 3}, {TokenID: "10", Quantity: 1}}},
+	}, diff.Changes)
+	assert.Equal(t, []Address{carol}, diff.NewHolders)
+	assert.Empty(t, diff.ExitedHolders)
+
+	diff = DiffSnapshots(b, HolderSnapshot{})
+	assert.Equal(t, []Address{alice, bob, carol}, diff.ExitedHolders)
+	assert.Len(t, diff.Changes, 3)
+
+	assert.Empty(t, DiffSnapshots(a, a).Changes)
+}
+
+func TestTakeHolderSnapshot(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"assets":[{"token_id":"1","owner":{"address":"0x000000000000000000000000000000000000000a"}}],"next":"page2"}`))
+			return
+		}
+		w.Write([]byte(`{"assets":[{"token_id":"2","owner":{"address":"0x000000000000000000000000000000000000000b"}},{"token_id":"3"}]}`))
+	})
+	snap, err := o.TakeHolderSnapshotWithContext(context.Background(), Address(contract))
+	assert.Nil(t, err)
+	assert.Equal(t, []Holding{
+		{Owner: "0x000000000000000000000000000000000000000a", TokenID: "1", Quantity: 1},
+		{Owner: "0x000000000000000000000000000000000000000b", TokenID: "2", Quantity: 1},
+	}, snap.Holdings)
+}
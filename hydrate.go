@@ -0,0 +1,182 @@
+package opensea
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// DefaultHydrationConcurrency is the number of lookups a Hydrator runs at
+// once by default.
+const DefaultHydrationConcurrency = 4
+
+// Hydrator attaches the full collection and contract to assets, which the
+// list endpoints only reference by slug and address. Every collection and
+// contract is looked up once and cached, so reuse a Hydrator across pages.
+type Hydrator struct {
+	o Opensea
+	// Concurrency is the number of lookups run at once.
+	Concurrency int
+
+	mu          sync.Mutex
+	collections map[string]*Collection
+	contracts   map[Address]*AssetContract
+}
+
+func (o Opensea) NewHydrator() *Hydrator {
+	return &Hydrator{
+		o:           o,
+		Concurrency: DefaultHydrationConcurrency,
+		collections: map[string]*Collection{},
+		contracts:   map[Address]*AssetContract{},
+	}
+}
+
+// HydrateAssets replaces the collection and contract references of assets
+// with the full objects. References OpenSea does not know are left as they
+// are.
+func (h *Hydrator) HydrateAssets(ctx context.Context, assets []Asset) error {
+	slugs := []string{}
+	addresses := []Address{}
+	seen := map[string]bool{}
+	h.mu.Lock()
+	for _, a := range assets {
+		if a.Collection != nil && a.Collection.Slug != "" && !seen["c/"+a.Collection.Slug] {
+			seen["c/"+a.Collection.Slug] = true
+			if _, ok := h.collections[a.Collection.Slug]; !ok {
+				slugs = append(slugs, a.Collection.Slug)
+			}
+		}
+		if a.AssetContract != nil && a.AssetContract.Address != "" {
+			address := Address(strings.ToLower(a.AssetContract.Address.String()))
+			if !seen["a/"+address.String()] {
+				seen["a/"+address.String()] = true
+				if _, ok := h.contracts[address]; !ok {
+					addresses = append(addresses, address)
+				}
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	jobs := []func(ctx context.Context) error{}
+	for _, slug := range slugs {
+		slug := slug
+		jobs = append(jobs, func(ctx context.Context) error {
+			c, err := h.o.GetCollectionWithContext(ctx, slug)
+			if err == ErrNotFound {
+				c, err = nil, nil
+			}
+			if err != nil {
+				return err
+			}
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if c == nil {
+				h.collections[slug] = nil
+			} else {
+				h.collections[slug] = &c.Collection
+			}
+			return nil
+		})
+	}
+	for _, address := range addresses {
+		address := address
+		jobs = append(jobs, func(ctx context.Context) error {
+			c, err := h.o.GetAssetContractWithContext(ctx, address)
+			if err == ErrNotFound {
+				c, err = nil, nil
+			}
+			if err != nil {
+				return err
+			}
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if c == nil {
+				h.contracts[address] = nil
+			} else {
+				h.contracts[address] = c.assetContract()
+			}
+			return nil
+		})
+	}
+	if err := runConcurrently(ctx, h.Concurrency, jobs); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := range assets {
+		a := &assets[i]
+		if a.Collection != nil {
+			if c := h.collections[a.Collection.Slug]; c != nil {
+				a.Collection = c
+			}
+		}
+		if a.AssetContract != nil {
+			if c := h.contracts[Address(strings.ToLower(a.AssetContract.Address.String()))]; c != nil {
+				a.AssetContract = c
+			}
+		}
+	}
+	return nil
+}
+
+func (c Contract) assetContract() *AssetContract {
+	return &AssetContract{
+		Address:                     c.Address,
+		AssetContractType:           c.AssetContractType,
+		CreatedDate:                 c.CreatedDate,
+		Name:                        c.Name,
+		NftVersion:                  c.NFTVersion,
+		OpenseaVersion:              c.OpenseaVersion,
+		Owner:                       c.Owner,
+		SchemaName:                  c.SchemaName,
+		Symbol:                      c.Symbol,
+		TotalSupply:                 c.TotalSupply,
+		Description:                 c.Description,
+		ExternalLink:                c.ExternalLink,
+		ImageURL:                    c.ImageURL,
+		DefaultToFiat:               c.DefaultToFiat,
+		DevBuyerFeeBasisPoints:      c.DevBuyerFeeBasisPoints,
+		DevSellerFeeBasisPoints:     c.DevSellerFeeBasisPoints,
+		OnlyProxiedTransfers:        c.OnlyProxiedTransfers,
+		OpenseaBuyerFeeBasisPoints:  c.OpenseaBuyerFeeBasisPoints,
+		OpenseaSellerFeeBasisPoints: c.OpenseaSellerFeeBasisPoints,
+		BuyerFeeBasisPoints:         c.BuyerFeeBasisPoints,
+		SellerFeeBasisPoints:        c.SellerFeeBasisPoints,
+		PayoutAddress:               c.PayoutAddress,
+	}
+}
+
+// runConcurrently runs jobs, n at once, and returns the first error, which
+// cancels the jobs still running.
+func runConcurrently(ctx context.Context, n int, jobs []func(ctx context.Context) error) error {
+	if n <= 0 {
+		n = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	sem := make(chan struct{}, n)
+	for _, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(job func(ctx context.Context) error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := job(ctx); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}(job)
+	}
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,52 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHydrateAssets(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/api/v1/collection/doodles-official":
+			w.Write([]byte(`{"collection":{"slug":"doodles-official","name":"Doodles","safelist_request_status":"verified"}}`))
+		case "/api/v1/asset_contract/" + contract:
+			w.Write([]byte(`{"address":"` + contract + `","name":"Doodles","schema_name":"ERC721","nft_version":"3.0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	page := func() []Asset {
+		return []Asset{
+			{TokenID: "1", Collection: &Collection{Slug: "doodles-official"}, AssetContract: &AssetContract{Address: Address(contract)}},
+			{TokenID: "2", Collection: &Collection{Slug: "doodles-official"}, AssetContract: &AssetContract{Address: "0xDCEAF1652A131F32A821468DC03A92DF0EDD86EA"}},
+			{TokenID: "3", Collection: &Collection{Slug: "unknown"}},
+		}
+	}
+	h := o.NewHydrator()
+	assets := page()
+	assert.Nil(t, h.HydrateAssets(context.Background(), assets))
+	assert.Equal(t, "Doodles", assets[0].Collection.Name)
+	assert.True(t, assets[1].IsVerified())
+	assert.Equal(t, "ERC721", assets[1].AssetContract.SchemaName)
+	assert.Equal(t, "3.0", assets[0].AssetContract.NftVersion)
+	assert.Equal(t, "unknown", assets[2].Collection.Slug)
+
+	assets = page()
+	assert.Nil(t, h.HydrateAssets(context.Background(), assets))
+	assert.Equal(t, "Doodles", assets[1].Collection.Name)
+	assert.Equal(t, map[string]int{
+		"/api/v1/collection/doodles-official": 1,
+		"/api/v1/collection/unknown":          1,
+		"/api/v1/asset_contract/" + contract:  1,
+	}, calls)
+}
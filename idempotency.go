@@ -0,0 +1,115 @@
+package opensea
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// IdempotencyKeyHeader carries the idempotency key of write requests.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey sets the idempotency key of the writes made with
+// the returned context. Orders default to their order hash, the same for
+// every submission of an order.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// postOrder posts a signed order to the listings or offers endpoint of
+// chain. Orders without a protocol address are posted for the deployment of
+// the client.
+func (o Opensea) postOrder(ctx context.Context, chain Chain, kind string, order *SignedOrder) (*SeaportOrder, error) {
+	if order.ProtocolAddress == "" {
+		withAddress := *order
+		withAddress.ProtocolAddress = o.protocolAddress(DefaultProtocol)
+		order = &withAddress
+	}
+	path := "/api/v2/orders/" + chain.String() + "/" + DefaultProtocol.PathSegment() + "/" + kind
+	return o.postSignedOrder(ctx, chain, path, order, order, decodeOrder)
+}
+
+// postSignedOrder posts body, the payload of a signed order, to path and
+// decodes the response with decode. Writes are not retried by request: a
+// failed attempt may still have created the order, so before each retry
+// the order is looked up by hash and returned if it exists.
+func (o Opensea) postSignedOrder(ctx context.Context, chain Chain, path string, order *SignedOrder, body interface{}, decode func([]byte) (*SeaportOrder, error)) (*SeaportOrder, error) {
+	if order.Signature == "" {
+		return nil, fmt.Errorf("Order is not signed")
+	}
+	hash, err := order.Parameters.Hash()
+	if err != nil {
+		return nil, err
+	}
+	orderHash := "0x" + hex.EncodeToString(hash[:])
+	if idempotencyKeyFromContext(ctx) == "" {
+		ctx = ContextWithIdempotencyKey(ctx, orderHash)
+	}
+	ctx = contextWithChain(ctx, chain)
+	if o.dryRun {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		o.logDryRun("POST", path, b)
+		return dryRunOrder(chain, orderHash, order), nil
+	}
+
+	backoff := o.backoff
+	for attempt := 0; ; attempt++ {
+		resp := new(Response)
+		b, err := o.PostPath(ContextWithResponse(ctx, resp), path, body)
+		if r := responseFromContext(ctx); r != nil {
+			*r = *resp
+		}
+		if err == nil {
+			return decode(b)
+		}
+		if attempt >= o.retries || ctx.Err() != nil || !retryableWrite(resp, err) {
+			return nil, err
+		}
+		if err := sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+
+		existing, lookupErr := o.GetOrderByHashWithContext(ctx, chain, order.ProtocolAddress, orderHash)
+		if lookupErr == nil {
+			return existing, nil
+		}
+		if !errors.Is(lookupErr, ErrNotFound) {
+			return nil, err
+		}
+	}
+}
+
+// retryableWrite reports whether a write failed on a transport error or a
+// retryable status.
+func retryableWrite(resp *Response, err error) bool {
+	var transportErr *url.Error
+	if errors.As(err, &transportErr) {
+		return true
+	}
+	return resp.StatusCode != 0 && retryable(resp.StatusCode)
+}
+
+// setIdempotencyKey sets the idempotency key of ctx on write requests.
+func setIdempotencyKey(ctx context.Context, req *http.Request) {
+	if req.Method == "GET" {
+		return
+	}
+	if key := idempotencyKeyFromContext(ctx); key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+}
@@ -0,0 +1,98 @@
+package opensea
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSignedOffer(t *testing.T) (*SignedOrder, string) {
+	params, err := BuildBid(BidParams{
+		Bidder: testBidder,
+		Item:   ERC721Item(testAuctionContract, "7"),
+		Amount: "1000",
+		Salt:   "1",
+	})
+	assert.Nil(t, err)
+	order, err := SignOrder(context.Background(), ChainEthereum, DefaultProtocol, params, &testSigner{address: testBidder})
+	assert.Nil(t, err)
+	hash, err := params.Hash()
+	assert.Nil(t, err)
+	return order, "0x" + hex.EncodeToString(hash[:])
+}
+
+func TestPostOrderIdempotency(t *testing.T) {
+	order, hash := testSignedOffer(t)
+
+	var posts, lookups int
+	var keys []string
+	created := false
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			lookups++
+			assert.True(t, strings.HasSuffix(r.URL.Path, "/"+hash))
+			if !created {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"order":{"order_hash":"` + hash + `"}}`))
+			return
+		}
+		posts++
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		// the first post creates the order but its response is lost
+		created = true
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"success":false}`))
+	}, WithRetries(2, time.Millisecond))
+
+	ret, err := o.CreateOfferWithContext(context.Background(), ChainEthereum, order)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, ret.OrderHash)
+	assert.Equal(t, 1, posts)
+	assert.Equal(t, 1, lookups)
+	assert.Equal(t, []string{hash}, keys)
+}
+
+func TestPostOrderRetry(t *testing.T) {
+	order, hash := testSignedOffer(t)
+
+	var posts int
+	var keys []string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		posts++
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if posts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"success":false}`))
+			return
+		}
+		w.Write([]byte(`{"order":{"order_hash":"` + hash + `"}}`))
+	}, WithRetries(2, time.Millisecond))
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "key-1")
+	ret, err := o.CreateOfferWithContext(ctx, ChainEthereum, order)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, ret.OrderHash)
+	assert.Equal(t, []string{"key-1", "key-1"}, keys)
+
+	// client errors are not retried
+	posts = 0
+	o = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success":false}`))
+	}, WithRetries(2, time.Millisecond))
+	_, err = o.CreateOfferWithContext(context.Background(), ChainEthereum, order)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, posts)
+}
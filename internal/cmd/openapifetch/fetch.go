@@ -0,0 +1,88 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fetcher downloads a specification to Out, checked against the checksum
+// of Sum. Unpinned specifications are refused unless Pin is set.
+type Fetcher struct {
+	Client *http.Client
+	URL    string
+	Out    string
+	// Sum defaults to Out with a .sha256 suffix.
+	Sum   string
+	Pin   bool
+	Force bool
+}
+
+// Fetch downloads the specification unless Out exists, and reports whether
+// it did. An existing specification is checked against Sum as a downloaded
+// one is.
+func (f Fetcher) Fetch() (bool, error) {
+	sumPath := f.Sum
+	if sumPath == "" {
+		sumPath = f.Out + ".sha256"
+	}
+	if b, err := ioutil.ReadFile(f.Out); err == nil && !f.Force {
+		return false, f.check(sumPath, f.Out, b)
+	} else if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if f.URL == "" {
+		return false, fmt.Errorf("%s is missing: set OPENSEA_OPENAPI_URL, or -url, to the JSON specification of the API reference", f.Out)
+	}
+
+	resp, err := f.Client.Get(f.URL)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("Downloading %s: status %d", f.URL, resp.StatusCode)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if !json.Valid(b) {
+		return false, fmt.Errorf("Downloading %s: not a JSON specification", f.URL)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.Out), 0755); err != nil {
+		return false, err
+	}
+	if err := f.check(sumPath, f.URL, b); err != nil {
+		return false, err
+	}
+	return true, ioutil.WriteFile(f.Out, b, 0644)
+}
+
+// check checks the specification b, read from src, against the checksum
+// pinned in sumPath, or pins it when Pin is set.
+func (f Fetcher) check(sumPath, src string, b []byte) error {
+	digest := sha256.Sum256(b)
+	got := hex.EncodeToString(digest[:])
+	if f.Pin {
+		return ioutil.WriteFile(sumPath, []byte(got+"\n"), 0644)
+	}
+	pinned, err := ioutil.ReadFile(sumPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%s is missing: run with -pin to pin the specification of %s", sumPath, src)
+	}
+	if err != nil {
+		return err
+	}
+	if want := strings.TrimSpace(string(pinned)); want != got {
+		return fmt.Errorf("Checksum of %s is %s, %s pins %s; run with -pin to update it", src, got, sumPath, want)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetch(t *testing.T) {
+	spec := `{"openapi":"3.0.3","paths":{}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(spec))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	f := Fetcher{Client: srv.Client(), URL: srv.URL, Out: filepath.Join(dir, "openapi", "opensea.json"), Pin: true}
+	fetched, err := f.Fetch()
+	assert.Nil(t, err)
+	assert.True(t, fetched)
+	b, err := ioutil.ReadFile(f.Out)
+	assert.Nil(t, err)
+	assert.Equal(t, spec, string(b))
+	sum, err := ioutil.ReadFile(f.Out + ".sha256")
+	assert.Nil(t, err)
+	assert.Len(t, sum, 65)
+
+	// an existing specification is kept when it matches its pin
+	f.Pin = false
+	fetched, err = f.Fetch()
+	assert.Nil(t, err)
+	assert.False(t, fetched)
+
+	// one edited since it was pinned is refused
+	assert.Nil(t, ioutil.WriteFile(f.Out, []byte(`{"openapi":"3.0.3","paths":{"/x":{}}}`), 0644))
+	_, err = f.Fetch()
+	assert.NotNil(t, err)
+	assert.Nil(t, ioutil.WriteFile(f.Out, []byte(spec), 0644))
+
+	// a specification changed upstream fails the pinned checksum
+	spec = `{"openapi":"3.1.0","paths":{}}`
+	f.Force = true
+	_, err = f.Fetch()
+	assert.NotNil(t, err)
+	b, _ = ioutil.ReadFile(f.Out)
+	assert.Contains(t, string(b), "3.0.3")
+
+	// an unpinned specification is refused
+	f.Out = filepath.Join(dir, "unpinned.json")
+	_, err = f.Fetch()
+	assert.NotNil(t, err)
+	_, err = ioutil.ReadFile(f.Out)
+	assert.NotNil(t, err)
+
+	f.URL = ""
+	f.Out = filepath.Join(dir, "missing.json")
+	_, err = f.Fetch()
+	assert.NotNil(t, err)
+}
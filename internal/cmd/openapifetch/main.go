@@ -0,0 +1,45 @@
+// Command openapifetch downloads OpenSea's OpenAPI (JSON) specification for
+// openapigen, pinned by the SHA-256 checksum committed next to it.
+//
+// The specification is only downloaded when missing, or with -force. Its
+// checksum, or the one of the existing specification, must match the one of
+// the -sum file; -pin writes the checksum instead, to pin a new version.
+//
+// Usage:
+//
+//	go run ./internal/cmd/openapifetch -url $OPENSEA_OPENAPI_URL -out openapi/opensea.json [-pin] [-force]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	url := flag.String("url", os.Getenv("OPENSEA_OPENAPI_URL"), "URL of the specification, $OPENSEA_OPENAPI_URL by default")
+	out := flag.String("out", "openapi/opensea.json", "output file")
+	sum := flag.String("sum", "", "checksum file, the output file with a .sha256 suffix by default")
+	pin := flag.Bool("pin", false, "write the checksum of the downloaded specification")
+	force := flag.Bool("force", false, "download even when the output file exists")
+	flag.Parse()
+
+	f := Fetcher{
+		Client: &http.Client{Timeout: time.Minute},
+		URL:    *url,
+		Out:    *out,
+		Sum:    *sum,
+		Pin:    *pin,
+		Force:  *force,
+	}
+	fetched, err := f.Fetch()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if fetched {
+		fmt.Fprintf(os.Stderr, "openapifetch: wrote %s\n", *out)
+	}
+}
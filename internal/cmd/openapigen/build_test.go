@@ -0,0 +1,112 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generateFlags returns the -ops and -skip flags of the openapigen
+// directive of the opensea package.
+func generateFlags(t *testing.T, path string) (ops, skip []string) {
+	b, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	for _, line := range strings.Split(string(b), "\n") {
+		if !strings.HasPrefix(line, "//go:generate ") || !strings.Contains(line, "openapigen") {
+			continue
+		}
+		fields := strings.Fields(line)
+		for i := 0; i+1 < len(fields); i++ {
+			switch fields[i] {
+			case "-ops":
+				ops = split(fields[i+1])
+			case "-skip":
+				skip = split(fields[i+1])
+			}
+		}
+	}
+	return ops, skip
+}
+
+// TestGeneratedEndpointsBuild generates the endpoints of the opensea
+// package from a fixture holding both generated and hand-written
+// operations, with the flags of its go:generate directive, and builds the
+// package with the result.
+func TestGeneratedEndpointsBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the opensea package")
+	}
+	root, err := filepath.Abs("../../..")
+	assert.Nil(t, err)
+	ops, skip := generateFlags(t, filepath.Join(root, "generate.go"))
+	if assert.NotEmpty(t, ops, "the openapigen directive needs an -ops allowlist") {
+		assert.NotEmpty(t, skip)
+	}
+
+	b, err := ioutil.ReadFile("testdata/opensea.json")
+	assert.Nil(t, err)
+	spec := new(Spec)
+	assert.Nil(t, json.Unmarshal(b, spec))
+	src, err := Generate(spec, Options{Package: "opensea", Operations: ops, Skip: skip})
+	if !assert.Nil(t, err) {
+		return
+	}
+	s := string(src)
+	assert.Contains(t, s, "func (o Opensea) GetCollectionOffersV2(")
+	assert.Contains(t, s, "func (o Opensea) ListEventsByNFTWithContext(")
+	for _, handWritten := range []string{"GetCollection(", "GetNFT(", "GetAccount(", "GetPaymentToken(", "GetCollectionStats(", "type NFT struct", "type AssetEvent struct"} {
+		assert.NotContains(t, s, handWritten)
+	}
+
+	dir := t.TempDir()
+	generated := filepath.Join(dir, "zz_generated_endpoints.go")
+	assert.Nil(t, ioutil.WriteFile(generated, src, 0644))
+	overlay, err := json.Marshal(map[string]map[string]string{
+		"Replace": {filepath.Join(root, "zz_generated_endpoints.go"): generated},
+	})
+	assert.Nil(t, err)
+	overlayPath := filepath.Join(dir, "overlay.json")
+	assert.Nil(t, ioutil.WriteFile(overlayPath, overlay, 0644))
+
+	cmd := exec.Command("go", "build", "-overlay", overlayPath, ".")
+	cmd.Dir = root
+	cmd.Env = os.Environ()
+	out, err := cmd.CombinedOutput()
+	assert.Nil(t, err, "%s\n%s", out, src)
+}
+
+// TestGeneratedEndpointsUpToDate checks the committed specification against
+// its pinned checksum, and the committed endpoints against the output of
+// the go:generate directive for it.
+func TestGeneratedEndpointsUpToDate(t *testing.T) {
+	root, err := filepath.Abs("../../..")
+	assert.Nil(t, err)
+	ops, skip := generateFlags(t, filepath.Join(root, "generate.go"))
+
+	b, err := ioutil.ReadFile(filepath.Join(root, "openapi", "opensea.json"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	pinned, err := ioutil.ReadFile(filepath.Join(root, "openapi", "opensea.json.sha256"))
+	assert.Nil(t, err)
+	digest := sha256.Sum256(b)
+	assert.Equal(t, strings.TrimSpace(string(pinned)), hex.EncodeToString(digest[:]))
+
+	spec := new(Spec)
+	assert.Nil(t, json.Unmarshal(b, spec))
+	src, err := Generate(spec, Options{Package: "opensea", Operations: ops, Skip: skip})
+	if !assert.Nil(t, err) {
+		return
+	}
+	committed, err := ioutil.ReadFile(filepath.Join(root, "zz_generated_endpoints.go"))
+	assert.Nil(t, err)
+	assert.Equal(t, string(src), string(committed), "zz_generated_endpoints.go is stale, run go generate")
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type Options struct {
+	Package string
+	// Operations restricts generation to these operationIds.
+	Operations []string
+	// Skip lists schemas that must not be generated, e.g. because the
+	// package defines them by hand.
+	Skip []string
+}
+
+type generator struct {
+	spec    *Spec
+	opts    Options
+	buf     bytes.Buffer
+	schemas map[string]bool
+	imports map[string]bool
+}
+
+// Generate returns the formatted Go source for the selected operations.
+func Generate(spec *Spec, opts Options) ([]byte, error) {
+	g := &generator{
+		spec:    spec,
+		opts:    opts,
+		schemas: map[string]bool{},
+		imports: map[string]bool{"context": true, "encoding/json": true},
+	}
+
+	var paths []string
+	for p := range spec.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	body := new(bytes.Buffer)
+	n := 0
+	for _, p := range paths {
+		item := spec.Paths[p]
+		if item.Get == nil || !g.selected(item.Get.OperationID) {
+			continue
+		}
+		if err := g.operation(body, p, item); err != nil {
+			return nil, err
+		}
+		n++
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("no operation selected")
+	}
+
+	models := new(bytes.Buffer)
+	var names []string
+	for name := range g.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := g.model(models, name); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Fprintf(&g.buf, "// Code generated by openapigen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", opts.Package)
+	var imports []string
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	for _, imp := range imports {
+		fmt.Fprintf(&g.buf, "\t%q\n", imp)
+	}
+	g.buf.WriteString(")\n\n")
+	g.buf.Write(body.Bytes())
+	g.buf.Write(models.Bytes())
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid source: %v\n%s", err, g.buf.String())
+	}
+	return src, nil
+}
+
+func (g *generator) selected(id string) bool {
+	if id == "" {
+		return false
+	}
+	if len(g.opts.Operations) == 0 {
+		return true
+	}
+	for _, op := range g.opts.Operations {
+		if op == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) skipped(name string) bool {
+	for _, s := range g.opts.Skip {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) operation(w *bytes.Buffer, path string, item PathItem) error {
+	op := item.Get
+	name := exportedName(op.OperationID)
+	params := append(append([]Parameter{}, item.Parameters...), op.Parameters...)
+
+	ret := "json.RawMessage"
+	if s := op.okSchema(); s != nil {
+		t, err := g.goType(s)
+		if err != nil {
+			return fmt.Errorf("%s: %v", op.OperationID, err)
+		}
+		ret = t
+	}
+
+	fmt.Fprintf(w, "type %sParams struct {\n", name)
+	for _, p := range params {
+		t, err := g.goType(p.Schema)
+		if err != nil {
+			return fmt.Errorf("%s: parameter %s: %v", op.OperationID, p.Name, err)
+		}
+		fmt.Fprintf(w, "\t%s %s\n", exportedName(p.Name), t)
+	}
+	w.WriteString("}\n\n")
+
+	if op.Summary != "" {
+		fmt.Fprintf(w, "// %s: %s\n", name, op.Summary)
+	}
+	fmt.Fprintf(w, "func (o Opensea) %s(params %sParams) (*%s, error) {\n", name, name, ret)
+	w.WriteString("\tctx := context.TODO()\n")
+	fmt.Fprintf(w, "\treturn o.%sWithContext(ctx, params)\n}\n\n", name)
+
+	fmt.Fprintf(w, "func (o Opensea) %sWithContext(ctx context.Context, params %sParams) (*%s, error) {\n", name, name, ret)
+	format, args := pathFormat(path, params)
+	if len(args) > 0 {
+		g.imports["fmt"] = true
+		g.imports["net/url"] = true
+		fmt.Fprintf(w, "\tpath := fmt.Sprintf(%q, %s)\n", format, strings.Join(args, ", "))
+	} else {
+		fmt.Fprintf(w, "\tpath := %q\n", format)
+	}
+
+	var query []Parameter
+	for _, p := range params {
+		if p.In == "query" {
+			query = append(query, p)
+		}
+	}
+	if len(query) > 0 {
+		g.imports["net/url"] = true
+		w.WriteString("\tvalues := url.Values{}\n")
+		for _, p := range query {
+			if err := g.queryParam(w, p); err != nil {
+				return fmt.Errorf("%s: %v", op.OperationID, err)
+			}
+		}
+		w.WriteString("\tif encodedValues := values.Encode(); encodedValues != \"\" {\n\t\tpath += \"?\" + encodedValues\n\t}\n")
+	}
+
+	w.WriteString("\tb, err := o.GetPath(ctx, path)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	fmt.Fprintf(w, "\tret := new(%s)\n\treturn ret, json.Unmarshal(b, ret)\n}\n\n", ret)
+	return nil
+}
+
+func (g *generator) queryParam(w *bytes.Buffer, p Parameter) error {
+	field := "params." + exportedName(p.Name)
+	t, err := g.goType(p.Schema)
+	if err != nil {
+		return err
+	}
+	switch t {
+	case "string":
+		fmt.Fprintf(w, "\tif %s != \"\" {\n\t\tvalues.Set(%q, %s)\n\t}\n", field, p.Name, field)
+	case "int64":
+		g.imports["strconv"] = true
+		fmt.Fprintf(w, "\tif %s != 0 {\n\t\tvalues.Set(%q, strconv.FormatInt(%s, 10))\n\t}\n", field, p.Name, field)
+	case "float64":
+		g.imports["strconv"] = true
+		fmt.Fprintf(w, "\tif %s != 0 {\n\t\tvalues.Set(%q, strconv.FormatFloat(%s, 'f', -1, 64))\n\t}\n", field, p.Name, field)
+	case "bool":
+		fmt.Fprintf(w, "\tif %s {\n\t\tvalues.Set(%q, \"true\")\n\t}\n", field, p.Name)
+	case "[]string":
+		fmt.Fprintf(w, "\tfor _, v := range %s {\n\t\tvalues.Add(%q, v)\n\t}\n", field, p.Name)
+	default:
+		return fmt.Errorf("unsupported query parameter type %s for %s", t, p.Name)
+	}
+	return nil
+}
+
+func (g *generator) model(w *bytes.Buffer, name string) error {
+	s, ok := g.spec.Components.Schemas[name]
+	if !ok {
+		return fmt.Errorf("unknown schema %s", name)
+	}
+	props := map[string]*Schema{}
+	for _, part := range append([]*Schema{s}, s.AllOf...) {
+		for k, v := range part.Properties {
+			props[k] = v
+		}
+	}
+
+	var keys []string
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	for _, k := range keys {
+		t, err := g.goType(props[k])
+		if err != nil {
+			return fmt.Errorf("%s.%s: %v", name, k, err)
+		}
+		fmt.Fprintf(w, "\t%s %s `json:\"%s\" bson:\"%s\"`\n", exportedName(k), t, k, k)
+	}
+	w.WriteString("}\n\n")
+	return nil
+}
+
+// goType maps a schema to a Go type, queueing referenced schemas.
+func (g *generator) goType(s *Schema) (string, error) {
+	if s == nil {
+		return "interface{}", nil
+	}
+	if ref := s.refName(); ref != "" {
+		if _, ok := g.spec.Components.Schemas[ref]; !ok {
+			return "", fmt.Errorf("unknown schema %s", ref)
+		}
+		if !g.skipped(ref) && !g.schemas[ref] {
+			g.schemas[ref] = true
+			// resolve nested references eagerly so that errors surface here
+			if err := g.resolve(ref); err != nil {
+				return "", err
+			}
+		}
+		return ref, nil
+	}
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		t, err := g.goType(s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + t, nil
+	}
+	return "interface{}", nil
+}
+
+func (g *generator) resolve(name string) error {
+	s := g.spec.Components.Schemas[name]
+	for _, part := range append([]*Schema{s}, s.AllOf...) {
+		for _, p := range part.Properties {
+			if _, err := g.goType(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pathFormat turns "/api/v2/collections/{slug}" into a Sprintf format and
+// its escaped arguments.
+func pathFormat(path string, params []Parameter) (string, []string) {
+	var args []string
+	format := path
+	for _, p := range params {
+		if p.In != "path" {
+			continue
+		}
+		placeholder := "{" + p.Name + "}"
+		if !strings.Contains(format, placeholder) {
+			continue
+		}
+		format = strings.Replace(format, placeholder, "%s", 1)
+		args = append(args, fmt.Sprintf("url.PathEscape(fmt.Sprint(params.%s))", exportedName(p.Name)))
+	}
+	return format, args
+}
+
+var initialisms = map[string]string{
+	"id":   "ID",
+	"url":  "URL",
+	"api":  "API",
+	"nft":  "NFT",
+	"nfts": "NFTs",
+	"usd":  "USD",
+	"eth":  "ETH",
+}
+
+// exportedName converts snake_case and camelCase identifiers to Go names.
+func exportedName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' ' || r == '.'
+	})
+	var b strings.Builder
+	for _, w := range words {
+		for _, part := range splitCamel(w) {
+			if in, ok := initialisms[strings.ToLower(part)]; ok {
+				b.WriteString(in)
+				continue
+			}
+			r := []rune(part)
+			r[0] = unicode.ToUpper(r[0])
+			b.WriteString(string(r))
+		}
+	}
+	return b.String()
+}
+
+func splitCamel(s string) []string {
+	var parts []string
+	start := 0
+	r := []rune(s)
+	for i := 1; i < len(r); i++ {
+		if unicode.IsUpper(r[i]) && unicode.IsLower(r[i-1]) {
+			parts = append(parts, string(r[start:i]))
+			start = i
+		}
+	}
+	return append(parts, string(r[start:]))
+}
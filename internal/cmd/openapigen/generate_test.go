@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadSpec(t *testing.T) *Spec {
+	b, err := ioutil.ReadFile("testdata/spec.json")
+	assert.Nil(t, err)
+	spec := new(Spec)
+	assert.Nil(t, json.Unmarshal(b, spec))
+	return spec
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate(loadSpec(t), Options{Package: "opensea"})
+	assert.Nil(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "gen.go", src, 0)
+	assert.Nil(t, err)
+
+	s := string(src)
+	assert.Contains(t, s, "func (o Opensea) GetCollection(params GetCollectionParams) (*DetailedCollection, error)")
+	assert.Contains(t, s, "func (o Opensea) ListNFTsByCollectionWithContext(ctx context.Context, params ListNFTsByCollectionParams) (*PaginatedNftList, error)")
+	assert.Contains(t, s, `path := fmt.Sprintf("/api/v2/collection/%s/nfts", url.PathEscape(fmt.Sprint(params.CollectionSlug)))`)
+	assert.Contains(t, s, `values.Set("limit", strconv.FormatInt(params.Limit, 10))`)
+	assert.Regexp(t, "NFTs +\\[\\]Nft +`json:\"nfts\" bson:\"nfts\"`", s)
+	assert.Regexp(t, "IsDisabled +bool +`json:\"is_disabled\" bson:\"is_disabled\"`", s)
+	assert.NotContains(t, s, "Ignored")
+}
+
+func TestGenerateSelection(t *testing.T) {
+	src, err := Generate(loadSpec(t), Options{
+		Package:    "opensea",
+		Operations: []string{"get_collection"},
+		Skip:       []string{"DetailedCollection"},
+	})
+	assert.Nil(t, err)
+	assert.NotContains(t, string(src), "type DetailedCollection struct")
+	assert.NotContains(t, string(src), "ListNFTsByCollection")
+
+	_, err = Generate(loadSpec(t), Options{Package: "opensea", Operations: []string{"missing"}})
+	assert.NotNil(t, err)
+}
+
+func TestExportedName(t *testing.T) {
+	assert.Equal(t, "ListNFTsByCollection", exportedName("list_nfts_by_collection"))
+	assert.Equal(t, "TokenID", exportedName("tokenId"))
+	assert.Equal(t, "ImageURL", exportedName("image_url"))
+}
@@ -0,0 +1,61 @@
+// Command openapigen generates endpoint methods and models of the opensea
+// package from OpenSea's OpenAPI (JSON) specification.
+//
+// Only GET operations are generated. Each operation becomes a pair of
+// methods following the conventions of the package, Op(params) and
+// OpWithContext(ctx, params), plus an OpParams struct holding its path and
+// query parameters. Schemas referenced by the selected operations become
+// structs with json and bson tags.
+//
+// Usage:
+//
+//	go run ./internal/cmd/openapigen -spec openapi/opensea.json -out zz_generated_endpoints.go [-ops a,b]
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "openapi/opensea.json", "OpenAPI specification in JSON")
+	out := flag.String("out", "zz_generated_endpoints.go", "output file")
+	pkg := flag.String("package", "opensea", "package name of the generated file")
+	ops := flag.String("ops", "", "comma separated operationIds to generate, all GET operations when empty")
+	skip := flag.String("skip", "", "comma separated schemas already defined by hand")
+	flag.Parse()
+
+	b, err := ioutil.ReadFile(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	spec := new(Spec)
+	if err := json.Unmarshal(b, spec); err != nil {
+		log.Fatalf("%s: %v", *specPath, err)
+	}
+
+	src, err := Generate(spec, Options{
+		Package:    *pkg,
+		Operations: split(*ops),
+		Skip:       split(*skip),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "openapigen: wrote %s\n", *out)
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
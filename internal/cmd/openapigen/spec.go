@@ -0,0 +1,66 @@
+package main
+
+import "strings"
+
+// Spec is the subset of an OpenAPI 3 document used by the generator.
+type Spec struct {
+	Paths      map[string]PathItem `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type PathItem struct {
+	Get        *Operation  `json:"get"`
+	Parameters []Parameter `json:"parameters"`
+}
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Parameters  []Parameter         `json:"parameters"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type Response struct {
+	Content map[string]struct {
+		Schema *Schema `json:"schema"`
+	} `json:"content"`
+}
+
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Format     string             `json:"format"`
+	Properties map[string]*Schema `json:"properties"`
+	Items      *Schema            `json:"items"`
+	Required   []string           `json:"required"`
+	AllOf      []*Schema          `json:"allOf"`
+}
+
+func (s *Schema) refName() string {
+	if s == nil || s.Ref == "" {
+		return ""
+	}
+	return s.Ref[strings.LastIndex(s.Ref, "/")+1:]
+}
+
+// okSchema returns the JSON schema of the 200 response.
+func (op *Operation) okSchema() *Schema {
+	r, ok := op.Responses["200"]
+	if !ok {
+		return nil
+	}
+	c, ok := r.Content["application/json"]
+	if !ok {
+		return nil
+	}
+	return c.Schema
+}
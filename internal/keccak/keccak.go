@@ -0,0 +1,16 @@
+// Package keccak implements the legacy Keccak-256 hash used by Ethereum,
+// which differs from SHA3-256 in its padding.
+package keccak
+
+import "golang.org/x/crypto/sha3"
+
+// Sum256 returns the Keccak-256 digest of the concatenation of data.
+func Sum256(data ...[]byte) [32]byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	var sum [32]byte
+	h.Sum(sum[:0])
+	return sum
+}
@@ -0,0 +1,32 @@
+package keccak
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum256(t *testing.T) {
+	for input, want := range map[string]string{
+		"":    "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		"abc": "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+		// the selector of transfer(address,uint256) is a9059cbb
+		"transfer(address,uint256)": "a9059cbb2ab09eb219583f4a59a5d0623ade346d962bcd4e46b11da047c9049b",
+		// inputs around and beyond the 136 bytes rate
+		strings.Repeat("a", 135):  "34367dc248bbd832f4e3e69dfaac2f92638bd0bbd18f2912ba4ef454919cf446",
+		strings.Repeat("a", 136):  "a6c4d403279fe3e0af03729caada8374b5ca54d8065329a3ebcaeb4b60aa386e",
+		strings.Repeat("a", 137):  "d869f639c7046b4929fc92a4d988a8b22c55fbadb802c0c66ebcd484f1915f39",
+		strings.Repeat("a", 272):  "cf7fcd4f705ee749930d19ca84561a9bf62516bd90a471545fa2f49fdc7e63c8",
+		strings.Repeat("a", 1000): "b6a4ac1f51884d71f30fa397a5e155de3099e11fc0edef5d08b646e621e19de9",
+	} {
+		sum := Sum256([]byte(input))
+		assert.Equal(t, want, hex.EncodeToString(sum[:]), input)
+	}
+
+	// data is hashed as one input
+	long := strings.Repeat("a", 200)
+	sum := Sum256([]byte(long[:100]), []byte(long[100:]))
+	assert.Equal(t, "96ea54061def936c4be90b518992fdc6f12f535068a256229aca54267b4d084d", hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,338 @@
+// Package inventory keeps the books of the tokens owned by a set of
+// accounts: whether each is unlisted, listed, pending a sale, sold or
+// transferred away.
+//
+// An Inventory is fed by an event stream, e.g. an AccountWatcher of the same
+// accounts, and reconciled against the assets endpoint to catch up on
+// missed events.
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// State is a stage of the lifecycle of an owned token.
+type State string
+
+const (
+	Unlisted    State = "unlisted"
+	Listed      State = "listed"
+	SalePending State = "sale_pending"
+	Sold        State = "sold"
+	Transferred State = "transferred"
+)
+
+// Final reports whether the token has left the inventory.
+func (s State) Final() bool {
+	return s == Sold || s == Transferred
+}
+
+// transitions lists the states each state may move to.
+var transitions = map[State][]State{
+	Unlisted:    {Listed, SalePending, Sold, Transferred},
+	Listed:      {Unlisted, Listed, SalePending, Sold, Transferred},
+	SalePending: {Unlisted, Listed, Sold, Transferred},
+	Sold:        {Unlisted},
+	Transferred: {Unlisted},
+}
+
+func canMove(from, to State) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Item is an owned token, or one that was owned.
+type Item struct {
+	Contract opensea.Address `json:"contract"`
+	TokenID  string          `json:"token_id"`
+	Owner    opensea.Address `json:"owner"`
+	State    State           `json:"state"`
+	// Price is the starting price of the listing of listed tokens, and the
+	// sale price of sold tokens, in the smallest unit of the payment token.
+	Price opensea.Number `json:"price,omitempty"`
+	Since time.Time      `json:"since"`
+}
+
+// Key identifies the token of the item as "<contract>/<token id>".
+func (i Item) Key() string {
+	return key(i.Contract, i.TokenID)
+}
+
+func key(contract opensea.Address, tokenID string) string {
+	return strings.ToLower(contract.String()) + "/" + tokenID
+}
+
+// Change is a change of state of an item.
+type Change struct {
+	Item Item  `json:"item"`
+	From State `json:"from"`
+}
+
+// Inventory tracks the tokens of a set of owners. It is safe for concurrent
+// use.
+type Inventory struct {
+	// OnChange is called, without lock held, for every change of state. It
+	// must be set before the inventory is fed.
+	OnChange func(c Change)
+
+	mu     sync.Mutex
+	owners map[opensea.Address]bool
+	items  map[string]*Item
+}
+
+func New(owners ...opensea.Address) *Inventory {
+	inv := &Inventory{owners: map[opensea.Address]bool{}, items: map[string]*Item{}}
+	for _, o := range owners {
+		inv.owners[opensea.Address(strings.ToLower(o.String()))] = true
+	}
+	return inv
+}
+
+func (inv *Inventory) owns(a *opensea.Account) bool {
+	return a != nil && inv.owners[opensea.Address(strings.ToLower(a.Address.String()))]
+}
+
+// move changes the state of a token, adding it when unknown, and returns
+// the change, if any. It must be called with the lock held.
+func (inv *Inventory) move(contract opensea.Address, tokenID string, owner opensea.Address, to State, price opensea.Number, at time.Time) *Change {
+	k := key(contract, tokenID)
+	item, ok := inv.items[k]
+	if !ok {
+		item = &Item{Contract: opensea.Address(strings.ToLower(contract.String())), TokenID: tokenID, State: Unlisted, Since: at}
+		inv.items[k] = item
+		if to == Unlisted {
+			item.Owner = owner
+			return &Change{Item: *item}
+		}
+	}
+	if !canMove(item.State, to) {
+		return nil
+	}
+	from := item.State
+	item.State, item.Price, item.Since = to, price, at
+	if owner != "" {
+		item.Owner = owner
+	}
+	return &Change{Item: *item, From: from}
+}
+
+func (inv *Inventory) notify(changes ...*Change) {
+	if inv.OnChange == nil {
+		return
+	}
+	for _, c := range changes {
+		if c != nil {
+			inv.OnChange(*c)
+		}
+	}
+}
+
+// Apply updates the inventory with an event of one of the owners.
+func (inv *Inventory) Apply(e *opensea.Event) {
+	if e.Asset == nil || e.Asset.AssetContract == nil {
+		return
+	}
+	contract, tokenID := e.Asset.AssetContract.Address, e.Asset.TokenID
+	at := e.EventTimestamp.Time()
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	inv.mu.Lock()
+	var c *Change
+	switch e.EventType {
+	case opensea.EventTypeCreated:
+		if inv.owns(e.Seller) {
+			c = inv.move(contract, tokenID, e.Seller.Address, Listed, opensea.Number(e.StartingPrice), at)
+		}
+	case opensea.EventTypeCancelled:
+		if inv.owns(e.Seller) && inv.state(contract, tokenID) == Listed {
+			c = inv.move(contract, tokenID, "", Unlisted, "", at)
+		}
+	case opensea.EventTypeSuccessful:
+		switch {
+		case inv.owns(e.Seller):
+			c = inv.move(contract, tokenID, "", Sold, e.TotalPrice, at)
+		case inv.owns(e.WinnerAccount):
+			c = inv.move(contract, tokenID, e.WinnerAccount.Address, Unlisted, "", at)
+		}
+	case opensea.EventTypeTransfer:
+		switch {
+		case inv.owns(e.ToAccount):
+			c = inv.move(contract, tokenID, e.ToAccount.Address, Unlisted, "", at)
+		case inv.owns(e.FromAccount) && !inv.state(contract, tokenID).Final():
+			// a transfer following a pending sale settles it
+			to := Transferred
+			if inv.state(contract, tokenID) == SalePending {
+				to = Sold
+			}
+			c = inv.move(contract, tokenID, "", to, inv.price(contract, tokenID), at)
+		}
+	}
+	inv.mu.Unlock()
+	inv.notify(c)
+}
+
+func (inv *Inventory) state(contract opensea.Address, tokenID string) State {
+	if item, ok := inv.items[key(contract, tokenID)]; ok {
+		return item.State
+	}
+	return ""
+}
+
+func (inv *Inventory) price(contract opensea.Address, tokenID string) opensea.Number {
+	if item, ok := inv.items[key(contract, tokenID)]; ok {
+		return item.Price
+	}
+	return ""
+}
+
+// MarkSalePending records that a sale of the token was initiated, e.g. an
+// offer was accepted, and awaits settlement.
+func (inv *Inventory) MarkSalePending(contract opensea.Address, tokenID string, price opensea.Number) error {
+	inv.mu.Lock()
+	state := inv.state(contract, tokenID)
+	if state == "" || state.Final() {
+		inv.mu.Unlock()
+		return fmt.Errorf("Token %s is not in the inventory", key(contract, tokenID))
+	}
+	c := inv.move(contract, tokenID, "", SalePending, price, time.Now())
+	inv.mu.Unlock()
+	inv.notify(c)
+	return nil
+}
+
+// Run applies the events of stream until it ends, and returns its error.
+func (inv *Inventory) Run(ctx context.Context, stream opensea.EventStream) error {
+	for {
+		select {
+		case e, ok := <-stream.Events():
+			if !ok {
+				return stream.Err()
+			}
+			inv.Apply(e)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// AssetLister lists assets; *opensea.Opensea is one.
+type AssetLister interface {
+	GetAssetsWithContext(ctx context.Context, params opensea.GetAssetsParams) (*opensea.AssetsResponse, error)
+}
+
+// Reconcile lists the assets of the owners: tokens missing from the
+// inventory are added as unlisted, and tokens of the inventory no longer
+// owned are marked sold, when their sale was pending, or transferred.
+func (inv *Inventory) Reconcile(ctx context.Context, assets AssetLister) error {
+	inv.mu.Lock()
+	owners := make([]opensea.Address, 0, len(inv.owners))
+	for o := range inv.owners {
+		owners = append(owners, o)
+	}
+	inv.mu.Unlock()
+
+	owned := map[string]opensea.Address{}
+	tokens := map[string]opensea.Asset{}
+	for _, owner := range owners {
+		params := opensea.GetAssetsParams{Owner: owner, Limit: 50, Fields: []string{"token_id", "asset_contract"}}
+		for {
+			resp, err := assets.GetAssetsWithContext(ctx, params)
+			if err != nil {
+				return err
+			}
+			for _, a := range resp.Assets {
+				if a.AssetContract == nil {
+					continue
+				}
+				k := key(a.AssetContract.Address, a.TokenID)
+				owned[k], tokens[k] = owner, a
+			}
+			if resp.Next == "" {
+				break
+			}
+			params.Cursor = resp.Next
+		}
+	}
+
+	now := time.Now()
+	changes := []*Change{}
+	inv.mu.Lock()
+	for k, owner := range owned {
+		if item, ok := inv.items[k]; !ok || item.State.Final() {
+			a := tokens[k]
+			changes = append(changes, inv.move(a.AssetContract.Address, a.TokenID, owner, Unlisted, "", now))
+		}
+	}
+	for k, item := range inv.items {
+		if _, ok := owned[k]; ok || item.State.Final() {
+			continue
+		}
+		to := Transferred
+		if item.State == SalePending {
+			to = Sold
+		}
+		changes = append(changes, inv.move(item.Contract, item.TokenID, "", to, item.Price, now))
+	}
+	inv.mu.Unlock()
+	inv.notify(changes...)
+	return nil
+}
+
+// Item returns the item of a token.
+func (inv *Inventory) Item(contract opensea.Address, tokenID string) (Item, bool) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	item, ok := inv.items[key(contract, tokenID)]
+	if !ok {
+		return Item{}, false
+	}
+	return *item, true
+}
+
+// Items returns the items in the given states, or every item, sorted by key.
+func (inv *Inventory) Items(states ...State) []Item {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	ret := []Item{}
+	for _, item := range inv.items {
+		if len(states) > 0 && !contains(states, item.State) {
+			continue
+		}
+		ret = append(ret, *item)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key() < ret[j].Key() })
+	return ret
+}
+
+// Counts returns the number of items in each state.
+func (inv *Inventory) Counts() map[State]int {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	ret := map[State]int{}
+	for _, item := range inv.items {
+		ret[item.State]++
+	}
+	return ret
+}
+
+func contains(states []State, s State) bool {
+	for _, state := range states {
+		if state == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	me      opensea.Address = "0x00000000000000000000000000000000000000aa"
+	buyer   opensea.Address = "0x00000000000000000000000000000000000000bb"
+	doodles opensea.Address = "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"
+)
+
+func event(t opensea.EventType, tokenID string) *opensea.Event {
+	return &opensea.Event{
+		EventType:      t,
+		EventTimestamp: opensea.TimeNano(time.Now()),
+		Asset:          &opensea.Asset{TokenID: tokenID, AssetContract: &opensea.AssetContract{Address: doodles}},
+	}
+}
+
+var _ AssetLister = &opensea.Opensea{}
+
+type testAssets []opensea.Asset
+
+func (a testAssets) GetAssetsWithContext(ctx context.Context, params opensea.GetAssetsParams) (*opensea.AssetsResponse, error) {
+	return &opensea.AssetsResponse{Assets: a}, nil
+}
+
+func TestInventory(t *testing.T) {
+	inv := New(me)
+	var changes []Change
+	inv.OnChange = func(c Change) { changes = append(changes, c) }
+
+	in := event(opensea.EventTypeTransfer, "1")
+	in.FromAccount, in.ToAccount = &opensea.Account{Address: buyer}, &opensea.Account{Address: me}
+	inv.Apply(in)
+	item, ok := inv.Item(doodles, "1")
+	assert.True(t, ok)
+	assert.Equal(t, Unlisted, item.State)
+	assert.Equal(t, me, item.Owner)
+
+	listed := event(opensea.EventTypeCreated, "1")
+	listed.Seller, listed.StartingPrice = &opensea.Account{Address: me}, "1000"
+	inv.Apply(listed)
+	item, _ = inv.Item(doodles, "1")
+	assert.Equal(t, Listed, item.State)
+	assert.Equal(t, opensea.Number("1000"), item.Price)
+
+	sale := event(opensea.EventTypeSuccessful, "1")
+	sale.Seller, sale.WinnerAccount, sale.TotalPrice = &opensea.Account{Address: me}, &opensea.Account{Address: buyer}, "1000"
+	inv.Apply(sale)
+	item, _ = inv.Item(doodles, "1")
+	assert.Equal(t, Sold, item.State)
+
+	// the transfer of the sale does not change a sold token
+	out := event(opensea.EventTypeTransfer, "1")
+	out.FromAccount, out.ToAccount = &opensea.Account{Address: me}, &opensea.Account{Address: buyer}
+	inv.Apply(out)
+
+	assert.Equal(t, []State{"", Unlisted, Listed}, []State{changes[0].From, changes[1].From, changes[2].From})
+	assert.Len(t, changes, 3)
+
+	// events of others are ignored
+	other := event(opensea.EventTypeCreated, "2")
+	other.Seller = &opensea.Account{Address: buyer}
+	inv.Apply(other)
+	_, ok = inv.Item(doodles, "2")
+	assert.False(t, ok)
+
+	assert.NotNil(t, inv.MarkSalePending(doodles, "1", "1000"))
+}
+
+func TestInventoryReconcile(t *testing.T) {
+	inv := New(me)
+	assert.Nil(t, inv.Reconcile(context.Background(), testAssets{
+		{TokenID: "1", AssetContract: &opensea.AssetContract{Address: doodles}},
+		{TokenID: "2", AssetContract: &opensea.AssetContract{Address: doodles}},
+	}))
+	assert.Equal(t, map[State]int{Unlisted: 2}, inv.Counts())
+
+	assert.Nil(t, inv.MarkSalePending(doodles, "2", "5000"))
+	assert.Equal(t, SalePending, inv.Items(SalePending)[0].State)
+
+	var changes []Change
+	inv.OnChange = func(c Change) { changes = append(changes, c) }
+	assert.Nil(t, inv.Reconcile(context.Background(), testAssets{
+		{TokenID: "3", AssetContract: &opensea.AssetContract{Address: doodles}},
+	}))
+	assert.Len(t, changes, 3)
+	assert.Equal(t, map[State]int{Unlisted: 1, Transferred: 1, Sold: 1}, inv.Counts())
+	sold := inv.Items(Sold)
+	assert.Equal(t, "2", sold[0].TokenID)
+	assert.Equal(t, opensea.Number("5000"), sold[0].Price)
+	assert.Len(t, inv.Items(), 3)
+}
+
+func TestInventoryRun(t *testing.T) {
+	inv := New(me)
+	events := make(chan *opensea.Event, 1)
+	in := event(opensea.EventTypeTransfer, "1")
+	in.ToAccount = &opensea.Account{Address: me}
+	events <- in
+	close(events)
+
+	assert.Nil(t, inv.Run(context.Background(), testStream{events}))
+	assert.Equal(t, map[State]int{Unlisted: 1}, inv.Counts())
+}
+
+type testStream struct {
+	events chan *opensea.Event
+}
+
+func (s testStream) Events() <-chan *opensea.Event {
+	return s.events
+}
+
+func (s testStream) Err() error {
+	return nil
+}
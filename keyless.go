@@ -0,0 +1,38 @@
+package opensea
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrAPIKeyRequired is returned by calls to endpoints that cannot be used
+// without an API key.
+var ErrAPIKeyRequired = errors.New("This endpoint requires an API key, see https://docs.opensea.io/reference/api-keys")
+
+// PublicRateLimit paces clients without an API key, which share the much
+// smaller budget OpenSea grants to anonymous traffic.
+var PublicRateLimit = RateLimit{RequestsPerSecond: 0.25, Burst: 1}
+
+// Path prefixes that are rejected without an API key.
+var keyRequiredPaths = []string{
+	"/api/v1/events",
+	"/wyvern/v1/orders",
+	"/api/v2/",
+}
+
+// Keyless reports whether the client has no API key. Keyless clients omit
+// the API key header, are paced by PublicRateLimit unless another limit is
+// configured, and fail fast with ErrAPIKeyRequired on endpoints needing a
+// key.
+func (o Opensea) Keyless() bool {
+	return o.APIKey == ""
+}
+
+func requiresAPIKey(path string) bool {
+	for _, prefix := range keyRequiredPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
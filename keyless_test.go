@@ -0,0 +1,55 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyless(t *testing.T) {
+	var header []string
+	status := http.StatusOK
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Values("X-API-KEY")
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	})
+	o.APIKey = ""
+	assert.True(t, o.Keyless())
+
+	_, err := o.GetPath(context.Background(), "/api/v1/asset/0x1/1")
+	assert.Nil(t, err)
+	assert.Empty(t, header)
+
+	_, err = o.GetPath(context.Background(), "/api/v1/events/?limit=1")
+	assert.Equal(t, ErrAPIKeyRequired, err)
+	_, err = o.GetPath(context.Background(), "/api/v2/collections/doodles")
+	assert.Equal(t, ErrAPIKeyRequired, err)
+
+	status = http.StatusUnauthorized
+	_, err = o.GetPath(context.Background(), "/api/v1/asset/0x1/1")
+	assert.Equal(t, ErrAPIKeyRequired, err)
+}
+
+func TestKeylessRateLimit(t *testing.T) {
+	o, err := NewOpensea("")
+	assert.Nil(t, err)
+	assert.NotNil(t, o.limiter)
+	assert.Equal(t, PublicRateLimit.RequestsPerSecond, o.limiter.rate)
+
+	o, err = NewOpensea("", WithRateLimit(RateLimit{RequestsPerSecond: 2}))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(2), o.limiter.rate)
+
+	o, err = NewOpensea("key")
+	assert.Nil(t, err)
+	assert.Nil(t, o.limiter)
+
+	m, err := NewMultiChainClient("", map[Chain]ChainConfig{ChainEthereum: {}, ChainPolygon: {}})
+	assert.Nil(t, err)
+	eth, _ := m.Client(ChainEthereum)
+	polygon, _ := m.Client(ChainPolygon)
+	assert.True(t, eth.limiter == polygon.limiter)
+}
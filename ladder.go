@@ -0,0 +1,137 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// LadderConfig describes the collection offers to maintain below the floor
+// of each collection.
+type LadderConfig struct {
+	// Spreads are the distances of the rungs below the floor, as fractions
+	// of the floor, e.g. 0.05 for an offer 5% below it.
+	Spreads []float64
+	// Quantity is the number of items asked for by each rung, 1 by default.
+	Quantity int
+	// Tolerance is the relative distance within which an existing offer is
+	// kept for a rung instead of being replaced.
+	Tolerance float64
+}
+
+// LadderOffer is a collection offer, existing or planned. Prices are in the
+// native currency of the chain.
+type LadderOffer struct {
+	// OrderHash is empty for planned offers.
+	OrderHash  string
+	Collection string
+	Price      float64
+	Quantity   int
+}
+
+// OfferPlan lists the offers to create and cancel to reach a ladder.
+type OfferPlan struct {
+	Create []LadderOffer
+	Cancel []LadderOffer
+	Keep   []LadderOffer
+}
+
+// IsEmpty reports whether the ladder is already in place.
+func (p OfferPlan) IsEmpty() bool {
+	return len(p.Create) == 0 && len(p.Cancel) == 0
+}
+
+// PlanOfferLadder computes the plan turning the existing offers into the
+// ladder of cfg for each collection of floors. Existing offers are matched
+// to the closest rung within the tolerance; the others, including the offers
+// on collections missing from floors, are cancelled.
+func PlanOfferLadder(floors map[string]float64, existing []LadderOffer, cfg LadderConfig) (*OfferPlan, error) {
+	if len(cfg.Spreads) == 0 {
+		return nil, fmt.Errorf("No rung in offer ladder")
+	}
+	for _, s := range cfg.Spreads {
+		if s < 0 || s >= 1 {
+			return nil, fmt.Errorf("Invalid offer ladder spread: %v", s)
+		}
+	}
+	if cfg.Tolerance < 0 {
+		return nil, fmt.Errorf("Invalid offer ladder tolerance: %v", cfg.Tolerance)
+	}
+	quantity := cfg.Quantity
+	if quantity <= 0 {
+		quantity = 1
+	}
+
+	byCollection := map[string][]LadderOffer{}
+	for _, offer := range existing {
+		byCollection[offer.Collection] = append(byCollection[offer.Collection], offer)
+	}
+
+	plan := &OfferPlan{}
+	slugs := make([]string, 0, len(byCollection)+len(floors))
+	for slug := range floors {
+		slugs = append(slugs, slug)
+	}
+	for slug := range byCollection {
+		if _, ok := floors[slug]; !ok {
+			slugs = append(slugs, slug)
+		}
+	}
+	sort.Strings(slugs)
+
+	for _, slug := range slugs {
+		offers := byCollection[slug]
+		floor, ok := floors[slug]
+		if !ok || floor <= 0 {
+			plan.Cancel = append(plan.Cancel, offers...)
+			continue
+		}
+
+		used := make([]bool, len(offers))
+		for _, spread := range cfg.Spreads {
+			target := floor * (1 - spread)
+			best := -1
+			for i, offer := range offers {
+				if used[i] || offer.Quantity != quantity {
+					continue
+				}
+				d := math.Abs(offer.Price-target) / target
+				if d <= cfg.Tolerance && (best < 0 || d < math.Abs(offers[best].Price-target)/target) {
+					best = i
+				}
+			}
+			if best >= 0 {
+				used[best] = true
+				plan.Keep = append(plan.Keep, offers[best])
+				continue
+			}
+			plan.Create = append(plan.Create, LadderOffer{Collection: slug, Price: target, Quantity: quantity})
+		}
+		for i, offer := range offers {
+			if !used[i] {
+				plan.Cancel = append(plan.Cancel, offer)
+			}
+		}
+	}
+	return plan, nil
+}
+
+func (o Opensea) PlanOfferLadder(slugs []string, existing []LadderOffer, cfg LadderConfig) (*OfferPlan, error) {
+	ctx := context.TODO()
+	return o.PlanOfferLadderWithContext(ctx, slugs, existing, cfg)
+}
+
+// PlanOfferLadderWithContext is PlanOfferLadder with the current floors of
+// the collections, read through SnapshotFloor.
+func (o Opensea) PlanOfferLadderWithContext(ctx context.Context, slugs []string, existing []LadderOffer, cfg LadderConfig) (*OfferPlan, error) {
+	floors := map[string]float64{}
+	for _, slug := range slugs {
+		snap, err := o.SnapshotFloor(ctx, slug)
+		if err != nil {
+			return nil, err
+		}
+		floors[slug] = snap.Floor
+	}
+	return PlanOfferLadder(floors, existing, cfg)
+}
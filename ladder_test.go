@@ -0,0 +1,54 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanOfferLadder(t *testing.T) {
+	cfg := LadderConfig{Spreads: []float64{0.05, 0.1}, Tolerance: 0.01}
+	existing := []LadderOffer{
+		{OrderHash: "0x1", Collection: "doodles", Price: 1.9, Quantity: 1},
+		{OrderHash: "0x2", Collection: "doodles", Price: 1.5, Quantity: 1},
+		{OrderHash: "0x3", Collection: "doodles", Price: 1.8, Quantity: 2},
+		{OrderHash: "0x4", Collection: "gone", Price: 1, Quantity: 1},
+	}
+	plan, err := PlanOfferLadder(map[string]float64{"doodles": 2, "azuki": 10}, existing, cfg)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []LadderOffer{existing[0]}, plan.Keep)
+	assert.Equal(t, []LadderOffer{existing[1], existing[2], existing[3]}, plan.Cancel)
+	assert.Len(t, plan.Create, 3)
+	assert.Equal(t, "azuki", plan.Create[0].Collection)
+	assert.InDelta(t, 9.5, plan.Create[0].Price, 1e-9)
+	assert.InDelta(t, 9, plan.Create[1].Price, 1e-9)
+	assert.Equal(t, "doodles", plan.Create[2].Collection)
+	assert.InDelta(t, 1.8, plan.Create[2].Price, 1e-9)
+	assert.Equal(t, 1, plan.Create[2].Quantity)
+	assert.False(t, plan.IsEmpty())
+
+	// applying the plan converges
+	var next []LadderOffer
+	next = append(next, plan.Keep...)
+	next = append(next, plan.Create...)
+	plan, err = PlanOfferLadder(map[string]float64{"doodles": 2, "azuki": 10}, next, cfg)
+	assert.Nil(t, err)
+	assert.True(t, plan.IsEmpty())
+
+	_, err = PlanOfferLadder(nil, nil, LadderConfig{Spreads: []float64{1}})
+	assert.NotNil(t, err)
+	_, err = PlanOfferLadder(nil, nil, LadderConfig{})
+	assert.NotNil(t, err)
+}
+
+func TestPlanOfferLadderWithContext(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"stats":{"floor_price":2}}`))
+	})
+	plan, err := o.PlanOfferLadderWithContext(context.Background(), []string{"doodles"}, nil, LadderConfig{Spreads: []float64{0.5}})
+	assert.Nil(t, err)
+	assert.Equal(t, []LadderOffer{{Collection: "doodles", Price: 1, Quantity: 1}}, plan.Create)
+}
@@ -0,0 +1,87 @@
+package opensea
+
+import "fmt"
+
+const (
+	webURL         = "https://opensea.io"
+	testnetsWebURL = "https://testnets.opensea.io"
+)
+
+var explorerURLs = map[Chain]string{
+	ChainEthereum:        "https://etherscan.io",
+	ChainPolygon:         "https://polygonscan.com",
+	ChainBase:            "https://basescan.org",
+	ChainArbitrum:        "https://arbiscan.io",
+	ChainOptimism:        "https://optimistic.etherscan.io",
+	ChainAvalanche:       "https://snowtrace.io",
+	ChainKlaytn:          "https://klaytnscope.com",
+	ChainZora:            "https://explorer.zora.energy",
+	ChainBlast:           "https://blastscan.io",
+	ChainSolana:          "https://solscan.io",
+	ChainSepolia:         "https://sepolia.etherscan.io",
+	ChainAmoy:            "https://amoy.polygonscan.com",
+	ChainBaseSepolia:     "https://sepolia.basescan.org",
+	ChainArbitrumSepolia: "https://sepolia.arbiscan.io",
+	ChainOptimismSepolia: "https://sepolia-optimism.etherscan.io",
+	ChainAvalancheFuji:   "https://testnet.snowtrace.io",
+	ChainBaobab:          "https://baobab.klaytnscope.com",
+	ChainZoraSepolia:     "https://sepolia.explorer.zora.energy",
+	ChainBlastSepolia:    "https://sepolia.blastscan.io",
+	ChainSolanaDevnet:    "https://solscan.io",
+}
+
+func webURLFor(chain Chain) string {
+	if chain.IsTestnet() {
+		return testnetsWebURL
+	}
+	return webURL
+}
+
+// AssetPermalink returns the OpenSea page of a token.
+func AssetPermalink(chain Chain, contractAddress Address, identifier string) string {
+	return fmt.Sprintf("%s/assets/%s/%s/%s", webURLFor(chain), chain, contractAddress, identifier)
+}
+
+// CollectionPermalink returns the OpenSea page of a collection.
+func CollectionPermalink(slug string) string {
+	return fmt.Sprintf("%s/collection/%s", webURL, slug)
+}
+
+// AccountPermalink returns the OpenSea profile page of an account.
+func AccountPermalink(chain Chain, address Address) string {
+	return fmt.Sprintf("%s/%s", webURLFor(chain), address)
+}
+
+// ExplorerTxURL returns the block explorer page of a transaction, or an
+// empty string when the chain has no known explorer.
+func ExplorerTxURL(chain Chain, txHash string) string {
+	return explorerURL(chain, "tx", txHash)
+}
+
+// ExplorerAddressURL returns the block explorer page of an account.
+func ExplorerAddressURL(chain Chain, address Address) string {
+	if !chain.IsEVM() {
+		return explorerURL(chain, "account", address.String())
+	}
+	return explorerURL(chain, "address", address.String())
+}
+
+// ExplorerTokenURL returns the block explorer page of a token.
+func ExplorerTokenURL(chain Chain, contractAddress Address, identifier string) string {
+	if !chain.IsEVM() {
+		return explorerURL(chain, "token", contractAddress.String())
+	}
+	return explorerURL(chain, "token", fmt.Sprintf("%s?a=%s", contractAddress, identifier))
+}
+
+func explorerURL(chain Chain, kind string, id string) string {
+	base, ok := explorerURLs[chain]
+	if !ok {
+		return ""
+	}
+	u := fmt.Sprintf("%s/%s/%s", base, kind, id)
+	if chain == ChainSolanaDevnet {
+		u += "?cluster=devnet"
+	}
+	return u
+}
@@ -0,0 +1,26 @@
+package opensea
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermalinks(t *testing.T) {
+	addr := Address(contract)
+
+	assert.Equal(t, "https://opensea.io/assets/matic/"+contract+"/1", AssetPermalink(ChainPolygon, addr, "1"))
+	assert.Equal(t, "https://testnets.opensea.io/assets/sepolia/"+contract+"/1", AssetPermalink(ChainSepolia, addr, "1"))
+	assert.Equal(t, "https://opensea.io/collection/doodles-official", CollectionPermalink("doodles-official"))
+	assert.Equal(t, "https://opensea.io/"+contract, AccountPermalink(ChainBase, addr))
+}
+
+func TestExplorerURLs(t *testing.T) {
+	addr := Address(contract)
+
+	assert.Equal(t, "https://basescan.org/tx/0xabc", ExplorerTxURL(ChainBase, "0xabc"))
+	assert.Equal(t, "https://polygonscan.com/address/"+contract, ExplorerAddressURL(ChainPolygon, addr))
+	assert.Equal(t, "https://etherscan.io/token/"+contract+"?a=5", ExplorerTokenURL(ChainEthereum, addr, "5"))
+	assert.Equal(t, "https://solscan.io/tx/abc?cluster=devnet", ExplorerTxURL(ChainSolanaDevnet, "abc"))
+	assert.Equal(t, "", ExplorerTxURL(Chain("unknown"), "0xabc"))
+}
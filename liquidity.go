@@ -0,0 +1,169 @@
+package opensea
+
+import (
+	"context"
+	"math"
+	"strings"
+)
+
+// DefaultDepthRange is the distance from the mid price, as a fraction of
+// it, within which GetLiquidityMetrics measures depth.
+const DefaultDepthRange = 0.1
+
+// DefaultLiquidityCurrencies are the payment tokens GetLiquidityMetrics
+// measures by default, worth the same.
+var DefaultLiquidityCurrencies = []string{"ETH", "WETH"}
+
+// LiquidityParams set how the liquidity of a collection is measured.
+type LiquidityParams struct {
+	// DepthRange defaults to DefaultDepthRange.
+	DepthRange float64
+	// Currencies are the symbols of the payment tokens of the orders
+	// measured, DefaultLiquidityCurrencies when empty. The prices of other
+	// tokens do not compare, so their orders are left out. Orders without a
+	// currency, which the orders endpoints give in the native token, are
+	// always measured.
+	Currencies []string
+}
+
+func (p LiquidityParams) withDefaults() LiquidityParams {
+	if p.DepthRange <= 0 {
+		p.DepthRange = DefaultDepthRange
+	}
+	if len(p.Currencies) == 0 {
+		p.Currencies = DefaultLiquidityCurrencies
+	}
+	return p
+}
+
+func (p LiquidityParams) measures(o SeaportOrder) bool {
+	currency := o.price().Currency
+	if currency == "" {
+		return true
+	}
+	for _, c := range p.Currencies {
+		if strings.EqualFold(c, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// LiquidityMetrics describe the market quality of a collection. Prices are
+// per item, in whole units of the Currencies of the orders measured.
+type LiquidityMetrics struct {
+	Slug       string   `json:"slug" bson:"slug"`
+	Currencies []string `json:"currencies" bson:"currencies"`
+	BestBid    float64  `json:"best_bid" bson:"best_bid"`
+	BestAsk    float64  `json:"best_ask" bson:"best_ask"`
+	Mid        float64  `json:"mid" bson:"mid"`
+	// Spread is BestAsk - BestBid, and SpreadRatio the spread relative to
+	// Mid. Both are zero unless the collection has bids and asks.
+	Spread      float64 `json:"spread" bson:"spread"`
+	SpreadRatio float64 `json:"spread_ratio" bson:"spread_ratio"`
+	// BidDepth and AskDepth count the items bid for and asked within
+	// DepthRange of Mid.
+	DepthRange float64 `json:"depth_range" bson:"depth_range"`
+	BidDepth   int64   `json:"bid_depth" bson:"bid_depth"`
+	AskDepth   int64   `json:"ask_depth" bson:"ask_depth"`
+	// Listed is the number of distinct items listed, and ListedRatio its
+	// share of Supply.
+	Listed      int     `json:"listed" bson:"listed"`
+	Supply      float64 `json:"supply" bson:"supply"`
+	ListedRatio float64 `json:"listed_ratio" bson:"listed_ratio"`
+
+	// Truncated is set when the listings or offers of a large collection
+	// were cut at the page limit of the order book reads, so that the
+	// metrics only measure their first pages.
+	Truncated bool `json:"truncated,omitempty" bson:"truncated,omitempty"`
+}
+
+// GetLiquidityMetrics computes the liquidity metrics of a collection from
+// its listings, offers and stats. Collections with many orders are measured
+// over their first pages of orders, and their metrics marked Truncated.
+func (o Opensea) GetLiquidityMetrics(slug string, params LiquidityParams) (*LiquidityMetrics, error) {
+	ctx := context.TODO()
+	return o.GetLiquidityMetricsWithContext(ctx, slug, params)
+}
+
+func (o Opensea) GetLiquidityMetricsWithContext(ctx context.Context, slug string, params LiquidityParams) (*LiquidityMetrics, error) {
+	listings, listingsTruncated, err := o.collectionOrders(ctx, ChainNone, slug, true)
+	if err != nil {
+		return nil, err
+	}
+	offers, offersTruncated, err := o.collectionOrders(ctx, ChainNone, slug, false)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := o.collectionStats(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	m := computeLiquidity(listings, offers, stats.TotalSupply, params)
+	m.Slug = slug
+	m.Truncated = listingsTruncated || offersTruncated
+	return m, nil
+}
+
+func computeLiquidity(listings, offers []SeaportOrder, supply float64, params LiquidityParams) *LiquidityMetrics {
+	params = params.withDefaults()
+	depthRange := params.DepthRange
+	m := &LiquidityMetrics{Currencies: params.Currencies, DepthRange: depthRange, Supply: supply}
+	listings, offers = filterOrders(listings, params.measures), filterOrders(offers, params.measures)
+
+	for _, l := range listings {
+		if p := l.unitPrice(true); p > 0 && (m.BestAsk == 0 || p < m.BestAsk) {
+			m.BestAsk = p
+		}
+	}
+	for _, of := range offers {
+		if p := of.unitPrice(false); p > m.BestBid {
+			m.BestBid = p
+		}
+	}
+	switch {
+	case m.BestAsk > 0 && m.BestBid > 0:
+		m.Mid = (m.BestAsk + m.BestBid) / 2
+		m.Spread = m.BestAsk - m.BestBid
+		m.SpreadRatio = m.Spread / m.Mid
+	case m.BestAsk > 0:
+		m.Mid = m.BestAsk
+	default:
+		m.Mid = m.BestBid
+	}
+
+	if m.Mid > 0 {
+		for _, l := range listings {
+			if math.Abs(l.unitPrice(true)-m.Mid) <= depthRange*m.Mid {
+				m.AskDepth += l.nftQuantity(true)
+			}
+		}
+		for _, of := range offers {
+			if math.Abs(of.unitPrice(false)-m.Mid) <= depthRange*m.Mid {
+				m.BidDepth += of.nftQuantity(false)
+			}
+		}
+	}
+
+	listed := map[string]bool{}
+	for _, l := range listings {
+		for _, token := range l.listedTokens() {
+			listed[token] = true
+		}
+	}
+	m.Listed = len(listed)
+	if supply > 0 {
+		m.ListedRatio = float64(m.Listed) / supply
+	}
+	return m
+}
+
+func filterOrders(orders []SeaportOrder, keep func(SeaportOrder) bool) []SeaportOrder {
+	ret := []SeaportOrder{}
+	for _, o := range orders {
+		if keep(o) {
+			ret = append(ret, o)
+		}
+	}
+	return ret
+}
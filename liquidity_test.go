@@ -0,0 +1,92 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLiquidityMetrics(t *testing.T) {
+	listingIn := func(currency string, decimals int, hash string, value string, token string) string {
+		return fmt.Sprintf(`{"order_hash":%q,"price":{"current":{"currency":%q,"decimals":%d,"value":%q}},
+			"protocol_data":{"parameters":{"offer":[{"itemType":2,"token":"0x00000000000000000000000000000000000000aa","identifierOrCriteria":%q,"startAmount":"1","endAmount":"1"}]}}}`, hash, currency, decimals, value, token)
+	}
+	listing := func(hash string, wei string, token string) string {
+		return listingIn("ETH", 18, hash, wei, token)
+	}
+	offer := func(hash string, wei string, quantity string) string {
+		return fmt.Sprintf(`{"order_hash":%q,"price":{"currency":"WETH","decimals":18,"value":%q},
+			"protocol_data":{"parameters":{"consideration":[{"itemType":4,"token":"0x00000000000000000000000000000000000000aa","identifierOrCriteria":"0","startAmount":%q,"endAmount":%q}]}}}`, hash, wei, quantity, quantity)
+	}
+
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/listings/collection/doodles/all":
+			if r.URL.Query().Get("next") == "" {
+				w.Write([]byte(`{"listings":[` + listing("0x1", "2000000000000000000", "1") + `,` + listing("0x2", "2100000000000000000", "1") + `],"next":"p2"}`))
+				return
+			}
+			// 1 USDC does not compare with the ETH prices
+			w.Write([]byte(`{"listings":[` + listing("0x3", "5000000000000000000", "2") + `,` + listingIn("USDC", 6, "0x6", "1000000", "3") + `]}`))
+		case "/api/v2/offers/collection/doodles/all":
+			// 3.6 WETH for 2 items is 1.8 each
+			w.Write([]byte(`{"offers":[` + offer("0x4", "3600000000000000000", "2") + `,` + offer("0x5", "1000000000000000000", "1") + `]}`))
+		case "/api/v1/collection/doodles/stats":
+			w.Write([]byte(`{"stats":{"total_supply":10}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	m, err := o.GetLiquidityMetricsWithContext(context.Background(), "doodles", LiquidityParams{})
+	assert.Nil(t, err)
+	assert.Equal(t, "doodles", m.Slug)
+	assert.False(t, m.Truncated)
+	assert.Equal(t, DefaultLiquidityCurrencies, m.Currencies)
+	assert.Equal(t, DefaultDepthRange, m.DepthRange)
+	assert.InDelta(t, 2.0, m.BestAsk, 1e-9)
+	assert.InDelta(t, 1.8, m.BestBid, 1e-9)
+	assert.InDelta(t, 1.9, m.Mid, 1e-9)
+	assert.InDelta(t, 0.2, m.Spread, 1e-9)
+	assert.InDelta(t, 0.2/1.9, m.SpreadRatio, 1e-9)
+	// 1.71 to 2.09 around the mid
+	assert.Equal(t, int64(1), m.AskDepth)
+	assert.Equal(t, int64(2), m.BidDepth)
+	assert.Equal(t, 2, m.Listed)
+	assert.InDelta(t, 0.2, m.ListedRatio, 1e-9)
+
+	// 1.52 to 2.28 around the mid
+	m, err = o.GetLiquidityMetricsWithContext(context.Background(), "doodles", LiquidityParams{DepthRange: 0.2})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), m.AskDepth)
+
+	m, err = o.GetLiquidityMetricsWithContext(context.Background(), "doodles", LiquidityParams{Currencies: []string{"usdc"}})
+	assert.Nil(t, err)
+	assert.InDelta(t, 1.0, m.BestAsk, 1e-9)
+	assert.Equal(t, 0.0, m.BestBid)
+	assert.Equal(t, 1, m.Listed)
+
+	empty := computeLiquidity(nil, nil, 0, LiquidityParams{})
+	assert.Equal(t, 0.0, empty.Mid)
+	assert.Equal(t, 0.0, empty.ListedRatio)
+}
+
+func TestGetLiquidityMetricsTruncated(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/listings/collection/doodles/all":
+			w.Write([]byte(`{"listings":[],"next":"more"}`))
+		case "/api/v1/collection/doodles/stats":
+			w.Write([]byte(`{"stats":{"total_supply":10}}`))
+		default:
+			w.Write([]byte(`{"offers":[]}`))
+		}
+	})
+
+	m, err := o.GetLiquidityMetricsWithContext(context.Background(), "doodles", LiquidityParams{})
+	assert.Nil(t, err)
+	assert.True(t, m.Truncated)
+}
@@ -0,0 +1,234 @@
+package opensea
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// DefaultListingDuration is the lifetime of listings built without an end
+// time.
+const DefaultListingDuration = 30 * 24 * time.Hour
+
+// Fee is a share of the sale price paid to a recipient, e.g. the OpenSea fee
+// or a creator royalty.
+type Fee struct {
+	Recipient   Address `json:"recipient" bson:"recipient"`
+	BasisPoints int     `json:"basis_points" bson:"basis_points"`
+}
+
+// ListingItem is a token offered by a listing.
+type ListingItem struct {
+	ItemType ItemType
+	Token    Address
+	TokenID  string
+	// Quantity defaults to 1; only ERC1155 items may list more.
+	Quantity int64
+}
+
+// ERC721Item is the ListingItem of an ERC721 token.
+func ERC721Item(token Address, tokenID string) ListingItem {
+	return ListingItem{ItemType: ItemERC721, Token: token, TokenID: tokenID, Quantity: 1}
+}
+
+// ERC1155Item is the ListingItem of quantity ERC1155 tokens.
+func ERC1155Item(token Address, tokenID string, quantity int64) ListingItem {
+	return ListingItem{ItemType: ItemERC1155, Token: token, TokenID: tokenID, Quantity: quantity}
+}
+
+// ListingParams describe a listing to build. A listing of several items is
+// a bundle: the items are sold together, for a single price, in one Seaport
+// order.
+type ListingParams struct {
+	Offerer Address
+	Items   []ListingItem
+	// Price is the total price, in the smallest unit of PaymentToken.
+	Price Number
+	// PaymentToken is the ERC20 the price is paid in; empty or NullAddress
+	// for the native currency of the chain.
+	PaymentToken Address
+	// Fees are deducted from Price; the offerer receives the rest.
+	Fees []Fee
+	// StartTime defaults to now and EndTime to DefaultListingDuration later.
+	StartTime time.Time
+	EndTime   time.Time
+	// Salt defaults to a random value.
+	Salt Number
+	// Counter is the current counter of the offerer on the protocol
+	// contract, 0 unless the offerer has cancelled all their orders.
+	Counter Number
+}
+
+// BuildListing builds the parameters of a listing, ready for SignOrder.
+func BuildListing(p ListingParams) (*OrderParameters, error) {
+	offerer, err := ParseAddress(p.Offerer.String())
+	if err != nil {
+		return nil, err
+	}
+	p.Offerer = offerer
+	if len(p.Items) == 0 {
+		return nil, fmt.Errorf("No item to list")
+	}
+
+	offer := make([]OfferItem, 0, len(p.Items))
+	seen := map[string]bool{}
+	for _, item := range p.Items {
+		o, err := item.offerItem()
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(o.Token.String()) + "/" + string(o.IdentifierOrCriteria)
+		if seen[key] {
+			return nil, fmt.Errorf("Duplicate item: %s", key)
+		}
+		seen[key] = true
+		offer = append(offer, o)
+	}
+
+	consideration, err := listingConsideration(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderParameters(p.Offerer, offer, consideration, p.StartTime, p.EndTime, DefaultListingDuration, p.Salt, p.Counter)
+}
+
+// newOrderParameters completes the parameters of an order: start defaults to
+// now, end to duration later, salt to a random value and counter to 0.
+func newOrderParameters(offerer Address, offer []OfferItem, consideration []ConsiderationItem, start, end time.Time, duration time.Duration, salt, counter Number) (*OrderParameters, error) {
+	if start.IsZero() {
+		start = time.Now()
+	}
+	if end.IsZero() {
+		end = start.Add(duration)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("End time %s is not after start time %s", end, start)
+	}
+
+	if salt == "" {
+		var err error
+		salt, err = randomSalt()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if counter == "" {
+		counter = "0"
+	}
+
+	return &OrderParameters{
+		Offerer:                         offerer,
+		Zone:                            NullAddress,
+		Offer:                           offer,
+		Consideration:                   consideration,
+		OrderType:                       OrderTypeFullOpen,
+		StartTime:                       Number(fmt.Sprint(start.Unix())),
+		EndTime:                         Number(fmt.Sprint(end.Unix())),
+		ZoneHash:                        zeroBytes32,
+		Salt:                            salt,
+		ConduitKey:                      OpenSeaConduitKey,
+		TotalOriginalConsiderationItems: len(consideration),
+		Counter:                         counter,
+	}, nil
+}
+
+// BuildBundleListing is BuildListing for bundles; it fails unless at least
+// two items are listed.
+func BuildBundleListing(p ListingParams) (*OrderParameters, error) {
+	if len(p.Items) < 2 {
+		return nil, fmt.Errorf("A bundle needs at least 2 items, got %d", len(p.Items))
+	}
+	return BuildListing(p)
+}
+
+func (item ListingItem) offerItem() (OfferItem, error) {
+	if item.ItemType != ItemERC721 && item.ItemType != ItemERC1155 {
+		return OfferItem{}, fmt.Errorf("Invalid item type: %d", item.ItemType)
+	}
+	token, err := ParseAddress(item.Token.String())
+	if err != nil {
+		return OfferItem{}, err
+	}
+	id, ok := new(big.Int).SetString(item.TokenID, 10)
+	if !ok || id.Sign() < 0 {
+		return OfferItem{}, fmt.Errorf("Invalid token ID: %s", item.TokenID)
+	}
+	quantity := item.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	if quantity < 0 || (item.ItemType == ItemERC721 && quantity != 1) {
+		return OfferItem{}, fmt.Errorf("Invalid quantity for %s/%s: %d", token, item.TokenID, quantity)
+	}
+	amount := Number(fmt.Sprint(quantity))
+	return OfferItem{
+		ItemType:             item.ItemType,
+		Token:                token,
+		IdentifierOrCriteria: Number(id.String()),
+		StartAmount:          amount,
+		EndAmount:            amount,
+	}, nil
+}
+
+// listingConsideration splits the price between the offerer and the fee
+// recipients, as computed by CalculateProceeds.
+func listingConsideration(p ListingParams) ([]ConsiderationItem, error) {
+	proceeds, err := CalculateProceeds(p.Price, p.Fees)
+	if err != nil {
+		return nil, err
+	}
+
+	itemType, token := ItemNative, NullAddress
+	if p.PaymentToken != "" && !p.PaymentToken.IsNullAddress() {
+		t, err := ParseAddress(p.PaymentToken.String())
+		if err != nil {
+			return nil, err
+		}
+		itemType, token = ItemERC20, t
+	}
+	item := func(amount Number, recipient Address) ConsiderationItem {
+		return ConsiderationItem{
+			ItemType:             itemType,
+			Token:                token,
+			IdentifierOrCriteria: "0",
+			StartAmount:          amount,
+			EndAmount:            amount,
+			Recipient:            recipient,
+		}
+	}
+
+	ret := []ConsiderationItem{item(proceeds.Net, p.Offerer)}
+	for _, fee := range proceeds.Fees {
+		ret = append(ret, item(fee.Amount, fee.Recipient))
+	}
+	return ret, nil
+}
+
+func randomSalt() (Number, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return Number(new(big.Int).SetBytes(b).String()), nil
+}
+
+// CreateListing posts a signed listing to the listings endpoint of chain.
+// Failed posts are retried as configured by WithRetries, unless the listing
+// turns out to have been created; a *ValidationError reports why the API
+// rejected it.
+func (o Opensea) CreateListing(chain Chain, order *SignedOrder) (*SeaportOrder, error) {
+	ctx := context.TODO()
+	return o.CreateListingWithContext(ctx, chain, order)
+}
+
+func (o Opensea) CreateListingWithContext(ctx context.Context, chain Chain, order *SignedOrder) (*SeaportOrder, error) {
+	chain, err := o.chainFor(chain, CapabilityListings)
+	if err != nil {
+		return nil, err
+	}
+	return o.postOrder(ctx, chain, "listings", order)
+}
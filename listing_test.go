@@ -0,0 +1,132 @@
+package opensea
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBundleListing(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	p := ListingParams{
+		Offerer: "0x1111111111111111111111111111111111111111",
+		Items: []ListingItem{
+			ERC721Item("0x2222222222222222222222222222222222222222", "1"),
+			ERC721Item("0x2222222222222222222222222222222222222222", "2"),
+			ERC1155Item("0x4444444444444444444444444444444444444444", "9", 3),
+		},
+		Price: "1000000000000000000",
+		Fees: []Fee{
+			{Recipient: OpenSeaFeeRecipient, BasisPoints: 250},
+			{Recipient: "0x5555555555555555555555555555555555555555", BasisPoints: 500},
+		},
+		StartTime: start,
+	}
+	order, err := BuildBundleListing(p)
+	assert.Nil(t, err)
+
+	assert.Len(t, order.Offer, 3)
+	assert.Equal(t, ItemERC1155, order.Offer[2].ItemType)
+	assert.Equal(t, Number("3"), order.Offer[2].StartAmount)
+
+	assert.Len(t, order.Consideration, 3)
+	assert.Equal(t, 3, order.TotalOriginalConsiderationItems)
+	assert.Equal(t, p.Offerer, order.Consideration[0].Recipient)
+	assert.Equal(t, Number("925000000000000000"), order.Consideration[0].StartAmount)
+	assert.Equal(t, Number("25000000000000000"), order.Consideration[1].StartAmount)
+	assert.Equal(t, Number("50000000000000000"), order.Consideration[2].EndAmount)
+	assert.Equal(t, ItemNative, order.Consideration[0].ItemType)
+
+	assert.Equal(t, Number("1700000000"), order.StartTime)
+	assert.Equal(t, Number("1702592000"), order.EndTime)
+	assert.NotEmpty(t, order.Salt)
+	assert.Equal(t, Number("0"), order.Counter)
+	assert.Equal(t, OpenSeaConduitKey, order.ConduitKey)
+
+	_, err = order.Digest(ChainEthereum, "")
+	assert.Nil(t, err)
+
+	p.PaymentToken = "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"
+	order, err = BuildListing(p)
+	assert.Nil(t, err)
+	assert.Equal(t, ItemERC20, order.Consideration[1].ItemType)
+	assert.Equal(t, Address("0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"), order.Consideration[1].Token)
+}
+
+func TestBuildListingValidation(t *testing.T) {
+	valid := func() ListingParams {
+		return ListingParams{
+			Offerer: "0x1111111111111111111111111111111111111111",
+			Items:   []ListingItem{ERC721Item("0x2222222222222222222222222222222222222222", "1")},
+			Price:   "100",
+			Fees:    []Fee{{Recipient: OpenSeaFeeRecipient, BasisPoints: 250}},
+		}
+	}
+	_, err := BuildListing(valid())
+	assert.Nil(t, err)
+
+	// a single item is not a bundle
+	_, err = BuildBundleListing(valid())
+	assert.NotNil(t, err)
+
+	for name, mutate := range map[string]func(p *ListingParams){
+		"no items":      func(p *ListingParams) { p.Items = nil },
+		"duplicate":     func(p *ListingParams) { p.Items = append(p.Items, p.Items[0]) },
+		"erc721 amount": func(p *ListingParams) { p.Items[0].Quantity = 2 },
+		"item type":     func(p *ListingParams) { p.Items[0].ItemType = ItemERC20 },
+		"token id":      func(p *ListingParams) { p.Items[0].TokenID = "0x1" },
+		"offerer":       func(p *ListingParams) { p.Offerer = "me" },
+		"price":         func(p *ListingParams) { p.Price = "0" },
+		"fee rounding":  func(p *ListingParams) { p.Price = "10" },
+		"fees total":    func(p *ListingParams) { p.Fees[0].BasisPoints = 10000 },
+		"end time":      func(p *ListingParams) { p.StartTime, p.EndTime = time.Unix(2, 0), time.Unix(1, 0) },
+	} {
+		p := valid()
+		mutate(&p)
+		_, err := BuildListing(p)
+		assert.NotNil(t, err, name)
+	}
+}
+
+func TestCreateListing(t *testing.T) {
+	params, err := BuildListing(ListingParams{
+		Offerer:   testBidder,
+		Items:     []ListingItem{ERC721Item(testAuctionContract, "7")},
+		Price:     "1000000",
+		StartTime: time.Unix(1700000000, 0),
+		Salt:      "1",
+	})
+	assert.Nil(t, err)
+	order, err := SignOrder(context.Background(), ChainEthereum, DefaultProtocol, params, &testSigner{address: testBidder})
+	assert.Nil(t, err)
+
+	rejected := false
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v2/orders/ethereum/seaport/listings", r.URL.Path)
+		if rejected {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":["['Order signature invalid']",{"field":"endTime"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"order":{"order_hash":"0xabc","protocol_address":"0x00000000000000adc04c56bf30ac9d3c0aaf14dc"}}`))
+	})
+	created, err := o.CreateListingWithContext(context.Background(), ChainEthereum, order)
+	assert.Nil(t, err)
+	assert.Equal(t, "0xabc", created.OrderHash)
+
+	rejected = true
+	_, err = o.CreateListingWithContext(context.Background(), ChainEthereum, order)
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, http.StatusBadRequest, validationErr.StatusCode)
+	assert.Equal(t, []string{"['Order signature invalid']", `{"field":"endTime"}`}, validationErr.Errors)
+
+	_, err = o.CreateListingWithContext(context.Background(), ChainEthereum, &SignedOrder{Parameters: order.Parameters})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,21 @@
+package opensea
+
+// Logger receives the diagnostics of the client, such as deprecation
+// warnings. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger sets the logger of the client. Without one, diagnostics are
+// dropped.
+func WithLogger(logger Logger) Option {
+	return func(o *Opensea) {
+		o.logger = logger
+	}
+}
+
+func (o Opensea) logf(format string, v ...interface{}) {
+	if o.logger != nil {
+		o.logger.Printf(format, v...)
+	}
+}
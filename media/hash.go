@@ -0,0 +1,89 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"math/bits"
+	"strconv"
+
+	// decoders of the formats served by OpenSea
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// Hash is the 64-bit difference hash of an image. Resized, recompressed or
+// slightly edited copies of an image have hashes at a small Distance.
+type Hash uint64
+
+func (h Hash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// ParseHash parses the hexadecimal form of a hash.
+func ParseHash(s string) (Hash, error) {
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid hash: %s", s)
+	}
+	return Hash(v), nil
+}
+
+// Distance is the number of differing bits between two hashes, from 0 for
+// identical images to 64.
+func Distance(a, b Hash) int {
+	return bits.OnesCount64(uint64(a ^ b))
+}
+
+// HashImage decodes a GIF, JPEG or PNG image and hashes it.
+func HashImage(data []byte) (Hash, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	return DHash(img), nil
+}
+
+// DHash computes the difference hash of img: the image is shrunk to 9x8
+// gray pixels and each bit tells whether a pixel is brighter than its right
+// neighbour.
+func DHash(img image.Image) Hash {
+	const w, hgt = 9, 8
+	var gray [hgt][w]float64
+	b := img.Bounds()
+	for y := 0; y < hgt; y++ {
+		y0 := b.Min.Y + y*b.Dy()/hgt
+		y1 := b.Min.Y + (y+1)*b.Dy()/hgt
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := b.Min.X + (x+1)*b.Dx()/w
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+			// box filter over the source pixels of the cell
+			var sum float64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					r, g, bl, _ := img.At(sx, sy).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+				}
+			}
+			gray[y][x] = sum / float64((y1-y0)*(x1-x0))
+		}
+	}
+
+	var h Hash
+	for y := 0; y < hgt; y++ {
+		for x := 0; x < w-1; x++ {
+			h <<= 1
+			if gray[y][x] > gray[y][x+1] {
+				h |= 1
+			}
+		}
+	}
+	return h
+}
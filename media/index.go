@@ -0,0 +1,109 @@
+package media
+
+import (
+	"sort"
+	"sync"
+)
+
+// Match is an indexed image similar to a query.
+type Match struct {
+	ID       string
+	Hash     Hash
+	Distance int
+}
+
+// Index finds near-duplicate images among hashed media, e.g. the assets of
+// a collection suspected to copy another one. IDs are chosen by the
+// caller, such as "collection/token_id". It is safe for concurrent use.
+type Index struct {
+	mu     sync.RWMutex
+	hashes map[string]Hash
+}
+
+func NewIndex() *Index {
+	return &Index{hashes: map[string]Hash{}}
+}
+
+// Add indexes the hash of an image, replacing any previous hash of id.
+func (ix *Index) Add(id string, h Hash) {
+	ix.mu.Lock()
+	ix.hashes[id] = h
+	ix.mu.Unlock()
+}
+
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.hashes)
+}
+
+// Similar returns the images within maxDistance of h, closest first.
+func (ix *Index) Similar(h Hash, maxDistance int) []Match {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	ret := []Match{}
+	for id, other := range ix.hashes {
+		if d := Distance(h, other); d <= maxDistance {
+			ret = append(ret, Match{ID: id, Hash: other, Distance: d})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Distance != ret[j].Distance {
+			return ret[i].Distance < ret[j].Distance
+		}
+		return ret[i].ID < ret[j].ID
+	})
+	return ret
+}
+
+// Duplicates groups the indexed images within maxDistance of each other,
+// transitively. Only groups of two images or more are returned, each sorted
+// by ID.
+func (ix *Index) Duplicates(maxDistance int) [][]string {
+	ix.mu.RLock()
+	ids := make([]string, 0, len(ix.hashes))
+	for id := range ix.hashes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	hashes := make([]Hash, len(ids))
+	for i, id := range ids {
+		hashes[i] = ix.hashes[id]
+	}
+	ix.mu.RUnlock()
+
+	// union-find over the pairs within distance
+	parent := make([]int, len(ids))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			if Distance(hashes[i], hashes[j]) <= maxDistance {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	groups := map[int][]string{}
+	for i, id := range ids {
+		root := find(i)
+		groups[root] = append(groups[root], id)
+	}
+	ret := [][]string{}
+	for _, g := range groups {
+		if len(g) > 1 {
+			ret = append(ret, g)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i][0] < ret[j][0] })
+	return ret
+}
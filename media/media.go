@@ -0,0 +1,111 @@
+// Package media downloads the images of assets and optionally computes
+// their perceptual hashes, so that copies of the same art can be found
+// across collections with an Index.
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// DefaultIPFSGateway serves ipfs:// URLs.
+const DefaultIPFSGateway = "https://ipfs.io/ipfs/"
+
+// DefaultMaxSize bounds the size of downloaded media.
+const DefaultMaxSize = 32 << 20
+
+// Media is a downloaded file.
+type Media struct {
+	URL         string
+	ContentType string
+	Data        []byte
+	// Hash is set when the downloader hashes images and the file could be
+	// decoded as one.
+	Hash *Hash
+}
+
+// Downloader fetches media over HTTP.
+type Downloader struct {
+	Client *http.Client
+	// Gateway replaces the ipfs:// scheme, DefaultIPFSGateway when empty.
+	Gateway string
+	// MaxSize bounds the size of files, DefaultMaxSize when zero.
+	MaxSize int64
+	// Hash enables perceptual hashing of the downloaded images.
+	Hash bool
+}
+
+func NewDownloader(hash bool) *Downloader {
+	return &Downloader{Client: http.DefaultClient, Hash: hash}
+}
+
+// Download fetches the media at url.
+func (d *Downloader) Download(ctx context.Context, url string) (*Media, error) {
+	url = d.resolve(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Download of %s failed: %s", url, resp.Status)
+	}
+
+	max := d.MaxSize
+	if max <= 0 {
+		max = DefaultMaxSize
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > max {
+		return nil, fmt.Errorf("Media %s exceeds %d bytes", url, max)
+	}
+
+	m := &Media{URL: url, ContentType: resp.Header.Get("Content-Type"), Data: data}
+	if d.Hash {
+		if h, err := HashImage(data); err == nil {
+			m.Hash = &h
+		}
+	}
+	return m, nil
+}
+
+// DownloadAsset fetches the original image of an asset, falling back to
+// the image cached by OpenSea.
+func (d *Downloader) DownloadAsset(ctx context.Context, a *opensea.Asset) (*Media, error) {
+	url := a.ImageOriginalURL
+	if url == "" {
+		url = a.ImageURL
+	}
+	if url == "" {
+		return nil, fmt.Errorf("Asset %s has no image", a.TokenID)
+	}
+	return d.Download(ctx, url)
+}
+
+func (d *Downloader) resolve(url string) string {
+	if !strings.HasPrefix(url, "ipfs://") {
+		return url
+	}
+	gateway := d.Gateway
+	if gateway == "" {
+		gateway = DefaultIPFSGateway
+	}
+	path := strings.TrimPrefix(strings.TrimPrefix(url, "ipfs://"), "ipfs/")
+	return strings.TrimSuffix(gateway, "/") + "/" + path
+}
@@ -0,0 +1,112 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+// gradient draws an image of size w x h whose brightness follows f.
+func gradient(w, h int, f func(x, y float64) float64) image.Image {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8(255 * f(float64(x)/float64(w), float64(y)/float64(h)))})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDHash(t *testing.T) {
+	wave := func(x, y float64) float64 {
+		if int(x*6+y*3)%2 == 0 {
+			return 0.9 - 0.5*x
+		}
+		return 0.2 + 0.3*y
+	}
+	other := func(x, y float64) float64 { return x * y }
+
+	original := DHash(gradient(180, 160, wave))
+	resized := DHash(gradient(90, 80, wave))
+	different := DHash(gradient(180, 160, other))
+
+	assert.LessOrEqual(t, Distance(original, resized), 4)
+	assert.Greater(t, Distance(original, different), 16)
+
+	h, err := HashImage(encodePNG(t, gradient(180, 160, wave)))
+	assert.Nil(t, err)
+	assert.Equal(t, original, h)
+
+	parsed, err := ParseHash(h.String())
+	assert.Nil(t, err)
+	assert.Equal(t, h, parsed)
+
+	_, err = HashImage([]byte("not an image"))
+	assert.NotNil(t, err)
+}
+
+func TestIndex(t *testing.T) {
+	ix := NewIndex()
+	ix.Add("a/1", 0xff00)
+	ix.Add("b/7", 0xff01)
+	ix.Add("b/8", 0xff03)
+	ix.Add("c/1", 0x00ff)
+
+	assert.Equal(t, []Match{{ID: "a/1", Hash: 0xff00, Distance: 0}, {ID: "b/7", Hash: 0xff01, Distance: 1}}, ix.Similar(0xff00, 1))
+	// b/8 joins through b/7
+	assert.Equal(t, [][]string{{"a/1", "b/7", "b/8"}}, ix.Duplicates(1))
+	assert.Empty(t, NewIndex().Duplicates(3))
+	assert.Equal(t, 4, ix.Len())
+}
+
+func TestDownloader(t *testing.T) {
+	img := encodePNG(t, gradient(20, 20, func(x, y float64) float64 { return x }))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/QmHash/1.png", "/image.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(img)
+		case "/big":
+			w.Write(make([]byte, 100))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	d := &Downloader{Client: srv.Client(), Gateway: srv.URL + "/ipfs/", Hash: true}
+	m, err := d.Download(context.Background(), "ipfs://QmHash/1.png")
+	assert.Nil(t, err)
+	assert.Equal(t, srv.URL+"/ipfs/QmHash/1.png", m.URL)
+	assert.Equal(t, "image/png", m.ContentType)
+	assert.NotNil(t, m.Hash)
+
+	m, err = d.DownloadAsset(context.Background(), &opensea.Asset{ImageURL: srv.URL + "/image.png"})
+	assert.Nil(t, err)
+	assert.Equal(t, img, m.Data)
+
+	_, err = d.DownloadAsset(context.Background(), &opensea.Asset{TokenID: "1"})
+	assert.NotNil(t, err)
+	_, err = d.Download(context.Background(), srv.URL+"/missing")
+	assert.NotNil(t, err)
+
+	d.MaxSize = 10
+	_, err = d.Download(context.Background(), srv.URL+"/big")
+	assert.NotNil(t, err)
+}
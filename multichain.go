@@ -0,0 +1,111 @@
+package opensea
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ChainConfig is the per-chain configuration of a MultiChainClient. Zero
+// values fall back to the defaults of the chain.
+type ChainConfig struct {
+	API string
+	// ProtocolAddress is the Seaport deployment the orders of the chain are
+	// signed for, posted to and looked up on.
+	ProtocolAddress Address
+	// RateLimit gives the chain its own budget. When zero the chain shares
+	// the budget of the MultiChainClient.
+	RateLimit RateLimit
+}
+
+// MultiChainClient routes calls to per-chain clients that share an API key
+// and, unless configured otherwise, a rate budget.
+type MultiChainClient struct {
+	clients map[Chain]*Opensea
+	configs map[Chain]ChainConfig
+}
+
+// NewMultiChainClient builds a client for each configured chain. The options
+// are applied to every per-chain client; a WithRateLimit option sets the
+// shared budget.
+func NewMultiChainClient(apiKey string, configs map[Chain]ChainConfig, opts ...Option) (*MultiChainClient, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("No chain configured")
+	}
+
+	// the options only run on shared to find the budget, but they may touch
+	// any field, such as the transport
+	shared := &Opensea{APIKey: apiKey, httpClient: defaultHttpClient()}
+	for _, opt := range opts {
+		opt(shared)
+	}
+	// anonymous budgets are per caller, so every chain shares one
+	if apiKey == "" && shared.limiter == nil {
+		shared.limiter = NewRateLimiter(PublicRateLimit)
+	}
+
+	m := &MultiChainClient{
+		clients: map[Chain]*Opensea{},
+		configs: map[Chain]ChainConfig{},
+	}
+	for chain, cfg := range configs {
+		if !chain.IsValid() {
+			return nil, fmt.Errorf("Invalid chain: %s", chain)
+		}
+		if cfg.API == "" {
+			cfg.API = mainnetAPI
+			if chain.IsTestnet() {
+				cfg.API = testnetsAPI
+			}
+		}
+		if cfg.ProtocolAddress == "" {
+			cfg.ProtocolAddress = DefaultProtocol.Address()
+		}
+
+		chainOpts := append([]Option{}, opts...)
+		chainOpts = append(chainOpts, WithChain(chain), WithRateLimiter(shared.limiter), WithProtocolAddress(cfg.ProtocolAddress))
+		if !cfg.RateLimit.IsZero() {
+			chainOpts = append(chainOpts, WithRateLimit(cfg.RateLimit))
+		}
+
+		o := &Opensea{
+			API:        cfg.API,
+			APIKey:     apiKey,
+			httpClient: defaultHttpClient(),
+		}
+		o, err := o.apply(chainOpts)
+		if err != nil {
+			return nil, err
+		}
+		m.clients[chain] = o
+		m.configs[chain] = cfg
+	}
+	return m, nil
+}
+
+// Client returns the client serving the chain.
+func (m *MultiChainClient) Client(chain Chain) (*Opensea, error) {
+	o, ok := m.clients[chain]
+	if !ok {
+		return nil, fmt.Errorf("Chain not configured: %s", chain)
+	}
+	return o, nil
+}
+
+// Config returns the resolved configuration of the chain.
+func (m *MultiChainClient) Config(chain Chain) (ChainConfig, error) {
+	cfg, ok := m.configs[chain]
+	if !ok {
+		return ChainConfig{}, fmt.Errorf("Chain not configured: %s", chain)
+	}
+	return cfg, nil
+}
+
+// Chains returns the configured chains in lexical order.
+func (m *MultiChainClient) Chains() []Chain {
+	chains := make([]Chain, 0, len(m.clients))
+	for chain := range m.clients {
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+	return chains
+}
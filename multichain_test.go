@@ -0,0 +1,101 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiChainClient(t *testing.T) {
+	m, err := NewMultiChainClient("key", map[Chain]ChainConfig{
+		ChainEthereum: {},
+		ChainPolygon:  {RateLimit: RateLimit{RequestsPerSecond: 1}},
+		ChainSepolia:  {},
+	}, WithRateLimit(RateLimit{RequestsPerSecond: 4}))
+	assert.Nil(t, err)
+	assert.Equal(t, []Chain{ChainEthereum, ChainPolygon, ChainSepolia}, m.Chains())
+
+	eth, err := m.Client(ChainEthereum)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainEthereum, eth.Chain)
+	assert.Equal(t, mainnetAPI, eth.API)
+
+	sepolia, err := m.Client(ChainSepolia)
+	assert.Nil(t, err)
+	assert.Equal(t, testnetsAPI, sepolia.API)
+	assert.Same(t, eth.limiter, sepolia.limiter)
+
+	polygon, err := m.Client(ChainPolygon)
+	assert.Nil(t, err)
+	assert.NotSame(t, eth.limiter, polygon.limiter)
+
+	cfg, err := m.Config(ChainPolygon)
+	assert.Nil(t, err)
+	assert.Equal(t, mainnetAPI, cfg.API)
+
+	_, err = m.Client(ChainBase)
+	assert.NotNil(t, err)
+}
+
+func TestMultiChainClientTransportOptions(t *testing.T) {
+	m, err := NewMultiChainClient("key", map[Chain]ChainConfig{ChainEthereum: {}, ChainPolygon: {}},
+		WithTimeout(5*time.Second),
+		WithMiddleware(func(next http.RoundTripper) http.RoundTripper { return next }),
+		WithFailureDumps(t.TempDir()))
+	assert.Nil(t, err)
+	o, err := m.Client(ChainPolygon)
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Second, o.httpClient.Timeout)
+}
+
+func TestMultiChainClientProtocolAddress(t *testing.T) {
+	const deployment Address = "0x2222222222222222222222222222222222222222"
+	var paths []string
+	var posted map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Method == "POST" {
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&posted))
+		}
+		w.Write([]byte(`{"order":{"order_hash":"0xabc"}}`))
+	}))
+	defer srv.Close()
+
+	m, err := NewMultiChainClient("key", map[Chain]ChainConfig{
+		ChainEthereum: {API: srv.URL},
+		ChainPolygon:  {API: srv.URL, ProtocolAddress: deployment},
+	})
+	assert.Nil(t, err)
+	cfg, err := m.Config(ChainEthereum)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultProtocol.Address(), cfg.ProtocolAddress)
+
+	ctx := context.Background()
+	p := testOrderParameters()
+	signer := &testSigner{address: p.Offerer}
+	eth, _ := m.Client(ChainEthereum)
+	order, err := eth.SignOrder(ctx, ChainEthereum, "", p, signer)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultProtocol.Address(), order.ProtocolAddress)
+
+	polygon, _ := m.Client(ChainPolygon)
+	order, err = polygon.SignOrder(ctx, ChainPolygon, "", p, signer)
+	assert.Nil(t, err)
+	assert.Equal(t, deployment, order.ProtocolAddress)
+	canonical, _ := p.Digest(ChainPolygon, "")
+	assert.NotEqual(t, canonical, signer.digests[1])
+
+	order.ProtocolAddress = ""
+	_, err = polygon.CreateListingWithContext(ctx, ChainPolygon, order)
+	assert.Nil(t, err)
+	assert.Equal(t, deployment.String(), posted["protocol_address"])
+
+	_, err = polygon.GetOrderByHashWithContext(ctx, ChainPolygon, "", "0xabc")
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/v2/orders/chain/"+ChainPolygon.String()+"/protocol/"+deployment.String()+"/0xabc", paths[len(paths)-1])
+}
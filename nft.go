@@ -0,0 +1,252 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// NFT is an item of the v2 NFTs endpoints, which replace the v1 assets
+// endpoints. Asset converts it to the v1 model.
+type NFT struct {
+	Identifier    string `json:"identifier" bson:"identifier"`
+	Collection    string `json:"collection" bson:"collection"`
+	Contract      string `json:"contract" bson:"contract"`
+	TokenStandard string `json:"token_standard" bson:"token_standard"`
+	Name          string `json:"name" bson:"name"`
+	Description   string `json:"description" bson:"description"`
+	ImageURL      string `json:"image_url" bson:"image_url"`
+	AnimationURL  string `json:"animation_url" bson:"animation_url"`
+	MetadataURL   string `json:"metadata_url" bson:"metadata_url"`
+	OpenseaURL    string `json:"opensea_url" bson:"opensea_url"`
+	UpdatedAt     string `json:"updated_at" bson:"updated_at"`
+	IsDisabled    bool   `json:"is_disabled" bson:"is_disabled"`
+	IsNSFW        bool   `json:"is_nsfw" bson:"is_nsfw"`
+	// Owners and Traits are only set by GetNFT, and Rarity by GetNFT or with
+	// GetNFTsParams.IncludeRarity.
+	Owners []NFTOwner `json:"owners,omitempty" bson:"owners,omitempty"`
+	Traits []Trait    `json:"traits,omitempty" bson:"traits,omitempty"`
+	Rarity *NFTRarity `json:"rarity,omitempty" bson:"rarity,omitempty"`
+}
+
+// NFTRarity is the rank of an NFT in its collection by a rarity strategy.
+type NFTRarity struct {
+	StrategyID      string  `json:"strategy_id" bson:"strategy_id"`
+	StrategyVersion string  `json:"strategy_version" bson:"strategy_version"`
+	Rank            int64   `json:"rank" bson:"rank"`
+	Score           float64 `json:"score,omitempty" bson:"score,omitempty"`
+	CalculatedAt    string  `json:"calculated_at,omitempty" bson:"calculated_at,omitempty"`
+}
+
+type NFTOwner struct {
+	Address  Address `json:"address" bson:"address"`
+	Quantity int64   `json:"quantity" bson:"quantity"`
+}
+
+// Asset converts n to the v1 model. Fields without a v2 counterpart are
+// left empty.
+func (n NFT) Asset() *Asset {
+	a := &Asset{
+		TokenID:       n.Identifier,
+		Name:          n.Name,
+		Description:   n.Description,
+		ImageURL:      n.ImageURL,
+		AnimationURL:  n.AnimationURL,
+		Permalink:     n.OpenseaURL,
+		AssetContract: &AssetContract{Address: Address(n.Contract)},
+		Collection:    &Collection{Slug: n.Collection},
+		Rarity:        n.Rarity,
+		APIVersion:    APIv2,
+	}
+	if n.Traits != nil {
+		a.Traits = n.Traits
+	}
+	if len(n.Owners) == 1 {
+		a.Owner = &Account{Address: n.Owners[0].Address}
+	}
+	return a
+}
+
+// NFT converts a to the v2 model. Fields without a v2 counterpart are
+// dropped.
+func (a Asset) NFT() *NFT {
+	n := &NFT{
+		Identifier:   a.TokenID,
+		Name:         a.Name,
+		Description:  a.Description,
+		ImageURL:     a.ImageURL,
+		AnimationURL: a.AnimationURL,
+		OpenseaURL:   a.Permalink,
+		Rarity:       a.Rarity,
+	}
+	if a.AssetContract != nil {
+		n.Contract = a.AssetContract.Address.String()
+		n.TokenStandard = strings.ToLower(a.AssetContract.SchemaName)
+	}
+	if a.Collection != nil {
+		n.Collection = a.Collection.Slug
+	}
+	if a.Owner != nil && a.Owner.Address != "" {
+		n.Owners = []NFTOwner{{Address: a.Owner.Address, Quantity: 1}}
+	}
+	switch traits := a.Traits.(type) {
+	case []Trait:
+		n.Traits = traits
+	case nil:
+	default:
+		// traits decoded from JSON are generic values
+		if b, err := json.Marshal(traits); err == nil {
+			json.Unmarshal(b, &n.Traits)
+		}
+	}
+	return n
+}
+
+// GetNFT returns an NFT with its owners, traits and rarity. It replaces
+// GetSingleAsset, and returns ErrNotFound for unknown tokens.
+func (o Opensea) GetNFT(chain Chain, contractAddress Address, identifier string) (*NFT, error) {
+	ctx := context.TODO()
+	return o.GetNFTWithContext(ctx, chain, contractAddress, identifier)
+}
+
+func (o Opensea) GetNFTWithContext(ctx context.Context, chain Chain, contractAddress Address, identifier string) (*NFT, error) {
+	chain, err := o.chainFor(chain, CapabilityNFTs)
+	if err != nil {
+		return nil, err
+	}
+	if contractAddress == "" || identifier == "" {
+		return nil, fmt.Errorf("Empty contract address or identifier")
+	}
+	path := fmt.Sprintf("/api/v2/chain/%s/contract/%s/nfts/%s", chain, url.PathEscape(contractAddress.String()), url.PathEscape(identifier))
+	b, err := o.GetPath(contextWithChain(ctx, chain), path)
+	if err != nil {
+		return nil, err
+	}
+	ret := &struct {
+		NFT NFT `json:"nft"`
+	}{}
+	if err := json.Unmarshal(b, ret); err != nil {
+		return nil, err
+	}
+	return &ret.NFT, nil
+}
+
+type GetNFTsParams struct {
+	// Collection limits the NFTs to those of a collection slug.
+	Collection string
+	Cursor     string
+	// Limit defaults to 200, the maximum.
+	Limit int
+	// IncludeRarity asks for the rarity of each NFT.
+	IncludeRarity bool
+}
+
+func (p GetNFTsParams) Encode() string {
+	values := url.Values{}
+	if p.Collection != "" {
+		values.Set("collection", p.Collection)
+	}
+	if p.Cursor != "" {
+		values.Set("next", p.Cursor)
+	}
+	limit := p.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+	values.Set("limit", fmt.Sprintf("%d", limit))
+	if p.IncludeRarity {
+		values.Set("include_rarity", "true")
+	}
+	return values.Encode()
+}
+
+type NFTsResponse struct {
+	NFTs []NFT  `json:"nfts" bson:"nfts"`
+	Next string `json:"next" bson:"next"`
+}
+
+// GetNFTsByAccount returns a page of the NFTs an address, or an ENS name on
+// EVM chains, owns on chain. It replaces GetAssets filtered by owner.
+func (o Opensea) GetNFTsByAccount(chain Chain, account Address, params GetNFTsParams) (*NFTsResponse, error) {
+	ctx := context.TODO()
+	return o.GetNFTsByAccountWithContext(ctx, chain, account, params)
+}
+
+func (o Opensea) GetNFTsByAccountWithContext(ctx context.Context, chain Chain, account Address, params GetNFTsParams) (*NFTsResponse, error) {
+	chain, err := o.chainFor(chain, CapabilityNFTs)
+	if err != nil {
+		return nil, err
+	}
+	address := account
+	if chain.IsEVM() {
+		if address, err = o.ResolveAddressWithContext(ctx, account.String()); err != nil {
+			return nil, err
+		}
+	} else if address == "" {
+		return nil, fmt.Errorf("Empty account address")
+	}
+	return o.nftsPage(ctx, chain, fmt.Sprintf("/api/v2/chain/%s/account/%s/nfts", chain, url.PathEscape(address.String())), params)
+}
+
+// GetNFTsByContract returns a page of the NFTs of a contract on chain. It
+// replaces GetAssets filtered by contract; the Collection filter of params
+// is not supported.
+func (o Opensea) GetNFTsByContract(chain Chain, contractAddress Address, params GetNFTsParams) (*NFTsResponse, error) {
+	ctx := context.TODO()
+	return o.GetNFTsByContractWithContext(ctx, chain, contractAddress, params)
+}
+
+func (o Opensea) GetNFTsByContractWithContext(ctx context.Context, chain Chain, contractAddress Address, params GetNFTsParams) (*NFTsResponse, error) {
+	chain, err := o.chainFor(chain, CapabilityNFTs)
+	if err != nil {
+		return nil, err
+	}
+	if params.Collection != "" {
+		return nil, fmt.Errorf("NFTs of a contract cannot be filtered by collection")
+	}
+	if contractAddress == "" {
+		return nil, fmt.Errorf("Empty contract address")
+	}
+	if chain.IsEVM() {
+		if contractAddress, err = ParseAddress(contractAddress.String()); err != nil {
+			return nil, err
+		}
+	}
+	return o.nftsPage(ctx, chain, fmt.Sprintf("/api/v2/chain/%s/contract/%s/nfts", chain, url.PathEscape(contractAddress.String())), params)
+}
+
+// GetNFTsByCollection returns a page of the NFTs of a collection, on any
+// chain, by slug. The Collection filter of params is not supported.
+func (o Opensea) GetNFTsByCollection(slug string, params GetNFTsParams) (*NFTsResponse, error) {
+	ctx := context.TODO()
+	return o.GetNFTsByCollectionWithContext(ctx, slug, params)
+}
+
+func (o Opensea) GetNFTsByCollectionWithContext(ctx context.Context, slug string, params GetNFTsParams) (*NFTsResponse, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("Empty collection slug")
+	}
+	if params.Collection != "" && params.Collection != slug {
+		return nil, fmt.Errorf("NFTs of collection %s cannot be filtered by collection %s", slug, params.Collection)
+	}
+	params.Collection = ""
+	return o.nftsPage(ctx, ChainNone, "/api/v2/collection/"+url.PathEscape(slug)+"/nfts", params)
+}
+
+// nftsPage reads a page of a v2 NFTs listing path.
+func (o Opensea) nftsPage(ctx context.Context, chain Chain, path string, params GetNFTsParams) (*NFTsResponse, error) {
+	b, err := o.GetPath(contextWithChain(ctx, chain), path+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	resp := &NFTsResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	if resp.NFTs == nil {
+		resp.NFTs = []NFT{}
+	}
+	return resp, nil
+}
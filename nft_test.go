@@ -0,0 +1,117 @@
+package opensea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNFTsByAccount(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/chain/base/account/"+testBidder.String()+"/nfts", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "doodles-official", q.Get("collection"))
+		assert.Equal(t, "200", q.Get("limit"))
+		assert.Equal(t, "p1", q.Get("next"))
+		w.Write([]byte(`{"nfts":[{"identifier":"42","collection":"doodles-official","contract":"` + contract + `","token_standard":"erc721","name":"Doodle #42","is_nsfw":false}],"next":"p2"}`))
+	})
+
+	resp, err := o.GetNFTsByAccount(ChainBase, testBidder, GetNFTsParams{Collection: "doodles-official", Cursor: "p1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "p2", resp.Next)
+	assert.Len(t, resp.NFTs, 1)
+	assert.Equal(t, "erc721", resp.NFTs[0].TokenStandard)
+
+	a := resp.NFTs[0].Asset()
+	assert.Equal(t, "42", a.TokenID)
+	assert.Equal(t, "doodles-official", a.Collection.Slug)
+	assert.Equal(t, Address(contract), a.AssetContract.Address)
+
+	_, err = o.GetNFTsByAccount(ChainBase, "not an address", GetNFTsParams{})
+	assert.NotNil(t, err)
+}
+
+func TestGetNFTsByContract(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/chain/ethereum/contract/"+contract+"/nfts", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		w.Write([]byte(`{"nfts":[{"identifier":"1"},{"identifier":"2"}]}`))
+	})
+
+	resp, err := o.GetNFTsByContract(ChainEthereum, Address(contract), GetNFTsParams{Limit: 50})
+	assert.Nil(t, err)
+	assert.Len(t, resp.NFTs, 2)
+	assert.Equal(t, "", resp.Next)
+
+	_, err = o.GetNFTsByContract(ChainEthereum, Address(contract), GetNFTsParams{Collection: "doodles-official"})
+	assert.NotNil(t, err)
+	_, err = o.GetNFTsByContract(ChainEthereum, "", GetNFTsParams{})
+	assert.NotNil(t, err)
+}
+
+func TestGetNFTsByCollection(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/collection/doodles-official/nfts", r.URL.Path)
+		assert.Equal(t, "", r.URL.Query().Get("collection"))
+		assert.Equal(t, "p1", r.URL.Query().Get("next"))
+		w.Write([]byte(`{"nfts":[{"identifier":"1","collection":"doodles-official"}],"next":"p2"}`))
+	})
+
+	resp, err := o.GetNFTsByCollection("doodles-official", GetNFTsParams{Collection: "doodles-official", Cursor: "p1"})
+	assert.Nil(t, err)
+	assert.Len(t, resp.NFTs, 1)
+	assert.Equal(t, "p2", resp.Next)
+
+	_, err = o.GetNFTsByCollection("doodles-official", GetNFTsParams{Collection: "other"})
+	assert.NotNil(t, err)
+	_, err = o.GetNFTsByCollection("", GetNFTsParams{})
+	assert.NotNil(t, err)
+}
+
+func TestGetNFT(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/chain/ethereum/contract/"+contract+"/nfts/42" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"nft":{"identifier":"42","collection":"doodles-official","contract":"` + contract + `",
+			"owners":[{"address":"` + testBidder.String() + `","quantity":1}],
+			"traits":[{"trait_type":"face","value":"happy"}],
+			"rarity":{"strategy_id":"openrarity","strategy_version":"1.0","rank":17}}}`))
+	})
+
+	n, err := o.GetNFT(ChainEthereum, Address(contract), "42")
+	assert.Nil(t, err)
+	assert.Equal(t, "42", n.Identifier)
+	assert.Equal(t, int64(17), n.Rarity.Rank)
+	assert.Len(t, n.Traits, 1)
+	assert.Equal(t, testBidder, n.Asset().Owner.Address)
+
+	_, err = o.GetNFT(ChainEthereum, Address(contract), "43")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestIncludeRarity(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("include_rarity"))
+		switch r.URL.Path {
+		case "/api/v2/collection/doodles-official/nfts":
+			w.Write([]byte(`{"nfts":[{"identifier":"1","rarity":{"strategy_id":"openrarity","rank":3,"calculated_at":"2024-01-02T03:04:05"}}]}`))
+		case "/api/v1/assets":
+			w.Write([]byte(`{"assets":[{"token_id":"1","rarity_data":{"strategy_id":"openrarity","rank":5,"calculated_at":"2024-01-02T03:04:05"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	resp, err := o.GetNFTsByCollection("doodles-official", GetNFTsParams{IncludeRarity: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), resp.NFTs[0].Rarity.Rank)
+	assert.Equal(t, "2024-01-02T03:04:05", resp.NFTs[0].Rarity.CalculatedAt)
+	assert.Equal(t, "openrarity", resp.NFTs[0].Asset().Rarity.StrategyID)
+
+	assets, err := o.GetAssets(GetAssetsParams{IncludeRarity: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), assets.Assets[0].Rarity.Rank)
+}
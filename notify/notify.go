@@ -0,0 +1,399 @@
+// Package notify turns events and orders into human-readable notifications
+// and renders them as plain text, Discord embeds or Slack Block Kit
+// messages.
+//
+// Notification only has exported fields, so custom layouts can be written
+// with text/template and TemplateFuncs instead of the built-in renderers.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"text/template"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+type Field struct {
+	Name   string
+	Value  string
+	URL    string
+	Inline bool
+}
+
+type Notification struct {
+	Title       string
+	URL         string
+	Description string
+	ImageURL    string
+	Fields      []Field
+	Timestamp   time.Time
+	EventType   opensea.EventType
+}
+
+var nativeSymbols = map[opensea.Chain]string{
+	opensea.ChainPolygon:       "MATIC",
+	opensea.ChainAmoy:          "MATIC",
+	opensea.ChainAvalanche:     "AVAX",
+	opensea.ChainAvalancheFuji: "AVAX",
+	opensea.ChainKlaytn:        "KLAY",
+	opensea.ChainBaobab:        "KLAY",
+	opensea.ChainSolana:        "SOL",
+	opensea.ChainSolanaDevnet:  "SOL",
+}
+
+// nativeDecimals lists the chains whose native currency does not have 18
+// decimals.
+var nativeDecimals = map[opensea.Chain]int64{
+	opensea.ChainSolana:       9,
+	opensea.ChainSolanaDevnet: 9,
+}
+
+// NativeDecimals returns the decimals of the native currency of a chain.
+func NativeDecimals(chain opensea.Chain) int64 {
+	if d, ok := nativeDecimals[chain]; ok {
+		return d
+	}
+	return 18
+}
+
+// NativeSymbol returns the symbol of the native currency of a chain.
+func NativeSymbol(chain opensea.Chain) string {
+	if s, ok := nativeSymbols[chain]; ok {
+		return s
+	}
+	return "ETH"
+}
+
+// FormatPrice formats an amount given in the smallest unit of a token with
+// the token decimals, e.g. "1.25 ETH". At most 4 fraction digits are
+// shown, more when the amount would otherwise round to zero.
+func FormatPrice(amount opensea.Number, decimals int64, symbol string) string {
+	v := amount.Big()
+	if v == nil {
+		return ""
+	}
+	s := formatDecimal(v, decimals)
+	if symbol == "" {
+		return s
+	}
+	return s + " " + symbol
+}
+
+func formatDecimal(v *big.Int, decimals int64) string {
+	neg := v.Sign() < 0
+	v = new(big.Int).Abs(v)
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil)
+	whole, frac := new(big.Int).QuoRem(v, unit, new(big.Int))
+
+	digits := ""
+	if decimals > 0 {
+		digits = fmt.Sprintf("%0*s", decimals, frac.String())
+		keep := 4
+		if whole.Sign() == 0 {
+			// keep the first significant digits of small amounts
+			if i := strings.IndexFunc(digits, func(r rune) bool { return r != '0' }); i >= keep {
+				keep = i + 2
+			}
+		}
+		if keep < len(digits) {
+			digits = digits[:keep]
+		}
+		digits = strings.TrimRight(digits, "0")
+	}
+
+	s := whole.String()
+	if digits != "" {
+		s += "." + digits
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// FormatUSD formats a USD amount with cents and thousands separators, e.g.
+// "$3,750.00".
+func FormatUSD(v float64) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	s := fmt.Sprintf("%.2f", v)
+	whole, cents := s[:len(s)-3], s[len(s)-3:]
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	return sign + "$" + whole + cents
+}
+
+// FormatPriceUSD is FormatPrice followed by the USD value of the amount
+// given by oracle, e.g. "1.25 ETH ($3,750.00)". Token is the payment token,
+// empty for the native currency. The USD value is left out when the oracle
+// fails.
+func FormatPriceUSD(ctx context.Context, oracle opensea.PriceOracle, chain opensea.Chain, token opensea.Address, amount opensea.Number, decimals int64, symbol string) string {
+	s := FormatPrice(amount, decimals, symbol)
+	v := amount.Big()
+	if s == "" || v == nil {
+		return s
+	}
+	units, _ := new(big.Float).Quo(new(big.Float).SetInt(v), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil))).Float64()
+	usd, err := opensea.ToUSD(ctx, oracle, chain, token, units)
+	if err != nil {
+		return s
+	}
+	return s + " (" + FormatUSD(usd) + ")"
+}
+
+// ShortAddress abbreviates an address as 0x1234…abcd.
+func ShortAddress(a opensea.Address) string {
+	s := a.String()
+	if len(s) <= 12 {
+		return s
+	}
+	return s[:6] + "…" + s[len(s)-4:]
+}
+
+// TemplateFuncs are helpers for text/template layouts of notifications.
+var TemplateFuncs = template.FuncMap{
+	"price": func(amount string, decimals int64, symbol string) string {
+		return FormatPrice(opensea.Number(amount), decimals, symbol)
+	},
+	"usd": FormatUSD,
+	"short": func(a string) string {
+		return ShortAddress(opensea.Address(a))
+	},
+}
+
+func assetName(a *opensea.Asset, slug string) string {
+	if a == nil {
+		return slug
+	}
+	if a.Name != "" {
+		return a.Name
+	}
+	if a.Collection != nil && a.Collection.Name != "" {
+		slug = a.Collection.Name
+	}
+	return fmt.Sprintf("%s #%s", slug, a.TokenID)
+}
+
+func accountField(chain opensea.Chain, name string, a *opensea.Account) (Field, bool) {
+	if a == nil || a.Address == "" {
+		return Field{}, false
+	}
+	value := a.User.Username
+	if value == "" {
+		value = ShortAddress(a.Address)
+	}
+	return Field{Name: name, Value: value, URL: opensea.AccountPermalink(chain, a.Address), Inline: true}, true
+}
+
+// TokenUnit returns the symbol and decimals of a payment token known
+// without asking the API: the native currency for the null address and its
+// wrapped token. Other tokens are shown in their smallest unit, after their
+// address.
+func TokenUnit(chain opensea.Chain, token opensea.Address) (string, int64) {
+	if token == "" || token.IsNullAddress() {
+		return NativeSymbol(chain), NativeDecimals(chain)
+	}
+	if wrapped, err := opensea.WrappedNativeToken(chain); err == nil && strings.EqualFold(wrapped.String(), token.String()) {
+		return "W" + NativeSymbol(chain), NativeDecimals(chain)
+	}
+	return ShortAddress(token), 0
+}
+
+// FromEvent describes an event of the given chain.
+func FromEvent(chain opensea.Chain, e *opensea.Event) *Notification {
+	name := assetName(e.Asset, e.CollectionSlug)
+
+	symbol, decimals := NativeSymbol(chain), NativeDecimals(chain)
+	if e.PaymentToken != nil {
+		symbol, decimals = e.PaymentToken.Symbol, e.PaymentToken.Decimals
+	}
+
+	n := &Notification{
+		Timestamp: e.EventTimestamp.Time(),
+		EventType: e.EventType,
+	}
+	switch e.EventType {
+	case opensea.EventTypeSuccessful:
+		n.Title = fmt.Sprintf("%s sold for %s", name, FormatPrice(e.TotalPrice, decimals, symbol))
+	case opensea.EventTypeCreated:
+		n.Title = fmt.Sprintf("%s listed for %s", name, FormatPrice(opensea.Number(e.StartingPrice), decimals, symbol))
+	case opensea.EventTypeBidEntered:
+		n.Title = fmt.Sprintf("New offer on %s: %s", name, FormatPrice(e.BidAmount, decimals, symbol))
+	case opensea.EventTypeBidWithdrawn:
+		n.Title = fmt.Sprintf("Offer on %s withdrawn", name)
+	case opensea.EventTypeCancelled:
+		n.Title = fmt.Sprintf("Listing of %s cancelled", name)
+	case opensea.EventTypeTransfer:
+		n.Title = fmt.Sprintf("%s transferred", name)
+	default:
+		n.Title = fmt.Sprintf("%s: %s", name, e.EventType)
+	}
+
+	if e.Asset != nil {
+		n.ImageURL = e.Asset.ImageURL
+		n.Description = e.Asset.Description
+		contract := e.ContractAddress
+		if e.Asset.AssetContract != nil {
+			contract = e.Asset.AssetContract.Address
+		}
+		n.URL = e.Asset.Permalink
+		if n.URL == "" && contract != "" {
+			n.URL = opensea.AssetPermalink(chain, contract, e.Asset.TokenID)
+		}
+	}
+	if n.URL == "" && e.CollectionSlug != "" {
+		n.URL = opensea.CollectionPermalink(e.CollectionSlug)
+	}
+
+	for _, f := range []struct {
+		name string
+		a    *opensea.Account
+	}{
+		{"Seller", e.Seller},
+		{"Buyer", e.WinnerAccount},
+		{"From", e.FromAccount},
+		{"To", e.ToAccount},
+	} {
+		if field, ok := accountField(chain, f.name, f.a); ok {
+			n.Fields = append(n.Fields, field)
+		}
+	}
+	if e.Transaction != nil && e.Transaction.TransactionHash != "" {
+		n.Fields = append(n.Fields, Field{
+			Name:  "Transaction",
+			Value: ShortAddress(opensea.Address(e.Transaction.TransactionHash)),
+			URL:   opensea.ExplorerTxURL(chain, e.Transaction.TransactionHash),
+		})
+	}
+	return n
+}
+
+// FromOrder describes a listing or an offer, priced with the decimals of
+// its payment token contract. Without it, see TokenUnit.
+func FromOrder(chain opensea.Chain, o *opensea.Order) *Notification {
+	name := assetName(&o.Asset, "")
+	symbol, decimals := TokenUnit(chain, o.PaymentToken)
+	if t := o.PaymentTokenContract; t != nil && t.Symbol != "" {
+		symbol, decimals = t.Symbol, t.Decimals
+	}
+	price := FormatPrice(o.CurrentPrice, decimals, symbol)
+
+	n := &Notification{
+		URL:         o.Asset.Permalink,
+		ImageURL:    o.Asset.ImageURL,
+		Description: o.Asset.Description,
+	}
+	if o.CreatedDate != nil {
+		n.Timestamp = o.CreatedDate.Time()
+	}
+	if o.Side == opensea.Sell {
+		n.Title = fmt.Sprintf("%s listed for %s", name, price)
+		n.EventType = opensea.EventTypeCreated
+	} else {
+		n.Title = fmt.Sprintf("New offer on %s: %s", name, price)
+		n.EventType = opensea.EventTypeBidEntered
+	}
+	if n.URL == "" && o.Asset.AssetContract != nil {
+		n.URL = opensea.AssetPermalink(chain, o.Asset.AssetContract.Address, o.Asset.TokenID)
+	}
+	if field, ok := accountField(chain, "Maker", &o.Maker); ok {
+		n.Fields = append(n.Fields, field)
+	}
+	if o.ExpirationTime > 0 {
+		n.Fields = append(n.Fields, Field{
+			Name:   "Expires",
+			Value:  time.Unix(o.ExpirationTime, 0).UTC().Format("2006-01-02 15:04 MST"),
+			Inline: true,
+		})
+	}
+	return n
+}
+
+// FromSeaportOrder describes a listing or an offer of the v2 order book.
+// Orders read with their price, such as criteria offers, are priced in its
+// currency and decimals; others in their payment token, see TokenUnit.
+func FromSeaportOrder(chain opensea.Chain, o *opensea.SeaportOrder) *Notification {
+	if o.Chain != "" {
+		chain = o.Chain
+	}
+	q := o.Quote()
+
+	price := o.Price.OrderPrice
+	if o.Price.Current != nil {
+		price = *o.Price.Current
+	}
+	var formatted string
+	if price.Value != "" {
+		formatted = FormatPrice(price.Value, price.Decimals, price.Currency)
+	} else {
+		symbol, decimals := TokenUnit(chain, seaportPaymentToken(o))
+		formatted = FormatPrice(o.CurrentPrice, decimals, symbol)
+	}
+
+	n := &Notification{Timestamp: o.StartTime()}
+	var name string
+	switch {
+	case q.Trait != nil:
+		name = fmt.Sprintf("%s (%s: %s)", q.Collection, q.Trait.Type, q.Trait.Value)
+		n.URL = opensea.CollectionPermalink(q.Collection)
+	case q.Collection != "":
+		name = q.Collection
+		n.URL = opensea.CollectionPermalink(q.Collection)
+	default:
+		name = fmt.Sprintf("%s #%s", ShortAddress(q.Contract), q.TokenID)
+		if q.Contract != "" {
+			n.URL = opensea.AssetPermalink(chain, q.Contract, q.TokenID)
+		}
+	}
+	if q.Side == opensea.QuoteAsk {
+		n.Title = fmt.Sprintf("%s listed for %s", name, formatted)
+		n.EventType = opensea.EventTypeCreated
+	} else {
+		n.Title = fmt.Sprintf("New offer on %s: %s", name, formatted)
+		n.EventType = opensea.EventTypeBidEntered
+	}
+
+	maker := o.Maker
+	if maker == nil && o.ProtocolData.Parameters.Offerer != "" {
+		maker = &opensea.Account{Address: o.ProtocolData.Parameters.Offerer}
+	}
+	if field, ok := accountField(chain, "Maker", maker); ok {
+		n.Fields = append(n.Fields, field)
+	}
+	if end := o.ExpiresAt(); !end.IsZero() {
+		n.Fields = append(n.Fields, Field{
+			Name:   "Expires",
+			Value:  end.UTC().Format("2006-01-02 15:04 MST"),
+			Inline: true,
+		})
+	}
+	return n
+}
+
+// seaportPaymentToken returns the currency an order is paid in: the first
+// native or ERC20 item of the consideration of a listing, or of the offer of
+// an offer.
+func seaportPaymentToken(o *opensea.SeaportOrder) opensea.Address {
+	params := o.ProtocolData.Parameters
+	if o.IsListing() {
+		for _, item := range params.Consideration {
+			if item.ItemType <= opensea.ItemERC20 {
+				return item.Token
+			}
+		}
+		return ""
+	}
+	for _, item := range params.Offer {
+		if item.ItemType <= opensea.ItemERC20 {
+			return item.Token
+		}
+	}
+	return ""
+}
@@ -0,0 +1,199 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"text/template"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPrice(t *testing.T) {
+	assert.Equal(t, "1.25 ETH", FormatPrice("1250000000000000000", 18, "ETH"))
+	assert.Equal(t, "2 ETH", FormatPrice("2000000000000000000", 18, "ETH"))
+	assert.Equal(t, "0.1234 ETH", FormatPrice("123456789000000000", 18, "ETH"))
+	assert.Equal(t, "0.000012 ETH", FormatPrice("12345000000000", 18, "ETH"))
+	assert.Equal(t, "1.5 USDC", FormatPrice("1500000", 6, "USDC"))
+	assert.Equal(t, "3", FormatPrice("3", 0, ""))
+	assert.Equal(t, "", FormatPrice("", 18, "ETH"))
+}
+
+type fixedOracle map[opensea.Address]float64
+
+func (o fixedOracle) USDPrice(ctx context.Context, chain opensea.Chain, token opensea.Address) (float64, error) {
+	if p, ok := o[token]; ok {
+		return p, nil
+	}
+	return 0, opensea.ErrNotFound
+}
+
+func TestFormatPriceUSD(t *testing.T) {
+	assert.Equal(t, "$3,750.00", FormatUSD(3750))
+	assert.Equal(t, "$1,234,567.89", FormatUSD(1234567.891))
+	assert.Equal(t, "-$0.50", FormatUSD(-0.5))
+
+	oracle := fixedOracle{"": 3000}
+	ctx := context.Background()
+	assert.Equal(t, "1.25 ETH ($3,750.00)", FormatPriceUSD(ctx, oracle, opensea.ChainEthereum, "", "1250000000000000000", 18, "ETH"))
+	assert.Equal(t, "1.5 USDC", FormatPriceUSD(ctx, oracle, opensea.ChainEthereum, "0xa0b8", "1500000", 6, "USDC"))
+}
+
+func TestShortAddress(t *testing.T) {
+	assert.Equal(t, "0x8a90…992e", ShortAddress("0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"))
+	assert.Equal(t, "0x0", ShortAddress("0x0"))
+}
+
+func testSale() *opensea.Event {
+	return &opensea.Event{
+		EventType:      opensea.EventTypeSuccessful,
+		EventTimestamp: opensea.TimeNano(time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)),
+		CollectionSlug: "doodles-official",
+		Asset: &opensea.Asset{
+			TokenID:       "42",
+			ImageURL:      "https://example.com/42.png",
+			AssetContract: &opensea.AssetContract{Address: "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"},
+			Collection:    &opensea.Collection{Name: "Doodles"},
+		},
+		TotalPrice:    "1250000000000000000",
+		PaymentToken:  &opensea.PaymentToken{Symbol: "ETH", Decimals: 18},
+		Seller:        &opensea.Account{Address: "0x0000000000000000000000000000000000000001", User: opensea.User{Username: "alice"}},
+		WinnerAccount: &opensea.Account{Address: "0x0000000000000000000000000000000000000002"},
+		Transaction:   &opensea.Transaction{TransactionHash: "0xabcdef0123456789"},
+	}
+}
+
+func TestFromEvent(t *testing.T) {
+	n := FromEvent(opensea.ChainEthereum, testSale())
+	assert.Equal(t, "Doodles #42 sold for 1.25 ETH", n.Title)
+	assert.Equal(t, "https://opensea.io/assets/ethereum/0x8a90cab2b38dba80c64b7734e58ee1db38b8992e/42", n.URL)
+	assert.Len(t, n.Fields, 3)
+	assert.Equal(t, "alice", n.Fields[0].Value)
+	assert.Equal(t, "0x0000…0002", n.Fields[1].Value)
+	assert.Equal(t, "https://etherscan.io/tx/0xabcdef0123456789", n.Fields[2].URL)
+
+	assert.Equal(t, "Doodles #42 sold for 1.25 ETH\n"+
+		"https://opensea.io/assets/ethereum/0x8a90cab2b38dba80c64b7734e58ee1db38b8992e/42\n"+
+		"Seller: alice\nBuyer: 0x0000…0002\nTransaction: 0xabcd…6789", n.Text())
+
+	offer := &opensea.Event{EventType: opensea.EventTypeBidEntered, CollectionSlug: "doodles-official", BidAmount: "500000000000000000"}
+	n = FromEvent(opensea.ChainPolygon, offer)
+	assert.Equal(t, "New offer on doodles-official: 0.5 MATIC", n.Title)
+	assert.Equal(t, "https://opensea.io/collection/doodles-official", n.URL)
+
+	offer.BidAmount = "1500000000"
+	n = FromEvent(opensea.ChainSolana, offer)
+	assert.Equal(t, "New offer on doodles-official: 1.5 SOL", n.Title)
+}
+
+func TestFromOrder(t *testing.T) {
+	o := &opensea.Order{
+		Side:           opensea.Sell,
+		CurrentPrice:   "3000000000000000000",
+		PaymentToken:   opensea.NullAddress,
+		Asset:          opensea.Asset{TokenID: "1", Name: "Doodle #1", Permalink: "https://opensea.io/assets/ethereum/0x1/1"},
+		Maker:          opensea.Account{Address: "0x0000000000000000000000000000000000000001"},
+		ExpirationTime: 1646136000,
+	}
+	n := FromOrder(opensea.ChainEthereum, o)
+	assert.Equal(t, "Doodle #1 listed for 3 ETH", n.Title)
+	assert.Equal(t, "2022-03-01 12:00 UTC", n.Fields[1].Value)
+
+	o.CurrentPrice = "2000000000"
+	n = FromOrder(opensea.ChainSolana, o)
+	assert.Equal(t, "Doodle #1 listed for 2 SOL", n.Title)
+
+	o.CurrentPrice = "2500000"
+	o.PaymentToken = "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	n = FromOrder(opensea.ChainEthereum, o)
+	assert.Equal(t, "Doodle #1 listed for 2500000 0xa0b8…eb48", n.Title)
+	o.PaymentTokenContract = &opensea.PaymentToken{Symbol: "USDC", Decimals: 6}
+	n = FromOrder(opensea.ChainEthereum, o)
+	assert.Equal(t, "Doodle #1 listed for 2.5 USDC", n.Title)
+}
+
+func TestFromSeaportOrder(t *testing.T) {
+	o := &opensea.SeaportOrder{
+		OrderHash:      "0x1",
+		Side:           "ask",
+		CurrentPrice:   "3000000000000000000",
+		ExpirationTime: 1646136000,
+		Maker:          &opensea.Account{Address: "0x0000000000000000000000000000000000000001"},
+		ProtocolData: opensea.ProtocolData{Parameters: opensea.OrderParameters{
+			Offer: []opensea.OfferItem{{ItemType: opensea.ItemERC721, Token: "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e", IdentifierOrCriteria: "7", StartAmount: "1"}},
+			Consideration: []opensea.ConsiderationItem{
+				{ItemType: opensea.ItemNative, Token: opensea.NullAddress, StartAmount: "2900000000000000000"},
+			},
+		}},
+	}
+	n := FromSeaportOrder(opensea.ChainEthereum, o)
+	assert.Equal(t, "0x8a90…992e #7 listed for 3 ETH", n.Title)
+	assert.Equal(t, opensea.EventTypeCreated, n.EventType)
+	assert.Equal(t, opensea.AssetPermalink(opensea.ChainEthereum, "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e", "7"), n.URL)
+	assert.Equal(t, "2022-03-01 12:00 UTC", n.Fields[1].Value)
+
+	// USDC has 6 decimals
+	o.CurrentPrice = "2500000"
+	o.ProtocolData.Parameters.Consideration[0] = opensea.ConsiderationItem{ItemType: opensea.ItemERC20, Token: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", StartAmount: "2500000"}
+	o.Price.Current = &opensea.OrderPrice{Currency: "USDC", Decimals: 6, Value: "2500000"}
+	n = FromSeaportOrder(opensea.ChainEthereum, o)
+	assert.Equal(t, "0x8a90…992e #7 listed for 2.5 USDC", n.Title)
+
+	offer := &opensea.SeaportOrder{
+		Chain:    opensea.ChainPolygon,
+		Side:     "bid",
+		Criteria: &opensea.OfferCriteria{Collection: &opensea.CollectionCriteria{Slug: "doodles-official"}},
+		Price:    opensea.SeaportOrderPrice{OrderPrice: opensea.OrderPrice{Currency: "WETH", Decimals: 18, Value: "1500000000000000000"}},
+		ProtocolData: opensea.ProtocolData{Parameters: opensea.OrderParameters{
+			Offerer: "0x0000000000000000000000000000000000000002",
+		}},
+	}
+	n = FromSeaportOrder(opensea.ChainEthereum, offer)
+	assert.Equal(t, "New offer on doodles-official: 1.5 WETH", n.Title)
+	assert.Equal(t, opensea.EventTypeBidEntered, n.EventType)
+	assert.Equal(t, "https://opensea.io/collection/doodles-official", n.URL)
+	assert.Equal(t, "Maker", n.Fields[0].Name)
+
+	// orders of the orders endpoints only carry the amount
+	offer.Price = opensea.SeaportOrderPrice{}
+	offer.CurrentPrice = "2000000000000000000"
+	offer.ProtocolData.Parameters.Offer = []opensea.OfferItem{{ItemType: opensea.ItemERC20, Token: "0x7ceb23fd6bc0add59e62ac25578270cff1b9f619", StartAmount: "2000000000000000000"}}
+	n = FromSeaportOrder(opensea.ChainEthereum, offer)
+	assert.Equal(t, "New offer on doodles-official: 2 WMATIC", n.Title)
+}
+
+func TestDiscord(t *testing.T) {
+	msg := FromEvent(opensea.ChainEthereum, testSale()).Discord()
+	b, err := json.Marshal(msg)
+	assert.Nil(t, err)
+
+	var got map[string][]map[string]interface{}
+	assert.Nil(t, json.Unmarshal(b, &got))
+	embed := got["embeds"][0]
+	assert.Equal(t, "Doodles #42 sold for 1.25 ETH", embed["title"])
+	assert.Equal(t, "2022-03-01T12:00:00Z", embed["timestamp"])
+	assert.Equal(t, float64(0x2ecc71), embed["color"])
+	fields := embed["fields"].([]interface{})
+	assert.Equal(t, "[alice](https://opensea.io/0x0000000000000000000000000000000000000001)", fields[0].(map[string]interface{})["value"])
+}
+
+func TestSlack(t *testing.T) {
+	e := testSale()
+	e.Asset.Name = "<Doodle & Co>"
+	msg := FromEvent(opensea.ChainEthereum, e).Slack()
+	assert.Equal(t, "<Doodle & Co> sold for 1.25 ETH", msg.Text)
+	assert.Len(t, msg.Blocks, 3)
+	assert.Contains(t, msg.Blocks[0].Text.Text, "|&lt;Doodle &amp; Co&gt; sold for 1.25 ETH>*")
+	assert.Equal(t, "https://example.com/42.png", msg.Blocks[0].Accessory.ImageURL)
+	assert.Len(t, msg.Blocks[1].Fields, 3)
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("t").Funcs(TemplateFuncs).Parse(`{{.Title}} by {{short "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"}} at {{price "1000000" 6 "USDC"}}`))
+	buf := new(bytes.Buffer)
+	assert.Nil(t, tmpl.Execute(buf, &Notification{Title: "Sale"}))
+	assert.Equal(t, "Sale by 0x8a90…992e at 1 USDC", buf.String())
+}
@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// Text renders the notification as plain text, one field per line.
+func (n *Notification) Text() string {
+	var b strings.Builder
+	b.WriteString(n.Title)
+	if n.URL != "" {
+		b.WriteString("\n" + n.URL)
+	}
+	for _, f := range n.Fields {
+		fmt.Fprintf(&b, "\n%s: %s", f.Name, f.Value)
+	}
+	return b.String()
+}
+
+type DiscordEmbed struct {
+	Title       string              `json:"title"`
+	URL         string              `json:"url,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Color       int                 `json:"color,omitempty"`
+	Timestamp   string              `json:"timestamp,omitempty"`
+	Image       *DiscordEmbedImage  `json:"image,omitempty"`
+	Fields      []DiscordEmbedField `json:"fields,omitempty"`
+}
+
+type DiscordEmbedImage struct {
+	URL string `json:"url"`
+}
+
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// DiscordMessage is the body of a Discord webhook execution.
+type DiscordMessage struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []DiscordEmbed `json:"embeds"`
+}
+
+var discordColors = map[opensea.EventType]int{
+	opensea.EventTypeSuccessful: 0x2ecc71,
+	opensea.EventTypeCreated:    0x2081e2,
+	opensea.EventTypeBidEntered: 0xf1c40f,
+	opensea.EventTypeCancelled:  0x95a5a6,
+}
+
+// Discord renders the notification as a single embed.
+func (n *Notification) Discord() DiscordMessage {
+	embed := DiscordEmbed{
+		Title:       n.Title,
+		URL:         n.URL,
+		Description: truncate(n.Description, 4096),
+		Color:       discordColors[n.EventType],
+	}
+	if !n.Timestamp.IsZero() {
+		embed.Timestamp = n.Timestamp.UTC().Format(time.RFC3339)
+	}
+	if n.ImageURL != "" {
+		embed.Image = &DiscordEmbedImage{URL: n.ImageURL}
+	}
+	for _, f := range n.Fields {
+		value := f.Value
+		if f.URL != "" {
+			value = fmt.Sprintf("[%s](%s)", f.Value, f.URL)
+		}
+		embed.Fields = append(embed.Fields, DiscordEmbedField{Name: f.Name, Value: value, Inline: f.Inline})
+	}
+	return DiscordMessage{Embeds: []DiscordEmbed{embed}}
+}
+
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type SlackBlock struct {
+	Type      string      `json:"type"`
+	Text      *SlackText  `json:"text,omitempty"`
+	Fields    []SlackText `json:"fields,omitempty"`
+	Accessory *SlackImage `json:"accessory,omitempty"`
+	Elements  []SlackText `json:"elements,omitempty"`
+}
+
+type SlackImage struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// SlackMessage is the body of a Slack incoming webhook or chat.postMessage
+// call. Text is the fallback shown in notifications.
+type SlackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// Slack renders the notification with Block Kit.
+func (n *Notification) Slack() SlackMessage {
+	title := "*" + slackEscape(n.Title) + "*"
+	if n.URL != "" {
+		title = fmt.Sprintf("*<%s|%s>*", n.URL, slackEscape(n.Title))
+	}
+	if n.Description != "" {
+		title += "\n" + slackEscape(truncate(n.Description, 2000))
+	}
+	section := SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: title}}
+	if n.ImageURL != "" {
+		section.Accessory = &SlackImage{Type: "image", ImageURL: n.ImageURL, AltText: n.Title}
+	}
+	blocks := []SlackBlock{section}
+
+	if len(n.Fields) > 0 {
+		fields := SlackBlock{Type: "section"}
+		for _, f := range n.Fields {
+			value := slackEscape(f.Value)
+			if f.URL != "" {
+				value = fmt.Sprintf("<%s|%s>", f.URL, value)
+			}
+			fields.Fields = append(fields.Fields, SlackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", f.Name, value)})
+		}
+		blocks = append(blocks, fields)
+	}
+	if !n.Timestamp.IsZero() {
+		blocks = append(blocks, SlackBlock{
+			Type:     "context",
+			Elements: []SlackText{{Type: "mrkdwn", Text: n.Timestamp.UTC().Format("2006-01-02 15:04 MST")}},
+		})
+	}
+	return SlackMessage{Text: n.Title, Blocks: blocks}
+}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackEscape(s string) string {
+	return slackEscaper.Replace(s)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,290 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// CreateOffer posts a signed offer, such as a bid, to the offers endpoint of
+// chain, and returns the created order with its hash and protocol data.
+// Failed posts are retried as configured by WithRetries, unless the offer
+// turns out to have been created; a *ValidationError reports why the API
+// rejected it.
+func (o Opensea) CreateOffer(chain Chain, order *SignedOrder) (*SeaportOrder, error) {
+	ctx := context.TODO()
+	return o.CreateOfferWithContext(ctx, chain, order)
+}
+
+func (o Opensea) CreateOfferWithContext(ctx context.Context, chain Chain, order *SignedOrder) (*SeaportOrder, error) {
+	chain, err := o.chainFor(chain, CapabilityOffers)
+	if err != nil {
+		return nil, err
+	}
+	return o.postOrder(ctx, chain, "offers", order)
+}
+
+// OfferCriteria select the items a criteria offer wants: any item of a
+// collection, or of a contract, having Trait when set.
+type OfferCriteria struct {
+	Collection *CollectionCriteria `json:"collection,omitempty" bson:"collection,omitempty"`
+	Contract   *ContractCriteria   `json:"contract,omitempty" bson:"contract,omitempty"`
+	Trait      *TraitCriteria      `json:"trait,omitempty" bson:"trait,omitempty"`
+	// EncodedTokenIDs are the token IDs behind the merkle root of the
+	// criteria, as returned by the build endpoint.
+	EncodedTokenIDs string `json:"encoded_token_ids,omitempty" bson:"encoded_token_ids,omitempty"`
+}
+
+type CollectionCriteria struct {
+	Slug string `json:"slug" bson:"slug"`
+}
+
+type ContractCriteria struct {
+	Address Address `json:"address" bson:"address"`
+}
+
+type TraitCriteria struct {
+	Type  string `json:"type" bson:"type"`
+	Value string `json:"value" bson:"value"`
+}
+
+// CollectionOfferParams describe an offer on any Quantity items of a
+// collection, or only on its items with a trait.
+type CollectionOfferParams struct {
+	Offerer Address
+	Slug    string
+	// TraitType and TraitValue make a trait offer when set.
+	TraitType  string
+	TraitValue string
+	// Quantity defaults to 1.
+	Quantity int64
+	// Amount is the total offered for Quantity items, in the smallest unit
+	// of PaymentToken. Fees are paid out of it.
+	Amount Number
+	// PaymentToken defaults to the wrapped native token of the chain the
+	// offer was built for: Chain or, without one, the chain of the client.
+	PaymentToken Address
+	Chain        Chain
+	Fees         []Fee
+	// StartTime defaults to now and EndTime to DefaultBidDuration later.
+	StartTime time.Time
+	EndTime   time.Time
+	Salt      Number
+	Counter   Number
+}
+
+func (p CollectionOfferParams) quantity() int64 {
+	if p.Quantity <= 0 {
+		return 1
+	}
+	return p.Quantity
+}
+
+func (p CollectionOfferParams) criteria() OfferCriteria {
+	c := OfferCriteria{Collection: &CollectionCriteria{Slug: p.Slug}}
+	if p.TraitType != "" {
+		c.Trait = &TraitCriteria{Type: p.TraitType, Value: p.TraitValue}
+	}
+	return c
+}
+
+// CollectionOfferBuild is the response of the offer build endpoint: the
+// consideration of the offer, with the merkle root of the criteria, and
+// the zone that must approve its fulfillments.
+type CollectionOfferBuild struct {
+	PartialParameters struct {
+		Consideration []ConsiderationItem `json:"consideration" bson:"consideration"`
+		Zone          Address             `json:"zone" bson:"zone"`
+		ZoneHash      string              `json:"zoneHash" bson:"zoneHash"`
+	} `json:"partialParameters" bson:"partialParameters"`
+	Criteria OfferCriteria `json:"criteria" bson:"criteria"`
+	// Chain is the chain the offer was built for.
+	Chain Chain `json:"-" bson:"-"`
+}
+
+// BuildCollectionOffer asks OpenSea for the criteria of a collection offer,
+// which OrderParameters completes into an order ready for SignOrder.
+func (o Opensea) BuildCollectionOffer(p CollectionOfferParams) (*CollectionOfferBuild, error) {
+	ctx := context.TODO()
+	return o.BuildCollectionOfferWithContext(ctx, p)
+}
+
+func (o Opensea) BuildCollectionOfferWithContext(ctx context.Context, p CollectionOfferParams) (*CollectionOfferBuild, error) {
+	chain, err := o.chainFor(p.Chain, CapabilityCollectionOffers)
+	if err != nil {
+		return nil, err
+	}
+	offerer, err := ParseAddress(p.Offerer.String())
+	if err != nil {
+		return nil, err
+	}
+	if p.Slug == "" {
+		return nil, fmt.Errorf("Empty collection slug")
+	}
+	if (p.TraitType == "") != (p.TraitValue == "") {
+		return nil, fmt.Errorf("Trait offers need a trait type and a value")
+	}
+	req := struct {
+		Offerer                Address       `json:"offerer"`
+		Quantity               int64         `json:"quantity"`
+		Criteria               OfferCriteria `json:"criteria"`
+		ProtocolAddress        Address       `json:"protocol_address"`
+		OfferProtectionEnabled bool          `json:"offer_protection_enabled"`
+	}{offerer, p.quantity(), p.criteria(), o.protocolAddress(DefaultProtocol), true}
+	b, err := o.postQuery(contextWithChain(ctx, chain), "/api/v2/offers/build", req)
+	if err != nil {
+		return nil, err
+	}
+	build := new(CollectionOfferBuild)
+	if err := json.Unmarshal(b, build); err != nil {
+		return nil, err
+	}
+	if len(build.PartialParameters.Consideration) == 0 {
+		return nil, fmt.Errorf("No consideration in the offer build of %s", p.Slug)
+	}
+	build.Chain = chain
+	return build, nil
+}
+
+// OrderParameters builds the parameters of the collection offer of p: the
+// offerer offers Amount of the payment token, and asks for the items of the
+// criteria and for the fees to be paid out of the amount.
+func (b *CollectionOfferBuild) OrderParameters(p CollectionOfferParams) (*OrderParameters, error) {
+	offerer, err := ParseAddress(p.Offerer.String())
+	if err != nil {
+		return nil, err
+	}
+	chain := b.Chain
+	if chain == ChainNone {
+		chain = p.Chain
+	}
+	if chain == ChainNone {
+		return nil, fmt.Errorf("No chain for the offer on %s", p.Slug)
+	}
+	token := p.PaymentToken
+	if token == "" {
+		if token, err = WrappedNativeToken(chain); err != nil {
+			return nil, err
+		}
+	}
+	if token.IsNullAddress() {
+		return nil, fmt.Errorf("Offers are paid in an ERC20, not in the native currency")
+	}
+	if token, err = ParseAddress(token.String()); err != nil {
+		return nil, err
+	}
+	proceeds, err := CalculateProceeds(p.Amount, p.Fees)
+	if err != nil {
+		return nil, err
+	}
+
+	offer := []OfferItem{{
+		ItemType:             ItemERC20,
+		Token:                token,
+		IdentifierOrCriteria: "0",
+		StartAmount:          p.Amount,
+		EndAmount:            p.Amount,
+	}}
+	consideration := append([]ConsiderationItem{}, b.PartialParameters.Consideration...)
+	for _, fee := range proceeds.Fees {
+		consideration = append(consideration, ConsiderationItem{
+			ItemType:             ItemERC20,
+			Token:                token,
+			IdentifierOrCriteria: "0",
+			StartAmount:          fee.Amount,
+			EndAmount:            fee.Amount,
+			Recipient:            fee.Recipient,
+		})
+	}
+	params, err := newOrderParameters(offerer, offer, consideration, p.StartTime, p.EndTime, DefaultBidDuration, p.Salt, p.Counter)
+	if err != nil {
+		return nil, err
+	}
+	restricted := false
+	if zone := b.PartialParameters.Zone; zone != "" && !zone.IsNullAddress() {
+		params.Zone = zone
+		params.ZoneHash = b.PartialParameters.ZoneHash
+		restricted = true
+	}
+	partial := p.quantity() > 1
+	switch {
+	case restricted && partial:
+		params.OrderType = OrderTypePartialRestricted
+	case restricted:
+		params.OrderType = OrderTypeFullRestricted
+	case partial:
+		params.OrderType = OrderTypePartialOpen
+	}
+	return params, nil
+}
+
+// PostCollectionOffer posts a signed collection offer, with the criteria of
+// its build. Failed posts are retried as configured by WithRetries, unless
+// the offer turns out to have been created.
+func (o Opensea) PostCollectionOffer(chain Chain, order *SignedOrder, criteria OfferCriteria) (*SeaportOrder, error) {
+	ctx := context.TODO()
+	return o.PostCollectionOfferWithContext(ctx, chain, order, criteria)
+}
+
+func (o Opensea) PostCollectionOfferWithContext(ctx context.Context, chain Chain, order *SignedOrder, criteria OfferCriteria) (*SeaportOrder, error) {
+	chain, err := o.chainFor(chain, CapabilityCollectionOffers)
+	if err != nil {
+		return nil, err
+	}
+	req := struct {
+		ProtocolData    ProtocolData  `json:"protocol_data"`
+		Criteria        OfferCriteria `json:"criteria"`
+		ProtocolAddress Address       `json:"protocol_address"`
+	}{ProtocolData{order.Parameters, order.Signature}, criteria, order.ProtocolAddress}
+	ret, err := o.postSignedOrder(ctx, chain, "/api/v2/offers", order, req, func(b []byte) (*SeaportOrder, error) {
+		offer := new(SeaportOrder)
+		if err := json.Unmarshal(b, offer); err != nil {
+			return nil, err
+		}
+		return offer, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ret.Criteria == nil {
+		ret.Criteria = &criteria
+	}
+	return ret, nil
+}
+
+// GetTraitOffers returns the active offers on the items of a collection
+// having a trait.
+func (o Opensea) GetTraitOffers(slug, traitType, traitValue string) ([]SeaportOrder, error) {
+	ctx := context.TODO()
+	return o.GetTraitOffersWithContext(ctx, slug, traitType, traitValue)
+}
+
+func (o Opensea) GetTraitOffersWithContext(ctx context.Context, slug, traitType, traitValue string) ([]SeaportOrder, error) {
+	chain, err := o.chainFor(ChainNone, CapabilityCollectionOffers)
+	if err != nil {
+		return nil, err
+	}
+	if slug == "" {
+		return nil, fmt.Errorf("Empty collection slug")
+	}
+	if traitType == "" || traitValue == "" {
+		return nil, fmt.Errorf("Trait offers need a trait type and a value")
+	}
+	q := url.Values{}
+	q.Set("type", traitType)
+	q.Set("value", traitValue)
+	path := fmt.Sprintf("/api/v2/offers/collection/%s/traits?%s", url.PathEscape(slug), q.Encode())
+	b, err := o.GetPath(contextWithChain(ctx, chain), path)
+	if err != nil {
+		return nil, err
+	}
+	resp := &struct {
+		Offers []SeaportOrder `json:"offers"`
+	}{Offers: []SeaportOrder{}}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return o.annotateOrders(resp.Offers), nil
+}
@@ -0,0 +1,156 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOffer(t *testing.T) {
+	order, hash := testSignedOffer(t)
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v2/orders/ethereum/seaport/offers", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		posted := new(SignedOrder)
+		assert.Nil(t, json.Unmarshal(body, posted))
+		assert.Equal(t, order.Signature, posted.Signature)
+
+		resp, _ := json.Marshal(map[string]interface{}{"order": map[string]interface{}{
+			"order_hash":       hash,
+			"protocol_address": order.ProtocolAddress,
+			"protocol_data":    map[string]interface{}{"parameters": posted.Parameters, "signature": posted.Signature},
+		}})
+		w.WriteHeader(http.StatusCreated)
+		w.Write(resp)
+	})
+	created, err := o.CreateOfferWithContext(context.Background(), ChainEthereum, order)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, created.OrderHash)
+	assert.Equal(t, order.Signature, created.ProtocolData.Signature)
+	assert.Equal(t, order.Parameters.Offerer, created.ProtocolData.Parameters.Offerer)
+	assert.Equal(t, order.Parameters.Salt, created.ProtocolData.Parameters.Salt)
+}
+
+func TestCollectionOffer(t *testing.T) {
+	const root = "0x0000000000000000000000000000000000000000000000000000000000000000"
+	const zone = "0x000056f7000000ece9003ca63978907a00ffd100"
+	var posted map[string]json.RawMessage
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/api/v2/offers/build":
+			req := struct {
+				Offerer  Address       `json:"offerer"`
+				Quantity int64         `json:"quantity"`
+				Criteria OfferCriteria `json:"criteria"`
+			}{}
+			assert.Nil(t, json.Unmarshal(body, &req))
+			assert.Equal(t, testBidder, req.Offerer)
+			assert.Equal(t, int64(2), req.Quantity)
+			assert.Equal(t, "doodles-official", req.Criteria.Collection.Slug)
+			w.Write([]byte(`{"partialParameters":{"consideration":[{"itemType":4,"token":"` + contract + `","identifierOrCriteria":"` + root + `","startAmount":"2","endAmount":"2","recipient":"` + testBidder.String() + `"}],
+				"zone":"` + zone + `","zoneHash":"` + root + `"},
+				"criteria":{"collection":{"slug":"doodles-official"},"contract":{"address":"` + contract + `"}}}`))
+		case "/api/v2/offers":
+			assert.Nil(t, json.Unmarshal(body, &posted))
+			w.Write([]byte(`{"order_hash":"0xabc","chain":"ethereum","criteria":{"collection":{"slug":"doodles-official"}},"protocol_address":"0x00000000000000adc04c56bf30ac9d3c0aaf14dc"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	p := CollectionOfferParams{
+		Offerer:   testBidder,
+		Slug:      "doodles-official",
+		Quantity:  2,
+		Amount:    "2000000",
+		Fees:      []Fee{{Recipient: OpenSeaFeeRecipient, BasisPoints: 250}},
+		StartTime: time.Unix(1700000000, 0),
+		Salt:      "1",
+	}
+	build, err := o.BuildCollectionOfferWithContext(context.Background(), p)
+	assert.Nil(t, err)
+	assert.Equal(t, Address(contract), build.Criteria.Contract.Address)
+
+	params, err := build.OrderParameters(p)
+	assert.Nil(t, err)
+	assert.Equal(t, OrderTypePartialRestricted, params.OrderType)
+	assert.Equal(t, Address(zone), params.Zone)
+	assert.Equal(t, ItemERC721WithCriteria, params.Consideration[0].ItemType)
+	assert.Equal(t, Number("50000"), params.Consideration[1].StartAmount)
+	assert.Equal(t, Number("1700604800"), params.EndTime)
+
+	order, err := SignOrder(context.Background(), ChainEthereum, DefaultProtocol, params, &testSigner{address: testBidder})
+	assert.Nil(t, err)
+	offer, err := o.PostCollectionOfferWithContext(context.Background(), ChainEthereum, order, build.Criteria)
+	assert.Nil(t, err)
+	assert.Equal(t, "0xabc", offer.OrderHash)
+	assert.Equal(t, "doodles-official", offer.Criteria.Collection.Slug)
+	assert.Contains(t, string(posted["criteria"]), contract)
+	assert.Contains(t, string(posted["protocol_data"]), order.Signature)
+
+	_, err = o.BuildCollectionOfferWithContext(context.Background(), CollectionOfferParams{Offerer: testBidder})
+	assert.NotNil(t, err)
+}
+
+func TestCollectionOfferChain(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"partialParameters":{"consideration":[{"itemType":4,"token":"` + contract + `","identifierOrCriteria":"1","startAmount":"1","endAmount":"1"}]},
+			"criteria":{"collection":{"slug":"doodles-official"}}}`))
+	}, WithChain(ChainPolygon))
+	p := CollectionOfferParams{Offerer: testBidder, Slug: "doodles-official", Amount: "1000"}
+	build, err := o.BuildCollectionOfferWithContext(context.Background(), p)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainPolygon, build.Chain)
+
+	params, err := build.OrderParameters(p)
+	assert.Nil(t, err)
+	polygonWETH, _ := WrappedNativeToken(ChainPolygon)
+	assert.Equal(t, polygonWETH, params.Offer[0].Token)
+
+	_, err = (&CollectionOfferBuild{}).OrderParameters(p)
+	assert.NotNil(t, err)
+}
+
+func TestTraitOffers(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/offers/build":
+			body, _ := ioutil.ReadAll(r.Body)
+			req := struct {
+				Criteria OfferCriteria `json:"criteria"`
+			}{}
+			assert.Nil(t, json.Unmarshal(body, &req))
+			assert.Equal(t, &TraitCriteria{Type: "Hat", Value: "Crown"}, req.Criteria.Trait)
+			w.Write([]byte(`{"partialParameters":{"consideration":[{"itemType":4,"token":"` + contract + `","identifierOrCriteria":"1","startAmount":"1","endAmount":"1"}]},
+				"criteria":{"collection":{"slug":"doodles-official"},"trait":{"type":"Hat","value":"Crown"}}}`))
+		case "/api/v2/offers/collection/doodles-official/traits":
+			assert.Equal(t, "Hat", r.URL.Query().Get("type"))
+			assert.Equal(t, "Crown", r.URL.Query().Get("value"))
+			w.Write([]byte(`{"offers":[{"order_hash":"0xabc","criteria":{"collection":{"slug":"doodles-official"},"trait":{"type":"Hat","value":"Crown"}}}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	p := CollectionOfferParams{Offerer: testBidder, Slug: "doodles-official", TraitType: "Hat", TraitValue: "Crown"}
+	build, err := o.BuildCollectionOfferWithContext(context.Background(), p)
+	assert.Nil(t, err)
+	assert.Equal(t, "Crown", build.Criteria.Trait.Value)
+
+	offers, err := o.GetTraitOffersWithContext(context.Background(), "doodles-official", "Hat", "Crown")
+	assert.Nil(t, err)
+	assert.Len(t, offers, 1)
+	assert.Equal(t, "Hat", offers[0].Criteria.Trait.Type)
+
+	p.TraitValue = ""
+	_, err = o.BuildCollectionOfferWithContext(context.Background(), p)
+	assert.NotNil(t, err)
+	_, err = o.GetTraitOffersWithContext(context.Background(), "doodles-official", "Hat", "")
+	assert.NotNil(t, err)
+}
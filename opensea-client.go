@@ -1,52 +1,190 @@
 package opensea
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/big"
 	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
 var (
-	mainnetAPI = "https://api.opensea.io"
-	rinkebyAPI = "https://rinkeby-api.opensea.io"
+	mainnetAPI  = "https://api.opensea.io"
+	testnetsAPI = "https://testnets-api.opensea.io"
 )
 
+// ErrRinkebyDeprecated is returned by NewOpenseaRinkeby. The Rinkeby network
+// has been shut down; use NewOpenseaTestnet instead.
+var ErrRinkebyDeprecated = errors.New("Rinkeby is no longer supported by OpenSea, use NewOpenseaTestnet")
+
+// ErrNotFound is returned when the requested resource does not exist. Every
+// method returns it when the API answers 404 Not Found, single resource
+// lookups such as GetSingleAsset, GetSingleContract, GetCollection and
+// GetAssetContract included, where earlier versions returned the "Backend
+// returns status 404" error.
+var ErrNotFound = errors.New("Not found")
+
 type Opensea struct {
 	API        string
 	APIKey     string
+	Chain      Chain
 	httpClient *http.Client
+	limiter    *RateLimiter
+	partitions *PartitionedRateLimiter
+	retries    int
+	backoff    time.Duration
+	logger     Logger
+	v2Compat   bool
+	floors     FloorSnapshotStore
+	profiles   *ProfileRegistry
+	accounts   *AccountCache
+	ens        ENSResolver
+	oracle     PriceOracle
+	headers    http.Header
+	query      url.Values
+	dryRun     bool
+	protocol   Address
+
+	accountConcurrency int
+	dropExpired        bool
+}
+
+// Option configures an Opensea client at construction time.
+type Option func(*Opensea)
+
+// WithChain sets the default chain used by chain-scoped methods when the
+// call does not specify one.
+func WithChain(chain Chain) Option {
+	return func(o *Opensea) {
+		o.Chain = chain
+	}
+}
+
+// WithAPI sets the base URL of the API, e.g. for a proxy. The chains of
+// the client are validated against it.
+func WithAPI(api string) Option {
+	return func(o *Opensea) {
+		o.API = api
+	}
+}
+
+// WithTimeout sets the timeout of every HTTP request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *Opensea) {
+		o.httpClient.Timeout = timeout
+	}
+}
+
+// WithRateLimit paces outgoing requests to the given budget.
+func WithRateLimit(limit RateLimit) Option {
+	return func(o *Opensea) {
+		o.limiter = NewRateLimiter(limit)
+	}
+}
+
+// WithChainRateLimits gives each chain its own budget, on top of any global
+// limit. Chains missing from limits get def; requests that do not target a
+// chain are partitioned by base URL.
+func WithChainRateLimits(def RateLimit, limits map[Chain]RateLimit) Option {
+	return func(o *Opensea) {
+		partitions := map[string]RateLimit{}
+		for chain, limit := range limits {
+			partitions[chain.String()] = limit
+		}
+		o.partitions = NewPartitionedRateLimiter(def, partitions)
+	}
+}
+
+// WithRateLimiter shares an existing limiter, e.g. between clients using the
+// same API key.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *Opensea) {
+		o.limiter = limiter
+	}
 }
 
 type errorResponse struct {
-	Success bool `json:"success" bson:"success"`
+	Success bool              `json:"success" bson:"success"`
+	Errors  []json.RawMessage `json:"errors" bson:"errors"`
 }
 
 func (e errorResponse) Error() string {
 	return "Not success"
 }
 
-func NewOpensea(apiKey string) (*Opensea, error) {
+// ValidationError is returned when the API rejects a request, e.g. an order
+// with an invalid signature or an expired listing, with the reasons it gives.
+type ValidationError struct {
+	StatusCode int
+	Errors     []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Request rejected with status %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+func (e errorResponse) validationError(statusCode int) *ValidationError {
+	ret := &ValidationError{StatusCode: statusCode, Errors: make([]string, len(e.Errors))}
+	for i, raw := range e.Errors {
+		var msg string
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			msg = string(raw)
+		}
+		ret.Errors[i] = msg
+	}
+	return ret
+}
+
+func NewOpensea(apiKey string, opts ...Option) (*Opensea, error) {
 	o := &Opensea{
 		API:        mainnetAPI,
 		APIKey:     apiKey,
 		httpClient: defaultHttpClient(),
 	}
-	return o, nil
+	return o.apply(opts)
 }
 
-func NewOpenseaRinkeby(apiKey string) (*Opensea, error) {
+// NewOpenseaTestnet returns a client for the OpenSea testnets API. Its
+// default chain is Sepolia.
+func NewOpenseaTestnet(apiKey string, opts ...Option) (*Opensea, error) {
 	o := &Opensea{
-		API:        rinkebyAPI,
+		API:        testnetsAPI,
 		APIKey:     apiKey,
 		httpClient: defaultHttpClient(),
 	}
+	return o.apply(opts)
+}
+
+// Deprecated: Rinkeby has been shut down. NewOpenseaRinkeby always returns
+// ErrRinkebyDeprecated; use NewOpenseaTestnet instead.
+func NewOpenseaRinkeby(apiKey string, opts ...Option) (*Opensea, error) {
+	return nil, ErrRinkebyDeprecated
+}
+
+func (o *Opensea) apply(opts []Option) (*Opensea, error) {
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.accounts == nil {
+		o.accounts = NewAccountCache(DefaultAccountCacheTTL)
+	}
+	if o.Keyless() && o.limiter == nil && o.partitions == nil {
+		o.limiter = NewRateLimiter(PublicRateLimit)
+	}
+	if o.Chain != ChainNone {
+		if _, err := o.resolveChain(o.Chain); err != nil {
+			return nil, err
+		}
+	}
 	return o, nil
 }
 
@@ -56,10 +194,15 @@ func (o Opensea) GetAssets(params GetAssetsParams) (*AssetsResponse, error) {
 }
 
 func (o Opensea) GetAssetsWithContext(ctx context.Context, params GetAssetsParams) (*AssetsResponse, error) {
+	o.deprecated("GetAssets")
 	path := "/api/v1/assets"
 	values := url.Values{}
 	if params.Owner != "" {
-		values.Set("owner", params.Owner.String())
+		owner, err := o.ownerAddress(ctx, params.Owner)
+		if err != nil {
+			return nil, err
+		}
+		values.Set("owner", owner.String())
 	}
 	if len(params.TokenIds) > 0 {
 		for _, tokenId := range params.TokenIds {
@@ -95,6 +238,9 @@ func (o Opensea) GetAssetsWithContext(ctx context.Context, params GetAssetsParam
 	if params.IncludeOrders {
 		values.Set("include_orders", "true")
 	}
+	if params.IncludeRarity {
+		values.Set("include_rarity", "true")
+	}
 
 	encodedValues := values.Encode()
 	if encodedValues != "" {
@@ -106,7 +252,42 @@ func (o Opensea) GetAssetsWithContext(ctx context.Context, params GetAssetsParam
 		return nil, err
 	}
 	ret := new(AssetsResponse)
-	return ret, json.Unmarshal(b, ret)
+	if len(params.Fields) == 0 {
+		if err := json.Unmarshal(b, ret); err != nil {
+			return nil, err
+		}
+		tagAssets(ret.Assets, APIv1)
+		if params.VerifiedOnly {
+			ret.Assets = FilterVerified(ret.Assets)
+		}
+		return ret, nil
+	}
+	fields := params.Fields
+	if params.VerifiedOnly {
+		fields = append(append([]string{}, fields...), "collection")
+	}
+
+	raw := &struct {
+		Assets   []json.RawMessage `json:"assets"`
+		Next     string            `json:"next"`
+		Previous string            `json:"previous"`
+	}{}
+	if err := json.Unmarshal(b, raw); err != nil {
+		return nil, err
+	}
+	ret.Next = raw.Next
+	ret.Previous = raw.Previous
+	ret.Assets = make([]Asset, len(raw.Assets))
+	for i, a := range raw.Assets {
+		if err := DecodeFields(a, &ret.Assets[i], fields); err != nil {
+			return nil, err
+		}
+	}
+	tagAssets(ret.Assets, APIv1)
+	if params.VerifiedOnly {
+		ret.Assets = FilterVerified(ret.Assets)
+	}
+	return ret, nil
 }
 
 func (o Opensea) GetSingleAsset(assetContractAddress string, tokenID *big.Int) (*Asset, error) {
@@ -118,49 +299,153 @@ func (o Opensea) GetSingleAssetWithContext(ctx context.Context, assetContractAdd
 	*Asset,
 	error,
 ) {
+	o.deprecated("GetSingleAsset")
+	if o.v2Compat {
+		return o.getSingleAssetV2(ctx, assetContractAddress, tokenID)
+	}
 	path := fmt.Sprintf("/api/v1/asset/%s/%s", assetContractAddress, tokenID.String())
 	b, err := o.GetPath(ctx, path)
 	if err != nil {
 		return nil, err
 	}
-	ret := new(Asset)
+	ret := &Asset{APIVersion: APIv1}
 	return ret, json.Unmarshal(b, ret)
 }
 
 func (o Opensea) GetPath(ctx context.Context, path string) ([]byte, error) {
+	if o.Keyless() && requiresAPIKey(path) {
+		return nil, ErrAPIKeyRequired
+	}
 	return o.getURL(ctx, o.API+path)
 }
 
-func (o Opensea) getURL(ctx context.Context, url string) ([]byte, error) {
-	client := o.httpClient
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	req.Header.Add("X-API-KEY", o.APIKey)
-	req.Header.Add("Accept", "application/json")
-	resp, err := client.Do(req)
+// PostPath posts v, encoded as JSON, to path and returns the response body.
+// Posts are not retried, as they may not be idempotent. Under WithDryRun,
+// the post is logged and the body is an empty JSON object.
+func (o Opensea) PostPath(ctx context.Context, path string, v interface{}) ([]byte, error) {
+	if o.Keyless() && requiresAPIKey(path) {
+		return nil, ErrAPIKeyRequired
+	}
+	body, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if o.dryRun {
+		o.logDryRun("POST", path, body)
+		return []byte("{}"), nil
+	}
+	return o.request(ctx, "POST", o.API+path, body)
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
+// postQuery is PostPath for POST requests that write nothing, such as
+// order builds, and are sent in dry runs too.
+func (o Opensea) postQuery(ctx context.Context, path string, v interface{}) ([]byte, error) {
+	if o.Keyless() && requiresAPIKey(path) {
+		return nil, ErrAPIKeyRequired
+	}
+	body, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
+	return o.request(ctx, "POST", o.API+path, body)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		e := new(errorResponse)
-		err = json.Unmarshal(body, e)
+func (o Opensea) getURL(ctx context.Context, url string) ([]byte, error) {
+	return o.request(ctx, "GET", url, nil)
+}
+
+func (o Opensea) request(ctx context.Context, method string, url string, reqBody []byte) ([]byte, error) {
+	start := time.Now()
+	backoff := o.backoff
+	for attempt := 0; ; attempt++ {
+		resp, body, err := o.do(contextWithAttempt(ctx, url, attempt), method, url, reqBody)
 		if err != nil {
 			return nil, err
 		}
-		if !e.Success {
-			return nil, e
+		if method == "GET" && attempt < o.retries && retryable(resp.StatusCode) {
+			if err := sleep(ctx, retryDelay(resp, backoff)); err != nil {
+				return nil, err
+			}
+			backoff *= 2
+			continue
+		}
+		responseFromContext(ctx).record(resp, start, attempt)
+
+		if o.Keyless() && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+			return nil, ErrAPIKeyRequired
+		}
+		if err := o.sunset(url, resp, body); err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, ErrNotFound
+		}
+		if resp.StatusCode != http.StatusOK && !(method != "GET" && (resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusAccepted)) {
+			e := new(errorResponse)
+			err = json.Unmarshal(body, e)
+			if err != nil {
+				return nil, err
+			}
+			if len(e.Errors) > 0 {
+				return nil, e.validationError(resp.StatusCode)
+			}
+			if !e.Success {
+				return nil, e
+			}
+
+			return nil, fmt.Errorf("Backend returns status %d msg: %s", resp.StatusCode, string(body))
 		}
 
-		return nil, fmt.Errorf("Backend returns status %d msg: %s", resp.StatusCode, string(body))
+		return body, nil
+	}
+}
+
+func (o Opensea) do(ctx context.Context, method string, url string, reqBody []byte) (*http.Response, []byte, error) {
+	client := o.httpClient
+	if err := o.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	var r io.Reader
+	if reqBody != nil {
+		r = bytes.NewReader(reqBody)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !o.Keyless() {
+		req.Header.Add("X-API-KEY", o.APIKey)
+	}
+	req.Header.Add("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	setIdempotencyKey(ctx, req)
+	o.decorate(req)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
 	}
+	return resp, body, nil
+}
 
-	return body, nil
+func (o Opensea) wait(ctx context.Context) error {
+	if o.partitions != nil {
+		partition := chainFromContext(ctx).String()
+		if partition == "" {
+			partition = o.API
+		}
+		if err := o.partitions.Wait(ctx, partition); err != nil {
+			return err
+		}
+	}
+	return o.limiter.Wait(ctx)
 }
 
 func (o Opensea) SetHttpClient(httpClient *http.Client) {
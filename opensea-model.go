@@ -33,7 +33,7 @@ func IsHexAddress(s string) bool {
 	if s == "0x0" {
 		return true
 	}
-	if s[0:2] != "0x" {
+	if len(s) < 2 || s[0:2] != "0x" {
 		return false
 	}
 	addressLength := 2 + 40
@@ -53,6 +53,44 @@ func ParseAddress(address string) (Address, error) {
 	return Address(strings.ToLower(address)), nil
 }
 
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// IsBase58Address reports whether s looks like a base58 encoded 32-byte
+// public key, as used for Solana accounts and mints.
+func IsBase58Address(s string) bool {
+	if len(s) < 32 || len(s) > 44 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune(base58Alphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseChainAddress parses an address in the format used by the chain. EVM
+// addresses are normalized to lower case, base58 addresses are kept as is
+// since they are case sensitive.
+func ParseChainAddress(chain Chain, address string) (Address, error) {
+	if chain.IsEVM() {
+		return ParseAddress(address)
+	}
+	if !IsBase58Address(address) {
+		return "", errors.New("Invalid address: " + address)
+	}
+	return Address(address), nil
+}
+
+// parseAnyAddress accepts both EVM and base58 addresses; it is used when
+// decoding API payloads, which may come from any chain.
+func parseAnyAddress(address string) (Address, error) {
+	if IsBase58Address(address) && !IsHexAddress(address) {
+		return Address(address), nil
+	}
+	return ParseAddress(address)
+}
+
 func (a Address) String() string {
 	return string(a)
 }
@@ -75,7 +113,11 @@ func (a *Address) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return err
 	}
-	*a, err = ParseAddress(s)
+	if s == "" {
+		*a = ""
+		return nil
+	}
+	*a, err = parseAnyAddress(s)
 	return err
 }
 
@@ -150,6 +192,14 @@ type Account struct {
 	Address       Address `json:"address" bson:"address"`
 	Config        string  `json:"config" bson:"config"`
 	DiscordID     string  `json:"discord_id" bson:"discord_id"`
+	// APIVersion is the version of the API the account was read from, empty
+	// for v1. Tag stores the account in the shape of that version.
+	APIVersion APIVersion `json:"-" bson:"-"`
+}
+
+// IsVerified reports whether OpenSea verified the account.
+func (a Account) IsVerified() bool {
+	return a.Config == "verified"
 }
 
 type User struct {
@@ -220,6 +270,12 @@ type Asset struct {
 	TokenMetadata        string         `json:"token_metadata" bson:"token_metadata"`
 	Traits               interface{}    `json:"traits" bson:"traits"`
 	LastSale             *Sale          `json:"last_sale"`
+	// Rarity is only set with GetAssetsParams.IncludeRarity.
+	Rarity *NFTRarity `json:"rarity_data,omitempty" bson:"rarity_data,omitempty"`
+	// APIVersion is the version of the API the asset was read from, APIv2 for
+	// the assets converted from NFTs. Tag stores the asset in the shape of
+	// that version.
+	APIVersion APIVersion `json:"-" bson:"-"`
 }
 
 type AssetContract struct {
@@ -264,6 +320,7 @@ type Collection struct {
 	SafelistRequestStatus       string      `json:"safelist_request_status" bson:"safelist_request_status"`
 	ImageUrl                    string      `json:"image_url" bson:"image_url"`
 	IsSubjectToWhitelist        bool        `json:"is_subject_to_whitelist" bson:"is_subject_to_whitelist"`
+	IsCreatorFeesEnforced       *bool       `json:"is_creator_fees_enforced,omitempty" bson:"is_creator_fees_enforced,omitempty"`
 	LargeImageUrl               string      `json:"large_image_url" bson:"large_image_url"`
 	MediumUsername              string      `json:"medium_username" bson:"medium_username"`
 	Name                        string      `json:"name" bson:"name"`
@@ -278,6 +335,21 @@ type Collection struct {
 	TwitterUsername             string      `json:"twitter_username" bson:"twitter_username"`
 	InstagramUsername           string      `json:"instagram_username" bson:"instagram_username"`
 	WikiUrl                     string      `json:"wiki_url" bson:"wiki_url"`
+	// Editors may update the collection. Only single collection responses
+	// list them.
+	Editors []Address `json:"editors,omitempty" bson:"editors,omitempty"`
+	// Category is set by the v2 endpoints, e.g. "art" or "pfps".
+	Category string `json:"category,omitempty" bson:"category,omitempty"`
+}
+
+// IsEditor reports whether address is an editor of the collection.
+func (c Collection) IsEditor(address Address) bool {
+	for _, e := range c.Editors {
+		if strings.EqualFold(e.String(), address.String()) {
+			return true
+		}
+	}
+	return false
 }
 
 type GetAssetsParams struct {
@@ -292,6 +364,14 @@ type GetAssetsParams struct {
 	Limit                  int
 	Cursor                 string
 	IncludeOrders          bool
+	// IncludeRarity asks for the rarity of each asset.
+	IncludeRarity bool
+	// Fields limits decoding of each asset to the given top-level JSON
+	// fields, e.g. "token_id" and "owner". Empty decodes every field.
+	Fields []string
+	// VerifiedOnly drops the assets of collections not verified by OpenSea.
+	// The collection field is then always decoded.
+	VerifiedOnly bool
 }
 
 type StatResponse struct {
@@ -327,13 +407,22 @@ type CollectionSingleResponse struct {
 }
 
 type CollectionSingle struct {
-	Editors               []Address      `json:"editors" bson:"editors"`
 	PaymentTokens         []PaymentToken `json:"payment_tokens" bson:"payment_tokens"`
 	PrimaryAssetContracts []Contract     `json:"primary_asset_contracts" bson:"primary_asset_contracts"`
 	Traits                interface{}    `json:"traits" bson:"traits"`
+	Stats                 *Stat          `json:"stats" bson:"stats"`
+	Fees                  *FeeSchedule   `json:"fees" bson:"fees"`
+	// OwnedAssetCount is set when listing the collections of an asset owner.
+	OwnedAssetCount int64 `json:"owned_asset_count,omitempty" bson:"owned_asset_count,omitempty"`
 	Collection
 }
 
+// FeeSchedule lists the fees of a collection in basis points, by recipient.
+type FeeSchedule struct {
+	SellerFees  map[string]int64 `json:"seller_fees" bson:"seller_fees"`
+	OpenseaFees map[string]int64 `json:"opensea_fees" bson:"opensea_fees"`
+}
+
 type Sale struct {
 	Asset struct {
 		Decimals int    `json:"decimals"`
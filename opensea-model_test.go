@@ -55,3 +55,26 @@ func TestSingleCollectionResponse(t *testing.T) {
 
 	assert.NotNil(t, osColl)
 }
+
+func TestSolanaAddress(t *testing.T) {
+	mint := "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"
+
+	addr, err := ParseChainAddress(ChainSolana, mint)
+	assert.Nil(t, err)
+	assert.Equal(t, mint, addr.String())
+
+	_, err = ParseChainAddress(ChainEthereum, mint)
+	assert.NotNil(t, err)
+
+	_, err = ParseChainAddress(ChainSolana, "0OIl"+mint[4:])
+	assert.NotNil(t, err)
+
+	var decoded Address
+	err = json.Unmarshal([]byte(`"`+mint+`"`), &decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, Address(mint), decoded)
+
+	err = json.Unmarshal([]byte(`"0xDCEAF1652A131F32A821468DC03A92DF0EDD86EA"`), &decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, Address(contract), decoded)
+}
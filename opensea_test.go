@@ -4,11 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"testing"
 
 	"github.com/cheekybits/is"
+	"github.com/stretchr/testify/assert"
 )
 
 var (
@@ -43,3 +46,43 @@ func print(in interface{}) {
 	}
 	fmt.Println(in)
 }
+
+// newTestClient returns a client talking to a local server serving handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Opensea {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := NewOpensea("test-key", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.API = srv.URL
+	return client
+}
+
+func TestNotFound(t *testing.T) {
+	var paths []string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false}`))
+	})
+
+	_, err := o.GetSingleAsset(contract, tokenID)
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetSingleContract(contract)
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetCollection("unknown")
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetAssetContract(Address(contract))
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetAssets(GetAssetsParams{AssetContractAddress: Address(contract)})
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, []string{
+		"/api/v1/asset/" + contract + "/50010001",
+		"/api/v1/asset_contract/" + contract,
+		"/api/v1/collection/unknown",
+		"/api/v1/asset_contract/" + contract,
+		"/api/v1/assets",
+	}, paths)
+}
@@ -0,0 +1,250 @@
+// Package openseapb holds the protobuf messages of opensea.proto, generated
+// by protoc-gen-go, and the converters from and to the models of package
+// opensea.
+package openseapb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative opensea.proto
+
+import (
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+func FromAccount(a *opensea.Account) *Account {
+	if a == nil {
+		return nil
+	}
+	return &Account{
+		Address:       a.Address.String(),
+		Username:      a.User.Username,
+		ProfileImgUrl: a.ProfileImgURL,
+	}
+}
+
+func (m *Account) ToAccount() *opensea.Account {
+	if m == nil {
+		return nil
+	}
+	return &opensea.Account{
+		Address:       opensea.Address(m.Address),
+		User:          opensea.User{Username: m.Username},
+		ProfileImgURL: m.ProfileImgUrl,
+	}
+}
+
+// FromAsset converts an asset; the asset does not carry its chain, so it is
+// given by the caller.
+func FromAsset(chain opensea.Chain, a *opensea.Asset) *NFT {
+	if a == nil {
+		return nil
+	}
+	m := &NFT{
+		Chain:        string(chain),
+		TokenId:      a.TokenID,
+		Name:         a.Name,
+		Description:  a.Description,
+		ImageUrl:     a.ImageURL,
+		AnimationUrl: a.AnimationURL,
+		Permalink:    a.Permalink,
+		Owner:        FromAccount(a.Owner),
+		NumSales:     a.NumSales,
+		ExternalLink: a.ExternalLink,
+	}
+	if a.AssetContract != nil {
+		m.ContractAddress = a.AssetContract.Address.String()
+	}
+	if a.Collection != nil {
+		m.CollectionSlug = a.Collection.Slug
+	}
+	return m
+}
+
+func (m *NFT) ToAsset() *opensea.Asset {
+	if m == nil {
+		return nil
+	}
+	a := &opensea.Asset{
+		TokenID:      m.TokenId,
+		Name:         m.Name,
+		Description:  m.Description,
+		ImageURL:     m.ImageUrl,
+		AnimationURL: m.AnimationUrl,
+		Permalink:    m.Permalink,
+		Owner:        m.Owner.ToAccount(),
+		NumSales:     m.NumSales,
+		ExternalLink: m.ExternalLink,
+	}
+	if m.ContractAddress != "" {
+		a.AssetContract = &opensea.AssetContract{Address: opensea.Address(m.ContractAddress)}
+	}
+	if m.CollectionSlug != "" {
+		a.Collection = &opensea.Collection{Slug: m.CollectionSlug}
+	}
+	return a
+}
+
+func FromCollection(c *opensea.Collection) *Collection {
+	if c == nil {
+		return nil
+	}
+	return &Collection{
+		Slug:            c.Slug,
+		Name:            c.Name,
+		Description:     c.Description,
+		ImageUrl:        c.ImageUrl,
+		BannerImageUrl:  c.BannerImageUrl,
+		ExternalUrl:     c.ExternalUrl,
+		TwitterUsername: c.TwitterUsername,
+		DiscordUrl:      c.DiscordUrl,
+		PayoutAddress:   c.PayoutAddress,
+		CreatedDate:     c.CreatedDate,
+	}
+}
+
+func (m *Collection) ToCollection() *opensea.Collection {
+	if m == nil {
+		return nil
+	}
+	return &opensea.Collection{
+		Slug:            m.Slug,
+		Name:            m.Name,
+		Description:     m.Description,
+		ImageUrl:        m.ImageUrl,
+		BannerImageUrl:  m.BannerImageUrl,
+		ExternalUrl:     m.ExternalUrl,
+		TwitterUsername: m.TwitterUsername,
+		DiscordUrl:      m.DiscordUrl,
+		PayoutAddress:   m.PayoutAddress,
+		CreatedDate:     m.CreatedDate,
+	}
+}
+
+func FromOrder(chain opensea.Chain, o *opensea.Order) *Order {
+	if o == nil {
+		return nil
+	}
+	m := &Order{
+		Id:             o.ID,
+		Nft:            FromAsset(chain, &o.Asset),
+		Maker:          FromAccount(&o.Maker),
+		Taker:          FromAccount(&o.Taker),
+		Side:           Order_Side(o.Side),
+		SaleKind:       Order_SaleKind(o.SaleKind),
+		CurrentPrice:   string(o.CurrentPrice),
+		BasePrice:      string(o.BasePrice),
+		PaymentToken:   o.PaymentToken.String(),
+		Quantity:       o.Quantity,
+		Exchange:       o.Exchange.String(),
+		ListingTime:    o.ListingTime,
+		ExpirationTime: o.ExpirationTime,
+		Cancelled:      o.Cancelled,
+		Finalized:      o.Finalized,
+	}
+	if o.CreatedDate != nil {
+		m.CreatedTime = o.CreatedDate.Time().Unix()
+	}
+	return m
+}
+
+func (m *Order) ToOrder() *opensea.Order {
+	if m == nil {
+		return nil
+	}
+	o := &opensea.Order{
+		ID:             m.Id,
+		Side:           opensea.Side(m.Side),
+		SaleKind:       opensea.SaleKind(m.SaleKind),
+		CurrentPrice:   opensea.Number(m.CurrentPrice),
+		BasePrice:      opensea.Number(m.BasePrice),
+		PaymentToken:   opensea.Address(m.PaymentToken),
+		Quantity:       m.Quantity,
+		Exchange:       opensea.Address(m.Exchange),
+		ListingTime:    m.ListingTime,
+		ExpirationTime: m.ExpirationTime,
+		Cancelled:      m.Cancelled,
+		Finalized:      m.Finalized,
+	}
+	if a := m.Nft.ToAsset(); a != nil {
+		o.Asset = *a
+	}
+	if a := m.Maker.ToAccount(); a != nil {
+		o.Maker = *a
+	}
+	if a := m.Taker.ToAccount(); a != nil {
+		o.Taker = *a
+	}
+	if m.CreatedTime != 0 {
+		t := opensea.TimeNano(time.Unix(m.CreatedTime, 0).UTC())
+		o.CreatedDate = &t
+	}
+	return o
+}
+
+func FromEvent(chain opensea.Chain, e *opensea.Event) *Event {
+	if e == nil {
+		return nil
+	}
+	m := &Event{
+		Id:              e.ID,
+		EventType:       string(e.EventType),
+		CollectionSlug:  e.CollectionSlug,
+		ContractAddress: e.ContractAddress.String(),
+		Nft:             FromAsset(chain, e.Asset),
+		Quantity:        e.Quantity,
+		TotalPrice:      string(e.TotalPrice),
+		BidAmount:       string(e.BidAmount),
+		Seller:          FromAccount(e.Seller),
+		Winner:          FromAccount(e.WinnerAccount),
+		From:            FromAccount(e.FromAccount),
+		To:              FromAccount(e.ToAccount),
+	}
+	if ts := e.EventTimestamp.Time(); !ts.IsZero() {
+		m.Timestamp = ts.Unix()
+	}
+	if e.PaymentToken != nil {
+		m.PaymentToken = &PaymentToken{
+			Symbol:   e.PaymentToken.Symbol,
+			Address:  e.PaymentToken.Address.String(),
+			Decimals: e.PaymentToken.Decimals,
+		}
+	}
+	if e.Transaction != nil {
+		m.TransactionHash = e.Transaction.TransactionHash
+	}
+	return m
+}
+
+func (m *Event) ToEvent() *opensea.Event {
+	if m == nil {
+		return nil
+	}
+	e := &opensea.Event{
+		ID:              m.Id,
+		EventType:       opensea.EventType(m.EventType),
+		CollectionSlug:  m.CollectionSlug,
+		ContractAddress: opensea.Address(m.ContractAddress),
+		Asset:           m.Nft.ToAsset(),
+		Quantity:        m.Quantity,
+		TotalPrice:      opensea.Number(m.TotalPrice),
+		BidAmount:       opensea.Number(m.BidAmount),
+		Seller:          m.Seller.ToAccount(),
+		WinnerAccount:   m.Winner.ToAccount(),
+		FromAccount:     m.From.ToAccount(),
+		ToAccount:       m.To.ToAccount(),
+	}
+	if m.Timestamp != 0 {
+		e.EventTimestamp = opensea.TimeNano(time.Unix(m.Timestamp, 0).UTC())
+	}
+	if m.PaymentToken != nil {
+		e.PaymentToken = &opensea.PaymentToken{
+			Symbol:   m.PaymentToken.Symbol,
+			Address:  opensea.Address(m.PaymentToken.Address),
+			Decimals: m.PaymentToken.Decimals,
+		}
+	}
+	if m.TransactionHash != "" {
+		e.Transaction = &opensea.Transaction{TransactionHash: m.TransactionHash}
+	}
+	return e
+}
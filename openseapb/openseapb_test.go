@@ -0,0 +1,98 @@
+package openseapb
+
+import (
+	"testing"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func roundTrip(m, got proto.Message) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(b, got)
+}
+
+func TestAccountWireFormat(t *testing.T) {
+	m := &Account{Address: "0xab", Username: "x"}
+	b, err := proto.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x0a, 4, '0', 'x', 'a', 'b', 0x12, 1, 'x'}, b)
+
+	// unknown fields of newer schemas are skipped
+	b = append(b, 0xa0, 0x01, 0x2a, 0x51, 1, 2, 3, 4, 5, 6, 7, 8)
+	got := new(Account)
+	assert.Nil(t, proto.Unmarshal(b, got))
+	assert.Equal(t, m.Address, got.Address)
+	assert.Equal(t, m.Username, got.Username)
+
+	assert.NotNil(t, proto.Unmarshal([]byte{0x0a, 10, 'a'}, got))
+}
+
+func TestEventRoundTrip(t *testing.T) {
+	ts := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	e := &opensea.Event{
+		ID:              42,
+		EventType:       opensea.EventTypeSuccessful,
+		EventTimestamp:  opensea.TimeNano(ts),
+		CollectionSlug:  "doodles-official",
+		ContractAddress: "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e",
+		Asset: &opensea.Asset{
+			TokenID:       "1",
+			AssetContract: &opensea.AssetContract{Address: "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"},
+			Collection:    &opensea.Collection{Slug: "doodles-official"},
+		},
+		TotalPrice:   "1000000000000000000",
+		PaymentToken: &opensea.PaymentToken{Symbol: "ETH", Decimals: 18},
+		Seller:       &opensea.Account{Address: "0x0000000000000000000000000000000000000001"},
+		Transaction:  &opensea.Transaction{TransactionHash: "0xabc"},
+	}
+
+	m := FromEvent(opensea.ChainEthereum, e)
+	got := new(Event)
+	assert.Nil(t, roundTrip(m, got))
+	assert.True(t, proto.Equal(m, got))
+	assert.Equal(t, "ethereum", got.Nft.Chain)
+
+	back := got.ToEvent()
+	assert.Equal(t, e.ID, back.ID)
+	assert.Equal(t, ts, back.EventTimestamp.Time())
+	assert.Equal(t, e.Asset.AssetContract.Address, back.Asset.AssetContract.Address)
+	assert.Equal(t, e.Seller.Address, back.Seller.Address)
+	assert.Equal(t, "0xabc", back.Transaction.TransactionHash)
+	assert.Nil(t, back.WinnerAccount)
+}
+
+func TestOrderRoundTrip(t *testing.T) {
+	created := opensea.TimeNano(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	o := &opensea.Order{
+		ID:           7,
+		Side:         opensea.Sell,
+		SaleKind:     opensea.DutchAuctions,
+		CurrentPrice: "5",
+		CreatedDate:  &created,
+		Cancelled:    true,
+	}
+
+	got := new(Order)
+	assert.Nil(t, roundTrip(FromOrder(opensea.ChainEthereum, o), got))
+	assert.Equal(t, Order_SELL, got.Side)
+
+	back := got.ToOrder()
+	assert.Equal(t, o.Side, back.Side)
+	assert.Equal(t, o.SaleKind, back.SaleKind)
+	assert.Equal(t, o.CurrentPrice, back.CurrentPrice)
+	assert.Equal(t, created.Time(), back.CreatedDate.Time())
+	assert.True(t, back.Cancelled)
+}
+
+func TestCollectionRoundTrip(t *testing.T) {
+	c := &opensea.Collection{Slug: "doodles-official", Name: "Doodles", ImageUrl: "https://example.com/a.png"}
+	got := new(Collection)
+	assert.Nil(t, roundTrip(FromCollection(c), got))
+	assert.Equal(t, c, got.ToCollection())
+}
@@ -0,0 +1,559 @@
+// Package openseatest provides an in-memory OpenSea server simulating the
+// lifecycle of orders, to test trading flows end to end without network:
+// posted listings and offers show up in order queries and in the events
+// endpoint, and test triggers fulfill or cancel them.
+package openseatest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// Order statuses.
+const (
+	StatusOpen      = "open"
+	StatusFulfilled = "fulfilled"
+	StatusCancelled = "cancelled"
+)
+
+// Order is an order held by the server.
+type Order struct {
+	opensea.SeaportOrder
+	Listing bool
+	Status  string
+}
+
+// Server is a fake OpenSea API. It is safe for concurrent use.
+type Server struct {
+	URL string
+	// Now is the clock of the events, time.Now by default.
+	Now func() time.Time
+
+	srv    *httptest.Server
+	mu     sync.Mutex
+	slugs  map[opensea.Address]string
+	orders map[string]*Order
+	events []opensea.Event
+	nextID uint64
+}
+
+func NewServer() *Server {
+	s := &Server{
+		Now:    time.Now,
+		slugs:  map[opensea.Address]string{},
+		orders: map[string]*Order{},
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a client of the server.
+func (s *Server) Client(opts ...opensea.Option) (*opensea.Opensea, error) {
+	o, err := opensea.NewOpensea("openseatest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	o.API = s.URL
+	return o, nil
+}
+
+// AddCollection maps the tokens of contract to the collection slug, for
+// the collection order endpoints and the slugs of events.
+func (s *Server) AddCollection(slug string, contract opensea.Address) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slugs[lower(contract)] = slug
+}
+
+// Order returns an order by hash.
+func (s *Server) Order(hash string) (Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.orders[strings.ToLower(hash)]
+	if !ok {
+		return Order{}, false
+	}
+	return *o, true
+}
+
+// Orders returns the open orders, oldest first.
+func (s *Server) Orders() []Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := []Order{}
+	for _, o := range s.open("", nil) {
+		ret = append(ret, *o)
+	}
+	return ret
+}
+
+// Events returns the events emitted so far, oldest first.
+func (s *Server) Events() []opensea.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]opensea.Event{}, s.events...)
+}
+
+// Fulfill fills an open order as taker: a listing is bought by taker, an
+// offer is accepted by taker. It emits the sale and the transfer of the
+// token.
+func (s *Server) Fulfill(hash string, taker opensea.Address) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.openOrder(hash)
+	if err != nil {
+		return err
+	}
+	o.Status = StatusFulfilled
+	seller, buyer := o.ProtocolData.Parameters.Offerer, lower(taker)
+	if !o.Listing {
+		seller, buyer = buyer, seller
+	}
+
+	sale := s.event(o, opensea.EventTypeSuccessful)
+	sale.Seller = &opensea.Account{Address: seller}
+	sale.WinnerAccount = &opensea.Account{Address: buyer}
+	sale.TotalPrice = o.Price.Value
+	s.emit(sale)
+
+	transfer := s.event(o, opensea.EventTypeTransfer)
+	transfer.FromAccount = &opensea.Account{Address: seller}
+	transfer.ToAccount = &opensea.Account{Address: buyer}
+	s.emit(transfer)
+	return nil
+}
+
+// Cancel cancels an open order, as its offerer would on chain.
+func (s *Server) Cancel(hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.openOrder(hash)
+	if err != nil {
+		return err
+	}
+	o.Status = StatusCancelled
+	if o.Listing {
+		e := s.event(o, opensea.EventTypeCancelled)
+		e.Seller = &opensea.Account{Address: o.ProtocolData.Parameters.Offerer}
+		s.emit(e)
+	} else {
+		e := s.event(o, opensea.EventTypeBidWithdrawn)
+		e.FromAccount = &opensea.Account{Address: o.ProtocolData.Parameters.Offerer}
+		e.BidAmount = o.Price.Value
+		s.emit(e)
+	}
+	return nil
+}
+
+func (s *Server) openOrder(hash string) (*Order, error) {
+	o, ok := s.orders[strings.ToLower(hash)]
+	if !ok {
+		return nil, fmt.Errorf("Unknown order %s", hash)
+	}
+	if o.Status != StatusOpen {
+		return nil, fmt.Errorf("Order %s is %s", hash, o.Status)
+	}
+	return o, nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	// /api/v2/orders/{chain}/seaport/{listings,offers}
+	case r.Method == "POST" && len(parts) == 6 && parts[2] == "orders" && parts[4] == opensea.DefaultProtocol.PathSegment():
+		s.postOrder(w, r, opensea.Chain(parts[3]), parts[5])
+	case r.Method == "GET" && len(parts) == 6 && parts[2] == "orders" && parts[4] == opensea.DefaultProtocol.PathSegment():
+		s.getOrders(w, r, opensea.Chain(parts[3]), parts[5])
+	// /api/v2/orders/chain/{chain}/protocol/{address}/{hash}
+	case r.Method == "GET" && len(parts) == 8 && parts[2] == "orders" && parts[3] == "chain":
+		s.mu.Lock()
+		o, ok := s.orders[strings.ToLower(parts[7])]
+		s.mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"order": o.SeaportOrder})
+	// /api/v2/{listings,offers}/collection/{slug}/{all,best}
+	case r.Method == "GET" && len(parts) == 6 && parts[3] == "collection":
+		s.collectionOrders(w, r, parts[2], parts[4], parts[5])
+	// /api/v2/{listings,offers}/collection/{slug}/nfts/{id}/best
+	case r.Method == "GET" && len(parts) == 8 && parts[3] == "collection" && parts[5] == "nfts" && parts[7] == "best":
+		s.bestTokenOrder(w, r, parts[2], parts[4], parts[6])
+	case r.Method == "GET" && strings.TrimSuffix(r.URL.Path, "/") == "/api/v1/events":
+		s.getEvents(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) postOrder(w http.ResponseWriter, r *http.Request, chain opensea.Chain, kind string) {
+	if kind != "listings" && kind != "offers" {
+		http.NotFound(w, r)
+		return
+	}
+	signed := new(opensea.SignedOrder)
+	if err := json.NewDecoder(r.Body).Decode(signed); err != nil {
+		writeError(w, err)
+		return
+	}
+	if signed.Signature == "" {
+		writeError(w, fmt.Errorf("Missing signature"))
+		return
+	}
+	h, err := signed.Parameters.Hash()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	hash := "0x" + hex.EncodeToString(h[:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if o, ok := s.orders[hash]; ok {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"order": o.SeaportOrder})
+		return
+	}
+	listing := kind == "listings"
+	o := &Order{
+		SeaportOrder: opensea.SeaportOrder{
+			OrderHash:       hash,
+			Chain:           chain,
+			Price:           orderPrice(signed.Parameters, listing),
+			ProtocolData:    opensea.ProtocolData{Parameters: signed.Parameters, Signature: signed.Signature},
+			ProtocolAddress: signed.ProtocolAddress,
+		},
+		Listing: listing,
+		Status:  StatusOpen,
+	}
+	if nft, ok := orderNFT(o); !ok || nft.Token == "" {
+		writeError(w, fmt.Errorf("Order has no NFT item"))
+		return
+	}
+	s.orders[hash] = o
+
+	if listing {
+		e := s.event(o, opensea.EventTypeCreated)
+		e.Seller = &opensea.Account{Address: signed.Parameters.Offerer}
+		e.StartingPrice = string(o.Price.Value)
+		s.emit(e)
+	} else {
+		e := s.event(o, opensea.EventTypeBidEntered)
+		e.FromAccount = &opensea.Account{Address: signed.Parameters.Offerer}
+		e.BidAmount = o.Price.Value
+		s.emit(e)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"order": o.SeaportOrder})
+}
+
+func (s *Server) collectionOrders(w http.ResponseWriter, r *http.Request, kind, slug, view string) {
+	if (kind != "listings" && kind != "offers") || (view != "all" && view != "best") {
+		http.NotFound(w, r)
+		return
+	}
+	listing := kind == "listings"
+	s.mu.Lock()
+	orders := s.open(slug, &listing)
+	s.mu.Unlock()
+	if view == "best" {
+		sort.SliceStable(orders, func(i, j int) bool {
+			c := orders[i].Price.Value.Big().Cmp(orders[j].Price.Value.Big())
+			if listing {
+				return c < 0
+			}
+			return c > 0
+		})
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 && limit < len(orders) {
+		orders = orders[:limit]
+	}
+	ret := make([]opensea.SeaportOrder, len(orders))
+	for i, o := range orders {
+		ret[i] = o.SeaportOrder
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{kind: ret})
+}
+
+// getOrders serves the orders endpoints, filtered by maker and token as
+// the API does.
+func (s *Server) getOrders(w http.ResponseWriter, r *http.Request, chain opensea.Chain, kind string) {
+	if kind != "listings" && kind != "offers" {
+		http.NotFound(w, r)
+		return
+	}
+	q := r.URL.Query()
+	listing := kind == "listings"
+	s.mu.Lock()
+	orders := s.open("", &listing)
+	s.mu.Unlock()
+
+	ret := []opensea.SeaportOrder{}
+	for _, o := range orders {
+		nft, _ := orderNFT(o)
+		if o.Chain != chain {
+			continue
+		}
+		if maker := q.Get("maker"); maker != "" && lower(o.ProtocolData.Parameters.Offerer) != lower(opensea.Address(maker)) {
+			continue
+		}
+		if c := q.Get("asset_contract_address"); c != "" && lower(nft.Token) != lower(opensea.Address(c)) {
+			continue
+		}
+		if ids := q["token_ids"]; len(ids) > 0 && !contains(ids, string(nft.IdentifierOrCriteria)) {
+			continue
+		}
+		ret = append(ret, orderView(o))
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 && limit < len(ret) {
+		ret = ret[:limit]
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"orders": ret, "next": ""})
+}
+
+// bestTokenOrder serves the cheapest listing or the highest offer on a
+// token of a collection, criteria offers included.
+func (s *Server) bestTokenOrder(w http.ResponseWriter, r *http.Request, kind, slug, tokenID string) {
+	if kind != "listings" && kind != "offers" {
+		http.NotFound(w, r)
+		return
+	}
+	listing := kind == "listings"
+	s.mu.Lock()
+	orders := s.open(slug, &listing)
+	s.mu.Unlock()
+
+	var best *Order
+	for _, o := range orders {
+		nft, _ := orderNFT(o)
+		criteria := nft.ItemType == opensea.ItemERC721WithCriteria || nft.ItemType == opensea.ItemERC1155WithCriteria
+		if !criteria && string(nft.IdentifierOrCriteria) != tokenID {
+			continue
+		}
+		if best == nil {
+			best = o
+			continue
+		}
+		c := o.Price.Value.Big().Cmp(best.Price.Value.Big())
+		if (listing && c < 0) || (!listing && c > 0) {
+			best = o
+		}
+	}
+	if best == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, orderView(best))
+}
+
+func (s *Server) getEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	after, _ := strconv.ParseInt(q.Get("occurred_after"), 10, 64)
+	before, err := strconv.ParseInt(q.Get("occurred_before"), 10, 64)
+	if err != nil {
+		before = 1<<63 - 1
+	}
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+
+	s.mu.Lock()
+	ret := []opensea.Event{}
+	// newest first, as the API
+	for i := len(s.events) - 1; i >= 0; i-- {
+		e := s.events[i]
+		ts := e.EventTimestamp.Time().Unix()
+		if ts < after || ts > before {
+			continue
+		}
+		if t := q.Get("event_type"); t != "" && string(e.EventType) != t {
+			continue
+		}
+		if slug := q.Get("collection_slug"); slug != "" && e.CollectionSlug != slug {
+			continue
+		}
+		if c := q.Get("asset_contract_address"); c != "" && string(e.Asset.AssetContract.Address) != strings.ToLower(c) {
+			continue
+		}
+		if id := q.Get("token_id"); id != "" && e.Asset.TokenID != id {
+			continue
+		}
+		ret = append(ret, e)
+	}
+	s.mu.Unlock()
+
+	if offset > len(ret) {
+		offset = len(ret)
+	}
+	ret = ret[offset:]
+	if limit < len(ret) {
+		ret = ret[:limit]
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"asset_events": ret})
+}
+
+// open returns the open orders, of slug and of the kind of listing when
+// set, oldest first. It must be called with the lock held.
+func (s *Server) open(slug string, listing *bool) []*Order {
+	ret := []*Order{}
+	for _, o := range s.orders {
+		if o.Status != StatusOpen || (listing != nil && o.Listing != *listing) {
+			continue
+		}
+		if slug != "" {
+			nft, _ := orderNFT(o)
+			if s.slugs[lower(nft.Token)] != slug {
+				continue
+			}
+		}
+		ret = append(ret, o)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].ProtocolData.Parameters.StartTime.Big().Cmp(ret[j].ProtocolData.Parameters.StartTime.Big()) < 0 ||
+			(ret[i].ProtocolData.Parameters.StartTime == ret[j].ProtocolData.Parameters.StartTime && ret[i].OrderHash < ret[j].OrderHash)
+	})
+	return ret
+}
+
+// event returns an event of the token of o. It must be called with the
+// lock held.
+func (s *Server) event(o *Order, t opensea.EventType) opensea.Event {
+	nft, _ := orderNFT(o)
+	contract := lower(nft.Token)
+	return opensea.Event{
+		EventType:      t,
+		EventTimestamp: opensea.TimeNano(s.Now().UTC()),
+		CollectionSlug: s.slugs[contract],
+		PaymentToken:   &opensea.PaymentToken{Symbol: o.Price.Currency, Decimals: o.Price.Decimals},
+		Quantity:       string(nft.StartAmount),
+		Asset: &opensea.Asset{
+			TokenID:       string(nft.IdentifierOrCriteria),
+			AssetContract: &opensea.AssetContract{Address: contract},
+		},
+	}
+}
+
+// emit records e. It must be called with the lock held.
+func (s *Server) emit(e opensea.Event) {
+	s.nextID++
+	e.ID = s.nextID
+	s.events = append(s.events, e)
+}
+
+// orderNFT returns the NFT of an order: offered by a listing, considered
+// by an offer.
+func orderNFT(o *Order) (opensea.OfferItem, bool) {
+	p := o.ProtocolData.Parameters
+	if o.Listing {
+		for _, item := range p.Offer {
+			if item.ItemType >= opensea.ItemERC721 {
+				return item, true
+			}
+		}
+		return opensea.OfferItem{}, false
+	}
+	for _, item := range p.Consideration {
+		if item.ItemType >= opensea.ItemERC721 {
+			return opensea.OfferItem{
+				ItemType:             item.ItemType,
+				Token:                item.Token,
+				IdentifierOrCriteria: item.IdentifierOrCriteria,
+				StartAmount:          item.StartAmount,
+				EndAmount:            item.EndAmount,
+			}, true
+		}
+	}
+	return opensea.OfferItem{}, false
+}
+
+// orderPrice is the total of the currency items of a listing's
+// consideration or of an offer.
+func orderPrice(p opensea.OrderParameters, listing bool) opensea.SeaportOrderPrice {
+	total := new(big.Int)
+	currency := "ETH"
+	add := func(itemType opensea.ItemType, amount opensea.Number) {
+		if itemType > opensea.ItemERC20 {
+			return
+		}
+		if itemType == opensea.ItemERC20 {
+			currency = "WETH"
+		}
+		if n := amount.Big(); n != nil {
+			total.Add(total, n)
+		}
+	}
+	if listing {
+		for _, item := range p.Consideration {
+			add(item.ItemType, item.StartAmount)
+		}
+	} else {
+		for _, item := range p.Offer {
+			add(item.ItemType, item.StartAmount)
+		}
+	}
+	price := opensea.OrderPrice{Currency: currency, Decimals: 18, Value: opensea.Number(total.String())}
+	ret := opensea.SeaportOrderPrice{OrderPrice: price}
+	if listing {
+		ret.Current = &price
+	}
+	return ret
+}
+
+// orderView is the SeaportOrder of o with the fields of the orders
+// endpoints set.
+func orderView(o *Order) opensea.SeaportOrder {
+	ret := o.SeaportOrder
+	ret.Maker = &opensea.Account{Address: lower(o.ProtocolData.Parameters.Offerer)}
+	ret.CurrentPrice = o.Price.Value
+	ret.Side = "bid"
+	if o.Listing {
+		ret.Side = "ask"
+	}
+	if n := o.ProtocolData.Parameters.StartTime.Big(); n != nil && n.IsInt64() {
+		ret.ListingTime = n.Int64()
+	}
+	if n := o.ProtocolData.Parameters.EndTime.Big(); n != nil && n.IsInt64() {
+		ret.ExpirationTime = n.Int64()
+	}
+	return ret
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func lower(a opensea.Address) opensea.Address {
+	return opensea.Address(strings.ToLower(a.String()))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false, "errors": []string{err.Error()}})
+}
@@ -0,0 +1,189 @@
+package openseatest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	seller  opensea.Address = "0x1111111111111111111111111111111111111111"
+	buyer   opensea.Address = "0x2222222222222222222222222222222222222222"
+	doodles opensea.Address = "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"
+)
+
+type testSigner struct {
+	address opensea.Address
+}
+
+func (s testSigner) Address() opensea.Address {
+	return s.address
+}
+
+func (s testSigner) SignDigest(ctx context.Context, digest [32]byte) ([]byte, error) {
+	sig := make([]byte, 65)
+	copy(sig, digest[:])
+	return sig, nil
+}
+
+func sign(t *testing.T, params *opensea.OrderParameters, signer opensea.Address) *opensea.SignedOrder {
+	order, err := opensea.SignOrder(context.Background(), opensea.ChainEthereum, opensea.DefaultProtocol, params, testSigner{signer})
+	assert.Nil(t, err)
+	return order
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddCollection("doodles-official", doodles)
+	o, err := s.Client()
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	listing, err := opensea.BuildListing(opensea.ListingParams{
+		Offerer: seller,
+		Items:   []opensea.ListingItem{opensea.ERC721Item(doodles, "1")},
+		Price:   "2000",
+	})
+	assert.Nil(t, err)
+	_, err = o.PostPath(ctx, "/api/v2/orders/ethereum/seaport/listings", sign(t, listing, seller))
+	assert.Nil(t, err)
+
+	bid, err := opensea.BuildBid(opensea.BidParams{Bidder: buyer, Item: opensea.ERC721Item(doodles, "1"), Amount: "1500"})
+	assert.Nil(t, err)
+	offer, err := o.CreateOfferWithContext(ctx, opensea.ChainEthereum, sign(t, bid, buyer))
+	assert.Nil(t, err)
+
+	// posting again is idempotent
+	_, err = o.CreateOfferWithContext(ctx, opensea.ChainEthereum, sign(t, bid, buyer))
+	assert.Nil(t, err)
+	assert.Len(t, s.Orders(), 2)
+
+	book, err := o.SnapshotOrderBookWithContext(ctx, "doodles-official")
+	assert.Nil(t, err)
+	assert.Len(t, book.Listings, 1)
+	assert.Len(t, book.Offers, 1)
+	assert.Equal(t, opensea.Number("2000"), book.Listings[0].Price.Current.Value)
+	assert.Equal(t, opensea.Number("1500"), book.Offers[0].Price.Value)
+
+	got, err := o.GetOrderByHashWithContext(ctx, opensea.ChainEthereum, offer.ProtocolAddress, offer.OrderHash)
+	assert.Nil(t, err)
+	assert.Equal(t, offer.OrderHash, got.OrderHash)
+
+	assert.Nil(t, s.Fulfill(offer.OrderHash, seller))
+	assert.NotNil(t, s.Fulfill(offer.OrderHash, seller))
+	book, err = o.SnapshotOrderBookWithContext(ctx, "doodles-official")
+	assert.Nil(t, err)
+	assert.Len(t, book.Offers, 0)
+
+	params := opensea.NewRetrievingEventsParams()
+	params.AssetContractAddress = doodles
+	params.OccurredAfter = time.Now().Add(-time.Minute).Unix()
+	params.OccurredBefore = time.Now().Add(time.Minute).Unix()
+	events, err := o.RetrievingEventsWithContext(ctx, params)
+	assert.Nil(t, err)
+	types := []opensea.EventType{}
+	for _, e := range events {
+		types = append(types, e.EventType)
+	}
+	assert.Equal(t, []opensea.EventType{opensea.EventTypeTransfer, opensea.EventTypeSuccessful, opensea.EventTypeBidEntered, opensea.EventTypeCreated}, types)
+	assert.Equal(t, seller, events[1].Seller.Address)
+	assert.Equal(t, buyer, events[1].WinnerAccount.Address)
+	assert.Equal(t, "doodles-official", events[1].CollectionSlug)
+}
+
+func TestEventPoller(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	o, err := s.Client()
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	params := opensea.NewRetrievingEventsParams()
+	params.OccurredAfter = time.Now().Add(-time.Minute).Unix()
+	poller := o.PollEvents(ctx, params, 10*time.Millisecond)
+
+	listing, err := opensea.BuildListing(opensea.ListingParams{
+		Offerer: seller,
+		Items:   []opensea.ListingItem{opensea.ERC721Item(doodles, "2")},
+		Price:   "1000",
+	})
+	assert.Nil(t, err)
+	signed := sign(t, listing, seller)
+	_, err = o.PostPath(ctx, "/api/v2/orders/ethereum/seaport/listings", signed)
+	assert.Nil(t, err)
+	assert.Nil(t, s.Cancel(s.Orders()[0].OrderHash))
+
+	var got []opensea.EventType
+	for e := range poller.Events() {
+		got = append(got, e.EventType)
+		if len(got) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []opensea.EventType{opensea.EventTypeCreated, opensea.EventTypeCancelled}, got)
+}
+
+func TestOrderQueries(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddCollection("doodles-official", doodles)
+	o, err := s.Client()
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	for _, l := range []struct{ tokenID, price string }{{"1", "2000"}, {"1", "1800"}, {"2", "3000"}} {
+		listing, err := opensea.BuildListing(opensea.ListingParams{
+			Offerer: seller,
+			Items:   []opensea.ListingItem{opensea.ERC721Item(doodles, l.tokenID)},
+			Price:   opensea.Number(l.price),
+		})
+		assert.Nil(t, err)
+		_, err = o.CreateListingWithContext(ctx, opensea.ChainEthereum, sign(t, listing, seller))
+		assert.Nil(t, err)
+	}
+	for _, amount := range []opensea.Number{"1500", "1700"} {
+		bid, err := opensea.BuildBid(opensea.BidParams{Bidder: buyer, Item: opensea.ERC721Item(doodles, "1"), Amount: amount})
+		assert.Nil(t, err)
+		_, err = o.CreateOfferWithContext(ctx, opensea.ChainEthereum, sign(t, bid, buyer))
+		assert.Nil(t, err)
+	}
+
+	resp, err := o.GetSeaportOrdersWithContext(ctx, opensea.GetSeaportOrdersParams{
+		Maker:                seller,
+		AssetContractAddress: doodles,
+		TokenIDs:             []string{"2"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Orders, 1)
+	assert.Equal(t, "ask", resp.Orders[0].Side)
+	assert.Equal(t, opensea.Number("3000"), resp.Orders[0].CurrentPrice)
+
+	listings, err := o.GetAccountListingsWithContext(ctx, seller, opensea.AccountOrdersParams{})
+	assert.Nil(t, err)
+	assert.Len(t, listings.Orders, 3)
+	listings, err = o.GetAccountListingsWithContext(ctx, buyer, opensea.AccountOrdersParams{})
+	assert.Nil(t, err)
+	assert.Len(t, listings.Orders, 0)
+
+	offers, err := o.GetAccountOffersWithContext(ctx, buyer, opensea.AccountOffersParams{})
+	assert.Nil(t, err)
+	assert.Len(t, offers.Orders, 2)
+	assert.Equal(t, buyer, offers.Orders[0].Maker.Address)
+
+	best, err := o.GetBestListingWithContext(ctx, "doodles-official", "1")
+	assert.Nil(t, err)
+	assert.Equal(t, opensea.Number("1800"), best.Price.Current.Value)
+	best, err = o.GetBestOfferWithContext(ctx, "doodles-official", "1")
+	assert.Nil(t, err)
+	assert.Equal(t, opensea.Number("1700"), best.Price.Value)
+
+	_, err = o.GetBestListingWithContext(ctx, "doodles-official", "3")
+	assert.Equal(t, opensea.ErrNotFound, err)
+	_, err = o.GetBestOfferWithContext(ctx, "doodles-official", "2")
+	assert.Equal(t, opensea.ErrNotFound, err)
+}
@@ -0,0 +1,121 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPriceTTL is the time a PaymentTokenOracle caches a price.
+const DefaultPriceTTL = 5 * time.Minute
+
+// PriceOracle gives the USD price of currencies, so that conversions to
+// fiat agree across the library. The token is a payment token address;
+// empty or the null address for the native currency of the chain, e.g. ETH
+// on Ethereum or MATIC on Polygon.
+type PriceOracle interface {
+	USDPrice(ctx context.Context, chain Chain, token Address) (float64, error)
+}
+
+// WithPriceOracle sets the oracle of the USD conversions of the client,
+// such as the USD estimate of valuations. It defaults to a
+// PaymentTokenOracle.
+func WithPriceOracle(oracle PriceOracle) Option {
+	return func(o *Opensea) {
+		o.oracle = oracle
+	}
+}
+
+// PriceOracle returns the oracle of the client.
+func (o Opensea) PriceOracle() PriceOracle {
+	if o.oracle != nil {
+		return o.oracle
+	}
+	return o.NewPaymentTokenOracle(DefaultPriceTTL)
+}
+
+// ToUSD converts amount, in whole units of token, to USD.
+func ToUSD(ctx context.Context, oracle PriceOracle, chain Chain, token Address, amount float64) (float64, error) {
+	price, err := oracle.USDPrice(ctx, chain, token)
+	if err != nil {
+		return 0, err
+	}
+	return amount * price, nil
+}
+
+// CrossRate returns the price of one unit of from in units of to, e.g. of
+// ETH on Ethereum in MATIC on Polygon, through their USD prices.
+func CrossRate(ctx context.Context, oracle PriceOracle, fromChain Chain, from Address, toChain Chain, to Address) (float64, error) {
+	fromPrice, err := oracle.USDPrice(ctx, fromChain, from)
+	if err != nil {
+		return 0, err
+	}
+	toPrice, err := oracle.USDPrice(ctx, toChain, to)
+	if err != nil {
+		return 0, err
+	}
+	if toPrice <= 0 {
+		return 0, fmt.Errorf("No USD price of %s on %s", to, toChain)
+	}
+	return fromPrice / toPrice, nil
+}
+
+// PaymentTokenOracle is the PriceOracle reading the USD prices OpenSea
+// gives its payment tokens, cached for TTL.
+type PaymentTokenOracle struct {
+	TTL time.Duration
+
+	o      Opensea
+	mu     sync.Mutex
+	prices map[string]cachedPrice
+}
+
+type cachedPrice struct {
+	usd float64
+	at  time.Time
+}
+
+func (o Opensea) NewPaymentTokenOracle(ttl time.Duration) *PaymentTokenOracle {
+	return &PaymentTokenOracle{TTL: ttl, o: o, prices: map[string]cachedPrice{}}
+}
+
+func (p *PaymentTokenOracle) USDPrice(ctx context.Context, chain Chain, token Address) (float64, error) {
+	chain, err := p.o.resolveChain(chain)
+	if err != nil {
+		return 0, err
+	}
+	if token == "" {
+		token = NullAddress
+	}
+	key := chain.String() + "/" + strings.ToLower(token.String())
+	p.mu.Lock()
+	cached, ok := p.prices[key]
+	p.mu.Unlock()
+	if ok && time.Since(cached.at) < p.TTL {
+		return cached.usd, nil
+	}
+
+	usd, err := p.o.paymentTokenUSDPrice(ctx, chain, token)
+	if err != nil {
+		return 0, err
+	}
+	p.mu.Lock()
+	p.prices[key] = cachedPrice{usd: usd, at: time.Now()}
+	p.mu.Unlock()
+	return usd, nil
+}
+
+// paymentTokenUSDPrice reads the USD price of a payment token.
+func (o Opensea) paymentTokenUSDPrice(ctx context.Context, chain Chain, token Address) (float64, error) {
+	t, err := o.GetPaymentTokenWithContext(ctx, chain, token)
+	if err != nil {
+		return 0, err
+	}
+	usd, ok := t.USDPrice()
+	if !ok || usd <= 0 {
+		return 0, fmt.Errorf("No USD price of %s on %s", token, chain)
+	}
+	return usd, nil
+}
@@ -0,0 +1,55 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentTokenOracle(t *testing.T) {
+	const usdc = Address("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	calls := map[string]int{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/api/v2/chain/ethereum/payment_token/" + NullAddress.String():
+			w.Write([]byte(`{"symbol":"ETH","decimals":18,"usd_price":"3000.5"}`))
+		case "/api/v2/chain/matic/payment_token/" + NullAddress.String():
+			w.Write([]byte(`{"symbol":"MATIC","decimals":18,"usd_price":0.5}`))
+		case "/api/v2/chain/ethereum/payment_token/" + usdc.String():
+			w.Write([]byte(`{"symbol":"USDC","decimals":6,"usd_price":""}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+	oracle := o.NewPaymentTokenOracle(time.Minute)
+
+	usd, err := oracle.USDPrice(ctx, ChainEthereum, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 3000.5, usd)
+	usd, err = ToUSD(ctx, oracle, ChainEthereum, NullAddress, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 6001.0, usd)
+	assert.Equal(t, 1, calls["/api/v2/chain/ethereum/payment_token/"+NullAddress.String()])
+
+	rate, err := CrossRate(ctx, oracle, ChainEthereum, "", ChainPolygon, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 6001.0, rate)
+
+	_, err = oracle.USDPrice(ctx, ChainEthereum, usdc)
+	assert.NotNil(t, err)
+}
+
+func TestWithPriceOracle(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	_, ok := o.PriceOracle().(*PaymentTokenOracle)
+	assert.True(t, ok)
+
+	oracle := o.NewPaymentTokenOracle(0)
+	o = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {}, WithPriceOracle(oracle))
+	assert.Equal(t, oracle, o.PriceOracle())
+}
@@ -40,7 +40,10 @@ type Order struct {
 	StaticTarget       Address   `json:"static_target" bson:"static_target"`
 	StaticExtradata    Bytes     `json:"static_extradata" bson:"static_extradata"`
 	PaymentToken       Address   `json:"payment_token" bson:"payment_token"`
-	// PaymentTokenContract PaymentTokenContract `json:"payment_token_contract" bson:"payment_token_contract"`
+
+	// PaymentTokenContract describes PaymentToken, with its decimals.
+	PaymentTokenContract *PaymentToken `json:"payment_token_contract,omitempty" bson:"payment_token_contract,omitempty"`
+
 	BasePrice       Number `json:"base_price" bson:"base_price"`
 	Extra           Number `json:"extra" bson:"extra"`
 	Quantity        string `json:"quantity" bson:"quantity"`
@@ -106,6 +109,22 @@ func (o Opensea) GetOrders(assetContractAddress string, listedAfter int64) ([]*O
 }
 
 func (o Opensea) GetOrdersWithContext(ctx context.Context, assetContractAddress string, listedAfter int64) (orders []*Order, err error) {
+	orders = []*Order{}
+	err = o.GetOrdersPagesWithContext(ctx, assetContractAddress, listedAfter, func(page []*Order) error {
+		orders = append(orders, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetOrdersPagesWithContext is like GetOrdersWithContext but hands each page
+// to fn as soon as it is fetched. Iteration stops at the first error
+// returned by fn.
+func (o Opensea) GetOrdersPagesWithContext(ctx context.Context, assetContractAddress string, listedAfter int64, fn func(orders []*Order) error) error {
+	o.deprecated("GetOrders")
 	offset := 0
 	limit := 100
 
@@ -116,14 +135,12 @@ func (o Opensea) GetOrdersWithContext(ctx context.Context, assetContractAddress
 	q.Set("order_by", "created_date")
 	q.Set("order_direction", "asc")
 
-	orders = []*Order{}
-
 	for true {
 		q.Set("offset", fmt.Sprintf("%d", offset))
 		path := "/wyvern/v1/orders?" + q.Encode()
 		b, err := o.GetPath(ctx, path)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		out := &struct {
@@ -133,9 +150,12 @@ func (o Opensea) GetOrdersWithContext(ctx context.Context, assetContractAddress
 
 		err = json.Unmarshal(b, out)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		orders = append(orders, out.Orders...)
+		if err := fn(out.Orders); err != nil {
+			return err
+		}
+		reportProgress(ctx, len(out.Orders))
 
 		if len(out.Orders) < limit {
 			break
@@ -143,5 +163,5 @@ func (o Opensea) GetOrdersWithContext(ctx context.Context, assetContractAddress
 		offset += limit
 	}
 
-	return
+	return nil
 }
@@ -0,0 +1,494 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxOrderBookPages bounds the pages of orders read for a collection.
+const maxOrderBookPages = 20
+
+// OrderPrice is an amount in the smallest unit of a currency.
+type OrderPrice struct {
+	Currency string `json:"currency" bson:"currency"`
+	Decimals int64  `json:"decimals" bson:"decimals"`
+	Value    Number `json:"value" bson:"value"`
+}
+
+// Float returns the price in whole units of its currency.
+func (p OrderPrice) Float() float64 {
+	v, ok := new(big.Float).SetString(string(p.Value))
+	if !ok {
+		return 0
+	}
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(p.Decimals), nil))
+	f, _ := v.Quo(v, scale).Float64()
+	return f
+}
+
+// SeaportOrderPrice is the price of an offer, or of a listing under Current.
+type SeaportOrderPrice struct {
+	OrderPrice `bson:",inline"`
+	Current    *OrderPrice `json:"current,omitempty" bson:"current,omitempty"`
+}
+
+// ProtocolData is the signed Seaport order behind an order of the order
+// book.
+type ProtocolData struct {
+	Parameters OrderParameters `json:"parameters" bson:"parameters"`
+	Signature  string          `json:"signature" bson:"signature"`
+}
+
+// SeaportOrder is a listing or an offer of the v2 order book. The
+// collection endpoints set Price; the orders endpoints set the maker, taker,
+// status and time fields and CurrentPrice instead.
+type SeaportOrder struct {
+	OrderHash       string            `json:"order_hash" bson:"order_hash"`
+	Chain           Chain             `json:"chain" bson:"chain"`
+	Price           SeaportOrderPrice `json:"price" bson:"price"`
+	ProtocolData    ProtocolData      `json:"protocol_data" bson:"protocol_data"`
+	ProtocolAddress Address           `json:"protocol_address" bson:"protocol_address"`
+
+	CreatedDate    string   `json:"created_date,omitempty" bson:"created_date,omitempty"`
+	ListingTime    int64    `json:"listing_time,omitempty" bson:"listing_time,omitempty"`
+	ExpirationTime int64    `json:"expiration_time,omitempty" bson:"expiration_time,omitempty"`
+	Maker          *Account `json:"maker,omitempty" bson:"maker,omitempty"`
+	Taker          *Account `json:"taker,omitempty" bson:"taker,omitempty"`
+	// CurrentPrice is in the smallest unit of the payment token.
+	CurrentPrice Number `json:"current_price,omitempty" bson:"current_price,omitempty"`
+	// Side is "ask" for listings and "bid" for offers.
+	Side          string `json:"side,omitempty" bson:"side,omitempty"`
+	OrderType     string `json:"order_type,omitempty" bson:"order_type,omitempty"`
+	Cancelled     bool   `json:"cancelled,omitempty" bson:"cancelled,omitempty"`
+	Finalized     bool   `json:"finalized,omitempty" bson:"finalized,omitempty"`
+	MarkedInvalid bool   `json:"marked_invalid,omitempty" bson:"marked_invalid,omitempty"`
+	// Criteria selects the items wanted by criteria offers.
+	Criteria *OfferCriteria `json:"criteria,omitempty" bson:"criteria,omitempty"`
+	// FetchedAt is the time the order was read from the order book.
+	FetchedAt time.Time `json:"fetched_at,omitempty" bson:"fetched_at,omitempty"`
+}
+
+func (o SeaportOrder) price() OrderPrice {
+	if o.Price.Current != nil {
+		return *o.Price.Current
+	}
+	if o.Price.Value == "" && o.CurrentPrice != "" {
+		// the orders endpoints only give the amount, in the 18 decimals of
+		// the native and wrapped native tokens
+		return OrderPrice{Decimals: 18, Value: o.CurrentPrice}
+	}
+	return o.Price.OrderPrice
+}
+
+// nftQuantity sums the amounts of the NFT items of the order: the offer of
+// a listing or the consideration of an offer.
+func (o SeaportOrder) nftQuantity(listing bool) int64 {
+	var amounts []Number
+	if listing {
+		for _, item := range o.ProtocolData.Parameters.Offer {
+			if item.ItemType >= ItemERC721 {
+				amounts = append(amounts, item.StartAmount)
+			}
+		}
+	} else {
+		for _, item := range o.ProtocolData.Parameters.Consideration {
+			if item.ItemType >= ItemERC721 {
+				amounts = append(amounts, item.StartAmount)
+			}
+		}
+	}
+	var total int64
+	for _, a := range amounts {
+		if n := a.Big(); n != nil && n.IsInt64() {
+			total += n.Int64()
+		}
+	}
+	if total <= 0 {
+		total = 1
+	}
+	return total
+}
+
+// unitPrice is the price of one item of the order.
+func (o SeaportOrder) unitPrice(listing bool) float64 {
+	return o.price().Float() / float64(o.nftQuantity(listing))
+}
+
+// listedTokens returns the contract/identifier keys of the items of a
+// listing.
+func (o SeaportOrder) listedTokens() []string {
+	ret := []string{}
+	for _, item := range o.ProtocolData.Parameters.Offer {
+		if item.ItemType >= ItemERC721 {
+			ret = append(ret, strings.ToLower(item.Token.String())+"/"+string(item.IdentifierOrCriteria))
+		}
+	}
+	return ret
+}
+
+// CollectionOrdersParams page the listings or offers of a collection.
+type CollectionOrdersParams struct {
+	// Cursor is the Next cursor of the previous page.
+	Cursor string
+	// Limit defaults to 100, the maximum.
+	Limit int
+}
+
+// CollectionOrdersResponse is a page of the listings or offers of a
+// collection. Next is empty on the last page.
+type CollectionOrdersResponse struct {
+	Listings []SeaportOrder `json:"listings,omitempty" bson:"listings,omitempty"`
+	Offers   []SeaportOrder `json:"offers,omitempty" bson:"offers,omitempty"`
+	Next     string         `json:"next" bson:"next"`
+}
+
+// GetCollectionListings returns a page of the active listings of a
+// collection; pass its Next cursor in the params to read the following
+// page.
+func (o Opensea) GetCollectionListings(slug string, params CollectionOrdersParams) (*CollectionOrdersResponse, error) {
+	ctx := context.TODO()
+	return o.GetCollectionListingsWithContext(ctx, slug, params)
+}
+
+func (o Opensea) GetCollectionListingsWithContext(ctx context.Context, slug string, params CollectionOrdersParams) (*CollectionOrdersResponse, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("Empty collection slug")
+	}
+	return o.collectionOrdersPage(ctx, ChainNone, slug, true, params)
+}
+
+// CollectionListingsIterator yields the SeaportOrder values of every page
+// of the listings of a collection, read with the priority of its profile.
+func (o Opensea) CollectionListingsIterator(slug string) Iterator {
+	return func(ctx context.Context, yield func(item interface{}) error) error {
+		ctx = o.contextWithProfile(ctx, slug)
+		params := CollectionOrdersParams{}
+		for {
+			resp, err := o.GetCollectionListingsWithContext(ctx, slug, params)
+			if err != nil {
+				return err
+			}
+			for _, l := range resp.Listings {
+				if err := yield(l); err != nil {
+					return err
+				}
+			}
+			reportProgress(ctx, len(resp.Listings))
+			if resp.Next == "" {
+				return nil
+			}
+			params.Cursor = resp.Next
+		}
+	}
+}
+
+// GetCollectionOffers returns a page of the active offers on the items of
+// a collection, criteria offers included; pass its Next cursor in the
+// params to read the following page.
+func (o Opensea) GetCollectionOffers(slug string, params CollectionOrdersParams) (*CollectionOrdersResponse, error) {
+	ctx := context.TODO()
+	return o.GetCollectionOffersWithContext(ctx, slug, params)
+}
+
+func (o Opensea) GetCollectionOffersWithContext(ctx context.Context, slug string, params CollectionOrdersParams) (*CollectionOrdersResponse, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("Empty collection slug")
+	}
+	return o.collectionOrdersPage(ctx, ChainNone, slug, false, params)
+}
+
+// CollectionOffersIterator yields the SeaportOrder values of every page of
+// the offers of a collection, read with the priority of its profile.
+func (o Opensea) CollectionOffersIterator(slug string) Iterator {
+	return func(ctx context.Context, yield func(item interface{}) error) error {
+		ctx = o.contextWithProfile(ctx, slug)
+		params := CollectionOrdersParams{}
+		for {
+			resp, err := o.GetCollectionOffersWithContext(ctx, slug, params)
+			if err != nil {
+				return err
+			}
+			for _, offer := range resp.Offers {
+				if err := yield(offer); err != nil {
+					return err
+				}
+			}
+			reportProgress(ctx, len(resp.Offers))
+			if resp.Next == "" {
+				return nil
+			}
+			params.Cursor = resp.Next
+		}
+	}
+}
+
+func (o Opensea) collectionOrdersPage(ctx context.Context, chain Chain, slug string, listings bool, params CollectionOrdersParams) (*CollectionOrdersResponse, error) {
+	capability, kind := CapabilityOffers, "offers"
+	if listings {
+		capability, kind = CapabilityListings, "listings"
+	}
+	chain, err := o.chainFor(chain, capability)
+	if err != nil {
+		return nil, err
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	q := url.Values{}
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	if params.Cursor != "" {
+		q.Set("next", params.Cursor)
+	}
+	b, err := o.GetPath(contextWithChain(ctx, chain), fmt.Sprintf("/api/v2/%s/collection/%s/all?%s", kind, url.PathEscape(slug), q.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	resp := &CollectionOrdersResponse{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	resp.Listings = o.annotateOrders(resp.Listings)
+	resp.Offers = o.annotateOrders(resp.Offers)
+	return resp, nil
+}
+
+// collectionOrders reads the listings or offers of a collection on chain,
+// following the cursor up to maxOrderBookPages. It reports whether pages
+// were left unread.
+func (o Opensea) collectionOrders(ctx context.Context, chain Chain, slug string, listings bool) ([]SeaportOrder, bool, error) {
+	ret := []SeaportOrder{}
+	params := CollectionOrdersParams{}
+	for page := 0; page < maxOrderBookPages; page++ {
+		resp, err := o.collectionOrdersPage(ctx, chain, slug, listings, params)
+		if err != nil {
+			return nil, false, err
+		}
+		ret = append(ret, resp.Listings...)
+		ret = append(ret, resp.Offers...)
+		if resp.Next == "" {
+			return ret, false, nil
+		}
+		params.Cursor = resp.Next
+	}
+	return ret, true, nil
+}
+
+// bestListings reads the cheapest listings of a collection, cheapest
+// first.
+func (o Opensea) bestListings(ctx context.Context, slug string, limit int) ([]SeaportOrder, error) {
+	chain, err := o.chainFor(ChainNone, CapabilityListings)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/api/v2/listings/collection/%s/best?limit=%d", url.PathEscape(slug), limit)
+	b, err := o.GetPath(contextWithChain(ctx, chain), path)
+	if err != nil {
+		return nil, err
+	}
+	resp := &struct {
+		Listings []SeaportOrder `json:"listings"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return o.annotateOrders(resp.Listings), nil
+}
+
+// GetBestListing returns the cheapest active listing of a token of a
+// collection, or ErrNotFound when it is not listed.
+func (o Opensea) GetBestListing(slug, tokenID string) (*SeaportOrder, error) {
+	ctx := context.TODO()
+	return o.GetBestListingWithContext(ctx, slug, tokenID)
+}
+
+func (o Opensea) GetBestListingWithContext(ctx context.Context, slug, tokenID string) (*SeaportOrder, error) {
+	return o.bestTokenOrder(ctx, CapabilityListings, "listings", slug, tokenID)
+}
+
+// GetBestOffer returns the highest active offer on a token of a collection,
+// criteria offers included, or ErrNotFound when there is none.
+func (o Opensea) GetBestOffer(slug, tokenID string) (*SeaportOrder, error) {
+	ctx := context.TODO()
+	return o.GetBestOfferWithContext(ctx, slug, tokenID)
+}
+
+func (o Opensea) GetBestOfferWithContext(ctx context.Context, slug, tokenID string) (*SeaportOrder, error) {
+	return o.bestTokenOrder(ctx, CapabilityOffers, "offers", slug, tokenID)
+}
+
+func (o Opensea) bestTokenOrder(ctx context.Context, capability Capability, kind, slug, tokenID string) (*SeaportOrder, error) {
+	chain, err := o.chainFor(ChainNone, capability)
+	if err != nil {
+		return nil, err
+	}
+	if slug == "" || tokenID == "" {
+		return nil, fmt.Errorf("Best %s need a collection slug and a token ID", kind)
+	}
+	path := fmt.Sprintf("/api/v2/%s/collection/%s/nfts/%s/best", kind, url.PathEscape(slug), url.PathEscape(tokenID))
+	b, err := o.GetPath(contextWithChain(ctx, chain), path)
+	if err != nil {
+		return nil, err
+	}
+	ret := new(SeaportOrder)
+	if err := json.Unmarshal(b, ret); err != nil {
+		return nil, err
+	}
+	if ret.OrderHash == "" {
+		return nil, ErrNotFound
+	}
+	return o.annotateOrder(ret)
+}
+
+// GetOrderByHash returns an order of the v2 order book by hash, or
+// ErrNotFound. An empty protocolAddress is the deployment of the client.
+func (o Opensea) GetOrderByHash(chain Chain, protocolAddress Address, orderHash string) (*SeaportOrder, error) {
+	ctx := context.TODO()
+	return o.GetOrderByHashWithContext(ctx, chain, protocolAddress, orderHash)
+}
+
+func (o Opensea) GetOrderByHashWithContext(ctx context.Context, chain Chain, protocolAddress Address, orderHash string) (*SeaportOrder, error) {
+	chain, err := o.resolveChain(chain)
+	if err != nil {
+		return nil, err
+	}
+	if protocolAddress == "" {
+		protocolAddress = o.protocolAddress(DefaultProtocol)
+	}
+	path := fmt.Sprintf("/api/v2/orders/chain/%s/protocol/%s/%s", chain, protocolAddress, orderHash)
+	b, err := o.GetPath(contextWithChain(ctx, chain), path)
+	if err != nil {
+		return nil, err
+	}
+	order, err := decodeOrder(b)
+	if err != nil {
+		return nil, err
+	}
+	order.FetchedAt = time.Now().UTC()
+	return order, nil
+}
+
+// decodeOrder decodes the {"order": ...} responses of the orders endpoints.
+func decodeOrder(b []byte) (*SeaportOrder, error) {
+	resp := &struct {
+		Order SeaportOrder `json:"order"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	return &resp.Order, nil
+}
+
+// GetSeaportOrdersParams filter the listings or offers of the orders
+// endpoints.
+type GetSeaportOrdersParams struct {
+	Chain Chain
+	// Offers reads offers instead of listings.
+	Offers bool
+	// Maker and Taker are addresses or ENS names.
+	Maker                Address
+	Taker                Address
+	AssetContractAddress Address
+	// TokenIDs require AssetContractAddress.
+	TokenIDs       []string
+	OrderDirection OrderDirection
+	ListedAfter    time.Time
+	ListedBefore   time.Time
+	// Cursor is the Next cursor of the previous page.
+	Cursor string
+	Limit  int
+}
+
+func (p GetSeaportOrdersParams) Encode() string {
+	q := url.Values{}
+	if p.Maker != "" {
+		q.Set("maker", p.Maker.String())
+	}
+	if p.Taker != "" {
+		q.Set("taker", p.Taker.String())
+	}
+	if p.AssetContractAddress != "" {
+		q.Set("asset_contract_address", p.AssetContractAddress.String())
+	}
+	for _, id := range p.TokenIDs {
+		q.Add("token_ids", id)
+	}
+	if p.OrderDirection != "" {
+		q.Set("order_by", "created_date")
+		q.Set("order_direction", string(p.OrderDirection))
+	}
+	if !p.ListedAfter.IsZero() {
+		q.Set("listed_after", fmt.Sprintf("%d", p.ListedAfter.Unix()))
+	}
+	if !p.ListedBefore.IsZero() {
+		q.Set("listed_before", fmt.Sprintf("%d", p.ListedBefore.Unix()))
+	}
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	}
+	if p.Limit > 0 {
+		q.Set("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	return q.Encode()
+}
+
+// SeaportOrdersResponse is a page of orders. Next is empty on the last page.
+type SeaportOrdersResponse struct {
+	Orders   []SeaportOrder `json:"orders" bson:"orders"`
+	Next     string         `json:"next" bson:"next"`
+	Previous string         `json:"previous" bson:"previous"`
+	// Truncated is set by GetAccountOffers when the offers of one of its
+	// collections were cut at the page limit of the order book reads.
+	Truncated bool `json:"truncated,omitempty" bson:"truncated,omitempty"`
+}
+
+// GetSeaportOrders returns a page of Seaport listings or offers; pass its
+// Next cursor in the params to read the following page. It replaces the v1
+// GetOrders.
+func (o Opensea) GetSeaportOrders(params GetSeaportOrdersParams) (*SeaportOrdersResponse, error) {
+	ctx := context.TODO()
+	return o.GetSeaportOrdersWithContext(ctx, params)
+}
+
+func (o Opensea) GetSeaportOrdersWithContext(ctx context.Context, params GetSeaportOrdersParams) (*SeaportOrdersResponse, error) {
+	capability, kind := CapabilityListings, "listings"
+	if params.Offers {
+		capability, kind = CapabilityOffers, "offers"
+	}
+	chain, err := o.chainFor(params.Chain, capability)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.TokenIDs) > 0 && params.AssetContractAddress == "" {
+		return nil, fmt.Errorf("Filtering by token IDs requires an asset contract address")
+	}
+	if !params.ListedAfter.IsZero() && !params.ListedBefore.IsZero() && !params.ListedAfter.Before(params.ListedBefore) {
+		return nil, fmt.Errorf("Invalid listing period: %s to %s", params.ListedAfter, params.ListedBefore)
+	}
+	for _, a := range []*Address{&params.Maker, &params.Taker} {
+		if *a == "" {
+			continue
+		}
+		resolved, err := o.ResolveAddressWithContext(ctx, a.String())
+		if err != nil {
+			return nil, err
+		}
+		*a = resolved
+	}
+	path := fmt.Sprintf("/api/v2/orders/%s/%s/%s?%s", chain, DefaultProtocol.PathSegment(), kind, params.Encode())
+	b, err := o.GetPath(contextWithChain(ctx, chain), path)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SeaportOrdersResponse{Orders: []SeaportOrder{}}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	resp.Orders = o.annotateOrders(resp.Orders)
+	return resp, nil
+}
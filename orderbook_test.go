@@ -0,0 +1,154 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSeaportOrders(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/orders/ethereum/seaport/offers", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "0x000000000000000000000000000000000000000a", q.Get("maker"))
+		assert.Equal(t, contract, q.Get("asset_contract_address"))
+		assert.Equal(t, []string{"1", "2"}, q["token_ids"])
+		assert.Equal(t, "asc", q.Get("order_direction"))
+		assert.Equal(t, "1700000000", q.Get("listed_after"))
+		assert.Equal(t, "", q.Get("listed_before"))
+		w.Write([]byte(`{"next":"page2","previous":null,"orders":[{
+			"created_date":"2023-11-14T22:13:20","listing_time":1700000000,"expiration_time":1700600000,
+			"order_hash":"0xabc","protocol_address":"0x00000000000000adc04c56bf30ac9d3c0aaf14dc",
+			"current_price":"1000000000000000000","side":"bid","order_type":"basic",
+			"maker":{"address":"0x000000000000000000000000000000000000000a"},"taker":null,
+			"cancelled":false,"finalized":false,"marked_invalid":false,
+			"protocol_data":{"parameters":{"offerer":"0x000000000000000000000000000000000000000a","startTime":"1700000000","endTime":"1700600000"},"signature":"0x01"}}]}`))
+	})
+	resp, err := o.GetSeaportOrdersWithContext(context.Background(), GetSeaportOrdersParams{
+		Offers:               true,
+		Maker:                "0x000000000000000000000000000000000000000A",
+		AssetContractAddress: Address(contract),
+		TokenIDs:             []string{"1", "2"},
+		OrderDirection:       Asc,
+		ListedAfter:          time.Unix(1700000000, 0),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "page2", resp.Next)
+	assert.Len(t, resp.Orders, 1)
+	order := resp.Orders[0]
+	assert.Equal(t, "0xabc", order.OrderHash)
+	assert.Equal(t, "bid", order.Side)
+	assert.Equal(t, Number("1000000000000000000"), order.CurrentPrice)
+	assert.Equal(t, int64(1700600000), order.ExpirationTime)
+	assert.Equal(t, Address("0x000000000000000000000000000000000000000a"), order.Maker.Address)
+	assert.Nil(t, order.Taker)
+	assert.Equal(t, Number("1700600000"), order.ProtocolData.Parameters.EndTime)
+
+	_, err = o.GetSeaportOrdersWithContext(context.Background(), GetSeaportOrdersParams{TokenIDs: []string{"1"}})
+	assert.NotNil(t, err)
+	_, err = o.GetSeaportOrdersWithContext(context.Background(), GetSeaportOrdersParams{
+		ListedAfter:  time.Unix(1700000000, 0),
+		ListedBefore: time.Unix(1600000000, 0),
+	})
+	assert.NotNil(t, err)
+}
+
+func TestGetBestListingAndOffer(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/listings/collection/doodles-official/nfts/7/best":
+			w.Write([]byte(`{"order_hash":"0xlisting","chain":"ethereum","price":{"current":{"currency":"ETH","decimals":18,"value":"2500000000000000000"}}}`))
+		case "/api/v2/offers/collection/doodles-official/nfts/7/best":
+			w.Write([]byte(`{"order_hash":"0xoffer","chain":"ethereum","price":{"currency":"WETH","decimals":18,"value":"2000000000000000000"}}`))
+		case "/api/v2/offers/collection/doodles-official/nfts/8/best":
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+	listing, err := o.GetBestListingWithContext(ctx, "doodles-official", "7")
+	assert.Nil(t, err)
+	assert.Equal(t, "0xlisting", listing.OrderHash)
+	assert.Equal(t, 2.5, listing.price().Float())
+	offer, err := o.GetBestOfferWithContext(ctx, "doodles-official", "7")
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, offer.price().Float())
+
+	_, err = o.GetBestListingWithContext(ctx, "doodles-official", "8")
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetBestOfferWithContext(ctx, "doodles-official", "8")
+	assert.Equal(t, ErrNotFound, err)
+	_, err = o.GetBestOfferWithContext(ctx, "doodles-official", "")
+	assert.NotNil(t, err)
+}
+
+func TestGetCollectionListings(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/listings/collection/doodles-official/all", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		if r.URL.Query().Get("next") == "" {
+			w.Write([]byte(`{"listings":[{"order_hash":"0x1"},{"order_hash":"0x2"}],"next":"page2"}`))
+			return
+		}
+		w.Write([]byte(`{"listings":[{"order_hash":"0x3"}]}`))
+	})
+	resp, err := o.GetCollectionListingsWithContext(context.Background(), "doodles-official", CollectionOrdersParams{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Listings, 2)
+	assert.Equal(t, "page2", resp.Next)
+	resp, err = o.GetCollectionListingsWithContext(context.Background(), "doodles-official", CollectionOrdersParams{Cursor: resp.Next})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Listings, 1)
+	assert.Equal(t, "", resp.Next)
+
+	hashes := []string{}
+	err = o.CollectionListingsIterator("doodles-official")(context.Background(), func(item interface{}) error {
+		hashes = append(hashes, item.(SeaportOrder).OrderHash)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0x1", "0x2", "0x3"}, hashes)
+
+	_, err = o.GetCollectionListingsWithContext(context.Background(), "", CollectionOrdersParams{})
+	assert.NotNil(t, err)
+}
+
+func TestCollectionOrdersEscapeSlug(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/listings/collection/a?b#c/all", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		w.Write([]byte(`{"listings":[{"order_hash":"0x1"}]}`))
+	})
+	resp, err := o.GetCollectionListingsWithContext(context.Background(), "a?b#c", CollectionOrdersParams{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Listings, 1)
+}
+
+func TestGetCollectionOffers(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/offers/collection/doodles-official/all", r.URL.Path)
+		if r.URL.Query().Get("next") == "" {
+			w.Write([]byte(`{"offers":[{"order_hash":"0x1","criteria":{"collection":{"slug":"doodles-official"},"contract":{"address":"` + contract + `"}}}],"next":"page2"}`))
+			return
+		}
+		w.Write([]byte(`{"offers":[{"order_hash":"0x2","criteria":{"collection":{"slug":"doodles-official"},"trait":{"type":"Hat","value":"Crown"}}}]}`))
+	})
+	resp, err := o.GetCollectionOffersWithContext(context.Background(), "doodles-official", CollectionOrdersParams{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Offers, 1)
+	assert.Equal(t, Address(contract), resp.Offers[0].Criteria.Contract.Address)
+
+	var traits []string
+	err = o.CollectionOffersIterator("doodles-official")(context.Background(), func(item interface{}) error {
+		if c := item.(SeaportOrder).Criteria; c.Trait != nil {
+			traits = append(traits, c.Trait.Value)
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Crown"}, traits)
+}
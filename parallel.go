@@ -0,0 +1,167 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Iterator produces the items of paginated results, calling yield for each
+// and stopping at the first error yield returns. AssetsIterator,
+// EventsIterator and SeaportOrdersIterator adapt the page methods.
+type Iterator func(ctx context.Context, yield func(item interface{}) error) error
+
+// Errors are the errors collected by ForEachParallelCollect.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// ForEachParallel calls fn on the items of it with workers goroutines. The
+// first error, of fn or of the iterator, cancels the run and is returned.
+func ForEachParallel(ctx context.Context, it Iterator, workers int, fn func(ctx context.Context, item interface{}) error) error {
+	return forEachParallel(ctx, it, workers, fn, false)
+}
+
+// ForEachParallelCollect is ForEachParallel carrying on past the errors of
+// fn, which are returned as Errors. Errors of the iterator still stop the
+// run, and are collected too.
+func ForEachParallelCollect(ctx context.Context, it Iterator, workers int, fn func(ctx context.Context, item interface{}) error) error {
+	return forEachParallel(ctx, it, workers, fn, true)
+}
+
+func forEachParallel(parent context.Context, it Iterator, workers int, fn func(ctx context.Context, item interface{}) error, collect bool) error {
+	if workers <= 0 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var mu sync.Mutex
+	var errs Errors
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+		if !collect {
+			cancel()
+		}
+	}
+
+	items := make(chan interface{})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range items {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := fn(ctx, item); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	err := it(ctx, func(item interface{}) error {
+		select {
+		case items <- item:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(items)
+	wg.Wait()
+	if err != nil && ctx.Err() == nil {
+		fail(err)
+	}
+
+	if parent.Err() != nil {
+		return parent.Err()
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	if !collect {
+		return errs[0]
+	}
+	return errs
+}
+
+// AssetsIterator yields the Asset values of every page of params. The
+// pages are read with the priority of the profile of the collection.
+func (o Opensea) AssetsIterator(params GetAssetsParams) Iterator {
+	return func(ctx context.Context, yield func(item interface{}) error) error {
+		slug := params.CollectionSlug
+		if slug == "" {
+			slug = params.Collection
+		}
+		ctx = o.contextWithProfile(ctx, slug)
+		for {
+			resp, err := o.GetAssetsWithContext(ctx, params)
+			if err != nil {
+				return err
+			}
+			for _, a := range resp.Assets {
+				if err := yield(a); err != nil {
+					return err
+				}
+			}
+			reportProgress(ctx, len(resp.Assets))
+			if resp.Next == "" {
+				return nil
+			}
+			params.Cursor = resp.Next
+		}
+	}
+}
+
+// EventsIterator yields the *Event values of RetrievingEventsPagesWithContext,
+// read with the priority of the profile of the collection.
+func (o Opensea) EventsIterator(params *RetrievingEventsParams) Iterator {
+	return func(ctx context.Context, yield func(item interface{}) error) error {
+		if params != nil {
+			ctx = o.contextWithProfile(ctx, params.CollectionSlug)
+		}
+		return o.RetrievingEventsPagesWithContext(ctx, params, func(events []*Event) error {
+			for _, e := range events {
+				if err := yield(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// SeaportOrdersIterator yields the SeaportOrder values of every page of
+// params.
+func (o Opensea) SeaportOrdersIterator(params GetSeaportOrdersParams) Iterator {
+	return func(ctx context.Context, yield func(item interface{}) error) error {
+		for {
+			resp, err := o.GetSeaportOrdersWithContext(ctx, params)
+			if err != nil {
+				return err
+			}
+			for _, order := range resp.Orders {
+				if err := yield(order); err != nil {
+					return err
+				}
+			}
+			reportProgress(ctx, len(resp.Orders))
+			if resp.Next == "" {
+				return nil
+			}
+			params.Cursor = resp.Next
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package opensea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intsIterator(n int) Iterator {
+	return func(ctx context.Context, yield func(item interface{}) error) error {
+		for i := 0; i < n; i++ {
+			if err := yield(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestForEachParallel(t *testing.T) {
+	var sum int64
+	err := ForEachParallel(context.Background(), intsIterator(100), 8, func(ctx context.Context, item interface{}) error {
+		atomic.AddInt64(&sum, int64(item.(int)))
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4950), sum)
+
+	boom := errors.New("boom")
+	var calls int64
+	err = ForEachParallel(context.Background(), intsIterator(1000), 2, func(ctx context.Context, item interface{}) error {
+		atomic.AddInt64(&calls, 1)
+		if item.(int) == 3 {
+			return boom
+		}
+		return nil
+	})
+	assert.Equal(t, boom, err)
+	assert.True(t, atomic.LoadInt64(&calls) < 1000)
+
+	err = ForEachParallelCollect(context.Background(), intsIterator(10), 3, func(ctx context.Context, item interface{}) error {
+		if item.(int)%5 == 0 {
+			return fmt.Errorf("item %d", item)
+		}
+		return nil
+	})
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = ForEachParallel(ctx, intsIterator(1000), 2, func(ctx context.Context, item interface{}) error {
+		if item.(int) == 10 {
+			cancel()
+		}
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	failing := func(ctx context.Context, yield func(item interface{}) error) error { return boom }
+	assert.Equal(t, boom, ForEachParallel(context.Background(), failing, 2, func(ctx context.Context, item interface{}) error { return nil }))
+}
+
+func TestAssetsIterator(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"assets":[{"token_id":"1"},{"token_id":"2"}],"next":"page2"}`))
+			return
+		}
+		w.Write([]byte(`{"assets":[{"token_id":"3"}]}`))
+	})
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	err := ForEachParallel(context.Background(), o.AssetsIterator(GetAssetsParams{AssetContractAddress: Address(contract)}), 2, func(ctx context.Context, item interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[item.(Asset).TokenID] = true
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"1": true, "2": true, "3": true}, seen)
+}
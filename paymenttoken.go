@@ -0,0 +1,99 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ETHPrice returns the price of one unit of the token in ETH.
+func (t PaymentToken) ETHPrice() (float64, bool) {
+	return priceValue(t.EthPrice)
+}
+
+// USDPrice returns the price of one unit of the token in USD.
+func (t PaymentToken) USDPrice() (float64, bool) {
+	return priceValue(t.UsdPrice)
+}
+
+// Units converts an amount in the smallest unit of the token, e.g. wei, to
+// whole units using the token decimals.
+func (t PaymentToken) Units(amount Number) float64 {
+	return OrderPrice{Currency: t.Symbol, Decimals: t.Decimals, Value: amount}.Float()
+}
+
+// USDValue returns the USD value of an amount in the smallest unit of the
+// token.
+func (t PaymentToken) USDValue(amount Number) (float64, bool) {
+	usd, ok := t.USDPrice()
+	if !ok {
+		return 0, false
+	}
+	return t.Units(amount) * usd, true
+}
+
+// priceValue reads the prices of payment tokens, given as numbers or
+// strings.
+func priceValue(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// GetPaymentToken returns a payment token of chain by address, empty or the
+// null address for the native currency. It returns ErrNotFound for tokens
+// OpenSea does not accept.
+func (o Opensea) GetPaymentToken(chain Chain, address Address) (*PaymentToken, error) {
+	ctx := context.TODO()
+	return o.GetPaymentTokenWithContext(ctx, chain, address)
+}
+
+func (o Opensea) GetPaymentTokenWithContext(ctx context.Context, chain Chain, address Address) (*PaymentToken, error) {
+	chain, err := o.resolveChain(chain)
+	if err != nil {
+		return nil, err
+	}
+	if address == "" {
+		address = NullAddress
+	}
+	b, err := o.GetPath(contextWithChain(ctx, chain), fmt.Sprintf("/api/v2/chain/%s/payment_token/%s", chain, address))
+	if err != nil {
+		return nil, err
+	}
+	resp := &struct {
+		Symbol   string      `json:"symbol"`
+		Address  Address     `json:"address"`
+		Chain    Chain       `json:"chain"`
+		Image    string      `json:"image"`
+		Name     string      `json:"name"`
+		Decimals int64       `json:"decimals"`
+		EthPrice interface{} `json:"eth_price"`
+		UsdPrice interface{} `json:"usd_price"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	t := &PaymentToken{
+		Symbol:   resp.Symbol,
+		Address:  resp.Address,
+		ImageURL: resp.Image,
+		Name:     resp.Name,
+		Decimals: resp.Decimals,
+		EthPrice: resp.EthPrice,
+		UsdPrice: resp.UsdPrice,
+		Chain:    resp.Chain,
+	}
+	if t.Chain == ChainNone {
+		t.Chain = chain
+	}
+	return t, nil
+}
@@ -0,0 +1,50 @@
+package opensea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPaymentToken(t *testing.T) {
+	const usdc = Address("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/chain/ethereum/payment_token/" + NullAddress.String():
+			w.Write([]byte(`{"symbol":"ETH","address":"0x0000000000000000000000000000000000000000","chain":"ethereum","image":"https://img/eth.png","name":"Ether","decimals":18,"eth_price":1,"usd_price":3000}`))
+		case "/api/v2/chain/ethereum/payment_token/" + usdc.String():
+			w.Write([]byte(`{"symbol":"USDC","address":"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48","name":"USD Coin","decimals":6,"eth_price":"0.00033","usd_price":"1.0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	eth, err := o.GetPaymentToken(ChainEthereum, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "ETH", eth.Symbol)
+	assert.Equal(t, int64(18), eth.Decimals)
+	assert.Equal(t, "https://img/eth.png", eth.ImageURL)
+	assert.Equal(t, ChainEthereum, eth.Chain)
+	assert.Equal(t, 1.5, eth.Units(Number("1500000000000000000")))
+	usd, ok := eth.USDValue(Number("1500000000000000000"))
+	assert.True(t, ok)
+	assert.Equal(t, 4500.0, usd)
+
+	token, err := o.GetPaymentToken(ChainEthereum, usdc)
+	assert.Nil(t, err)
+	assert.Equal(t, ChainEthereum, token.Chain)
+	assert.Equal(t, 2.5, token.Units(Number("2500000")))
+	price, ok := token.ETHPrice()
+	assert.True(t, ok)
+	assert.Equal(t, 0.00033, price)
+	usd, ok = token.USDValue(Number("2500000"))
+	assert.True(t, ok)
+	assert.Equal(t, 2.5, usd)
+
+	_, err = o.GetPaymentToken(ChainEthereum, "0x1111111111111111111111111111111111111111")
+	assert.Equal(t, ErrNotFound, err)
+
+	_, ok = PaymentToken{Symbol: "WETH", Decimals: 18}.USDValue(Number("1"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,404 @@
+package opensea
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AssetPredicate, NFTPredicate, OrderPredicate and EventPredicate select
+// the assets, NFTs, orders and events of drained pages in FilterAssets,
+// FilterNFTs, FilterOrders and FilterEvents. Price bounds are in the native
+// currency, and 0 leaves a bound open.
+type AssetPredicate func(Asset) bool
+
+type NFTPredicate func(NFT) bool
+
+type OrderPredicate func(SeaportOrder) bool
+
+type EventPredicate func(AssetEvent) bool
+
+// FilterAssets returns the assets matching every predicate.
+func FilterAssets(assets []Asset, preds ...AssetPredicate) []Asset {
+	ret := []Asset{}
+	for _, a := range assets {
+		if matchAsset(a, preds) {
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
+
+func matchAsset(a Asset, preds []AssetPredicate) bool {
+	for _, p := range preds {
+		if !p(a) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyAsset matches the assets matching one of preds.
+func AnyAsset(preds ...AssetPredicate) AssetPredicate {
+	return func(a Asset) bool {
+		for _, p := range preds {
+			if p(a) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func NotAsset(pred AssetPredicate) AssetPredicate {
+	return func(a Asset) bool { return !pred(a) }
+}
+
+// AssetHasTrait matches the assets with the trait, compared case
+// insensitively.
+func AssetHasTrait(traitType, value string) AssetPredicate {
+	return func(a Asset) bool {
+		for _, t := range a.TraitList() {
+			if strings.EqualFold(t.TraitType, traitType) && strings.EqualFold(traitValue(t), value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AssetLastSaleBetween matches the assets last sold within the bounds.
+func AssetLastSaleBetween(min, max float64) AssetPredicate {
+	return func(a Asset) bool {
+		price, ok := a.LastSalePrice()
+		return ok && inRange(price, min, max)
+	}
+}
+
+// TraitList returns the traits of the asset, or nil when none were decoded.
+func (a Asset) TraitList() []Trait {
+	switch v := a.Traits.(type) {
+	case nil:
+		return nil
+	case []Trait:
+		return v
+	}
+	b, err := json.Marshal(a.Traits)
+	if err != nil {
+		return nil
+	}
+	var ret []Trait
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil
+	}
+	return ret
+}
+
+// LastSalePrice returns the unit price of the last sale of the asset in the
+// native currency.
+func (a Asset) LastSalePrice() (float64, bool) {
+	if a.LastSale == nil {
+		return 0, false
+	}
+	return salePrice(&Event{
+		TotalPrice:   Number(a.LastSale.TotalPrice),
+		PaymentToken: a.LastSale.PaymentToken,
+		Quantity:     a.LastSale.Quantity,
+	})
+}
+
+// RarityScore sums, over the traits of the asset, the inverse of the share
+// of the supply having the trait. Rarer assets score higher; it is 0 when
+// the trait counts are unknown.
+func (a Asset) RarityScore(supply int64) float64 {
+	score := 0.0
+	for _, t := range a.TraitList() {
+		if t.TraitCount > 0 {
+			score += float64(supply) / float64(t.TraitCount)
+		}
+	}
+	return score
+}
+
+// SortAssetsByLastSale sorts assets by last sale price, cheapest first, or
+// most expensive first when desc. Unsold assets come last.
+func SortAssetsByLastSale(assets []Asset, desc bool) {
+	sort.SliceStable(assets, func(i, j int) bool {
+		pi, oki := assets[i].LastSalePrice()
+		pj, okj := assets[j].LastSalePrice()
+		if oki != okj {
+			return oki
+		}
+		return less(pi, pj, desc)
+	})
+}
+
+// SortAssetsByRarity sorts assets rarest first, as scored by RarityScore.
+func SortAssetsByRarity(assets []Asset, supply int64) {
+	sort.SliceStable(assets, func(i, j int) bool {
+		return assets[i].RarityScore(supply) > assets[j].RarityScore(supply)
+	})
+}
+
+// FilterNFTs returns the NFTs matching every predicate.
+func FilterNFTs(nfts []NFT, preds ...NFTPredicate) []NFT {
+	ret := []NFT{}
+	for _, n := range nfts {
+		if matchNFT(n, preds) {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+func matchNFT(n NFT, preds []NFTPredicate) bool {
+	for _, p := range preds {
+		if !p(n) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyNFT matches the NFTs matching one of preds.
+func AnyNFT(preds ...NFTPredicate) NFTPredicate {
+	return func(n NFT) bool {
+		for _, p := range preds {
+			if p(n) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func NotNFT(pred NFTPredicate) NFTPredicate {
+	return func(n NFT) bool { return !pred(n) }
+}
+
+// NFTHasTrait matches the NFTs with the trait, compared case insensitively.
+// Only GetNFT returns the traits of an NFT.
+func NFTHasTrait(traitType, value string) NFTPredicate {
+	return func(n NFT) bool {
+		for _, t := range n.Traits {
+			if strings.EqualFold(t.TraitType, traitType) && strings.EqualFold(traitValue(t), value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NFTRankBetween matches the NFTs with a rarity rank within the bounds.
+func NFTRankBetween(min, max int64) NFTPredicate {
+	return func(n NFT) bool {
+		return n.Rarity != nil && n.Rarity.Rank > 0 && inRange(float64(n.Rarity.Rank), float64(min), float64(max))
+	}
+}
+
+// NFTSafe matches the NFTs neither disabled nor flagged NSFW.
+func NFTSafe() NFTPredicate {
+	return func(n NFT) bool {
+		return !n.IsDisabled && !n.IsNSFW
+	}
+}
+
+// SortNFTsByRarity sorts NFTs rarest first, by rarity rank. Unranked NFTs
+// come last.
+func SortNFTsByRarity(nfts []NFT) {
+	sort.SliceStable(nfts, func(i, j int) bool {
+		ri, rj := nftRank(nfts[i]), nftRank(nfts[j])
+		if (ri > 0) != (rj > 0) {
+			return ri > 0
+		}
+		return ri < rj
+	})
+}
+
+func nftRank(n NFT) int64 {
+	if n.Rarity == nil {
+		return 0
+	}
+	return n.Rarity.Rank
+}
+
+// SortNFTsByIdentifier sorts NFTs by contract, then by numeric token ID.
+func SortNFTsByIdentifier(nfts []NFT) {
+	sort.SliceStable(nfts, func(i, j int) bool {
+		ci, cj := strings.ToLower(nfts[i].Contract), strings.ToLower(nfts[j].Contract)
+		if ci != cj {
+			return ci < cj
+		}
+		return compareAmounts(Number(nfts[i].Identifier), Number(nfts[j].Identifier)) < 0
+	})
+}
+
+// FilterOrders returns the orders matching every predicate.
+func FilterOrders(orders []SeaportOrder, preds ...OrderPredicate) []SeaportOrder {
+	ret := []SeaportOrder{}
+	for _, o := range orders {
+		ok := true
+		for _, p := range preds {
+			if !p(o) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			ret = append(ret, o)
+		}
+	}
+	return ret
+}
+
+// OrderUnitPriceBetween matches the orders with a unit price within the
+// bounds.
+func OrderUnitPriceBetween(min, max float64) OrderPredicate {
+	return func(o SeaportOrder) bool {
+		return inRange(o.UnitPrice(), min, max)
+	}
+}
+
+// OrderListedAfter matches the orders starting after t.
+func OrderListedAfter(t time.Time) OrderPredicate {
+	return func(o SeaportOrder) bool {
+		return o.StartTime().After(t)
+	}
+}
+
+// OrderForToken matches the orders selling or buying the token.
+func OrderForToken(contract Address, tokenID string) OrderPredicate {
+	key := strings.ToLower(contract.String()) + "/" + tokenID
+	return func(o SeaportOrder) bool {
+		for _, k := range o.tokens() {
+			if k == key {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// IsListing reports whether the order sells NFTs, rather than offering to
+// buy them.
+func (o SeaportOrder) IsListing() bool {
+	if o.Side != "" {
+		return o.Side == "ask"
+	}
+	return len(o.listedTokens()) > 0
+}
+
+// UnitPrice returns the price of one item of the order in whole units of
+// its currency.
+func (o SeaportOrder) UnitPrice() float64 {
+	return o.unitPrice(o.IsListing())
+}
+
+// StartTime returns the time the order becomes valid.
+func (o SeaportOrder) StartTime() time.Time {
+	if o.ListingTime > 0 {
+		return time.Unix(o.ListingTime, 0)
+	}
+	if n := o.ProtocolData.Parameters.StartTime.Big(); n != nil && n.IsInt64() {
+		return time.Unix(n.Int64(), 0)
+	}
+	return time.Time{}
+}
+
+// tokens returns the contract/identifier keys of the NFTs of the order.
+func (o SeaportOrder) tokens() []string {
+	ret := o.listedTokens()
+	for _, item := range o.ProtocolData.Parameters.Consideration {
+		if item.ItemType >= ItemERC721 {
+			ret = append(ret, strings.ToLower(item.Token.String())+"/"+string(item.IdentifierOrCriteria))
+		}
+	}
+	return ret
+}
+
+// SortOrdersByPrice sorts orders by unit price, cheapest first, or most
+// expensive first when desc.
+func SortOrdersByPrice(orders []SeaportOrder, desc bool) {
+	sort.SliceStable(orders, func(i, j int) bool {
+		return less(orders[i].UnitPrice(), orders[j].UnitPrice(), desc)
+	})
+}
+
+// SortOrdersByListingTime sorts orders newest first.
+func SortOrdersByListingTime(orders []SeaportOrder) {
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].StartTime().After(orders[j].StartTime())
+	})
+}
+
+// FilterEvents returns the events matching every predicate.
+func FilterEvents(events []AssetEvent, preds ...EventPredicate) []AssetEvent {
+	ret := []AssetEvent{}
+	for _, e := range events {
+		if matchEvent(e, preds) {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
+
+func matchEvent(e AssetEvent, preds []EventPredicate) bool {
+	for _, p := range preds {
+		if !p(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// EventOfType matches the events of one of the types.
+func EventOfType(types ...EventType) EventPredicate {
+	return func(e AssetEvent) bool {
+		for _, t := range types {
+			if e.EventType == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// EventPriceBetween matches the sales with a unit price within the bounds.
+func EventPriceBetween(min, max float64) EventPredicate {
+	return func(e AssetEvent) bool {
+		price, ok := e.SalePrice()
+		return ok && inRange(price, min, max)
+	}
+}
+
+// SortEventsByTime sorts events newest first.
+func SortEventsByTime(events []AssetEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].EventTimestamp.Time().After(events[j].EventTimestamp.Time())
+	})
+}
+
+// SortEventsByPrice sorts the events by sale price, cheapest first, or most
+// expensive first when desc. Unpriced events come last.
+func SortEventsByPrice(events []AssetEvent, desc bool) {
+	sort.SliceStable(events, func(i, j int) bool {
+		pi, oki := events[i].SalePrice()
+		pj, okj := events[j].SalePrice()
+		if oki != okj {
+			return oki
+		}
+		return less(pi, pj, desc)
+	})
+}
+
+func inRange(v, min, max float64) bool {
+	return (min <= 0 || v >= min) && (max <= 0 || v <= max)
+}
+
+func less(a, b float64, desc bool) bool {
+	if desc {
+		return a > b
+	}
+	return a < b
+}
@@ -0,0 +1,113 @@
+package opensea
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterAssets(t *testing.T) {
+	var assets []Asset
+	assert.Nil(t, json.Unmarshal([]byte(`[
+		{"token_id":"1","traits":[{"trait_type":"Hat","value":"Crown","trait_count":10},{"trait_type":"Eyes","value":"Blue","trait_count":500}],
+			"last_sale":{"total_price":"3000000000000000000","quantity":"1","payment_token":{"decimals":18,"eth_price":"1.0"}}},
+		{"token_id":"2","traits":[{"trait_type":"Hat","value":"Cap","trait_count":400}],
+			"last_sale":{"total_price":"1000000000000000000","quantity":"1","payment_token":{"decimals":18,"eth_price":"1.0"}}},
+		{"token_id":"3","traits":[]}]`), &assets))
+
+	crowned := FilterAssets(assets, AssetHasTrait("hat", "crown"))
+	assert.Len(t, crowned, 1)
+	assert.Equal(t, "1", crowned[0].TokenID)
+	assert.Len(t, FilterAssets(assets, AssetLastSaleBetween(2, 0)), 1)
+	assert.Len(t, FilterAssets(assets, NotAsset(AssetLastSaleBetween(0, 0))), 1)
+	assert.Len(t, FilterAssets(assets, AnyAsset(AssetHasTrait("Hat", "Cap"), AssetLastSaleBetween(2, 0))), 2)
+
+	SortAssetsByLastSale(assets, false)
+	assert.Equal(t, []string{"2", "1", "3"}, tokenIDs(assets))
+	SortAssetsByRarity(assets, 1000)
+	assert.Equal(t, []string{"1", "2", "3"}, tokenIDs(assets))
+	assert.Equal(t, 102.0, assets[0].RarityScore(1000))
+}
+
+func tokenIDs(assets []Asset) []string {
+	ret := []string{}
+	for _, a := range assets {
+		ret = append(ret, a.TokenID)
+	}
+	return ret
+}
+
+func TestFilterNFTs(t *testing.T) {
+	var nfts []NFT
+	assert.Nil(t, json.Unmarshal([]byte(`[
+		{"identifier":"10","contract":"0xb","traits":[{"trait_type":"Hat","value":"Crown"}],"rarity":{"rank":3}},
+		{"identifier":"9","contract":"0xb","is_nsfw":true,"rarity":{"rank":120}},
+		{"identifier":"100000000000000000000","contract":"0xa"},
+		{"identifier":"2","contract":"0xb","is_disabled":true,"rarity":{"rank":1}}]`), &nfts))
+
+	crowned := FilterNFTs(nfts, NFTHasTrait("hat", "crown"))
+	assert.Len(t, crowned, 1)
+	assert.Equal(t, "10", crowned[0].Identifier)
+	assert.Len(t, FilterNFTs(nfts, NFTRankBetween(0, 100)), 2)
+	assert.Len(t, FilterNFTs(nfts, NFTSafe()), 2)
+	assert.Len(t, FilterNFTs(nfts, NotNFT(NFTRankBetween(0, 0))), 1)
+	assert.Len(t, FilterNFTs(nfts, AnyNFT(NFTHasTrait("Hat", "Crown"), NotNFT(NFTSafe()))), 3)
+
+	SortNFTsByRarity(nfts)
+	assert.Equal(t, []string{"2", "10", "9", "100000000000000000000"}, nftIdentifiers(nfts))
+	SortNFTsByIdentifier(nfts)
+	assert.Equal(t, []string{"100000000000000000000", "2", "9", "10"}, nftIdentifiers(nfts))
+}
+
+func nftIdentifiers(nfts []NFT) []string {
+	ret := []string{}
+	for _, n := range nfts {
+		ret = append(ret, n.Identifier)
+	}
+	return ret
+}
+
+func TestFilterOrders(t *testing.T) {
+	listing := func(hash, id, price string, start int64) SeaportOrder {
+		o := SeaportOrder{OrderHash: hash, CurrentPrice: Number(price), ListingTime: start}
+		o.ProtocolData.Parameters.Offer = []OfferItem{{ItemType: ItemERC721, Token: Address(contract), IdentifierOrCriteria: Number(id), StartAmount: "1"}}
+		return o
+	}
+	orders := []SeaportOrder{
+		listing("a", "1", "2000000000000000000", 1700000000),
+		listing("b", "2", "1000000000000000000", 1700000100),
+	}
+	assert.True(t, orders[0].IsListing())
+	assert.Equal(t, 2.0, orders[0].UnitPrice())
+	assert.Len(t, FilterOrders(orders, OrderUnitPriceBetween(0, 1.5)), 1)
+	assert.Len(t, FilterOrders(orders, OrderListedAfter(time.Unix(1700000050, 0))), 1)
+	assert.Len(t, FilterOrders(orders, OrderForToken(Address(contract), "2")), 1)
+
+	SortOrdersByPrice(orders, false)
+	assert.Equal(t, "b", orders[0].OrderHash)
+	SortOrdersByListingTime(orders)
+	assert.Equal(t, "b", orders[0].OrderHash)
+	SortOrdersByPrice(orders, true)
+	assert.Equal(t, "a", orders[0].OrderHash)
+}
+
+func TestFilterEvents(t *testing.T) {
+	var events []AssetEvent
+	eth := `"payment_token":{"decimals":18,"eth_price":"1.0"}`
+	assert.Nil(t, json.Unmarshal([]byte(`[
+		{"event_type":"successful","event_timestamp":"2023-01-01T00:00:00","total_price":"1000000000000000000",`+eth+`},
+		{"event_type":"transfer","event_timestamp":"2023-01-03T00:00:00"},
+		{"event_type":"successful","event_timestamp":"2023-01-02T00:00:00","total_price":"3000000000000000000",`+eth+`}]`), &events))
+
+	assert.Len(t, FilterEvents(events, EventOfType(EventTypeSuccessful)), 2)
+	assert.Len(t, FilterEvents(events, EventPriceBetween(2, 5)), 1)
+
+	SortEventsByPrice(events, true)
+	assert.Equal(t, Number("3000000000000000000"), events[0].TotalPrice)
+	assert.Equal(t, EventTypeTransfer, events[2].EventType)
+	SortEventsByTime(events)
+	assert.Equal(t, EventTypeTransfer, events[0].EventType)
+	assert.Equal(t, Number("3000000000000000000"), events[1].TotalPrice)
+}
@@ -0,0 +1,224 @@
+// Package pricing prices listings from expressions of live market data, such
+// as "floor * 0.98" or "max(last_sale, floor)", and recommends repricing as
+// the data changes.
+package pricing
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// DefaultTolerance is the relative price change below which the Engine does
+// not recommend repricing.
+const DefaultTolerance = 0.005
+
+// Inputs from valuations, set by UpdateValuation.
+const (
+	InputFloor       = "floor"
+	InputTraitFloor  = "trait_floor"
+	InputLastSale    = "last_sale"
+	InputComparables = "comparables"
+	InputEstimate    = "estimate"
+)
+
+// Rule prices a token, identified by a key such as "<contract>/<token id>".
+type Rule struct {
+	Key  string
+	Expr *Expr
+	// Price is the current listing price of the token, zero when unlisted.
+	Price float64
+}
+
+// Recommendation is a new price for a token.
+type Recommendation struct {
+	Key   string  `json:"key"`
+	Expr  string  `json:"expr"`
+	Price float64 `json:"price"`
+	// Current is the listing price the recommendation replaces.
+	Current float64 `json:"current"`
+}
+
+// Engine re-evaluates its rules when their inputs change. Inputs are either
+// global to the rules, e.g. the floor of a collection, or set for one token,
+// e.g. its trait floor; token inputs take precedence. It is safe for
+// concurrent use.
+type Engine struct {
+	// OnReprice is called, without lock held, with each recommendation. A
+	// token is recommended again on each change of its inputs until it is
+	// Repriced. It must be set before rules and inputs are.
+	OnReprice func(r Recommendation)
+	// Tolerance defaults to DefaultTolerance.
+	Tolerance float64
+
+	mu      sync.Mutex
+	rules   map[string]*Rule
+	globals map[string]float64
+	inputs  map[string]map[string]float64
+}
+
+func NewEngine() *Engine {
+	return &Engine{
+		Tolerance: DefaultTolerance,
+		rules:     map[string]*Rule{},
+		globals:   map[string]float64{},
+		inputs:    map[string]map[string]float64{},
+	}
+}
+
+// SetRule prices key with expr, from its current listing price, and
+// evaluates it at once.
+func (e *Engine) SetRule(key string, expr string, price float64) error {
+	x, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.rules[key] = &Rule{Key: key, Expr: x, Price: price}
+	recs := e.evaluate(key)
+	e.mu.Unlock()
+	e.notify(recs)
+	return nil
+}
+
+func (e *Engine) DeleteRule(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.rules, key)
+	delete(e.inputs, key)
+}
+
+// Repriced records the new listing price of key, e.g. after following a
+// recommendation.
+func (e *Engine) Repriced(key string, price float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if r, ok := e.rules[key]; ok {
+		r.Price = price
+	}
+}
+
+// Set sets a global input.
+func (e *Engine) Set(name string, value float64) {
+	e.mu.Lock()
+	if v, ok := e.globals[name]; ok && v == value {
+		e.mu.Unlock()
+		return
+	}
+	e.globals[name] = value
+	recs := e.evaluate("")
+	e.mu.Unlock()
+	e.notify(recs)
+}
+
+// SetInput sets an input of one token.
+func (e *Engine) SetInput(key string, name string, value float64) {
+	e.SetInputs(key, map[string]float64{name: value})
+}
+
+// SetInputs sets inputs of one token, evaluating its rule once.
+func (e *Engine) SetInputs(key string, values map[string]float64) {
+	e.mu.Lock()
+	inputs, ok := e.inputs[key]
+	if !ok {
+		inputs = map[string]float64{}
+		e.inputs[key] = inputs
+	}
+	changed := false
+	for name, value := range values {
+		if v, ok := inputs[name]; !ok || v != value {
+			inputs[name] = value
+			changed = true
+		}
+	}
+	if !changed {
+		e.mu.Unlock()
+		return
+	}
+	recs := e.evaluate(key)
+	e.mu.Unlock()
+	e.notify(recs)
+}
+
+// UpdateValuation sets the inputs of a token from its valuation: floor,
+// trait_floor, last_sale, comparables and estimate.
+func (e *Engine) UpdateValuation(key string, v *opensea.Valuation) {
+	names := map[opensea.ValuationSignal]string{
+		opensea.SignalCollectionFloor: InputFloor,
+		opensea.SignalTraitFloor:      InputTraitFloor,
+		opensea.SignalLastSale:        InputLastSale,
+		opensea.SignalComparables:     InputComparables,
+	}
+	values := map[string]float64{InputEstimate: v.Estimate}
+	for signal, price := range v.Signals {
+		if name, ok := names[signal]; ok {
+			values[name] = price
+		}
+	}
+	e.SetInputs(key, values)
+}
+
+// Evaluate evaluates the rule of key with the current inputs.
+func (e *Engine) Evaluate(key string) (float64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	r, ok := e.rules[key]
+	if !ok {
+		return 0, nil
+	}
+	return r.Expr.Eval(e.vars(key))
+}
+
+// vars merges the global and token inputs of key.
+func (e *Engine) vars(key string) map[string]float64 {
+	vars := make(map[string]float64, len(e.globals)+len(e.inputs[key]))
+	for k, v := range e.globals {
+		vars[k] = v
+	}
+	for k, v := range e.inputs[key] {
+		vars[k] = v
+	}
+	return vars
+}
+
+// evaluate re-evaluates the rule of key, or every rule when key is empty,
+// and returns the recommendations. Rules missing inputs are skipped. It must
+// be called with the lock held.
+func (e *Engine) evaluate(key string) []Recommendation {
+	keys := []string{key}
+	if key == "" {
+		keys = keys[:0]
+		for k := range e.rules {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+	}
+
+	recs := []Recommendation{}
+	for _, k := range keys {
+		r, ok := e.rules[k]
+		if !ok {
+			continue
+		}
+		price, err := r.Expr.Eval(e.vars(k))
+		if err != nil || price <= 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+			continue
+		}
+		if r.Price > 0 && math.Abs(price-r.Price) <= e.Tolerance*r.Price {
+			continue
+		}
+		recs = append(recs, Recommendation{Key: k, Expr: r.Expr.String(), Price: price, Current: r.Price})
+	}
+	return recs
+}
+
+func (e *Engine) notify(recs []Recommendation) {
+	if e.OnReprice == nil {
+		return
+	}
+	for _, r := range recs {
+		e.OnReprice(r)
+	}
+}
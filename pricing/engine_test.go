@@ -0,0 +1,60 @@
+package pricing
+
+import (
+	"testing"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEngine(t *testing.T) {
+	e := NewEngine()
+	var recs []Recommendation
+	e.OnReprice = func(r Recommendation) { recs = append(recs, r) }
+
+	assert.Nil(t, e.SetRule("a/1", "floor * 0.98", 2))
+	assert.Nil(t, e.SetRule("a/2", "max(last_sale, floor)", 0))
+	assert.NotNil(t, e.SetRule("a/3", "floor *", 0))
+	// no input yet
+	assert.Empty(t, recs)
+
+	// a/2 still misses its last sale
+	e.Set("floor", 2)
+	assert.Equal(t, []Recommendation{{Key: "a/1", Expr: "floor * 0.98", Price: 1.96, Current: 2}}, recs)
+
+	recs = nil
+	e.SetInput("a/2", "last_sale", 3)
+	assert.Len(t, recs, 1)
+	assert.Equal(t, "a/2", recs[0].Key)
+	assert.Equal(t, 3.0, recs[0].Price)
+
+	// unchanged inputs and changes within the tolerance are ignored
+	recs = nil
+	e.Repriced("a/1", 1.96)
+	e.Repriced("a/2", 3)
+	e.Set("floor", 2)
+	e.Set("floor", 2.001)
+	assert.Empty(t, recs)
+
+	// token inputs take precedence
+	e.SetInput("a/2", "floor", 4)
+	assert.Len(t, recs, 1)
+	assert.Equal(t, 4.0, recs[0].Price)
+
+	recs = nil
+	e.UpdateValuation("a/1", &opensea.Valuation{
+		Estimate: 2.5,
+		Signals:  map[opensea.ValuationSignal]float64{opensea.SignalCollectionFloor: 2.5},
+	})
+	assert.Len(t, recs, 1)
+	assert.InDelta(t, 2.45, recs[0].Price, 1e-9)
+	v, err := e.Evaluate("a/1")
+	assert.Nil(t, err)
+	assert.InDelta(t, 2.45, v, 1e-9)
+
+	e.DeleteRule("a/2")
+	recs = nil
+	e.Set("floor", 10)
+	assert.Len(t, recs, 1)
+	assert.Equal(t, "a/1", recs[0].Key)
+}
@@ -0,0 +1,291 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// units scale amounts to whole units of the native currency.
+var units = map[string]float64{
+	"eth":  1,
+	"gwei": 1e-9,
+	"wei":  1e-18,
+}
+
+var functions = map[string]func(args []float64) (float64, error){
+	"min": func(args []float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, fmt.Errorf("min needs an argument")
+		}
+		ret := args[0]
+		for _, a := range args[1:] {
+			ret = math.Min(ret, a)
+		}
+		return ret, nil
+	},
+	"max": func(args []float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, fmt.Errorf("max needs an argument")
+		}
+		ret := args[0]
+		for _, a := range args[1:] {
+			ret = math.Max(ret, a)
+		}
+		return ret, nil
+	},
+	"abs": func(args []float64) (float64, error) {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("abs takes 1 argument, got %d", len(args))
+		}
+		return math.Abs(args[0]), nil
+	},
+}
+
+// node is a node of a parsed expression.
+type node func(vars map[string]float64) (float64, error)
+
+// Expr is a parsed price expression, such as "floor * 0.98",
+// "trait_floor - 0.01 ETH" or "max(last_sale, floor)". Prices are in whole
+// units of the native currency.
+type Expr struct {
+	src  string
+	root node
+	vars []string
+}
+
+// Parse parses a price expression: numbers, optionally followed by a unit
+// (eth, gwei, wei), variables, + - * /, parentheses and the functions min,
+// max and abs.
+func Parse(src string) (*Expr, error) {
+	p := &parser{src: src, vars: map[string]bool{}}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+	root, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("Unexpected %q in %q", p.tokens[p.pos], src)
+	}
+	vars := make([]string, 0, len(p.vars))
+	for v := range p.vars {
+		vars = append(vars, v)
+	}
+	sort.Strings(vars)
+	return &Expr{src: src, root: root, vars: vars}, nil
+}
+
+func MustParse(src string) *Expr {
+	e, err := Parse(src)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Vars returns the variables the expression reads, sorted.
+func (e *Expr) Vars() []string {
+	return e.vars
+}
+
+// Eval evaluates the expression. It fails on missing variables.
+func (e *Expr) Eval(vars map[string]float64) (float64, error) {
+	return e.root(vars)
+}
+
+type parser struct {
+	src    string
+	tokens []string
+	pos    int
+	vars   map[string]bool
+}
+
+func (p *parser) lex() error {
+	s := p.src
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.ContainsRune("+-*/(),", c):
+			p.tokens = append(p.tokens, string(c))
+			i++
+		case unicode.IsDigit(c) || c == '.':
+			j := i
+			for j < len(s) && (unicode.IsDigit(rune(s[j])) || s[j] == '.') {
+				j++
+			}
+			p.tokens = append(p.tokens, s[i:j])
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || s[j] == '_') {
+				j++
+			}
+			p.tokens = append(p.tokens, strings.ToLower(s[i:j]))
+			i = j
+		default:
+			return fmt.Errorf("Unexpected %q in %q", c, p.src)
+		}
+	}
+	return nil
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) binary(operand func() (node, error), ops string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() != "" && strings.Contains(ops, p.peek()) {
+		op := p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = apply(op, left, right)
+	}
+	return left, nil
+}
+
+func apply(op string, left, right node) node {
+	return func(vars map[string]float64) (float64, error) {
+		l, err := left(vars)
+		if err != nil {
+			return 0, err
+		}
+		r, err := right(vars)
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case "+":
+			return l + r, nil
+		case "-":
+			return l - r, nil
+		case "*":
+			return l * r, nil
+		}
+		if r == 0 {
+			return 0, fmt.Errorf("Division by zero")
+		}
+		return l / r, nil
+	}
+}
+
+func (p *parser) expr() (node, error) {
+	return p.binary(p.term, "+-")
+}
+
+func (p *parser) term() (node, error) {
+	return p.binary(p.unary, "*/")
+}
+
+func (p *parser) unary() (node, error) {
+	if p.peek() == "-" {
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(vars map[string]float64) (float64, error) {
+			v, err := operand(vars)
+			return -v, err
+		}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch {
+	case t == "":
+		return nil, fmt.Errorf("Unexpected end of %q", p.src)
+	case t == "(":
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("Missing ) in %q", p.src)
+		}
+		return n, nil
+	case unicode.IsDigit(rune(t[0])) || t[0] == '.':
+		v, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid number %q in %q", t, p.src)
+		}
+		if scale, ok := units[p.peek()]; ok {
+			p.next()
+			v *= scale
+		}
+		return func(map[string]float64) (float64, error) { return v, nil }, nil
+	case unicode.IsLetter(rune(t[0])) || t[0] == '_':
+		if p.peek() == "(" {
+			return p.call(t)
+		}
+		p.vars[t] = true
+		return func(vars map[string]float64) (float64, error) {
+			v, ok := vars[t]
+			if !ok {
+				return 0, fmt.Errorf("Missing input %s", t)
+			}
+			return v, nil
+		}, nil
+	}
+	return nil, fmt.Errorf("Unexpected %q in %q", t, p.src)
+}
+
+func (p *parser) call(name string) (node, error) {
+	f, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown function %s in %q", name, p.src)
+	}
+	p.next()
+	args := []node{}
+	for p.peek() != ")" {
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.peek() == "," {
+			p.next()
+		} else if p.peek() != ")" {
+			return nil, fmt.Errorf("Missing ) in %q", p.src)
+		}
+	}
+	p.next()
+	return func(vars map[string]float64) (float64, error) {
+		values := make([]float64, len(args))
+		for i, arg := range args {
+			v, err := arg(vars)
+			if err != nil {
+				return 0, err
+			}
+			values[i] = v
+		}
+		return f(values)
+	}, nil
+}
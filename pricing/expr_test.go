@@ -0,0 +1,41 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	vars := map[string]float64{"floor": 2, "trait_floor": 3, "last_sale": 1.5}
+	for src, want := range map[string]float64{
+		"floor * 0.98":             1.96,
+		"trait_floor - 0.01 ETH":   2.99,
+		"max(last_sale, floor)":    2,
+		"min(last_sale, floor, 1)": 1,
+		"-floor + 3":               1,
+		"(floor + 1) * 2 / 3":      2,
+		"floor - 500 gwei":         2 - 500e-9,
+		"abs(last_sale - floor)":   0.5,
+		"Floor * 1":                2,
+	} {
+		e, err := Parse(src)
+		assert.Nil(t, err, src)
+		v, err := e.Eval(vars)
+		assert.Nil(t, err, src)
+		assert.InDelta(t, want, v, 1e-12, src)
+	}
+
+	e := MustParse("max(last_sale, floor) * 1.1")
+	assert.Equal(t, []string{"floor", "last_sale"}, e.Vars())
+	assert.Equal(t, "max(last_sale, floor) * 1.1", e.String())
+	_, err := e.Eval(map[string]float64{"floor": 1})
+	assert.NotNil(t, err)
+
+	for _, src := range []string{"", "floor *", "(floor", "median(floor)", "floor $ 2", "floor 2", "max(floor"} {
+		_, err := Parse(src)
+		assert.NotNil(t, err, src)
+	}
+	_, err = MustParse("floor / 0").Eval(vars)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,125 @@
+package opensea
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is the interval of pollers created without one, for
+// collections without a profile.
+const DefaultPollInterval = time.Minute
+
+// Priority ranks collections when a budget must be shared between them. The
+// calls made for a collection carry the priority of its profile in their
+// RequestInfo, for middlewares to schedule them.
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+)
+
+// CollectionProfile shapes the requests made for a collection. Zero
+// durations fall back to the default profile of the registry.
+type CollectionProfile struct {
+	// CacheTTL is how long fetched data, e.g. floor snapshots, is reused
+	// before being fetched again.
+	CacheTTL time.Duration
+	// PollInterval is the interval of pollers of the collection.
+	PollInterval time.Duration
+	Priority     Priority
+}
+
+// ProfileRegistry holds the profiles of collections, so that high-value
+// collections get fresher data and long-tail ones consume less budget. It
+// is safe for concurrent use.
+type ProfileRegistry struct {
+	mu       sync.RWMutex
+	def      CollectionProfile
+	profiles map[string]CollectionProfile
+}
+
+// NewProfileRegistry returns a registry applying def to collections without
+// a profile.
+func NewProfileRegistry(def CollectionProfile) *ProfileRegistry {
+	return &ProfileRegistry{
+		def:      def,
+		profiles: map[string]CollectionProfile{},
+	}
+}
+
+// WithProfiles makes the client consult the registry for the collections it
+// polls, snapshots and iterates.
+func WithProfiles(registry *ProfileRegistry) Option {
+	return func(o *Opensea) {
+		o.profiles = registry
+	}
+}
+
+// Set registers the profile of a collection, replacing any previous one.
+func (r *ProfileRegistry) Set(slug string, profile CollectionProfile) {
+	r.mu.Lock()
+	r.profiles[slug] = profile
+	r.mu.Unlock()
+}
+
+// Delete reverts a collection to the default profile.
+func (r *ProfileRegistry) Delete(slug string) {
+	r.mu.Lock()
+	delete(r.profiles, slug)
+	r.mu.Unlock()
+}
+
+// Profile returns the profile of a collection merged with the default one.
+func (r *ProfileRegistry) Profile(slug string) CollectionProfile {
+	if r == nil {
+		return CollectionProfile{}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.profiles[slug]
+	if !ok {
+		return r.def
+	}
+	if p.CacheTTL == 0 {
+		p.CacheTTL = r.def.CacheTTL
+	}
+	if p.PollInterval == 0 {
+		p.PollInterval = r.def.PollInterval
+	}
+	return p
+}
+
+// Prioritize returns the slugs ordered by decreasing priority, keeping the
+// given order between collections of the same priority.
+func (r *ProfileRegistry) Prioritize(slugs []string) []string {
+	ret := append([]string{}, slugs...)
+	sort.SliceStable(ret, func(i, j int) bool {
+		return r.Profile(ret[i]).Priority > r.Profile(ret[j]).Priority
+	})
+	return ret
+}
+
+func (o Opensea) profile(slug string) CollectionProfile {
+	return o.profiles.Profile(slug)
+}
+
+// contextWithProfile gives the calls made with the returned context the
+// priority of the profile of slug, unless ctx already carries one other
+// than PriorityNormal.
+func (o Opensea) contextWithProfile(ctx context.Context, slug string) context.Context {
+	if o.profiles == nil || slug == "" {
+		return ctx
+	}
+	if info, _ := RequestInfoFromContext(ctx); info.Priority != PriorityNormal {
+		return ctx
+	}
+	if priority := o.profile(slug).Priority; priority != PriorityNormal {
+		return ContextWithPriority(ctx, priority)
+	}
+	return ctx
+}
@@ -0,0 +1,92 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileRegistry(t *testing.T) {
+	r := NewProfileRegistry(CollectionProfile{CacheTTL: time.Hour, PollInterval: 10 * time.Minute, Priority: PriorityLow})
+	r.Set("doodles", CollectionProfile{PollInterval: 30 * time.Second, Priority: PriorityHigh})
+	r.Set("azuki", CollectionProfile{})
+
+	p := r.Profile("doodles")
+	assert.Equal(t, time.Hour, p.CacheTTL)
+	assert.Equal(t, 30*time.Second, p.PollInterval)
+	assert.Equal(t, PriorityHigh, p.Priority)
+
+	assert.Equal(t, PriorityLow, r.Profile("unknown").Priority)
+	// an explicit profile is normal priority unless set otherwise
+	assert.Equal(t, PriorityNormal, r.Profile("azuki").Priority)
+
+	assert.Equal(t, []string{"doodles", "azuki", "unknown", "other"}, r.Prioritize([]string{"unknown", "azuki", "doodles", "other"}))
+
+	r.Delete("doodles")
+	assert.Equal(t, 10*time.Minute, r.Profile("doodles").PollInterval)
+
+	var none *ProfileRegistry
+	assert.Equal(t, CollectionProfile{}, none.Profile("doodles"))
+}
+
+func TestProfileConsumers(t *testing.T) {
+	calls := 0
+	r := NewProfileRegistry(CollectionProfile{})
+	r.Set("doodles", CollectionProfile{CacheTTL: time.Hour, PollInterval: 5 * time.Second})
+	o := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		w.Write([]byte(`{"stats":{"floor_price":1.5}}`))
+	}, WithProfiles(r), WithFloorStore(NewMemoryFloorStore()))
+
+	for i := 0; i < 3; i++ {
+		snap, err := o.SnapshotFloor(context.Background(), "doodles")
+		assert.Nil(t, err)
+		assert.Equal(t, 1.5, snap.Floor)
+	}
+	assert.Equal(t, 1, calls)
+
+	// collections without a TTL are always fetched
+	o.SnapshotFloor(context.Background(), "azuki")
+	o.SnapshotFloor(context.Background(), "azuki")
+	assert.Equal(t, 3, calls)
+
+	params := NewRetrievingEventsParams()
+	params.CollectionSlug = "doodles"
+	assert.Equal(t, 5*time.Second, o.NewEventPoller(params, 0).interval)
+	assert.Equal(t, time.Second, o.NewEventPoller(params, time.Second).interval)
+	assert.Equal(t, DefaultPollInterval, o.NewEventPoller(nil, 0).interval)
+}
+
+func TestProfilePriority(t *testing.T) {
+	r := NewProfileRegistry(CollectionProfile{})
+	r.Set("doodles", CollectionProfile{Priority: PriorityHigh})
+	r.Set("azuki", CollectionProfile{Priority: PriorityLow})
+	priorities := map[string]Priority{}
+	o := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"listings":[],"offers":[],"stats":{"floor_price":1}}`))
+	}, WithProfiles(r), WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := RequestInfoFromContext(req.Context())
+			priorities[req.URL.Path] = info.Priority
+			return next.RoundTrip(req)
+		})
+	}))
+	ctx := context.Background()
+
+	assert.Nil(t, o.CollectionListingsIterator("doodles")(ctx, func(item interface{}) error { return nil }))
+	assert.Equal(t, PriorityHigh, priorities["/api/v2/listings/collection/doodles/all"])
+	assert.Nil(t, o.CollectionOffersIterator("azuki")(ctx, func(item interface{}) error { return nil }))
+	assert.Equal(t, PriorityLow, priorities["/api/v2/offers/collection/azuki/all"])
+
+	_, err := o.SnapshotFloor(ctx, "doodles")
+	assert.Nil(t, err)
+	assert.Equal(t, PriorityHigh, priorities["/api/v1/collection/doodles/stats"])
+
+	// the priority of the context wins
+	_, err = o.SnapshotFloor(ContextWithPriority(ctx, PriorityLow), "doodles")
+	assert.Nil(t, err)
+	assert.Equal(t, PriorityLow, priorities["/api/v1/collection/doodles/stats"])
+}
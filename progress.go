@@ -0,0 +1,128 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Progress is the progress of an enumeration job. Total is an estimate of
+// the number of items, and is 0 when unknown.
+type Progress struct {
+	Done    int64
+	Total   int64
+	Elapsed time.Duration
+}
+
+// Percent returns the completion of the job, capped at 100 since Total is
+// an estimate. It reports false when Total is unknown.
+func (p Progress) Percent() (float64, bool) {
+	if p.Total <= 0 {
+		return 0, false
+	}
+	pct := 100 * float64(p.Done) / float64(p.Total)
+	if pct > 100 {
+		pct = 100
+	}
+	return pct, true
+}
+
+// ETA estimates the time left at the rate observed so far. It reports false
+// when Total is unknown or nothing is done yet.
+func (p Progress) ETA() (time.Duration, bool) {
+	if p.Total <= 0 || p.Done <= 0 {
+		return 0, false
+	}
+	if p.Done >= p.Total {
+		return 0, true
+	}
+	perItem := float64(p.Elapsed) / float64(p.Done)
+	return time.Duration(perItem * float64(p.Total-p.Done)), true
+}
+
+func (p Progress) String() string {
+	pct, ok := p.Percent()
+	if !ok {
+		return fmt.Sprintf("%d done", p.Done)
+	}
+	eta, _ := p.ETA()
+	return fmt.Sprintf("%d/%d (%.1f%%, eta %s)", p.Done, p.Total, pct, eta.Round(time.Second))
+}
+
+// ProgressFunc is called with the progress of a job after each page.
+type ProgressFunc func(Progress)
+
+// ProgressTracker counts the items of an enumeration job. Pass it to the
+// page iterators with ContextWithProgress.
+type ProgressTracker struct {
+	mu    sync.Mutex
+	total int64
+	done  int64
+	start time.Time
+	fn    ProgressFunc
+	now   func() time.Time
+}
+
+// NewProgressTracker tracks a job of about total items, 0 if unknown, and
+// calls fn, if not nil, on each update.
+func NewProgressTracker(total int64, fn ProgressFunc) *ProgressTracker {
+	return &ProgressTracker{total: total, fn: fn, now: time.Now, start: time.Now()}
+}
+
+// Add counts n more items done and returns the progress.
+func (t *ProgressTracker) Add(n int) Progress {
+	t.mu.Lock()
+	t.done += int64(n)
+	p := t.progress()
+	t.mu.Unlock()
+	if t.fn != nil {
+		t.fn(p)
+	}
+	return p
+}
+
+func (t *ProgressTracker) Progress() Progress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.progress()
+}
+
+func (t *ProgressTracker) progress() Progress {
+	return Progress{Done: t.done, Total: t.total, Elapsed: t.now().Sub(t.start)}
+}
+
+type progressContextKey struct{}
+
+// ContextWithProgress makes the page iterators called with the returned
+// context, e.g. RetrievingEventsPagesWithContext, GetOrdersPagesWithContext
+// and TakeHolderSnapshotWithContext, count the items of each page in t.
+func ContextWithProgress(ctx context.Context, t *ProgressTracker) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, t)
+}
+
+func reportProgress(ctx context.Context, n int) {
+	if t, ok := ctx.Value(progressContextKey{}).(*ProgressTracker); ok {
+		t.Add(n)
+	}
+}
+
+// EstimateCollectionSize returns the total supply of a collection from its
+// stats, the expected item count when enumerating its assets.
+func (o Opensea) EstimateCollectionSize(ctx context.Context, slug string) (int64, error) {
+	stats, err := o.collectionStats(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+	return int64(stats.TotalSupply), nil
+}
+
+// EstimateCollectionOwners returns the number of owners of a collection from
+// its stats, the expected item count when enumerating its holders.
+func (o Opensea) EstimateCollectionOwners(ctx context.Context, slug string) (int64, error) {
+	stats, err := o.collectionStats(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+	return int64(stats.NumOwners), nil
+}
@@ -0,0 +1,66 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	p := Progress{Done: 250, Total: 1000, Elapsed: 10 * time.Second}
+	pct, ok := p.Percent()
+	assert.True(t, ok)
+	assert.Equal(t, 25.0, pct)
+	eta, ok := p.ETA()
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, eta)
+	assert.Equal(t, "250/1000 (25.0%, eta 30s)", p.String())
+
+	// the total is an estimate
+	pct, _ = Progress{Done: 1100, Total: 1000}.Percent()
+	assert.Equal(t, 100.0, pct)
+	eta, ok = Progress{Done: 1100, Total: 1000}.ETA()
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), eta)
+
+	_, ok = Progress{Done: 10}.Percent()
+	assert.False(t, ok)
+	_, ok = Progress{Total: 10}.ETA()
+	assert.False(t, ok)
+	assert.Equal(t, "10 done", Progress{Done: 10}.String())
+}
+
+func TestProgressTracker(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/collection/doodles-official/stats" {
+			w.Write([]byte(`{"stats":{"total_supply":3,"num_owners":2}}`))
+			return
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"assets":[{"token_id":"1","owner":{"address":"0x000000000000000000000000000000000000000a"}}],"next":"page2"}`))
+			return
+		}
+		w.Write([]byte(`{"assets":[{"token_id":"2","owner":{"address":"0x000000000000000000000000000000000000000b"}},{"token_id":"3"}]}`))
+	})
+	ctx := context.Background()
+	total, err := o.EstimateCollectionSize(ctx, "doodles-official")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), total)
+	owners, err := o.EstimateCollectionOwners(ctx, "doodles-official")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), owners)
+
+	var updates []Progress
+	tracker := NewProgressTracker(total, func(p Progress) { updates = append(updates, p) })
+	_, err = o.TakeHolderSnapshotWithContext(ContextWithProgress(ctx, tracker), Address(contract))
+	assert.Nil(t, err)
+	assert.Len(t, updates, 2)
+	assert.Equal(t, int64(1), updates[0].Done)
+	assert.Equal(t, int64(3), updates[1].Done)
+	pct, ok := tracker.Progress().Percent()
+	assert.True(t, ok)
+	assert.Equal(t, 100.0, pct)
+}
@@ -0,0 +1,74 @@
+package opensea
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DecodeFields decodes only the given top-level JSON fields of b into v. The
+// other fields are skipped without being decoded, which avoids allocating
+// the nested structures they hold. An empty fields decodes everything.
+func DecodeFields(b []byte, v interface{}, fields []string) error {
+	if len(fields) == 0 {
+		return json.Unmarshal(b, v)
+	}
+
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if m, ok := raw[f]; ok {
+			selected[f] = m
+		}
+	}
+	b, err := json.Marshal(selected)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// Prune zeroes every top-level field of the struct pointed to by v whose
+// JSON name is not in fields. Fields of embedded structs count as top-level,
+// as they do in the JSON encoding.
+func Prune(v interface{}, fields ...string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Prune expects a pointer to a struct, got %T", v)
+	}
+	keep := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		keep[f] = true
+	}
+	prune(rv.Elem(), keep)
+	return nil
+}
+
+func prune(rv reflect.Value, keep map[string]bool) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			prune(rv.Field(i), keep)
+			continue
+		}
+		if !keep[jsonName(f)] {
+			rv.Field(i).Set(reflect.Zero(f.Type))
+		}
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
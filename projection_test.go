@@ -0,0 +1,46 @@
+package opensea
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeFields(t *testing.T) {
+	a := Asset{}
+	err := DecodeFields([]byte(`{"token_id":"1","name":"n","owner":{"address":"`+contract+`"}}`), &a, []string{"token_id", "owner"})
+	assert.Nil(t, err)
+	assert.Equal(t, "1", a.TokenID)
+	assert.Equal(t, "", a.Name)
+	assert.Equal(t, Address(contract), a.Owner.Address)
+}
+
+func TestPrune(t *testing.T) {
+	c := CollectionSingle{Collection: Collection{Editors: []Address{NullAddress}}}
+	c.Slug = "doodles"
+	c.Name = "Doodles"
+	assert.Nil(t, Prune(&c, "slug", "editors"))
+	assert.Equal(t, "doodles", c.Slug)
+	assert.Equal(t, "", c.Name)
+	assert.Len(t, c.Editors, 1)
+
+	assert.NotNil(t, Prune(c, "slug"))
+}
+
+func TestGetAssetsFields(t *testing.T) {
+	fixture, err := ioutil.ReadFile("test-files/opensea-assets-collectibles.json")
+	assert.Nil(t, err)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+
+	ret, err := client.GetAssetsWithContext(context.Background(), GetAssetsParams{Fields: []string{"token_id"}})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, ret.Assets)
+	assert.NotEmpty(t, ret.Assets[0].TokenID)
+	assert.Nil(t, ret.Assets[0].AssetContract)
+	assert.Nil(t, ret.Assets[0].Collection)
+}
@@ -0,0 +1,82 @@
+package opensea
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Protocol is a version of the Seaport protocol accepted by the orders
+// endpoints.
+type Protocol string
+
+const (
+	ProtocolSeaport15 Protocol = "seaport1.5"
+	ProtocolSeaport16 Protocol = "seaport1.6"
+)
+
+// DefaultProtocol tracks the Seaport version currently used by OpenSea.
+const DefaultProtocol = ProtocolSeaport16
+
+var protocolAddresses = map[Protocol]Address{
+	ProtocolSeaport15: "0x00000000000000adc04c56bf30ac9d3c0aaf14dc",
+	ProtocolSeaport16: "0x0000000000000068f116a894984e2db1123eb395",
+}
+
+func ParseProtocol(s string) (Protocol, error) {
+	p := Protocol(strings.ToLower(strings.TrimSpace(s)))
+	if !p.IsValid() {
+		return "", fmt.Errorf("Invalid protocol: %s", s)
+	}
+	return p, nil
+}
+
+func (p Protocol) String() string {
+	return string(p)
+}
+
+func (p Protocol) IsValid() bool {
+	_, ok := protocolAddresses[p]
+	return ok
+}
+
+// Address returns the canonical protocol contract, deployed at the same
+// address on every supported chain.
+func (p Protocol) Address() Address {
+	return protocolAddresses[p]
+}
+
+// PathSegment returns the protocol segment of the orders routes, e.g.
+// /api/v2/orders/{chain}/seaport/listings. The route does not carry the
+// Seaport version.
+func (p Protocol) PathSegment() string {
+	return "seaport"
+}
+
+// WithProtocolAddress sets the Seaport deployment the client signs orders
+// for and looks them up on, for chains where it is not at the address of
+// the protocol version.
+func WithProtocolAddress(address Address) Option {
+	return func(o *Opensea) {
+		o.protocol = address
+	}
+}
+
+// protocolAddress returns the deployment of protocol the client targets.
+func (o Opensea) protocolAddress(protocol Protocol) Address {
+	if o.protocol != "" {
+		return o.protocol
+	}
+	return protocol.Address()
+}
+
+// resolveProtocol returns DefaultProtocol for an empty protocol and fails on
+// unsupported ones.
+func resolveProtocol(p Protocol) (Protocol, error) {
+	if p == "" {
+		return DefaultProtocol, nil
+	}
+	if !p.IsValid() {
+		return "", fmt.Errorf("Invalid protocol: %s", p)
+	}
+	return p, nil
+}
@@ -0,0 +1,22 @@
+package opensea
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProtocol(t *testing.T) {
+	p, err := ParseProtocol("Seaport1.5")
+	assert.Nil(t, err)
+	assert.Equal(t, ProtocolSeaport15, p)
+	assert.Equal(t, Address("0x00000000000000adc04c56bf30ac9d3c0aaf14dc"), p.Address())
+	assert.Equal(t, "seaport", p.PathSegment())
+
+	_, err = ParseProtocol("wyvern")
+	assert.NotNil(t, err)
+
+	p, err = resolveProtocol("")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultProtocol, p)
+}
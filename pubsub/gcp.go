@@ -0,0 +1,85 @@
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const pubSubEndpoint = "https://pubsub.googleapis.com"
+
+// PubSubPublisher publishes to a Google Cloud Pub/Sub topic through the
+// REST API. Token returns an OAuth2 access token, e.g. from the metadata
+// server or golang.org/x/oauth2/google.
+type PubSubPublisher struct {
+	Project    string
+	Topic      string
+	Token      func(ctx context.Context) (string, error)
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+func NewPubSubPublisher(project string, topic string, token func(ctx context.Context) (string, error)) *PubSubPublisher {
+	return &PubSubPublisher{
+		Project:    project,
+		Topic:      topic,
+		Token:      token,
+		Endpoint:   pubSubEndpoint,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type pubSubMessage struct {
+	Data        []byte            `json:"data"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	OrderingKey string            `json:"orderingKey,omitempty"`
+}
+
+func (p *PubSubPublisher) Publish(ctx context.Context, m *Message) error {
+	body, err := json.Marshal(map[string][]pubSubMessage{
+		"messages": {{Data: m.Data, Attributes: m.Attributes, OrderingKey: m.Key}},
+	})
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/v1/projects/%s/topics/%s:publish", p.Endpoint, url.PathEscape(p.Project), url.PathEscape(p.Topic))
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.Token != nil {
+		token, err := p.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Pub/Sub topic %s returns status %d", p.Topic, resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *PubSubPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubSubPublisher(t *testing.T) {
+	var body struct {
+		Messages []pubSubMessage `json:"messages"`
+	}
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/projects/my-project/topics/opensea-events:publish", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(status)
+		w.Write([]byte(`{"messageIds":["1"]}`))
+	}))
+	defer srv.Close()
+
+	p := NewPubSubPublisher("my-project", "opensea-events", func(ctx context.Context) (string, error) {
+		return "token", nil
+	})
+	p.Endpoint = srv.URL
+
+	m := &Message{Key: "doodles", Data: []byte(`{"id":1}`), Attributes: map[string]string{"event_type": "successful"}}
+	assert.Nil(t, p.Publish(context.Background(), m))
+	assert.Len(t, body.Messages, 1)
+	assert.Equal(t, m.Data, body.Messages[0].Data)
+	assert.Equal(t, "doodles", body.Messages[0].OrderingKey)
+	assert.Equal(t, "successful", body.Messages[0].Attributes["event_type"])
+
+	status = http.StatusForbidden
+	assert.NotNil(t, p.Publish(context.Background(), m))
+}
@@ -0,0 +1,76 @@
+package pubsub
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaProducer sends records to Kafka. KafkaWriter implements it with
+// kafka-go; other client libraries, such as confluent-kafka-go, can be
+// wrapped instead.
+type KafkaProducer interface {
+	Produce(ctx context.Context, topic string, key []byte, value []byte, headers map[string]string) error
+	Close() error
+}
+
+// KafkaPublisher publishes to a single topic, keyed by Message.Key, with
+// the attributes as record headers.
+type KafkaPublisher struct {
+	Producer KafkaProducer
+	Topic    string
+}
+
+func NewKafkaPublisher(producer KafkaProducer, topic string) *KafkaPublisher {
+	return &KafkaPublisher{Producer: producer, Topic: topic}
+}
+
+func (k *KafkaPublisher) Publish(ctx context.Context, m *Message) error {
+	return k.Producer.Produce(ctx, k.Topic, []byte(m.Key), m.Data, m.Attributes)
+}
+
+func (k *KafkaPublisher) Close() error {
+	return k.Producer.Close()
+}
+
+// KafkaWriter is a KafkaProducer on a kafka-go Writer. Records are
+// partitioned by the hash of their key, so that the events of a collection
+// stay ordered, and Produce returns once every in-sync replica has them.
+type KafkaWriter struct {
+	w *kafka.Writer
+}
+
+// NewKafkaWriter writes to the cluster of brokers, e.g.
+// []string{"localhost:9092"}. TLS and SASL are configured on the
+// transport, as in &kafka.Transport{TLS: config, SASL: mechanism}; a nil
+// transport is kafka.DefaultTransport.
+func NewKafkaWriter(brokers []string, transport kafka.RoundTripper) *KafkaWriter {
+	return &KafkaWriter{w: &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		// Produce blocks until its batch is sent, so batches of the relay
+		// are not held for the default second
+		BatchTimeout: 10 * time.Millisecond,
+		Transport:    transport,
+	}}
+}
+
+func (k *KafkaWriter) Produce(ctx context.Context, topic string, key []byte, value []byte, headers map[string]string) error {
+	m := kafka.Message{Topic: topic, Key: key, Value: value}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		m.Headers = append(m.Headers, kafka.Header{Key: name, Value: []byte(headers[name])})
+	}
+	return k.w.WriteMessages(ctx, m)
+}
+
+func (k *KafkaWriter) Close() error {
+	return k.w.Close()
+}
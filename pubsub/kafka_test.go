@@ -0,0 +1,95 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/kafka-go/protocol/produce"
+	"github.com/stretchr/testify/assert"
+)
+
+type kafkaRecord struct {
+	topic     string
+	partition int32
+	key       string
+	value     string
+	headers   map[string]string
+}
+
+// kafkaTransport answers the metadata and produce requests of a kafka-go
+// Writer for a topic of four partitions, recording the produced records.
+type kafkaTransport struct {
+	mu      sync.Mutex
+	records []kafkaRecord
+}
+
+func (k *kafkaTransport) RoundTrip(ctx context.Context, addr net.Addr, req protocol.Message) (protocol.Message, error) {
+	switch req := req.(type) {
+	case *metadata.Request:
+		resp := &metadata.Response{Brokers: []metadata.ResponseBroker{{NodeID: 1, Host: "127.0.0.1", Port: 9092}}}
+		for _, name := range req.TopicNames {
+			topic := metadata.ResponseTopic{Name: name}
+			for i := int32(0); i < 4; i++ {
+				topic.Partitions = append(topic.Partitions, metadata.ResponsePartition{PartitionIndex: i, LeaderID: 1})
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+	case *produce.Request:
+		resp := &produce.Response{}
+		for _, topic := range req.Topics {
+			rt := produce.ResponseTopic{Topic: topic.Topic}
+			for _, p := range topic.Partitions {
+				for {
+					r, err := p.RecordSet.Records.ReadRecord()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						return nil, err
+					}
+					key, _ := protocol.ReadAll(r.Key)
+					value, _ := protocol.ReadAll(r.Value)
+					headers := map[string]string{}
+					for _, h := range r.Headers {
+						headers[h.Key] = string(h.Value)
+					}
+					k.mu.Lock()
+					k.records = append(k.records, kafkaRecord{topic.Topic, p.Partition, string(key), string(value), headers})
+					k.mu.Unlock()
+				}
+				rt.Partitions = append(rt.Partitions, produce.ResponsePartition{Partition: p.Partition})
+			}
+			resp.Topics = append(resp.Topics, rt)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("unexpected request %T", req)
+}
+
+func TestKafkaWriter(t *testing.T) {
+	transport := &kafkaTransport{}
+	p := NewKafkaPublisher(NewKafkaWriter([]string{"127.0.0.1:9092"}, transport), "opensea-events")
+	defer p.Close()
+
+	ctx := context.Background()
+	for _, key := range []string{"doodles", "azuki", "doodles"} {
+		err := p.Publish(ctx, &Message{Key: key, Data: []byte(`{}`), Attributes: map[string]string{"event_type": "successful"}})
+		assert.Nil(t, err)
+	}
+
+	assert.Len(t, transport.records, 3)
+	r := transport.records[0]
+	assert.Equal(t, "opensea-events", r.topic)
+	assert.Equal(t, "doodles", r.key)
+	assert.Equal(t, "{}", r.value)
+	assert.Equal(t, map[string]string{"event_type": "successful"}, r.headers)
+	// the events of a collection stay on one partition
+	assert.Equal(t, r.partition, transport.records[2].partition)
+}
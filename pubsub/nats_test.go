@@ -0,0 +1,159 @@
+package pubsub
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveNATS accepts one connection, speaks just enough of the protocol for
+// a publisher and sends the CONNECT, client PONG and published lines to
+// lines. With config, the connection is upgraded to TLS after INFO.
+func serveNATS(t *testing.T, config *tls.Config, lines chan<- string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if config != nil {
+			conn.Write([]byte("INFO {\"server_id\":\"test\",\"max_payload\":1048576,\"tls_required\":true}\r\n"))
+			conn = tls.Server(conn, config)
+		} else {
+			conn.Write([]byte("INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n"))
+		}
+		r := bufio.NewReader(conn)
+		pinged := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				lines <- strings.TrimSpace(line)
+			case strings.HasPrefix(line, "PING"):
+				conn.Write([]byte("PONG\r\n"))
+				if !pinged {
+					// a server ping must be answered
+					conn.Write([]byte("PING\r\n"))
+					pinged = true
+				}
+			case strings.HasPrefix(line, "PONG"):
+				lines <- "PONG"
+			case strings.HasPrefix(line, "PUB "):
+				data, _ := r.ReadString('\n')
+				lines <- strings.TrimSpace(line) + " " + strings.TrimSpace(data)
+			}
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// waitFor returns the next line of lines starting with prefix.
+func waitFor(t *testing.T, lines <-chan string, prefix string) string {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case line := <-lines:
+			if strings.HasPrefix(line, prefix) {
+				return line
+			}
+		case <-timeout:
+			t.Fatalf("No %s line", prefix)
+			return ""
+		}
+	}
+}
+
+func TestNATSPublisher(t *testing.T) {
+	lines := make(chan string, 8)
+	addr := serveNATS(t, nil, lines)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, err := DialNATS(ctx, addr, "opensea.events", nats.Token("secret-token"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer p.Close()
+
+	assert.Contains(t, waitFor(t, lines, "CONNECT "), `"auth_token":"secret-token"`)
+	waitFor(t, lines, "PONG")
+
+	err = p.Publish(ctx, &Message{Data: []byte(`{"id":1}`), Attributes: map[string]string{"event_type": "successful"}})
+	assert.Nil(t, err)
+	assert.Equal(t, `PUB opensea.events.successful 8 {"id":1}`, waitFor(t, lines, "PUB "))
+
+	assert.NotNil(t, p.Publish(ctx, &Message{Attributes: map[string]string{"event_type": "a b"}}))
+
+	canceled, cancelPublish := context.WithCancel(context.Background())
+	cancelPublish()
+	assert.Equal(t, context.Canceled, p.Publish(canceled, &Message{Data: []byte("x")}))
+
+	p.Close()
+	assert.NotNil(t, p.Publish(ctx, &Message{Data: []byte("x")}))
+}
+
+func TestNATSPublisherTLS(t *testing.T) {
+	// the certificate of an httptest server is valid for 127.0.0.1
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	lines := make(chan string, 8)
+	addr := serveNATS(t, srv.TLS, lines)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	p, err := DialNATS(ctx, "tls://"+addr, "opensea.events",
+		nats.UserInfo("alice", "secret-password"),
+		nats.Secure(&tls.Config{RootCAs: roots}),
+	)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer p.Close()
+
+	connect := waitFor(t, lines, "CONNECT ")
+	assert.Contains(t, connect, `"user":"alice"`)
+	assert.Contains(t, connect, `"pass":"secret-password"`)
+
+	assert.Nil(t, p.Publish(ctx, &Message{Data: []byte("x")}))
+	assert.Equal(t, "PUB opensea.events 1 x", waitFor(t, lines, "PUB "))
+}
+
+func TestDialNATSDeadline(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer ln.Close()
+	// the server never greets
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(2 * time.Second)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = DialNATS(ctx, ln.Addr().String(), "opensea.events", nats.NoReconnect())
+	assert.NotNil(t, err)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
@@ -0,0 +1,87 @@
+// Package pubsub publishes OpenSea events to message buses. Publisher is
+// implemented for NATS, with the nats.go client, for Kafka, with kafka-go
+// behind the KafkaProducer interface, and for Google Cloud Pub/Sub without
+// extra dependencies.
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+// Message is a bus-neutral message. Key selects the partition or ordering
+// key; buses without keys ignore it.
+type Message struct {
+	Key        string
+	Data       []byte
+	Attributes map[string]string
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, m *Message) error
+	Close() error
+}
+
+// NewMessage encodes e as JSON, keyed by its collection so that the events
+// of a collection stay ordered.
+func NewMessage(e *opensea.Event) (*Message, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	key := e.CollectionSlug
+	if key == "" {
+		key = e.ContractAddress.String()
+	}
+	return &Message{
+		Key:  key,
+		Data: data,
+		Attributes: map[string]string{
+			"id":              strconv.FormatUint(e.ID, 10),
+			"event_type":      string(e.EventType),
+			"collection_slug": e.CollectionSlug,
+		},
+	}, nil
+}
+
+// Relay publishes the events of a stream.
+type Relay struct {
+	Publisher Publisher
+	// OnError is called with events that could not be published. When nil
+	// the first error stops Run.
+	OnError func(e *opensea.Event, err error)
+}
+
+func NewRelay(p Publisher) *Relay {
+	return &Relay{Publisher: p}
+}
+
+// Run publishes events until the stream ends or ctx is done.
+func (r *Relay) Run(ctx context.Context, stream opensea.EventStream) error {
+	for {
+		select {
+		case e, ok := <-stream.Events():
+			if !ok {
+				return stream.Err()
+			}
+			m, err := NewMessage(e)
+			if err == nil {
+				err = r.Publisher.Publish(ctx, m)
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if r.OnError == nil {
+					return err
+				}
+				r.OnError(e, err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStream struct {
+	events chan *opensea.Event
+}
+
+func (s testStream) Events() <-chan *opensea.Event {
+	return s.events
+}
+
+func (s testStream) Err() error {
+	return nil
+}
+
+func newTestStream(events ...*opensea.Event) testStream {
+	s := testStream{make(chan *opensea.Event, len(events))}
+	for _, e := range events {
+		s.events <- e
+	}
+	close(s.events)
+	return s
+}
+
+type testProducer struct {
+	topics  []string
+	keys    []string
+	headers []map[string]string
+	err     error
+}
+
+func (p *testProducer) Produce(ctx context.Context, topic string, key []byte, value []byte, headers map[string]string) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.topics = append(p.topics, topic)
+	p.keys = append(p.keys, string(key))
+	p.headers = append(p.headers, headers)
+	return nil
+}
+
+func (p *testProducer) Close() error {
+	return nil
+}
+
+func TestRelay(t *testing.T) {
+	producer := &testProducer{}
+	stream := newTestStream(
+		&opensea.Event{ID: 1, EventType: opensea.EventTypeSuccessful, CollectionSlug: "doodles"},
+		&opensea.Event{ID: 2, EventType: opensea.EventTypeCreated, ContractAddress: "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"},
+	)
+
+	err := NewRelay(NewKafkaPublisher(producer, "opensea-events")).Run(context.Background(), stream)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"opensea-events", "opensea-events"}, producer.topics)
+	assert.Equal(t, []string{"doodles", "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"}, producer.keys)
+	assert.Equal(t, "successful", producer.headers[0]["event_type"])
+	assert.Equal(t, "2", producer.headers[1]["id"])
+}
+
+func TestRelayErrors(t *testing.T) {
+	producer := &testProducer{err: errors.New("broker down")}
+	relay := NewRelay(NewKafkaPublisher(producer, "opensea-events"))
+	stream := newTestStream(&opensea.Event{ID: 1}, &opensea.Event{ID: 2})
+	assert.Equal(t, producer.err, relay.Run(context.Background(), stream))
+
+	var failed []uint64
+	relay.OnError = func(e *opensea.Event, err error) {
+		failed = append(failed, e.ID)
+	}
+	stream = newTestStream(&opensea.Event{ID: 1}, &opensea.Event{ID: 2})
+	assert.Nil(t, relay.Run(context.Background(), stream))
+	assert.Equal(t, []uint64{1, 2}, failed)
+}
+
+func TestNewMessage(t *testing.T) {
+	m, err := NewMessage(&opensea.Event{ID: 7, EventType: opensea.EventTypeTransfer})
+	assert.Nil(t, err)
+
+	e := new(opensea.Event)
+	assert.Nil(t, json.Unmarshal(m.Data, e))
+	assert.Equal(t, uint64(7), e.ID)
+	assert.Equal(t, "transfer", m.Attributes["event_type"])
+}
@@ -0,0 +1,139 @@
+package opensea
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuoteSide is the side of the book of a quote.
+type QuoteSide string
+
+const (
+	// QuoteAsk quotes sell NFTs: listings.
+	QuoteAsk QuoteSide = "ask"
+	// QuoteBid quotes buy NFTs: offers.
+	QuoteBid QuoteSide = "bid"
+)
+
+// QuoteScope is what a quote applies to.
+type QuoteScope string
+
+const (
+	QuoteToken      QuoteScope = "token"
+	QuoteBundle     QuoteScope = "bundle"
+	QuoteCollection QuoteScope = "collection"
+	QuoteTrait      QuoteScope = "trait"
+)
+
+// QuoteSource references the order behind a quote.
+type QuoteSource struct {
+	// Protocol is "seaport" for the v2 order book and "wyvern" for the v1
+	// orders.
+	Protocol  string `json:"protocol" bson:"protocol"`
+	OrderHash string `json:"order_hash,omitempty" bson:"order_hash,omitempty"`
+	// OrderID is the ID of v1 orders.
+	OrderID int64 `json:"order_id,omitempty" bson:"order_id,omitempty"`
+}
+
+// Quote is a listing or an offer reduced to what pricing needs, whatever
+// the order it comes from: SeaportOrder.Quote and Order.Quote derive it.
+type Quote struct {
+	Side  QuoteSide  `json:"side" bson:"side"`
+	Scope QuoteScope `json:"scope" bson:"scope"`
+	Chain Chain      `json:"chain,omitempty" bson:"chain,omitempty"`
+	// Contract and TokenID are the item of token quotes, and the first
+	// item of bundles.
+	Contract Address `json:"contract,omitempty" bson:"contract,omitempty"`
+	TokenID  string  `json:"token_id,omitempty" bson:"token_id,omitempty"`
+	// Collection is the slug of collection and trait quotes.
+	Collection string         `json:"collection,omitempty" bson:"collection,omitempty"`
+	Trait      *TraitCriteria `json:"trait,omitempty" bson:"trait,omitempty"`
+	// Currency is the symbol of the payment token, empty when the order
+	// does not tell.
+	Currency string `json:"currency,omitempty" bson:"currency,omitempty"`
+	// UnitPrice is the price of one item in whole units of the currency.
+	UnitPrice float64 `json:"unit_price" bson:"unit_price"`
+	Quantity  int64   `json:"quantity" bson:"quantity"`
+	// ExpiresAt is zero when the order has no known end.
+	ExpiresAt time.Time   `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	Source    QuoteSource `json:"source" bson:"source"`
+}
+
+// Expired reports whether the quote has expired at now.
+func (q Quote) Expired(now time.Time) bool {
+	return !q.ExpiresAt.IsZero() && !now.Before(q.ExpiresAt)
+}
+
+// Quote returns the quote of a v2 listing or offer.
+func (o SeaportOrder) Quote() Quote {
+	listing := o.IsListing()
+	q := Quote{
+		Side:      QuoteBid,
+		Chain:     o.Chain,
+		Currency:  o.price().Currency,
+		UnitPrice: o.unitPrice(listing),
+		Quantity:  o.nftQuantity(listing),
+		ExpiresAt: o.ExpiresAt(),
+		Source:    QuoteSource{Protocol: "seaport", OrderHash: o.OrderHash},
+	}
+	if listing {
+		q.Side = QuoteAsk
+	}
+	if c := o.Criteria; c != nil && (c.Collection != nil || c.Trait != nil) {
+		q.Scope = QuoteCollection
+		if c.Collection != nil {
+			q.Collection = c.Collection.Slug
+		}
+		if c.Trait != nil {
+			q.Scope = QuoteTrait
+			q.Trait = c.Trait
+		}
+		return q
+	}
+	q.Scope = QuoteToken
+	tokens := o.tokens()
+	if len(tokens) > 1 {
+		q.Scope = QuoteBundle
+	}
+	if len(tokens) > 0 {
+		parts := strings.SplitN(tokens[0], "/", 2)
+		q.Contract, q.TokenID = Address(parts[0]), parts[1]
+	}
+	return q
+}
+
+// Quote returns the quote of a v1 order, priced in the 18 decimals of the
+// native and wrapped native tokens.
+func (o Order) Quote() Quote {
+	q := Quote{
+		Side:     QuoteBid,
+		Scope:    QuoteToken,
+		TokenID:  o.Asset.TokenID,
+		Quantity: 1,
+		Source:   QuoteSource{Protocol: "wyvern", OrderID: o.ID},
+	}
+	if o.Side == Sell {
+		q.Side = QuoteAsk
+	}
+	if o.Asset.AssetContract != nil {
+		q.Contract = o.Asset.AssetContract.Address
+	}
+	if n, err := strconv.ParseInt(o.Quantity, 10, 64); err == nil && n > 0 {
+		q.Quantity = n
+	}
+	q.UnitPrice = OrderPrice{Decimals: 18, Value: o.CurrentPrice}.Float() / float64(q.Quantity)
+	if o.ExpirationTime > 0 {
+		q.ExpiresAt = time.Unix(o.ExpirationTime, 0)
+	}
+	return q
+}
+
+// Quotes returns the quotes of orders.
+func Quotes(orders []SeaportOrder) []Quote {
+	ret := make([]Quote, len(orders))
+	for i, o := range orders {
+		ret[i] = o.Quote()
+	}
+	return ret
+}
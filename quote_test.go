@@ -0,0 +1,57 @@
+package opensea
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeaportOrderQuote(t *testing.T) {
+	listing := SeaportOrder{OrderHash: "0xa", Chain: ChainEthereum, CurrentPrice: "3000000000000000000", ExpirationTime: 1700000000}
+	listing.ProtocolData.Parameters.Offer = []OfferItem{{ItemType: ItemERC1155, Token: Address(contract), IdentifierOrCriteria: "7", StartAmount: "3"}}
+	q := listing.Quote()
+	assert.Equal(t, QuoteAsk, q.Side)
+	assert.Equal(t, QuoteToken, q.Scope)
+	assert.Equal(t, Address(contract), q.Contract)
+	assert.Equal(t, "7", q.TokenID)
+	assert.Equal(t, int64(3), q.Quantity)
+	assert.Equal(t, 1.0, q.UnitPrice)
+	assert.Equal(t, QuoteSource{Protocol: "seaport", OrderHash: "0xa"}, q.Source)
+	assert.True(t, q.Expired(time.Unix(1700000000, 0)))
+	assert.False(t, q.Expired(time.Unix(1699999999, 0)))
+
+	offer := SeaportOrder{
+		OrderHash: "0xb",
+		Price:     SeaportOrderPrice{OrderPrice: OrderPrice{Currency: "WETH", Decimals: 18, Value: "2000000000000000000"}},
+		Criteria:  &OfferCriteria{Collection: &CollectionCriteria{Slug: "doodles-official"}, Trait: &TraitCriteria{Type: "face", Value: "happy"}},
+	}
+	offer.ProtocolData.Parameters.Consideration = []ConsiderationItem{{ItemType: ItemERC721WithCriteria, Token: Address(contract), StartAmount: "2"}}
+	q = offer.Quote()
+	assert.Equal(t, QuoteBid, q.Side)
+	assert.Equal(t, QuoteTrait, q.Scope)
+	assert.Equal(t, "doodles-official", q.Collection)
+	assert.Equal(t, "happy", q.Trait.Value)
+	assert.Equal(t, "WETH", q.Currency)
+	assert.Equal(t, 1.0, q.UnitPrice)
+	assert.True(t, q.ExpiresAt.IsZero())
+	assert.Len(t, Quotes([]SeaportOrder{listing, offer}), 2)
+}
+
+func TestOrderQuote(t *testing.T) {
+	o := Order{
+		ID:             12,
+		Asset:          Asset{TokenID: "5", AssetContract: &AssetContract{Address: Address(contract)}},
+		CurrentPrice:   "4000000000000000000",
+		Quantity:       "2",
+		Side:           Sell,
+		ExpirationTime: 1700000000,
+	}
+	q := o.Quote()
+	assert.Equal(t, QuoteAsk, q.Side)
+	assert.Equal(t, "5", q.TokenID)
+	assert.Equal(t, 2.0, q.UnitPrice)
+	assert.Equal(t, int64(2), q.Quantity)
+	assert.Equal(t, QuoteSource{Protocol: "wyvern", OrderID: 12}, q.Source)
+	assert.Equal(t, time.Unix(1700000000, 0), q.ExpiresAt)
+}
@@ -0,0 +1,142 @@
+package opensea
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit describes a request budget.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+func (r RateLimit) IsZero() bool {
+	return r.RequestsPerSecond <= 0
+}
+
+// RateLimiter is a token bucket limiter shared by every copy of the client
+// it is attached to.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   limit.RequestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+	d := l.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	l.tokens++
+	l.mu.Unlock()
+}
+
+// PartitionedRateLimiter keeps an independent budget per partition, so that
+// traffic on one chain cannot starve another one sharing the same API key.
+type PartitionedRateLimiter struct {
+	mu       sync.Mutex
+	def      RateLimit
+	limits   map[string]RateLimit
+	limiters map[string]*RateLimiter
+}
+
+// NewPartitionedRateLimiter returns a limiter applying limits to the named
+// partitions and def to every other partition. A zero def leaves other
+// partitions unlimited.
+func NewPartitionedRateLimiter(def RateLimit, limits map[string]RateLimit) *PartitionedRateLimiter {
+	return &PartitionedRateLimiter{
+		def:      def,
+		limits:   limits,
+		limiters: map[string]*RateLimiter{},
+	}
+}
+
+func (p *PartitionedRateLimiter) Wait(ctx context.Context, partition string) error {
+	if p == nil {
+		return nil
+	}
+	return p.limiter(partition).Wait(ctx)
+}
+
+func (p *PartitionedRateLimiter) limiter(partition string) *RateLimiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	l, ok := p.limiters[partition]
+	if !ok {
+		limit, ok := p.limits[partition]
+		if !ok {
+			limit = p.def
+		}
+		if !limit.IsZero() {
+			l = NewRateLimiter(limit)
+		}
+		p.limiters[partition] = l
+	}
+	return l
+}
+
+type chainContextKey struct{}
+
+// contextWithChain records the chain a request targets; it selects the rate
+// limit partition of the request.
+func contextWithChain(ctx context.Context, chain Chain) context.Context {
+	return context.WithValue(ctx, chainContextKey{}, chain)
+}
+
+func chainFromContext(ctx context.Context) Chain {
+	chain, _ := ctx.Value(chainContextKey{}).(Chain)
+	return chain
+}
@@ -0,0 +1,56 @@
+package opensea
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(RateLimit{RequestsPerSecond: 1, Burst: 2})
+	now := time.Now()
+
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, time.Second, l.reserve(now))
+	assert.Equal(t, time.Second, l.reserve(now.Add(time.Second)))
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := NewRateLimiter(RateLimit{RequestsPerSecond: 0.1})
+	assert.Nil(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Wait(ctx))
+}
+
+func TestPartitionedRateLimiter(t *testing.T) {
+	p := NewPartitionedRateLimiter(RateLimit{}, map[string]RateLimit{
+		ChainPolygon.String(): {RequestsPerSecond: 0.1},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Nil(t, p.Wait(ctx, ChainPolygon.String()))
+	assert.NotNil(t, p.Wait(ctx, ChainPolygon.String()))
+
+	// ethereum has no limit and is not starved by polygon
+	assert.Nil(t, p.Wait(context.Background(), ChainEthereum.String()))
+	assert.Nil(t, p.Wait(context.Background(), ChainEthereum.String()))
+}
+
+func TestChainRateLimitsOption(t *testing.T) {
+	client, err := NewOpensea("", WithChainRateLimits(RateLimit{}, map[Chain]RateLimit{
+		ChainPolygon: {RequestsPerSecond: 0.1},
+	}))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(contextWithChain(context.Background(), ChainPolygon), 10*time.Millisecond)
+	defer cancel()
+	assert.Nil(t, client.wait(ctx))
+	assert.NotNil(t, client.wait(ctx))
+	assert.Nil(t, client.wait(contextWithChain(context.Background(), ChainEthereum)))
+}
@@ -0,0 +1,180 @@
+package opensea
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// RedactAction is what a RedactionPolicy does to a sensitive value.
+type RedactAction string
+
+const (
+	RedactKeep  RedactAction = ""
+	RedactStrip RedactAction = "strip"
+	// RedactHash replaces values with a salted hash, so that redacted
+	// records can still be joined on them.
+	RedactHash RedactAction = "hash"
+	// RedactTruncate keeps the ends of values, e.g. 0x1234…abcd.
+	RedactTruncate RedactAction = "truncate"
+)
+
+// RedactionPolicy tells how to redact the personal data of models before
+// logging or exporting them. Addresses and Usernames apply to accounts,
+// owners and order offerers, not to contracts; Fields apply to string fields by Go name at any
+// depth, e.g. "DiscordID".
+type RedactionPolicy struct {
+	Addresses RedactAction
+	Usernames RedactAction
+	Fields    map[string]RedactAction
+	// Salt is mixed into hashes so that they cannot be reversed by hashing
+	// known addresses.
+	Salt string
+}
+
+// DefaultRedactionPolicy truncates addresses, hashes usernames and strips
+// Discord IDs and emails.
+func DefaultRedactionPolicy() RedactionPolicy {
+	return RedactionPolicy{
+		Addresses: RedactTruncate,
+		Usernames: RedactHash,
+		Fields: map[string]RedactAction{
+			"DiscordID": RedactStrip,
+			"Email":     RedactStrip,
+		},
+	}
+}
+
+// Apply redacts a single value with action.
+func (p RedactionPolicy) Apply(action RedactAction, value string) string {
+	if value == "" {
+		return value
+	}
+	switch action {
+	case RedactStrip:
+		return ""
+	case RedactHash:
+		sum := sha256.Sum256([]byte(p.Salt + strings.ToLower(value)))
+		return hex.EncodeToString(sum[:8])
+	case RedactTruncate:
+		r := []rune(value)
+		if strings.HasPrefix(value, "0x") && len(r) > 12 {
+			return string(r[:6]) + "…" + string(r[len(r)-4:])
+		}
+		if len(r) > 4 {
+			return string(r[:2]) + "…" + string(r[len(r)-2:])
+		}
+		return "…"
+	}
+	return value
+}
+
+// Redact returns a redacted copy of v, a model or a pointer to one, of the
+// same type. The copy is made through JSON, so fields without a JSON name
+// are dropped.
+func (p RedactionPolicy) Redact(v interface{}) (interface{}, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+	copied := reflect.New(t)
+	if err := json.Unmarshal(b, copied.Interface()); err != nil {
+		return nil, fmt.Errorf("Cannot copy %s to redact it: %v", t, err)
+	}
+	p.walk(copied.Elem())
+	if isPtr {
+		return copied.Interface(), nil
+	}
+	return copied.Elem().Interface(), nil
+}
+
+var (
+	accountType         = reflect.TypeOf(Account{})
+	accountV2Type       = reflect.TypeOf(AccountV2{})
+	nftOwnerType        = reflect.TypeOf(NFTOwner{})
+	orderParametersType = reflect.TypeOf(OrderParameters{})
+)
+
+func (p RedactionPolicy) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			p.walk(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			p.walk(v.Index(i))
+		}
+	case reflect.Struct:
+		switch v.Type() {
+		case accountType:
+			p.set(v.FieldByName("Address"), p.Addresses)
+			p.set(v.FieldByName("User").FieldByName("Username"), p.Usernames)
+		case accountV2Type:
+			p.set(v.FieldByName("Address"), p.Addresses)
+			p.set(v.FieldByName("Username"), p.Usernames)
+		case nftOwnerType:
+			p.set(v.FieldByName("Address"), p.Addresses)
+		case orderParametersType:
+			p.set(v.FieldByName("Offerer"), p.Addresses)
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if action, ok := p.Fields[field.Name]; ok {
+				p.set(v.Field(i), action)
+				continue
+			}
+			p.walk(v.Field(i))
+		}
+	}
+}
+
+func (p RedactionPolicy) set(v reflect.Value, action RedactAction) {
+	if v.Kind() == reflect.String && v.CanSet() {
+		v.SetString(p.Apply(action, v.String()))
+	}
+}
+
+var addressPattern = regexp.MustCompile(`0x[0-9a-fA-F]{40}\b`)
+
+// RedactText redacts the addresses found in free text, such as log lines,
+// with the Addresses action. Contract addresses cannot be told apart and
+// are redacted too.
+func (p RedactionPolicy) RedactText(s string) string {
+	if p.Addresses == RedactKeep {
+		return s
+	}
+	return addressPattern.ReplaceAllStringFunc(s, func(a string) string {
+		return p.Apply(p.Addresses, a)
+	})
+}
+
+// RedactingLogger returns a Logger redacting the addresses of the lines
+// before passing them to logger.
+func RedactingLogger(logger Logger, p RedactionPolicy) Logger {
+	return redactingLogger{logger: logger, policy: p}
+}
+
+type redactingLogger struct {
+	logger Logger
+	policy RedactionPolicy
+}
+
+func (l redactingLogger) Printf(format string, v ...interface{}) {
+	l.logger.Printf("%s", l.policy.RedactText(fmt.Sprintf(format, v...)))
+}
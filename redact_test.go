@@ -0,0 +1,61 @@
+package opensea
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactionPolicy(t *testing.T) {
+	p := DefaultRedactionPolicy()
+	event := Event{
+		EventType:     EventTypeSuccessful,
+		Asset:         &Asset{TokenID: "1", AssetContract: &AssetContract{Address: Address(contract)}},
+		Seller:        &Account{Address: testBidder, User: User{Username: "alice"}, DiscordID: "alice#1234"},
+		Transaction:   &Transaction{FromAccount: Account{Address: testBidder}},
+		WinnerAccount: &Account{Address: testAuctionContract},
+	}
+
+	redacted, err := p.Redact(event)
+	assert.Nil(t, err)
+	e := redacted.(Event)
+	assert.Equal(t, Address("0x3333…3333"), e.Seller.Address)
+	assert.Equal(t, p.Apply(RedactHash, "alice"), e.Seller.User.Username)
+	assert.NotEqual(t, "alice", e.Seller.User.Username)
+	assert.Equal(t, "", e.Seller.DiscordID)
+	assert.Equal(t, Address("0x3333…3333"), e.Transaction.FromAccount.Address)
+	assert.Equal(t, Address(contract), e.Asset.AssetContract.Address)
+	assert.Equal(t, testBidder, event.Seller.Address)
+
+	ptr, err := p.Redact(&NFT{Owners: []NFTOwner{{Address: testBidder, Quantity: 1}}})
+	assert.Nil(t, err)
+	assert.Equal(t, Address("0x3333…3333"), ptr.(*NFT).Owners[0].Address)
+
+	ptr, err = p.Redact(&AccountV2{Address: testBidder, Username: "alice"})
+	assert.Nil(t, err)
+	assert.Equal(t, Address("0x3333…3333"), ptr.(*AccountV2).Address)
+	assert.Equal(t, p.Apply(RedactHash, "alice"), ptr.(*AccountV2).Username)
+
+	order := SeaportOrder{ProtocolData: ProtocolData{Parameters: OrderParameters{Offerer: testBidder}}}
+	redacted, err = p.Redact(order)
+	assert.Nil(t, err)
+	assert.Equal(t, Address("0x3333…3333"), redacted.(SeaportOrder).ProtocolData.Parameters.Offerer)
+
+	// multi-byte usernames are truncated on characters
+	truncated := p.Apply(RedactTruncate, "ジョンさん")
+	assert.True(t, utf8.ValidString(truncated))
+	assert.Equal(t, "ジョ…さん", truncated)
+
+	salted := p
+	salted.Salt = "pepper"
+	assert.NotEqual(t, p.Apply(RedactHash, "alice"), salted.Apply(RedactHash, "alice"))
+	assert.Equal(t, p.Apply(RedactHash, "Alice"), p.Apply(RedactHash, "alice"))
+}
+
+func TestRedactingLogger(t *testing.T) {
+	logger := &testLogger{}
+	l := RedactingLogger(logger, DefaultRedactionPolicy())
+	l.Printf("offer from %s", testBidder)
+	assert.Equal(t, []string{"offer from 0x3333…3333"}, logger.lines)
+}
@@ -0,0 +1,207 @@
+package opensea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultRefreshInterval paces the refreshes of RefreshCollectionMetadata.
+// OpenSea limits refreshes more strictly than reads.
+const DefaultRefreshInterval = 500 * time.Millisecond
+
+type RefreshOptions struct {
+	// Chain is the chain of the collection, the client chain if empty.
+	Chain Chain
+	// Interval is the time between two refreshes, DefaultRefreshInterval
+	// if zero.
+	Interval time.Duration
+	// Retries is the number of passes retrying the failed refreshes.
+	Retries int
+	// RetryDelay is the wait before each retry pass, 10 intervals if zero.
+	RetryDelay time.Duration
+}
+
+// RefreshFailure is a token whose refresh failed on every attempt.
+type RefreshFailure struct {
+	Contract Address
+	TokenID  string
+	Err      error
+}
+
+// RefreshReport sums up a bulk refresh.
+type RefreshReport struct {
+	Requested int
+	Refreshed int
+	Failed    []RefreshFailure
+	Elapsed   time.Duration
+}
+
+// RefreshCollectionMetadata asks OpenSea to refresh the metadata of every
+// token of a collection, given by slug or contract address, e.g. after a
+// reveal. The tokens are enumerated first, then refreshed one at a time
+// every opts.Interval, reporting progress per token to the tracker of ctx.
+// Failed refreshes are retried opts.Retries times and listed in the report.
+// A canceled ctx returns the report so far with the context error.
+func (o Opensea) RefreshCollectionMetadata(ctx context.Context, collection string, opts RefreshOptions) (*RefreshReport, error) {
+	start := time.Now()
+	chain, err := o.chainFor(opts.Chain, CapabilityNFTs)
+	if err != nil {
+		return nil, err
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	retryDelay := opts.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = 10 * interval
+	}
+
+	pending, err := o.refreshTargets(ctx, chain, collection)
+	if err != nil {
+		return nil, err
+	}
+	report := &RefreshReport{Requested: len(pending), Failed: []RefreshFailure{}}
+	for pass := 0; len(pending) > 0; pass++ {
+		if pass > 0 {
+			if pass > opts.Retries {
+				break
+			}
+			if err := sleep(ctx, retryDelay); err != nil {
+				report.Failed = pending
+				report.Elapsed = time.Since(start)
+				return report, err
+			}
+		}
+		failed := []RefreshFailure{}
+		for i, t := range pending {
+			if i > 0 {
+				if err := sleep(ctx, interval); err != nil {
+					report.Failed = append(failed, pending[i:]...)
+					report.Elapsed = time.Since(start)
+					return report, err
+				}
+			}
+			err := o.RefreshNFTMetadataWithContext(ctx, chain, t.Contract, t.TokenID)
+			if err == ErrAPIKeyRequired {
+				return nil, err
+			}
+			if err != nil {
+				t.Err = err
+				failed = append(failed, t)
+				// back off as asked before the next token
+				var throttled *ThrottledError
+				if errors.As(err, &throttled) {
+					if err := sleep(ctx, throttled.RetryAfter); err != nil {
+						report.Failed = append(failed, pending[i+1:]...)
+						report.Elapsed = time.Since(start)
+						return report, err
+					}
+				}
+			} else {
+				report.Refreshed++
+			}
+			if pass == 0 {
+				reportProgress(ctx, 1)
+			}
+		}
+		pending = failed
+	}
+	report.Failed = pending
+	report.Elapsed = time.Since(start)
+	return report, nil
+}
+
+// refreshTargets lists the tokens of a collection slug or contract address,
+// as failures without error to share the shape of the retry passes.
+func (o Opensea) refreshTargets(ctx context.Context, chain Chain, collection string) ([]RefreshFailure, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("Empty collection")
+	}
+	contract, err := ParseAddress(collection)
+	byContract := err == nil
+	ret := []RefreshFailure{}
+	params := GetNFTsParams{}
+	for {
+		var resp *NFTsResponse
+		if byContract {
+			resp, err = o.GetNFTsByContractWithContext(ctx, chain, contract, params)
+		} else {
+			resp, err = o.GetNFTsByCollectionWithContext(ctx, collection, params)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range resp.NFTs {
+			t := RefreshFailure{Contract: Address(n.Contract), TokenID: n.Identifier}
+			if t.Contract == "" {
+				t.Contract = contract
+			}
+			ret = append(ret, t)
+		}
+		if resp.Next == "" {
+			return ret, nil
+		}
+		params.Cursor = resp.Next
+	}
+}
+
+// ThrottledError is returned when OpenSea keeps throttling a call after
+// the retries of the client. RetryAfter is the wait it asks for, 0 if
+// unknown.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("Throttled, retry after %s", e.RetryAfter)
+	}
+	return "Throttled"
+}
+
+// RefreshNFTMetadata asks OpenSea to pull the metadata of a token again,
+// e.g. after a reveal. The refresh is queued: OpenSea answers 200 or 202
+// and updates the token shortly after. Throttled calls are retried as set
+// by WithRetries, honoring Retry-After, then fail with a *ThrottledError.
+func (o Opensea) RefreshNFTMetadata(chain Chain, contractAddress Address, identifier string) error {
+	ctx := context.TODO()
+	return o.RefreshNFTMetadataWithContext(ctx, chain, contractAddress, identifier)
+}
+
+func (o Opensea) RefreshNFTMetadataWithContext(ctx context.Context, chain Chain, contractAddress Address, identifier string) error {
+	chain, err := o.chainFor(chain, CapabilityNFTs)
+	if err != nil {
+		return err
+	}
+	if contractAddress == "" || identifier == "" {
+		return fmt.Errorf("Empty contract address or identifier")
+	}
+	ctx = contextWithChain(ctx, chain)
+	path := fmt.Sprintf("/api/v2/chain/%s/contract/%s/nfts/%s/refresh", chain, url.PathEscape(contractAddress.String()), url.PathEscape(identifier))
+
+	// Refreshing twice is harmless, so throttled attempts are retried.
+	backoff := o.backoff
+	for attempt := 0; ; attempt++ {
+		resp := new(Response)
+		_, err := o.PostPath(ContextWithResponse(ctx, resp), path, struct{}{})
+		if r := responseFromContext(ctx); r != nil {
+			*r = *resp
+		}
+		if err == nil || resp.StatusCode != http.StatusTooManyRequests {
+			return err
+		}
+		retryAfter := retryDelay(&http.Response{Header: resp.Header}, 0)
+		if attempt >= o.retries {
+			return &ThrottledError{RetryAfter: retryAfter}
+		}
+		if err := sleep(ctx, retryDelay(&http.Response{Header: resp.Header}, backoff)); err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
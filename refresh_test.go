@@ -0,0 +1,85 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshCollectionMetadata(t *testing.T) {
+	var mu sync.Mutex
+	refreshes := map[string]int{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/api/v2/collection/doodles-official/nfts":
+			if r.URL.Query().Get("next") == "" {
+				w.Write([]byte(`{"nfts":[{"identifier":"1","contract":"` + contract + `"},{"identifier":"2","contract":"` + contract + `"}],"next":"p2"}`))
+				return
+			}
+			w.Write([]byte(`{"nfts":[{"identifier":"3","contract":"` + contract + `"}]}`))
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/refresh"):
+			id := strings.Split(r.URL.Path, "/")[8]
+			refreshes[id]++
+			if id == "2" && refreshes[id] == 1 || id == "3" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"detail":"try later"}`))
+				return
+			}
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	tracker := NewProgressTracker(3, nil)
+	ctx := ContextWithProgress(context.Background(), tracker)
+	report, err := o.RefreshCollectionMetadata(ctx, "doodles-official", RefreshOptions{Chain: ChainEthereum, Interval: time.Millisecond, Retries: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.Requested)
+	assert.Equal(t, 2, report.Refreshed)
+	assert.Len(t, report.Failed, 1)
+	assert.Equal(t, "3", report.Failed[0].TokenID)
+	assert.NotNil(t, report.Failed[0].Err)
+	assert.Equal(t, map[string]int{"1": 1, "2": 2, "3": 3}, refreshes)
+	assert.Equal(t, int64(3), tracker.Progress().Done)
+}
+
+func TestRefreshNFTMetadata(t *testing.T) {
+	calls := 0
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v2/chain/ethereum/contract/"+contract+"/nfts/42/refresh", r.URL.Path)
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"detail":"throttled"}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{}`))
+	}, WithRetries(1, time.Millisecond))
+
+	assert.Nil(t, o.RefreshNFTMetadata(ChainEthereum, Address(contract), "42"))
+	assert.Equal(t, 2, calls)
+}
+
+func TestRefreshNFTMetadataThrottled(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"detail":"throttled"}`))
+	})
+
+	err := o.RefreshNFTMetadata(ChainEthereum, Address(contract), "42")
+	throttled, ok := err.(*ThrottledError)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, throttled.RetryAfter)
+}
@@ -0,0 +1,83 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// RequestInfo describes the request being made, for middlewares and custom
+// transports, which read it from the request context with
+// RequestInfoFromContext.
+type RequestInfo struct {
+	// Endpoint names the call, as set by ContextWithEndpoint, and defaults to
+	// the path of the request.
+	Endpoint string
+	// Attempt is 0 for the first attempt and counts retries after it.
+	Attempt  int
+	Priority Priority
+	// Chain is the chain targeted by the call, if any.
+	Chain Chain
+}
+
+type requestInfoContextKey struct{}
+
+// ContextWithEndpoint names the calls made with the returned context, e.g.
+// "floor-poller", for attribution by middlewares.
+func ContextWithEndpoint(ctx context.Context, endpoint string) context.Context {
+	info, _ := RequestInfoFromContext(ctx)
+	info.Endpoint = endpoint
+	return context.WithValue(ctx, requestInfoContextKey{}, info)
+}
+
+// ContextWithPriority sets the priority of the calls made with the returned
+// context.
+func ContextWithPriority(ctx context.Context, priority Priority) context.Context {
+	info, _ := RequestInfoFromContext(ctx)
+	info.Priority = priority
+	return context.WithValue(ctx, requestInfoContextKey{}, info)
+}
+
+// RequestInfoFromContext returns the RequestInfo of ctx. Within a request,
+// e.g. from req.Context() in a RoundTripper, every field is set.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoContextKey{}).(RequestInfo)
+	return info, ok
+}
+
+// Middleware wraps the transport of the client.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithMiddleware wraps the transport of the client with mw. The middleware
+// added last sees requests first.
+func WithMiddleware(mw Middleware) Option {
+	return func(o *Opensea) {
+		next := o.httpClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		o.httpClient.Transport = mw(next)
+	}
+}
+
+// RoundTripperFunc adapts a function to http.RoundTripper, e.g. to write
+// middlewares.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// contextWithAttempt returns the context of an attempt of a request to
+// rawURL.
+func contextWithAttempt(ctx context.Context, rawURL string, attempt int) context.Context {
+	info, _ := RequestInfoFromContext(ctx)
+	if info.Endpoint == "" {
+		if u, err := url.Parse(rawURL); err == nil {
+			info.Endpoint = u.Path
+		}
+	}
+	info.Attempt = attempt
+	info.Chain = chainFromContext(ctx)
+	return context.WithValue(ctx, requestInfoContextKey{}, info)
+}
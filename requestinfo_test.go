@@ -0,0 +1,51 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestInfo(t *testing.T) {
+	failures := 1
+	var infos []RequestInfo
+	var tenants []string
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		tenants = append(tenants, r.Header.Get("X-Tenant"))
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	},
+		WithRetries(1, time.Millisecond),
+		WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				info, ok := RequestInfoFromContext(req.Context())
+				assert.True(t, ok)
+				infos = append(infos, info)
+				req.Header.Set("X-Tenant", info.Endpoint)
+				return next.RoundTrip(req)
+			})
+		}),
+	)
+
+	ctx := ContextWithPriority(ContextWithEndpoint(context.Background(), "floor-poller"), PriorityHigh)
+	_, err := o.GetPath(contextWithChain(ctx, ChainBase), "/api/v1/collection/x/stats?limit=1")
+	assert.Nil(t, err)
+	assert.Equal(t, []RequestInfo{
+		{Endpoint: "floor-poller", Attempt: 0, Priority: PriorityHigh, Chain: ChainBase},
+		{Endpoint: "floor-poller", Attempt: 1, Priority: PriorityHigh, Chain: ChainBase},
+	}, infos)
+	assert.Equal(t, []string{"floor-poller", "floor-poller"}, tenants)
+
+	infos = nil
+	_, err = o.GetPath(context.Background(), "/api/v1/collection/x/stats")
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/v1/collection/x/stats", infos[0].Endpoint)
+	assert.Equal(t, PriorityNormal, infos[0].Priority)
+}
@@ -0,0 +1,96 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Response is the HTTP metadata of a call.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	// Latency spans every attempt, including rate limiting and retry
+	// delays.
+	Latency time.Duration
+	// Retries is the number of attempts made before the final one.
+	Retries int
+	// CacheStatus is the CDN cache status, e.g. "HIT" or "MISS", when
+	// reported.
+	CacheStatus string
+}
+
+type responseContextKey struct{}
+
+// ContextWithResponse returns a context in which calls record their Response
+// into resp, ala go-github:
+//
+//	resp := new(opensea.Response)
+//	asset, err := o.GetSingleAssetWithContext(opensea.ContextWithResponse(ctx, resp), contract, id)
+//
+// Helpers making several calls record the last one.
+func ContextWithResponse(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseContextKey{}, resp)
+}
+
+func responseFromContext(ctx context.Context) *Response {
+	resp, _ := ctx.Value(responseContextKey{}).(*Response)
+	return resp
+}
+
+func (r *Response) record(resp *http.Response, start time.Time, retries int) {
+	if r == nil {
+		return
+	}
+	*r = Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Latency:    time.Since(start),
+		Retries:    retries,
+	}
+	r.CacheStatus = resp.Header.Get("CF-Cache-Status")
+	if r.CacheStatus == "" {
+		r.CacheStatus = resp.Header.Get("X-Cache")
+	}
+}
+
+// WithRetries retries throttled (429) and server error (5xx) responses up to
+// max times, waiting backoff before the first retry and doubling it after
+// each one. A Retry-After header takes precedence over the backoff.
+func WithRetries(max int, backoff time.Duration) Option {
+	return func(o *Opensea) {
+		o.retries = max
+		o.backoff = backoff
+	}
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+func retryDelay(resp *http.Response, backoff time.Duration) time.Duration {
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if seconds, err := strconv.Atoi(s); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if t, err := http.ParseTime(s); err == nil {
+			return time.Until(t)
+		}
+	}
+	return backoff
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,56 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithResponse(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("CF-Cache-Status", "HIT")
+		w.Write([]byte(`{}`))
+	})
+
+	resp := new(Response)
+	_, err := o.GetPath(ContextWithResponse(context.Background(), resp), "/api/v1/asset/0x1/1")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "HIT", resp.CacheStatus)
+	assert.Equal(t, 0, resp.Retries)
+	assert.True(t, resp.Latency > 0)
+
+	// without a recorded response nothing is captured
+	_, err = o.GetPath(context.Background(), "/api/v1/asset/0x1/1")
+	assert.Nil(t, err)
+}
+
+func TestWithRetries(t *testing.T) {
+	calls := 0
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"success":false}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}, WithRetries(3, time.Millisecond))
+
+	resp := new(Response)
+	_, err := o.GetPath(ContextWithResponse(context.Background(), resp), "/")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 2, resp.Retries)
+
+	calls = 0
+	o.retries = 1
+	_, err = o.GetPath(ContextWithResponse(context.Background(), resp), "/")
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
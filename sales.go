@@ -0,0 +1,101 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// saleV2 is a sale event of the v2 events endpoints.
+type saleV2 struct {
+	EventType   string  `json:"event_type"`
+	OrderHash   string  `json:"order_hash"`
+	ClosingDate int64   `json:"closing_date"`
+	Quantity    int64   `json:"quantity"`
+	Seller      Address `json:"seller"`
+	Buyer       Address `json:"buyer"`
+	Transaction string  `json:"transaction"`
+	NFT         NFT     `json:"nft"`
+	Payment     struct {
+		Quantity     Number  `json:"quantity"`
+		TokenAddress Address `json:"token_address"`
+		Decimals     int64   `json:"decimals"`
+		Symbol       string  `json:"symbol"`
+	} `json:"payment"`
+}
+
+func (s saleV2) time() time.Time {
+	return time.Unix(s.ClosingDate, 0)
+}
+
+// unitPrice returns the price of one item in whole units of the native
+// currency. Sales in other currencies than the native one and its wrapped
+// token are not priced.
+func (s saleV2) unitPrice() (float64, bool) {
+	p := s.Payment
+	native := p.TokenAddress == "" || p.TokenAddress.IsNullAddress()
+	if !native && !strings.EqualFold(p.Symbol, "WETH") && !strings.EqualFold(p.Symbol, "ETH") {
+		return 0, false
+	}
+	price := OrderPrice{Currency: p.Symbol, Decimals: p.Decimals, Value: p.Quantity}.Float()
+	if price <= 0 {
+		return 0, false
+	}
+	quantity := s.Quantity
+	if quantity <= 0 {
+		quantity = 1
+	}
+	return price / float64(quantity), true
+}
+
+// getSalesV2 reads up to limit sales from a v2 events path, most recent
+// first.
+func (o Opensea) getSalesV2(ctx context.Context, path string, limit int) ([]saleV2, error) {
+	ret := []saleV2{}
+	next := ""
+	for len(ret) < limit {
+		q := url.Values{}
+		q.Set("event_type", "sale")
+		q.Set("limit", "50")
+		if next != "" {
+			q.Set("next", next)
+		}
+		b, err := o.GetPath(ctx, path+"?"+q.Encode())
+		if err != nil {
+			return nil, err
+		}
+		resp := &struct {
+			AssetEvents []saleV2 `json:"asset_events"`
+			Next        string   `json:"next"`
+		}{}
+		if err := json.Unmarshal(b, resp); err != nil {
+			return nil, err
+		}
+		for _, s := range resp.AssetEvents {
+			if s.EventType == "sale" && len(ret) < limit {
+				ret = append(ret, s)
+			}
+		}
+		if resp.Next == "" || len(resp.AssetEvents) == 0 {
+			break
+		}
+		next = resp.Next
+	}
+	return ret, nil
+}
+
+// median returns the median of values, which it sorts.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
@@ -0,0 +1,174 @@
+package opensea
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// APIVersion is the version of the API a payload was read from.
+type APIVersion string
+
+const (
+	APIv1 APIVersion = "v1"
+	APIv2 APIVersion = "v2"
+)
+
+// Kinds of the payloads with built-in migrations.
+const (
+	KindAsset   = "asset"
+	KindAccount = "account"
+)
+
+// Tagged is a stored payload tagged with its kind and the API version it
+// came from, so that it can be read back once the models move on.
+type Tagged struct {
+	Kind    string          `json:"kind" bson:"kind"`
+	Version APIVersion      `json:"version" bson:"version"`
+	Data    json.RawMessage `json:"data" bson:"data"`
+}
+
+// Tag encodes v as a payload of kind and version.
+func Tag(kind string, version APIVersion, v interface{}) (*Tagged, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Tagged{Kind: kind, Version: version, Data: b}, nil
+}
+
+// Migration converts the data of a payload to the next version.
+type Migration func(data json.RawMessage) (json.RawMessage, error)
+
+type migrationStep struct {
+	to APIVersion
+	fn Migration
+}
+
+// Migrations upgrade tagged payloads to the version the models of a kind
+// decode. DefaultMigrations knows the kinds of this package.
+type Migrations struct {
+	mu      sync.RWMutex
+	current map[string]APIVersion
+	steps   map[string]map[APIVersion]migrationStep
+}
+
+func NewMigrations() *Migrations {
+	return &Migrations{
+		current: map[string]APIVersion{},
+		steps:   map[string]map[APIVersion]migrationStep{},
+	}
+}
+
+// SetCurrent sets the version the model of kind decodes.
+func (m *Migrations) SetCurrent(kind string, version APIVersion) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.current[kind] = version
+}
+
+// Register adds the migration of the payloads of kind from one version to
+// another. Upgrades chain migrations up to the current version.
+func (m *Migrations) Register(kind string, from, to APIVersion, fn Migration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.steps[kind] == nil {
+		m.steps[kind] = map[APIVersion]migrationStep{}
+	}
+	m.steps[kind][from] = migrationStep{to: to, fn: fn}
+}
+
+// Upgrade migrates t to the current version of its kind.
+func (m *Migrations) Upgrade(t Tagged) (*Tagged, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	current, ok := m.current[t.Kind]
+	if !ok {
+		return nil, fmt.Errorf("Unknown payload kind: %s", t.Kind)
+	}
+	seen := map[APIVersion]bool{}
+	for t.Version != current {
+		if seen[t.Version] {
+			return nil, fmt.Errorf("Migration cycle for %s at %s", t.Kind, t.Version)
+		}
+		seen[t.Version] = true
+		step, ok := m.steps[t.Kind][t.Version]
+		if !ok {
+			return nil, fmt.Errorf("No migration of %s from %s to %s", t.Kind, t.Version, current)
+		}
+		data, err := step.fn(t.Data)
+		if err != nil {
+			return nil, fmt.Errorf("Migrating %s from %s: %v", t.Kind, t.Version, err)
+		}
+		t = Tagged{Kind: t.Kind, Version: step.to, Data: data}
+	}
+	return &t, nil
+}
+
+// Decode upgrades t and decodes it into v, e.g. an *Asset for KindAsset.
+func (m *Migrations) Decode(t Tagged, v interface{}) error {
+	upgraded, err := m.Upgrade(t)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(upgraded.Data, v)
+}
+
+// Tag encodes a as a KindAsset payload of the version it was read from.
+func (a Asset) Tag() (*Tagged, error) {
+	if a.APIVersion == APIv2 {
+		return a.NFT().Tag()
+	}
+	return Tag(KindAsset, APIv1, a)
+}
+
+// Tag encodes n as a KindAsset payload of APIv2.
+func (n NFT) Tag() (*Tagged, error) {
+	return Tag(KindAsset, APIv2, n)
+}
+
+// Tag encodes a as a KindAccount payload of the version it was read from.
+func (a Account) Tag() (*Tagged, error) {
+	if a.APIVersion == APIv2 {
+		return a.AccountV2().Tag()
+	}
+	return Tag(KindAccount, APIv1, a)
+}
+
+// Tag encodes a as a KindAccount payload of APIv2.
+func (a AccountV2) Tag() (*Tagged, error) {
+	return Tag(KindAccount, APIv2, a)
+}
+
+// DefaultMigrations decode assets into NFT and accounts into AccountV2, the
+// models of the current endpoints, upgrading the assets and accounts of the
+// v1 endpoints.
+var DefaultMigrations = defaultMigrations()
+
+func defaultMigrations() *Migrations {
+	m := NewMigrations()
+	m.SetCurrent(KindAsset, APIv2)
+	m.Register(KindAsset, APIv1, APIv2, func(data json.RawMessage) (json.RawMessage, error) {
+		a := new(Asset)
+		if err := json.Unmarshal(data, a); err != nil {
+			return nil, err
+		}
+		return json.Marshal(a.NFT())
+	})
+	m.SetCurrent(KindAccount, APIv2)
+	m.Register(KindAccount, APIv1, APIv2, func(data json.RawMessage) (json.RawMessage, error) {
+		a := new(Account)
+		if err := json.Unmarshal(data, a); err != nil {
+			return nil, err
+		}
+		return json.Marshal(a.AccountV2())
+	})
+	return m
+}
+
+// tagAssets sets the API version of assets read from version.
+func tagAssets(assets []Asset, version APIVersion) {
+	for i := range assets {
+		assets[i].APIVersion = version
+	}
+}
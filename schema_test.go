@@ -0,0 +1,121 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMigrationsAsset(t *testing.T) {
+	stored := `{"kind":"asset","version":"v1","data":{"token_id":"42","name":"Doodle #42","permalink":"https://opensea.io/assets/42","asset_contract":{"address":"` + contract + `","schema_name":"ERC721"},"collection":{"slug":"doodles-official"},"owner":{"address":"` + testBidder.String() + `"},"traits":[{"trait_type":"face","value":"happy"}]}}`
+	tagged := Tagged{}
+	assert.Nil(t, json.Unmarshal([]byte(stored), &tagged))
+	assert.Equal(t, APIv1, tagged.Version)
+
+	n := new(NFT)
+	assert.Nil(t, DefaultMigrations.Decode(tagged, n))
+	assert.Equal(t, "42", n.Identifier)
+	assert.Equal(t, "Doodle #42", n.Name)
+	assert.Equal(t, "doodles-official", n.Collection)
+	assert.Equal(t, contract, n.Contract)
+	assert.Equal(t, "erc721", n.TokenStandard)
+	assert.Equal(t, "https://opensea.io/assets/42", n.OpenseaURL)
+	assert.Equal(t, []NFTOwner{{Address: testBidder, Quantity: 1}}, n.Owners)
+	assert.Len(t, n.Traits, 1)
+
+	current, err := n.Tag()
+	assert.Nil(t, err)
+	assert.Equal(t, APIv2, current.Version)
+	m := new(NFT)
+	assert.Nil(t, DefaultMigrations.Decode(*current, m))
+	assert.Equal(t, n, m)
+}
+
+func TestDefaultMigrationsAccount(t *testing.T) {
+	tagged, err := Account{Address: testBidder, User: User{Username: "alice"}, ProfileImgURL: "https://img"}.Tag()
+	assert.Nil(t, err)
+	assert.Equal(t, APIv1, tagged.Version)
+	a := new(AccountV2)
+	assert.Nil(t, DefaultMigrations.Decode(*tagged, a))
+	assert.Equal(t, testBidder, a.Address)
+	assert.Equal(t, "alice", a.Username)
+	assert.Equal(t, "https://img", a.ProfileImageURL)
+}
+
+func TestReadsTagVersions(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/asset/" + contract + "/1":
+			w.Write([]byte(`{"token_id":"1","collection":{"slug":"doodles"}}`))
+		case "/api/v1/assets":
+			w.Write([]byte(`{"assets":[{"token_id":"2"}]}`))
+		case "/api/v2/accounts/alice":
+			w.Write([]byte(`{"address":"` + testBidder.String() + `","username":"alice"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	a, err := o.GetSingleAssetWithContext(ctx, contract, big.NewInt(1))
+	assert.Nil(t, err)
+	assert.Equal(t, APIv1, a.APIVersion)
+	tagged, err := a.Tag()
+	assert.Nil(t, err)
+	assert.Equal(t, APIv1, tagged.Version)
+	n := new(NFT)
+	assert.Nil(t, DefaultMigrations.Decode(*tagged, n))
+	assert.Equal(t, "doodles", n.Collection)
+
+	assets, err := o.GetAssetsWithContext(ctx, GetAssetsParams{})
+	assert.Nil(t, err)
+	assert.Equal(t, APIv1, assets.Assets[0].APIVersion)
+
+	converted := NFT{Identifier: "3", Collection: "doodles"}.Asset()
+	assert.Equal(t, APIv2, converted.APIVersion)
+	tagged, err = converted.Tag()
+	assert.Nil(t, err)
+	assert.Equal(t, APIv2, tagged.Version)
+
+	account, err := o.GetAccountWithContext(ctx, "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, APIv2, account.APIVersion)
+	tagged, err = account.Tag()
+	assert.Nil(t, err)
+	assert.Equal(t, APIv2, tagged.Version)
+	assert.JSONEq(t, `{"address":"`+testBidder.String()+`","username":"alice","profile_image_url":"","config":""}`, string(tagged.Data))
+}
+
+func TestMigrationsChain(t *testing.T) {
+	m := NewMigrations()
+	m.SetCurrent("thing", "v3")
+	m.Register("thing", "v1", "v2", func(data json.RawMessage) (json.RawMessage, error) {
+		return json.RawMessage(`{"n":2}`), nil
+	})
+	m.Register("thing", "v2", "v3", func(data json.RawMessage) (json.RawMessage, error) {
+		v := map[string]int{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		v["n"]++
+		return json.Marshal(v)
+	})
+	upgraded, err := m.Upgrade(Tagged{Kind: "thing", Version: "v1", Data: json.RawMessage(`{}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, APIVersion("v3"), upgraded.Version)
+	assert.JSONEq(t, `{"n":3}`, string(upgraded.Data))
+
+	_, err = m.Upgrade(Tagged{Kind: "thing", Version: "v0"})
+	assert.NotNil(t, err)
+	_, err = m.Upgrade(Tagged{Kind: "other", Version: "v1"})
+	assert.NotNil(t, err)
+
+	m.Register("thing", "v4", "v5", func(data json.RawMessage) (json.RawMessage, error) { return data, nil })
+	m.Register("thing", "v5", "v4", func(data json.RawMessage) (json.RawMessage, error) { return data, nil })
+	_, err = m.Upgrade(Tagged{Kind: "thing", Version: "v4"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,330 @@
+package opensea
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/quintics-io/go-opensea/internal/keccak"
+)
+
+// ItemType is the kind of token of a Seaport offer or consideration item.
+type ItemType uint8
+
+const (
+	ItemNative ItemType = iota
+	ItemERC20
+	ItemERC721
+	ItemERC1155
+	ItemERC721WithCriteria
+	ItemERC1155WithCriteria
+)
+
+// OrderType tells whether a Seaport order can be partially filled and
+// whether its zone must approve fulfillments.
+type OrderType uint8
+
+const (
+	OrderTypeFullOpen OrderType = iota
+	OrderTypePartialOpen
+	OrderTypeFullRestricted
+	OrderTypePartialRestricted
+)
+
+const (
+	// OpenSeaConduitKey selects the OpenSea conduit, the contract approved to
+	// transfer the tokens of OpenSea users.
+	OpenSeaConduitKey = "0x0000007b02230091a7ed01230072f7006a004d60a8d4e71d599b8104250f0000"
+	// OpenSeaFeeRecipient receives the OpenSea marketplace fee.
+	OpenSeaFeeRecipient Address = "0x0000a26b00c1f0df003000390027140000faa719"
+
+	zeroBytes32 = "0x0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+type OfferItem struct {
+	ItemType             ItemType `json:"itemType" bson:"itemType"`
+	Token                Address  `json:"token" bson:"token"`
+	IdentifierOrCriteria Number   `json:"identifierOrCriteria" bson:"identifierOrCriteria"`
+	StartAmount          Number   `json:"startAmount" bson:"startAmount"`
+	EndAmount            Number   `json:"endAmount" bson:"endAmount"`
+}
+
+type ConsiderationItem struct {
+	ItemType             ItemType `json:"itemType" bson:"itemType"`
+	Token                Address  `json:"token" bson:"token"`
+	IdentifierOrCriteria Number   `json:"identifierOrCriteria" bson:"identifierOrCriteria"`
+	StartAmount          Number   `json:"startAmount" bson:"startAmount"`
+	EndAmount            Number   `json:"endAmount" bson:"endAmount"`
+	Recipient            Address  `json:"recipient" bson:"recipient"`
+}
+
+// OrderParameters are the parameters of a Seaport order, in the format of
+// the orders endpoints.
+type OrderParameters struct {
+	Offerer                         Address             `json:"offerer" bson:"offerer"`
+	Zone                            Address             `json:"zone" bson:"zone"`
+	Offer                           []OfferItem         `json:"offer" bson:"offer"`
+	Consideration                   []ConsiderationItem `json:"consideration" bson:"consideration"`
+	OrderType                       OrderType           `json:"orderType" bson:"orderType"`
+	StartTime                       Number              `json:"startTime" bson:"startTime"`
+	EndTime                         Number              `json:"endTime" bson:"endTime"`
+	ZoneHash                        string              `json:"zoneHash" bson:"zoneHash"`
+	Salt                            Number              `json:"salt" bson:"salt"`
+	ConduitKey                      string              `json:"conduitKey" bson:"conduitKey"`
+	TotalOriginalConsiderationItems int                 `json:"totalOriginalConsiderationItems" bson:"totalOriginalConsiderationItems"`
+	Counter                         Number              `json:"counter" bson:"counter"`
+}
+
+// SignedOrder is an order ready to be posted to OpenSea.
+type SignedOrder struct {
+	Parameters      OrderParameters `json:"parameters" bson:"parameters"`
+	Signature       string          `json:"signature" bson:"signature"`
+	ProtocolAddress Address         `json:"protocol_address" bson:"protocol_address"`
+}
+
+// Signer signs order digests with the key of the offerer. Keys never go
+// through this package; implementations typically wrap a wallet or a KMS.
+type Signer interface {
+	Address() Address
+	// SignDigest returns the 65-byte r || s || v signature of an EIP-712
+	// digest.
+	SignDigest(ctx context.Context, digest [32]byte) ([]byte, error)
+}
+
+const (
+	eip712DomainType      = "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"
+	offerItemType         = "OfferItem(uint8 itemType,address token,uint256 identifierOrCriteria,uint256 startAmount,uint256 endAmount)"
+	considerationItemType = "ConsiderationItem(uint8 itemType,address token,uint256 identifierOrCriteria,uint256 startAmount,uint256 endAmount,address recipient)"
+	orderComponentsType   = "OrderComponents(address offerer,address zone,OfferItem[] offer,ConsiderationItem[] consideration,uint8 orderType,uint256 startTime,uint256 endTime,bytes32 zoneHash,uint256 salt,bytes32 conduitKey,uint256 counter)" +
+		considerationItemType + offerItemType
+)
+
+var protocolVersions = map[Protocol]string{
+	ProtocolSeaport15: "1.5",
+	ProtocolSeaport16: "1.6",
+}
+
+// Hash returns the EIP-712 struct hash of the order, the order hash used by
+// Seaport and the orders endpoints.
+func (p OrderParameters) Hash() ([32]byte, error) {
+	offer := make([]byte, 0, 32*len(p.Offer))
+	for _, item := range p.Offer {
+		h, err := hashStruct(offerItemType,
+			encodeUint8(uint8(item.ItemType)),
+			encodeAddress(item.Token),
+			encodeUint(item.IdentifierOrCriteria),
+			encodeUint(item.StartAmount),
+			encodeUint(item.EndAmount),
+		)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		offer = append(offer, h[:]...)
+	}
+	consideration := make([]byte, 0, 32*len(p.Consideration))
+	for _, item := range p.Consideration {
+		h, err := hashStruct(considerationItemType,
+			encodeUint8(uint8(item.ItemType)),
+			encodeAddress(item.Token),
+			encodeUint(item.IdentifierOrCriteria),
+			encodeUint(item.StartAmount),
+			encodeUint(item.EndAmount),
+			encodeAddress(item.Recipient),
+		)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		consideration = append(consideration, h[:]...)
+	}
+	offerHash := keccak.Sum256(offer)
+	considerationHash := keccak.Sum256(consideration)
+
+	return hashStruct(orderComponentsType,
+		encodeAddress(p.Offerer),
+		encodeAddress(p.Zone),
+		encodedWord(offerHash),
+		encodedWord(considerationHash),
+		encodeUint8(uint8(p.OrderType)),
+		encodeUint(p.StartTime),
+		encodeUint(p.EndTime),
+		encodeBytes32(p.ZoneHash),
+		encodeUint(p.Salt),
+		encodeBytes32(p.ConduitKey),
+		encodeUint(p.Counter),
+	)
+}
+
+// Digest returns the EIP-712 digest the offerer signs for the order on chain
+// through protocol. An empty protocol is DefaultProtocol.
+func (p OrderParameters) Digest(chain Chain, protocol Protocol) ([32]byte, error) {
+	return p.digest(chain, protocol, "")
+}
+
+// digest returns the digest of the order for the deployment of protocol at
+// address, or at the address of protocol when empty.
+func (p OrderParameters) digest(chain Chain, protocol Protocol, address Address) ([32]byte, error) {
+	domain, err := domainSeparator(chain, protocol, address)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	h, err := p.Hash()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return keccak.Sum256([]byte{0x19, 0x01}, domain[:], h[:]), nil
+}
+
+// SignOrder signs the order on chain through protocol. The signer must be
+// the offerer.
+func SignOrder(ctx context.Context, chain Chain, protocol Protocol, params *OrderParameters, signer Signer) (*SignedOrder, error) {
+	return signOrder(ctx, chain, protocol, "", params, signer)
+}
+
+// SignOrder signs the order on chain for the Seaport deployment of the
+// client, set by WithProtocolAddress.
+func (o Opensea) SignOrder(ctx context.Context, chain Chain, protocol Protocol, params *OrderParameters, signer Signer) (*SignedOrder, error) {
+	chain, err := o.resolveChain(chain)
+	if err != nil {
+		return nil, err
+	}
+	return signOrder(ctx, chain, protocol, o.protocol, params, signer)
+}
+
+func signOrder(ctx context.Context, chain Chain, protocol Protocol, address Address, params *OrderParameters, signer Signer) (*SignedOrder, error) {
+	protocol, err := resolveProtocol(protocol)
+	if err != nil {
+		return nil, err
+	}
+	if address == "" {
+		address = protocol.Address()
+	}
+	if !strings.EqualFold(signer.Address().String(), params.Offerer.String()) {
+		return nil, fmt.Errorf("Signer %s is not the offerer %s", signer.Address(), params.Offerer)
+	}
+	digest, err := params.digest(chain, protocol, address)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.SignDigest(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("Invalid signature length: %d", len(sig))
+	}
+	return &SignedOrder{
+		Parameters:      *params,
+		Signature:       "0x" + hex.EncodeToString(sig),
+		ProtocolAddress: address,
+	}, nil
+}
+
+func domainSeparator(chain Chain, protocol Protocol, address Address) ([32]byte, error) {
+	protocol, err := resolveProtocol(protocol)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if address == "" {
+		address = protocol.Address()
+	}
+	id := chain.ChainID()
+	if id == 0 {
+		return [32]byte{}, fmt.Errorf("Chain not supported for orders: %s", chain)
+	}
+	name := keccak.Sum256([]byte("Seaport"))
+	version := keccak.Sum256([]byte(protocolVersions[protocol]))
+	return hashStruct(eip712DomainType,
+		encodedWord(name),
+		encodedWord(version),
+		encodeUint(Number(fmt.Sprint(id))),
+		encodeAddress(address),
+	)
+}
+
+// encoded is a 32-byte ABI word or the error met while encoding it, so that
+// struct fields can be encoded inline.
+type encoded struct {
+	word [32]byte
+	err  error
+}
+
+func encodedWord(w [32]byte) encoded {
+	return encoded{word: w}
+}
+
+func hashStruct(typ string, fields ...encoded) ([32]byte, error) {
+	typeHash := keccak.Sum256([]byte(typ))
+	b := make([]byte, 0, 32*(len(fields)+1))
+	b = append(b, typeHash[:]...)
+	for _, f := range fields {
+		if f.err != nil {
+			return [32]byte{}, f.err
+		}
+		b = append(b, f.word[:]...)
+	}
+	return keccak.Sum256(b), nil
+}
+
+func encodeUint8(v uint8) encoded {
+	var w [32]byte
+	w[31] = v
+	return encoded{word: w}
+}
+
+// encodeUint encodes a decimal amount, or a hexadecimal one prefixed with
+// 0x. Leading zeros are decimal, not octal.
+func encodeUint(n Number) encoded {
+	var w [32]byte
+	s, base := string(n), 10
+	if s == "" {
+		s = "0"
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+	v, ok := new(big.Int).SetString(s, base)
+	if !ok || v.Sign() < 0 || v.BitLen() > 256 {
+		return encoded{err: fmt.Errorf("Invalid uint256: %s", n)}
+	}
+	v.FillBytes(w[:])
+	return encoded{word: w}
+}
+
+func encodeAddress(a Address) encoded {
+	var w [32]byte
+	if a == "" {
+		return encoded{word: w}
+	}
+	b, err := hexBytes(a.String(), 20)
+	if err != nil {
+		return encoded{err: fmt.Errorf("Invalid address: %s", a)}
+	}
+	copy(w[12:], b)
+	return encoded{word: w}
+}
+
+func encodeBytes32(s string) encoded {
+	var w [32]byte
+	if s == "" {
+		return encoded{word: w}
+	}
+	b, err := hexBytes(s, 32)
+	if err != nil {
+		return encoded{err: fmt.Errorf("Invalid bytes32: %s", s)}
+	}
+	copy(w[:], b)
+	return encoded{word: w}
+}
+
+func hexBytes(s string, size int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("Expected %d bytes, got %d", size, len(b))
+	}
+	return b, nil
+}
@@ -0,0 +1,158 @@
+package opensea
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/quintics-io/go-opensea/internal/keccak"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHashStruct checks the EIP-712 encoding against the example of the
+// EIP.
+func TestHashStruct(t *testing.T) {
+	domain, err := hashStruct(eip712DomainType,
+		encodedWord(keccak.Sum256([]byte("Ether Mail"))),
+		encodedWord(keccak.Sum256([]byte("1"))),
+		encodeUint("1"),
+		encodeAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToString(domain[:]))
+
+	personType := "Person(string name,address wallet)"
+	from, err := hashStruct(personType, encodedWord(keccak.Sum256([]byte("Cow"))), encodeAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"))
+	assert.Nil(t, err)
+	to, err := hashStruct(personType, encodedWord(keccak.Sum256([]byte("Bob"))), encodeAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"))
+	assert.Nil(t, err)
+	mail, err := hashStruct("Mail(Person from,Person to,string contents)"+personType,
+		encodedWord(from), encodedWord(to), encodedWord(keccak.Sum256([]byte("Hello, Bob!"))))
+	assert.Nil(t, err)
+	assert.Equal(t, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex.EncodeToString(mail[:]))
+
+	digest := keccak.Sum256([]byte{0x19, 0x01}, domain[:], mail[:])
+	assert.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(digest[:]))
+
+	_, err = hashStruct(personType, encodeUint("-1"))
+	assert.NotNil(t, err)
+	_, err = hashStruct(personType, encodeAddress("0x1234"))
+	assert.NotNil(t, err)
+}
+
+type testSigner struct {
+	address Address
+	digests [][32]byte
+}
+
+func (s *testSigner) Address() Address {
+	return s.address
+}
+
+func (s *testSigner) SignDigest(ctx context.Context, digest [32]byte) ([]byte, error) {
+	s.digests = append(s.digests, digest)
+	sig := make([]byte, 65)
+	copy(sig, digest[:])
+	sig[64] = 27
+	return sig, nil
+}
+
+func testOrderParameters() *OrderParameters {
+	return &OrderParameters{
+		Offerer: "0x1111111111111111111111111111111111111111",
+		Zone:    NullAddress,
+		Offer: []OfferItem{
+			{ItemType: ItemERC721, Token: "0x2222222222222222222222222222222222222222", IdentifierOrCriteria: "7", StartAmount: "1", EndAmount: "1"},
+		},
+		Consideration: []ConsiderationItem{
+			{ItemType: ItemNative, Token: NullAddress, IdentifierOrCriteria: "0", StartAmount: "975", EndAmount: "975", Recipient: "0x1111111111111111111111111111111111111111"},
+			{ItemType: ItemNative, Token: NullAddress, IdentifierOrCriteria: "0", StartAmount: "25", EndAmount: "25", Recipient: OpenSeaFeeRecipient},
+		},
+		OrderType:                       OrderTypeFullOpen,
+		StartTime:                       "1700000000",
+		EndTime:                         "1702592000",
+		ZoneHash:                        zeroBytes32,
+		Salt:                            "42",
+		ConduitKey:                      OpenSeaConduitKey,
+		TotalOriginalConsiderationItems: 2,
+		Counter:                         "0",
+	}
+}
+
+func TestOrderDigest(t *testing.T) {
+	p := testOrderParameters()
+	h, err := p.Hash()
+	assert.Nil(t, err)
+
+	// every component is part of the hash
+	q := *p
+	q.Salt = "43"
+	h2, err := q.Hash()
+	assert.Nil(t, err)
+	assert.NotEqual(t, h, h2)
+
+	// totalOriginalConsiderationItems is not an order component
+	q = *p
+	q.TotalOriginalConsiderationItems = 3
+	h2, err = q.Hash()
+	assert.Nil(t, err)
+	assert.Equal(t, h, h2)
+
+	eth, err := p.Digest(ChainEthereum, "")
+	assert.Nil(t, err)
+	polygon, err := p.Digest(ChainPolygon, "")
+	assert.Nil(t, err)
+	v15, err := p.Digest(ChainEthereum, ProtocolSeaport15)
+	assert.Nil(t, err)
+	assert.NotEqual(t, eth, polygon)
+	assert.NotEqual(t, eth, v15)
+
+	_, err = p.Digest(ChainSolana, "")
+	assert.NotNil(t, err)
+
+	q = *p
+	q.StartTime = "soon"
+	_, err = q.Hash()
+	assert.NotNil(t, err)
+
+	// zero-padded amounts are decimal, hexadecimal ones need their prefix
+	base, _ := p.Hash()
+	q = *p
+	q.Salt = "0" + q.Salt
+	padded, err := q.Hash()
+	assert.Nil(t, err)
+	assert.Equal(t, base, padded)
+	q.Salt = "0x2a"
+	prefixed, err := q.Hash()
+	assert.Nil(t, err)
+	assert.Equal(t, base, prefixed)
+	for _, invalid := range []Number{"0b101", "1_000", "0o17", "ff"} {
+		q.Salt = invalid
+		_, err = q.Hash()
+		assert.NotNil(t, err, string(invalid))
+	}
+}
+
+func TestSignOrder(t *testing.T) {
+	p := testOrderParameters()
+	signer := &testSigner{address: p.Offerer}
+	order, err := SignOrder(context.Background(), ChainEthereum, "", p, signer)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultProtocol.Address(), order.ProtocolAddress)
+	assert.Len(t, signer.digests, 1)
+
+	digest, _ := p.Digest(ChainEthereum, "")
+	assert.Equal(t, digest, signer.digests[0])
+	assert.Equal(t, fmt.Sprintf("0x%x%x1b", digest[:], make([]byte, 32)), order.Signature)
+
+	b, err := json.Marshal(order)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"startAmount":"975"`)
+	assert.Contains(t, string(b), `"protocol_address":"0x0000000000000068f116a894984e2db1123eb395"`)
+
+	other := &testSigner{address: "0x3333333333333333333333333333333333333333"}
+	_, err = SignOrder(context.Background(), ChainEthereum, "", p, other)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,219 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type SearchType string
+
+const (
+	SearchCollections SearchType = "collections"
+	SearchAccounts    SearchType = "accounts"
+)
+
+// SearchResult is a match of a query. Exactly one of Collection and Account
+// is set, according to Type. Score ranks the results, 1 being an exact
+// match.
+type SearchResult struct {
+	Type       SearchType  `json:"type" bson:"type"`
+	Score      float64     `json:"score" bson:"score"`
+	Collection *Collection `json:"collection,omitempty" bson:"collection,omitempty"`
+	Account    *Account    `json:"account,omitempty" bson:"account,omitempty"`
+}
+
+func (o Opensea) Search(query string, types ...SearchType) ([]SearchResult, error) {
+	ctx := context.TODO()
+	return o.SearchWithContext(ctx, query, types...)
+}
+
+// SearchWithContext looks the query up as collection slugs and as an
+// account username or address, concurrently, and returns the matches best
+// first. No type searches every type. OpenSea has no full-text search, so
+// collections are matched by slug candidates derived from the query.
+func (o Opensea) SearchWithContext(ctx context.Context, query string, types ...SearchType) ([]SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []SearchResult{}, nil
+	}
+	if len(types) == 0 {
+		types = []SearchType{SearchCollections, SearchAccounts}
+	}
+
+	var lookups []func(ctx context.Context) (*SearchResult, error)
+	for _, t := range types {
+		switch t {
+		case SearchCollections:
+			for _, c := range slugCandidates(query) {
+				c := c
+				lookups = append(lookups, func(ctx context.Context) (*SearchResult, error) {
+					return o.searchCollection(ctx, query, c.slug, c.score)
+				})
+			}
+		case SearchAccounts:
+			lookups = append(lookups, func(ctx context.Context) (*SearchResult, error) {
+				return o.searchAccount(ctx, query)
+			})
+		default:
+			return nil, fmt.Errorf("Invalid search type: %s", t)
+		}
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*SearchResult, len(lookups))
+	errs := make([]error, len(lookups))
+	for i, lookup := range lookups {
+		wg.Add(1)
+		go func(i int, lookup func(ctx context.Context) (*SearchResult, error)) {
+			defer wg.Done()
+			results[i], errs[i] = lookup(ctx)
+		}(i, lookup)
+	}
+	wg.Wait()
+
+	ret := []SearchResult{}
+	seen := map[string]bool{}
+	for i, r := range results {
+		if errs[i] != nil {
+			if errs[i] == ErrNotFound {
+				continue
+			}
+			return nil, errs[i]
+		}
+		if r == nil {
+			continue
+		}
+		key := string(r.Type) + ":"
+		if r.Collection != nil {
+			key += r.Collection.Slug
+		} else {
+			key += r.Account.Address.String()
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ret = append(ret, *r)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Score > ret[j].Score
+	})
+	return ret, nil
+}
+
+type slugCandidate struct {
+	slug  string
+	score float64
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugCandidates turns "Bored Ape Yacht Club" into "bored-ape-yacht-club",
+// "boredapeyachtclub" and the common "-official" variant.
+func slugCandidates(query string) []slugCandidate {
+	q := strings.ToLower(query)
+	dashed := strings.Trim(nonSlug.ReplaceAllString(q, "-"), "-")
+	if dashed == "" {
+		return nil
+	}
+	ret := []slugCandidate{{dashed, 1}}
+	if joined := strings.Replace(dashed, "-", "", -1); joined != dashed {
+		ret = append(ret, slugCandidate{joined, 0.9})
+	}
+	if !strings.HasSuffix(dashed, "-official") {
+		ret = append(ret, slugCandidate{dashed + "-official", 0.8})
+	}
+	return ret
+}
+
+type collectionV2 struct {
+	Collection     string `json:"collection"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	ImageURL       string `json:"image_url"`
+	BannerImageURL string `json:"banner_image_url"`
+	SafelistStatus string `json:"safelist_status"`
+	ProjectURL     string `json:"project_url"`
+	DiscordURL     string `json:"discord_url"`
+	TwitterUser    string `json:"twitter_username"`
+	Category       string `json:"category"`
+	// CreatorFeesEnforced is only served for some collections.
+	CreatorFeesEnforced *bool `json:"is_creator_fees_enforced"`
+	Fees                []struct {
+		Fee       float64 `json:"fee"`
+		Recipient Address `json:"recipient"`
+		Required  bool    `json:"required"`
+	} `json:"fees"`
+}
+
+func (o Opensea) getCollectionV2(ctx context.Context, slug string) (*collectionV2, error) {
+	b, err := o.GetPath(ctx, "/api/v2/collections/"+url.PathEscape(slug))
+	if err != nil {
+		return nil, err
+	}
+	c := new(collectionV2)
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c collectionV2) collection() *Collection {
+	return &Collection{
+		Slug:                  c.Collection,
+		Name:                  c.Name,
+		Description:           c.Description,
+		ImageUrl:              c.ImageURL,
+		BannerImageUrl:        c.BannerImageURL,
+		SafelistRequestStatus: c.SafelistStatus,
+		ExternalUrl:           c.ProjectURL,
+		DiscordUrl:            c.DiscordURL,
+		TwitterUsername:       c.TwitterUser,
+		Category:              c.Category,
+		IsCreatorFeesEnforced: c.CreatorFeesEnforced,
+	}
+}
+
+func (o Opensea) searchCollection(ctx context.Context, query string, slug string, score float64) (*SearchResult, error) {
+	c, err := o.getCollectionV2(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(c.Name, query) {
+		score = 1
+	}
+	return &SearchResult{Type: SearchCollections, Score: score, Collection: c.collection()}, nil
+}
+
+func (o Opensea) getAccountV2(ctx context.Context, addressOrUsername string) (*Account, error) {
+	b, err := o.GetPath(ctx, "/api/v2/accounts/"+url.PathEscape(addressOrUsername))
+	if err != nil {
+		return nil, err
+	}
+	a := new(AccountV2)
+	if err := json.Unmarshal(b, a); err != nil {
+		return nil, err
+	}
+	return a.Account(), nil
+}
+
+func (o Opensea) searchAccount(ctx context.Context, query string) (*SearchResult, error) {
+	if strings.ContainsAny(query, " /?#") {
+		return nil, nil
+	}
+	a, err := o.getAccountV2(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	score := 0.7
+	if strings.EqualFold(a.User.Username, query) || strings.EqualFold(a.Address.String(), query) {
+		score = 1
+	}
+	return &SearchResult{Type: SearchAccounts, Score: score, Account: a}, nil
+}
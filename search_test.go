@@ -0,0 +1,55 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// usernames are case insensitive
+		switch strings.ToLower(r.URL.Path) {
+		case "/api/v2/collections/doodles-official":
+			w.Write([]byte(`{"collection":"doodles-official","name":"Doodles","image_url":"https://example.com/d.png"}`))
+		case "/api/v2/accounts/doodles":
+			w.Write([]byte(`{"address":"0x0000000000000000000000000000000000000001","username":"doodles"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	results, err := o.SearchWithContext(context.Background(), "Doodles")
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	for _, r := range results {
+		assert.Equal(t, float64(1), r.Score)
+	}
+	assert.Equal(t, SearchCollections, results[0].Type)
+	assert.Equal(t, "doodles-official", results[0].Collection.Slug)
+	assert.Equal(t, SearchAccounts, results[1].Type)
+	assert.Equal(t, "doodles", results[1].Account.User.Username)
+
+	results, err = o.SearchWithContext(context.Background(), "Doodles", SearchAccounts)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+
+	results, err = o.SearchWithContext(context.Background(), "nothing here")
+	assert.Nil(t, err)
+	assert.Empty(t, results)
+
+	_, err = o.SearchWithContext(context.Background(), "x", SearchType("assets"))
+	assert.NotNil(t, err)
+}
+
+func TestSlugCandidates(t *testing.T) {
+	assert.Equal(t, []slugCandidate{
+		{"bored-ape-yacht-club", 1},
+		{"boredapeyachtclub", 0.9},
+		{"bored-ape-yacht-club-official", 0.8},
+	}, slugCandidates("Bored Ape Yacht Club"))
+	assert.Empty(t, slugCandidates("!!"))
+}
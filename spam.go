@@ -0,0 +1,149 @@
+package opensea
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// SpamReason is a heuristic flagging an asset as likely spam.
+type SpamReason string
+
+const (
+	SpamBlocklisted    SpamReason = "blocklisted"
+	SpamHidden         SpamReason = "hidden"
+	SpamSuspiciousName SpamReason = "suspicious_name"
+	SpamZeroVolume     SpamReason = "zero_volume"
+	// SpamUnverified is reported but does not make an asset spam on its
+	// own, as most legitimate collections are not verified.
+	SpamUnverified SpamReason = "unverified"
+)
+
+// DefaultSpamPatterns match the names of typical spam airdrops, which lure
+// holders to phishing sites.
+var DefaultSpamPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)https?://|www\.`),
+	regexp.MustCompile(`(?i)\b[a-z0-9-]+\.(com|io|xyz|org|net|app|site|fun|top|live|gift)\b`),
+	regexp.MustCompile(`(?i)\b(claim|reward|voucher|giveaway|free mint|airdrop)s?\b`),
+	regexp.MustCompile(`\$\s?\d`),
+}
+
+// SpamFilter flags likely spam in enumerated assets, e.g. before listing
+// the NFTs of a wallet. Safelisted collections are never flagged and
+// blocklisted ones always are. It is safe for concurrent use once
+// configured.
+type SpamFilter struct {
+	// Patterns flag suspicious asset and collection names.
+	Patterns []*regexp.Regexp
+	// Volumes are the total volumes of collections by slug, e.g. from their
+	// stats. Collections missing from Volumes are not checked for volume.
+	Volumes map[string]float64
+
+	mu        sync.RWMutex
+	safelist  map[string]bool
+	blocklist map[string]bool
+}
+
+func NewSpamFilter() *SpamFilter {
+	return &SpamFilter{
+		Patterns:  DefaultSpamPatterns,
+		Volumes:   map[string]float64{},
+		safelist:  map[string]bool{},
+		blocklist: map[string]bool{},
+	}
+}
+
+// Safelist exempts collections, given by slug or contract address.
+func (f *SpamFilter) Safelist(ids ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range ids {
+		f.safelist[strings.ToLower(id)] = true
+		delete(f.blocklist, strings.ToLower(id))
+	}
+}
+
+// Blocklist flags collections, given by slug or contract address.
+func (f *SpamFilter) Blocklist(ids ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range ids {
+		f.blocklist[strings.ToLower(id)] = true
+		delete(f.safelist, strings.ToLower(id))
+	}
+}
+
+// Check returns the heuristics flagging the asset, none for safelisted and
+// verified collections.
+func (f *SpamFilter) Check(a *Asset) []SpamReason {
+	slug, contract := "", ""
+	if a.Collection != nil {
+		slug = strings.ToLower(a.Collection.Slug)
+	}
+	if a.AssetContract != nil {
+		contract = strings.ToLower(a.AssetContract.Address.String())
+	}
+
+	f.mu.RLock()
+	safe := f.safelist[slug] || f.safelist[contract]
+	blocked := f.blocklist[slug] || f.blocklist[contract]
+	f.mu.RUnlock()
+	if blocked {
+		return []SpamReason{SpamBlocklisted}
+	}
+	if safe || a.IsVerified() {
+		return nil
+	}
+
+	reasons := []SpamReason{}
+	if a.Collection != nil && a.Collection.Hidden {
+		reasons = append(reasons, SpamHidden)
+	}
+	names := []string{a.Name}
+	if a.Collection != nil {
+		names = append(names, a.Collection.Name)
+	}
+	if f.suspicious(names...) {
+		reasons = append(reasons, SpamSuspiciousName)
+	}
+	if volume, ok := f.Volumes[slug]; ok && slug != "" && volume == 0 {
+		reasons = append(reasons, SpamZeroVolume)
+	}
+	reasons = append(reasons, SpamUnverified)
+	return reasons
+}
+
+// IsSpam reports whether a heuristic other than SpamUnverified flags the
+// asset.
+func (f *SpamFilter) IsSpam(a *Asset) bool {
+	for _, r := range f.Check(a) {
+		if r != SpamUnverified {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter splits assets into the ones to show and the likely spam.
+func (f *SpamFilter) Filter(assets []Asset) (kept []Asset, spam []Asset) {
+	kept, spam = []Asset{}, []Asset{}
+	for i := range assets {
+		if f.IsSpam(&assets[i]) {
+			spam = append(spam, assets[i])
+		} else {
+			kept = append(kept, assets[i])
+		}
+	}
+	return
+}
+
+func (f *SpamFilter) suspicious(names ...string) bool {
+	for _, name := range names {
+		for _, p := range f.Patterns {
+			if name != "" && p.MatchString(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package opensea
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpamFilter(t *testing.T) {
+	f := NewSpamFilter()
+	f.Volumes["quiet"] = 0
+	f.Volumes["doodles"] = 100
+
+	asset := func(name, slug, status string) Asset {
+		return Asset{
+			Name:          name,
+			AssetContract: &AssetContract{Address: "0x00000000000000000000000000000000000000aa"},
+			Collection:    &Collection{Slug: slug, SafelistRequestStatus: status},
+		}
+	}
+
+	a := asset("Doodle #1", "doodles", "not_requested")
+	assert.Equal(t, []SpamReason{SpamUnverified}, f.Check(&a))
+	assert.False(t, f.IsSpam(&a))
+
+	a = asset("Claim your reward at doodles-gift.xyz", "doodles-gift", "not_requested")
+	assert.Equal(t, []SpamReason{SpamSuspiciousName, SpamUnverified}, f.Check(&a))
+	assert.True(t, f.IsSpam(&a))
+
+	a = asset("Token", "quiet", "")
+	assert.Equal(t, []SpamReason{SpamZeroVolume, SpamUnverified}, f.Check(&a))
+
+	a = asset("Visit www.example.com", "quiet", "verified")
+	assert.Empty(t, f.Check(&a))
+
+	a = asset("Quiet #1", "quiet", "")
+	f.Safelist("quiet")
+	assert.False(t, f.IsSpam(&a))
+	f.Blocklist("0x00000000000000000000000000000000000000AA")
+	assert.Equal(t, []SpamReason{SpamBlocklisted}, f.Check(&a))
+
+	doodle := asset("Doodle #2", "doodles", "")
+	doodle.AssetContract = &AssetContract{Address: "0x00000000000000000000000000000000000000bb"}
+	kept, spam := f.Filter([]Asset{doodle, a})
+	assert.Len(t, kept, 1)
+	assert.Len(t, spam, 1)
+}
@@ -0,0 +1,191 @@
+package sqlstore
+
+import (
+	"context"
+	"encoding/json"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+var (
+	assetsTable = table{
+		name: "opensea_assets",
+		columns: []column{
+			{"chain", typeText},
+			{"contract_address", typeText},
+			{"token_id", typeText},
+			{"opensea_id", typeInt},
+			{"collection_slug", typeText},
+			{"name", typeText},
+			{"owner", typeText},
+			{"num_sales", typeInt},
+			{"image_url", typeText},
+			{"permalink", typeText},
+			{"traits", typeJSON},
+		},
+		key: []string{"chain", "contract_address", "token_id"},
+	}
+
+	collectionsTable = table{
+		name: "opensea_collections",
+		columns: []column{
+			{"slug", typeText},
+			{"name", typeText},
+			{"description", typeText},
+			{"image_url", typeText},
+			{"external_url", typeText},
+			{"safelist_request_status", typeText},
+			{"payout_address", typeText},
+			{"created_date", typeText},
+		},
+		key: []string{"slug"},
+	}
+
+	ordersTable = table{
+		name: "opensea_orders",
+		columns: []column{
+			{"id", typeInt},
+			{"side", typeInt},
+			{"contract_address", typeText},
+			{"token_id", typeText},
+			{"maker", typeText},
+			{"taker", typeText},
+			{"current_price", typeNumber},
+			{"payment_token", typeText},
+			{"listing_time", typeInt},
+			{"expiration_time", typeInt},
+			{"cancelled", typeBool},
+			{"finalized", typeBool},
+		},
+		key: []string{"id"},
+	}
+
+	eventsTable = table{
+		name: "opensea_events",
+		columns: []column{
+			{"id", typeInt},
+			{"event_type", typeText},
+			{"event_timestamp", typeTime},
+			{"collection_slug", typeText},
+			{"contract_address", typeText},
+			{"token_id", typeText},
+			{"total_price", typeNumber},
+			{"payment_token", typeText},
+			{"from_address", typeText},
+			{"to_address", typeText},
+			{"transaction_hash", typeText},
+		},
+		key: []string{"id"},
+	}
+
+	tables = []table{assetsTable, collectionsTable, ordersTable, eventsTable}
+)
+
+// UpsertAsset stores an asset fetched from chain.
+func UpsertAsset(ctx context.Context, db Execer, d Dialect, chain opensea.Chain, a *opensea.Asset) error {
+	var contract, slug, owner string
+	if a.AssetContract != nil {
+		contract = a.AssetContract.Address.String()
+	}
+	if a.Collection != nil {
+		slug = a.Collection.Slug
+	}
+	if a.Owner != nil {
+		owner = a.Owner.Address.String()
+	}
+	traits, err := json.Marshal(a.Traits)
+	if err != nil {
+		return err
+	}
+	return upsert(ctx, db, d, assetsTable, []interface{}{
+		chain.String(),
+		contract,
+		a.TokenID,
+		a.ID,
+		slug,
+		a.Name,
+		owner,
+		a.NumSales,
+		a.ImageURL,
+		a.Permalink,
+		string(traits),
+	})
+}
+
+func UpsertCollection(ctx context.Context, db Execer, d Dialect, c *opensea.Collection) error {
+	return upsert(ctx, db, d, collectionsTable, []interface{}{
+		c.Slug,
+		c.Name,
+		c.Description,
+		c.ImageUrl,
+		c.ExternalUrl,
+		c.SafelistRequestStatus,
+		c.PayoutAddress,
+		c.CreatedDate,
+	})
+}
+
+func UpsertOrder(ctx context.Context, db Execer, d Dialect, o *opensea.Order) error {
+	var contract string
+	if o.Asset.AssetContract != nil {
+		contract = o.Asset.AssetContract.Address.String()
+	}
+	return upsert(ctx, db, d, ordersTable, []interface{}{
+		o.ID,
+		int64(o.Side),
+		contract,
+		o.Asset.TokenID,
+		o.Maker.Address.String(),
+		o.Taker.Address.String(),
+		bigString(o.CurrentPrice),
+		o.PaymentToken.String(),
+		o.ListingTime,
+		o.ExpirationTime,
+		o.Cancelled,
+		o.Finalized,
+	})
+}
+
+func UpsertEvent(ctx context.Context, db Execer, d Dialect, e *opensea.Event) error {
+	var tokenID, token, from, to, txHash string
+	if e.Asset != nil {
+		tokenID = e.Asset.TokenID
+	}
+	if e.PaymentToken != nil {
+		token = e.PaymentToken.Address.String()
+	}
+	if e.FromAccount != nil {
+		from = e.FromAccount.Address.String()
+	}
+	if e.ToAccount != nil {
+		to = e.ToAccount.Address.String()
+	}
+	if e.Transaction != nil {
+		txHash = e.Transaction.TransactionHash
+	}
+	return upsert(ctx, db, d, eventsTable, []interface{}{
+		int64(e.ID),
+		string(e.EventType),
+		e.EventTimestamp.Time().UTC(),
+		e.CollectionSlug,
+		e.ContractAddress.String(),
+		tokenID,
+		bigString(e.TotalPrice),
+		token,
+		from,
+		to,
+		txHash,
+	})
+}
+
+// bigString drops the fractional part some endpoints append to wei amounts.
+func bigString(n opensea.Number) string {
+	if n == "" {
+		return ""
+	}
+	b := n.Big()
+	if b == nil {
+		return ""
+	}
+	return b.String()
+}
@@ -0,0 +1,152 @@
+// Package sqlstore persists OpenSea models with database/sql.
+//
+// Tables and upserts are generated for SQLite and PostgreSQL; the caller
+// brings the driver. Keys are stable across dialects: assets are keyed by
+// chain, contract and token id, collections by slug, orders and events by
+// their OpenSea id.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Execer is satisfied by *sql.DB, *sql.Tx and *sql.Conn.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+type Dialect struct {
+	Name        string
+	placeholder func(n int) string
+	types       map[columnType]string
+}
+
+var (
+	SQLite = Dialect{
+		Name:        "sqlite",
+		placeholder: func(n int) string { return "?" },
+		types: map[columnType]string{
+			typeText:   "TEXT",
+			typeInt:    "INTEGER",
+			typeBool:   "INTEGER",
+			typeJSON:   "TEXT",
+			typeTime:   "TIMESTAMP",
+			typeNumber: "TEXT",
+		},
+	}
+	Postgres = Dialect{
+		Name:        "postgres",
+		placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+		types: map[columnType]string{
+			typeText:   "TEXT",
+			typeInt:    "BIGINT",
+			typeBool:   "BOOLEAN",
+			typeJSON:   "JSONB",
+			typeTime:   "TIMESTAMPTZ",
+			typeNumber: "NUMERIC(78, 0)",
+		},
+	}
+)
+
+type columnType int
+
+const (
+	typeText columnType = iota
+	typeInt
+	typeBool
+	typeJSON
+	typeTime
+	// typeNumber holds uint256 amounts in wei.
+	typeNumber
+)
+
+type column struct {
+	name string
+	typ  columnType
+}
+
+type table struct {
+	name    string
+	columns []column
+	key     []string
+}
+
+func (t table) create(d Dialect) string {
+	defs := make([]string, 0, len(t.columns)+1)
+	for _, c := range t.columns {
+		def := fmt.Sprintf("%s %s", c.name, d.types[c.typ])
+		if t.isKey(c.name) {
+			def += " NOT NULL"
+		}
+		defs = append(defs, def)
+	}
+	defs = append(defs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(t.key, ", ")))
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n\t%s\n)", t.name, strings.Join(defs, ",\n\t"))
+}
+
+func (t table) upsert(d Dialect) string {
+	names := make([]string, len(t.columns))
+	values := make([]string, len(t.columns))
+	var updates []string
+	for i, c := range t.columns {
+		names[i] = c.name
+		values[i] = d.placeholder(i + 1)
+		if !t.isKey(c.name) {
+			updates = append(updates, fmt.Sprintf("%s = excluded.%s", c.name, c.name))
+		}
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s",
+		t.name,
+		strings.Join(names, ", "),
+		strings.Join(values, ", "),
+		strings.Join(t.key, ", "),
+		strings.Join(updates, ", "),
+	)
+}
+
+func (t table) isKey(name string) bool {
+	for _, k := range t.key {
+		if k == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema returns the CREATE TABLE statements of every table.
+func Schema(d Dialect) []string {
+	ret := make([]string, len(tables))
+	for i, t := range tables {
+		ret[i] = t.create(d)
+	}
+	return ret
+}
+
+// CreateSchema creates the missing tables.
+func CreateSchema(ctx context.Context, db Execer, d Dialect) error {
+	for _, stmt := range Schema(d) {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func upsert(ctx context.Context, db Execer, d Dialect, t table, values []interface{}) error {
+	if len(values) != len(t.columns) {
+		return fmt.Errorf("sqlstore: %d values for %d columns of %s", len(values), len(t.columns), t.name)
+	}
+	if d.Name == Postgres.Name {
+		for i, c := range t.columns {
+			// NUMERIC rejects the empty string used for unknown amounts
+			if s, ok := values[i].(string); ok && s == "" && c.typ == typeNumber {
+				values[i] = nil
+			}
+		}
+	}
+	_, err := db.ExecContext(ctx, t.upsert(d), values...)
+	return err
+}
@@ -0,0 +1,70 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	queries []string
+	args    [][]interface{}
+}
+
+func (r *recorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.queries = append(r.queries, query)
+	r.args = append(r.args, args)
+	return nil, nil
+}
+
+func TestSchema(t *testing.T) {
+	stmts := Schema(Postgres)
+	assert.Len(t, stmts, 4)
+	assert.Contains(t, stmts[0], "CREATE TABLE IF NOT EXISTS opensea_assets")
+	assert.Contains(t, stmts[0], "PRIMARY KEY (chain, contract_address, token_id)")
+	assert.Contains(t, stmts[0], "traits JSONB")
+	assert.Contains(t, Schema(SQLite)[0], "traits TEXT")
+
+	r := &recorder{}
+	assert.Nil(t, CreateSchema(context.Background(), r, SQLite))
+	assert.Len(t, r.queries, 4)
+}
+
+func TestUpsertAsset(t *testing.T) {
+	r := &recorder{}
+	asset := &opensea.Asset{
+		ID:            1,
+		TokenID:       "42",
+		AssetContract: &opensea.AssetContract{Address: "0xdceaf1652a131f32a821468dc03a92df0edd86ea"},
+		Collection:    &opensea.Collection{Slug: "mch"},
+	}
+	err := UpsertAsset(context.Background(), r, Postgres, opensea.ChainEthereum, asset)
+	assert.Nil(t, err)
+
+	q := r.queries[0]
+	assert.True(t, strings.HasPrefix(q, "INSERT INTO opensea_assets (chain, contract_address, token_id,"))
+	assert.Contains(t, q, "VALUES ($1, $2, $3,")
+	assert.Contains(t, q, "ON CONFLICT (chain, contract_address, token_id) DO UPDATE SET opensea_id = excluded.opensea_id")
+	assert.NotContains(t, q, "token_id = excluded")
+	assert.Equal(t, []interface{}{"ethereum", "0xdceaf1652a131f32a821468dc03a92df0edd86ea", "42"}, r.args[0][:3])
+}
+
+func TestUpsertEventNumeric(t *testing.T) {
+	r := &recorder{}
+	err := UpsertEvent(context.Background(), r, Postgres, &opensea.Event{ID: 3, TotalPrice: "1000000000000000000.0"})
+	assert.Nil(t, err)
+	assert.Equal(t, "1000000000000000000", r.args[0][6])
+
+	err = UpsertEvent(context.Background(), r, Postgres, &opensea.Event{ID: 4})
+	assert.Nil(t, err)
+	assert.Nil(t, r.args[1][6])
+
+	err = UpsertEvent(context.Background(), r, SQLite, &opensea.Event{ID: 4})
+	assert.Nil(t, err)
+	assert.Contains(t, r.queries[2], "VALUES (?, ?")
+	assert.Equal(t, "", r.args[2][6])
+}
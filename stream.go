@@ -0,0 +1,178 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventStream delivers events as they happen. Events is closed when the
+// stream ends, after which Err reports why.
+type EventStream interface {
+	Events() <-chan *Event
+	Err() error
+}
+
+// EventPoller is an EventStream backed by periodic calls to the events
+// endpoint. It is a fallback for consumers that cannot hold the Stream API
+// websocket open, and the reference source of the stream consumers in this
+// module.
+type EventPoller struct {
+	// OnError is called with errors of individual polls, which are retried
+	// on the next tick. It must be set before Start.
+	OnError func(err error)
+
+	o        Opensea
+	params   RetrievingEventsParams
+	interval time.Duration
+	events   chan *Event
+	// fetch reads the events of a window, the v1 events by default.
+	fetch func(ctx context.Context, after int64, before int64) ([]*Event, error)
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewEventPoller returns a poller of events matching params every interval,
+// starting at params.OccurredAfter. Offset, Limit and OccurredBefore are
+// managed by the poller. A zero interval is the poll interval of the profile
+// of params.CollectionSlug, or DefaultPollInterval, and the polls have the
+// priority of that profile.
+func (o Opensea) NewEventPoller(params *RetrievingEventsParams, interval time.Duration) *EventPoller {
+	if params == nil {
+		params = NewRetrievingEventsParams()
+	}
+	if interval <= 0 {
+		interval = o.profile(params.CollectionSlug).PollInterval
+	}
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	p := &EventPoller{
+		o:        o,
+		params:   *params,
+		interval: interval,
+		events:   make(chan *Event),
+	}
+	p.fetch = p.poll
+	return p
+}
+
+// PollEvents creates and starts an EventPoller.
+func (o Opensea) PollEvents(ctx context.Context, params *RetrievingEventsParams, interval time.Duration) *EventPoller {
+	p := o.NewEventPoller(params, interval)
+	p.Start(ctx)
+	return p
+}
+
+// Start polls in the background until ctx is done.
+func (p *EventPoller) Start(ctx context.Context) {
+	go p.run(ctx)
+}
+
+func (p *EventPoller) Events() <-chan *Event {
+	return p.events
+}
+
+func (p *EventPoller) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *EventPoller) run(ctx context.Context) {
+	defer close(p.events)
+	ctx = p.o.contextWithProfile(ctx, p.params.CollectionSlug)
+
+	// keys of the events already emitted at the newest timestamp, as the
+	// next window starts at that same second
+	seen := map[string]bool{}
+	after := p.params.OccurredAfter
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		before := time.Now().Unix()
+		events, err := p.fetch(ctx, after, before)
+		if err != nil && ctx.Err() == nil && p.OnError != nil {
+			p.OnError(err)
+		}
+		if err == nil {
+			newest := after
+			for _, e := range events {
+				key := pollKey(e)
+				if seen[key] {
+					continue
+				}
+				select {
+				case p.events <- e:
+				case <-ctx.Done():
+					p.setErr(ctx.Err())
+					return
+				}
+				if ts := e.EventTimestamp.Time().Unix(); ts > newest {
+					newest = ts
+					seen = map[string]bool{}
+				}
+				seen[key] = true
+			}
+			after = newest
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			p.setErr(ctx.Err())
+			return
+		}
+	}
+}
+
+func (p *EventPoller) poll(ctx context.Context, after int64, before int64) ([]*Event, error) {
+	params := p.params
+	params.Offset = 0
+	params.OccurredAfter = after
+	params.OccurredBefore = before
+	if params.Limit == 0 {
+		params.Limit = 100
+	}
+
+	events, err := p.o.RetrievingEventsWithContext(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].EventTimestamp.Time().Before(events[j].EventTimestamp.Time())
+	})
+	return events, nil
+}
+
+// pollKey identifies an event among the events of a poller at the same
+// second: by its keys or, without any, by its type, item and accounts.
+func pollKey(e *Event) string {
+	if keys := eventKeys(e); len(keys) > 0 {
+		return strings.Join(keys, " ")
+	}
+	key := fmt.Sprint(e.EventType, "/", e.EventTimestamp.Time().Unix())
+	if e.Asset != nil {
+		if e.Asset.AssetContract != nil {
+			key += "/" + strings.ToLower(e.Asset.AssetContract.Address.String())
+		}
+		key += "/" + e.Asset.TokenID
+	}
+	for _, a := range []*Account{e.FromAccount, e.ToAccount, e.Seller, e.WinnerAccount} {
+		if a != nil {
+			key += "/" + strings.ToLower(a.Address.String())
+		}
+	}
+	return key
+}
+
+func (p *EventPoller) setErr(err error) {
+	p.mu.Lock()
+	p.err = err
+	p.mu.Unlock()
+}
@@ -0,0 +1,40 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventPoller(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// the same event is returned by every poll
+		w.Write([]byte(`{"asset_events":[
+			{"id":2,"event_type":"successful","event_timestamp":"2022-05-01T10:00:01"},
+			{"id":1,"event_type":"transfer","event_timestamp":"2022-05-01T10:00:00"}
+		]}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := client.PollEvents(ctx, nil, 5*time.Millisecond)
+
+	e := <-p.Events()
+	assert.Equal(t, uint64(1), e.ID)
+	e = <-p.Events()
+	assert.Equal(t, uint64(2), e.ID)
+
+	select {
+	case e := <-p.Events():
+		t.Fatalf("unexpected duplicate event %d", e.ID)
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	cancel()
+	for range p.Events() {
+	}
+	assert.Equal(t, context.Canceled, p.Err())
+}
@@ -0,0 +1,156 @@
+package opensea
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrEndpointSunset is returned when OpenSea answers that the endpoint of a
+// request is retired: a 410 Gone, a client error with a Sunset or
+// Deprecation header, or a 404 or 405 of one of the retired v1 paths whose
+// body says so. Other errors mentioning a deprecation, such as a 400 about
+// a deprecated parameter, keep their usual error.
+type ErrEndpointSunset struct {
+	// Endpoint is the path of the request.
+	Endpoint string
+	Status   int
+	// Sunset is the date of the Sunset header, if any.
+	Sunset time.Time
+	// Replacement is the successor link of the response or, without one, the
+	// replacement of the method in Deprecations.
+	Replacement string
+	// Message is the start of the response body.
+	Message string
+}
+
+func (e ErrEndpointSunset) Error() string {
+	msg := fmt.Sprintf("Endpoint %s was retired by OpenSea (status %d)", e.Endpoint, e.Status)
+	if !e.Sunset.IsZero() {
+		msg += fmt.Sprintf(" on %s", e.Sunset.Format("2006-01-02"))
+	}
+	if e.Replacement != "" {
+		msg += fmt.Sprintf(", use %s instead", e.Replacement)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// sunsetMessages are the phrases of the error bodies of retired endpoints.
+var sunsetMessages = regexp.MustCompile(`(?i)deprecated|sunset|no longer (supported|available)|has been (retired|removed|discontinued)`)
+
+// sunsetPaths map the v1 paths to the deprecated methods calling them.
+var sunsetPaths = []struct {
+	prefix, suffix, method string
+}{
+	{"/api/v1/assets", "", "GetAssets"},
+	{"/api/v1/asset_contract/", "", "GetSingleContract"},
+	{"/api/v1/asset/", "", "GetSingleAsset"},
+	{"/api/v1/events", "", "GetEvents"},
+	{"/api/v1/bundles", "", "GetBundles"},
+	{"/api/v1/collections", "", "GetCollections"},
+	{"/api/v1/collection/", "/stats", "GetCollectionStats"},
+	{"/api/v1/collection/", "", "GetCollection"},
+	{"/wyvern/v1/orders", "", "GetOrders"},
+}
+
+var sunsetWarned sync.Map
+
+// sunset returns the ErrEndpointSunset of a response of a retired endpoint.
+// Successful responses of an endpoint announcing its deprecation are only
+// logged, once per path.
+func (o Opensea) sunset(rawURL string, resp *http.Response, body []byte) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	deprecated := resp.Header.Get("Deprecation") != "" || resp.Header.Get("Sunset") != ""
+	path := u.Path
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		if !deprecated {
+			return nil
+		}
+		if _, loaded := sunsetWarned.LoadOrStore(path, true); loaded {
+			return nil
+		}
+		e := newErrEndpointSunset(u, resp, body)
+		msg := fmt.Sprintf("opensea: %s is deprecated by OpenSea", path)
+		if !e.Sunset.IsZero() {
+			msg += fmt.Sprintf(" and sunsets on %s", e.Sunset.Format("2006-01-02"))
+		}
+		if e.Replacement != "" {
+			msg += fmt.Sprintf(", use %s instead", e.Replacement)
+		}
+		o.logf("%s", msg)
+		return nil
+	}
+	clientError := resp.StatusCode >= 400 && resp.StatusCode <= 499 && resp.StatusCode != http.StatusTooManyRequests
+	_, retired := sunsetMethod(path)
+	noRoute := resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed
+	switch {
+	case resp.StatusCode == http.StatusGone,
+		clientError && deprecated,
+		noRoute && retired && sunsetMessages.Match(body):
+		return newErrEndpointSunset(u, resp, body)
+	}
+	return nil
+}
+
+// sunsetMethod returns the deprecated method calling the v1 path, if any.
+func sunsetMethod(path string) (string, bool) {
+	for _, p := range sunsetPaths {
+		if strings.HasPrefix(path, p.prefix) && strings.HasSuffix(path, p.suffix) {
+			return p.method, true
+		}
+	}
+	return "", false
+}
+
+func newErrEndpointSunset(u *url.URL, resp *http.Response, body []byte) ErrEndpointSunset {
+	e := ErrEndpointSunset{
+		Endpoint:    u.Path,
+		Status:      resp.StatusCode,
+		Replacement: successorLink(u, resp.Header),
+		Message:     strings.TrimSpace(string(body)),
+	}
+	if r := []rune(e.Message); len(r) > 200 {
+		e.Message = string(r[:200]) + "…"
+	}
+	if t, err := http.ParseTime(resp.Header.Get("Sunset")); err == nil {
+		e.Sunset = t
+	}
+	if e.Replacement == "" {
+		if method, ok := sunsetMethod(e.Endpoint); ok {
+			e.Replacement = deprecations[method].Replacement
+		}
+	}
+	return e
+}
+
+// successorLink returns the target of the successor-version or alternate
+// link of a response to u, resolved against u.
+func successorLink(u *url.URL, h http.Header) string {
+	for _, header := range h.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			for _, param := range parts[1:] {
+				param = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(param), `"`, ""))
+				if param != "rel=successor-version" && param != "rel=alternate" {
+					continue
+				}
+				if t, err := url.Parse(target); err == nil {
+					return u.ResolveReference(t).String()
+				}
+				return target
+			}
+		}
+	}
+	return ""
+}
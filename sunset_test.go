@@ -0,0 +1,82 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrEndpointSunset(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/assets":
+			w.WriteHeader(http.StatusGone)
+		case "/api/v1/collection/doodles-official/stats":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail":"This endpoint is no longer supported"}`))
+		case "/api/v1/events":
+			w.Header().Set("Sunset", "Tue, 31 Dec 2024 23:59:59 GMT")
+			w.Header().Set("Link", `</api/v2/events>; rel="successor-version"`)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success":false}`))
+		case "/api/v1/asset/0x1/1":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"detail":"The include_orders parameter is deprecated"}`))
+		case "/api/v2/chain/ethereum/contract/0x1/nfts/1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":["NFT of a deprecated contract not found"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	_, err := o.GetPath(context.Background(), "/api/v1/assets?limit=1")
+	e, ok := err.(ErrEndpointSunset)
+	assert.True(t, ok)
+	assert.Equal(t, "/api/v1/assets", e.Endpoint)
+	assert.Equal(t, http.StatusGone, e.Status)
+	assert.Equal(t, deprecations["GetAssets"].Replacement, e.Replacement)
+
+	_, err = o.GetPath(context.Background(), "/api/v1/collection/doodles-official/stats")
+	e, ok = err.(ErrEndpointSunset)
+	assert.True(t, ok)
+	assert.Equal(t, deprecations["GetCollectionStats"].Replacement, e.Replacement)
+	assert.Contains(t, e.Error(), "no longer supported")
+
+	_, err = o.GetPath(context.Background(), "/api/v1/events")
+	e, ok = err.(ErrEndpointSunset)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC), e.Sunset)
+	assert.Equal(t, o.API+"/api/v2/events", e.Replacement)
+	assert.Contains(t, e.Error(), "on 2024-12-31")
+
+	_, err = o.GetPath(context.Background(), "/api/v2/unknown")
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = o.GetPath(context.Background(), "/api/v1/asset/0x1/1")
+	assert.NotNil(t, err)
+	_, ok = err.(ErrEndpointSunset)
+	assert.False(t, ok)
+
+	_, err = o.GetPath(context.Background(), "/api/v2/chain/ethereum/contract/0x1/nfts/1")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestSunsetWarning(t *testing.T) {
+	logger := &testLogger{}
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Write([]byte(`{}`))
+	}, WithLogger(logger))
+
+	for i := 0; i < 2; i++ {
+		_, err := o.GetPath(context.Background(), "/api/v1/asset_contract/0x1")
+		assert.Nil(t, err)
+	}
+	assert.Len(t, logger.lines, 1)
+	assert.Contains(t, logger.lines[0], "/api/v1/asset_contract/0x1 is deprecated")
+	assert.Contains(t, logger.lines[0], deprecations["GetSingleContract"].Replacement)
+}
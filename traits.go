@@ -0,0 +1,117 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// TraitCategory is the kind of values of a trait type.
+type TraitCategory string
+
+const (
+	TraitString TraitCategory = "string"
+	TraitNumber TraitCategory = "number"
+	TraitDate   TraitCategory = "date"
+)
+
+// TraitRange is the range of the values of a numeric trait type.
+type TraitRange struct {
+	Min float64 `json:"min" bson:"min"`
+	Max float64 `json:"max" bson:"max"`
+}
+
+// CollectionTraits are the trait types of a collection, with the number of
+// items having each value. Numeric types have a range instead of counts.
+type CollectionTraits struct {
+	Categories map[string]TraitCategory    `json:"categories" bson:"categories"`
+	Counts     map[string]map[string]int64 `json:"counts" bson:"counts"`
+	Ranges     map[string]TraitRange       `json:"ranges,omitempty" bson:"ranges,omitempty"`
+}
+
+// Types returns the trait types, sorted.
+func (t CollectionTraits) Types() []string {
+	ret := make([]string, 0, len(t.Categories))
+	for name := range t.Categories {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Count returns the number of items with a trait, matched case
+// insensitively like the traits of assets.
+func (t CollectionTraits) Count(traitType, value string) int64 {
+	for name, values := range t.Counts {
+		if !strings.EqualFold(name, traitType) {
+			continue
+		}
+		for v, n := range values {
+			if strings.EqualFold(v, value) {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// Frequency returns the share of the supply items having a trait.
+func (t CollectionTraits) Frequency(traitType, value string, supply int64) float64 {
+	if supply <= 0 {
+		return 0
+	}
+	return float64(t.Count(traitType, value)) / float64(supply)
+}
+
+// GetCollectionTraits returns the trait types and value counts of a
+// collection. It returns ErrNotFound for unknown slugs.
+func (o Opensea) GetCollectionTraits(slug string) (*CollectionTraits, error) {
+	ctx := context.TODO()
+	return o.GetCollectionTraitsWithContext(ctx, slug)
+}
+
+func (o Opensea) GetCollectionTraitsWithContext(ctx context.Context, slug string) (*CollectionTraits, error) {
+	if slug == "" {
+		return nil, fmt.Errorf("Empty collection slug")
+	}
+	b, err := o.GetPath(ctx, "/api/v2/traits/"+url.PathEscape(slug))
+	if err != nil {
+		return nil, err
+	}
+	resp := &struct {
+		Categories map[string]TraitCategory          `json:"categories"`
+		Counts     map[string]map[string]json.Number `json:"counts"`
+	}{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, err
+	}
+	ret := &CollectionTraits{
+		Categories: map[string]TraitCategory{},
+		Counts:     map[string]map[string]int64{},
+		Ranges:     map[string]TraitRange{},
+	}
+	for name, category := range resp.Categories {
+		ret.Categories[name] = category
+	}
+	for name, values := range resp.Counts {
+		if ret.Categories[name] == TraitNumber {
+			min, _ := values["min"].Float64()
+			max, _ := values["max"].Float64()
+			ret.Ranges[name] = TraitRange{Min: min, Max: max}
+			continue
+		}
+		counts := map[string]int64{}
+		for v, n := range values {
+			count, err := n.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("Invalid count of trait %s: %s", name, n)
+			}
+			counts[v] = count
+		}
+		ret.Counts[name] = counts
+	}
+	return ret, nil
+}
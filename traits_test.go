@@ -0,0 +1,34 @@
+package opensea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCollectionTraits(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/traits/doodles-official" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{
+			"categories":{"face":"string","head":"string","level":"number"},
+			"counts":{"face":{"happy":120,"Sad":30},"head":{"cap":50},"level":{"min":1,"max":9.5}}
+		}`))
+	})
+
+	traits, err := o.GetCollectionTraits("doodles-official")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"face", "head", "level"}, traits.Types())
+	assert.Equal(t, TraitNumber, traits.Categories["level"])
+	assert.Equal(t, int64(30), traits.Count("Face", "sad"))
+	assert.Equal(t, int64(0), traits.Count("face", "angry"))
+	assert.Equal(t, 0.012, traits.Frequency("face", "happy", 10000))
+	assert.Equal(t, TraitRange{Min: 1, Max: 9.5}, traits.Ranges["level"])
+	assert.NotContains(t, traits.Counts, "level")
+
+	_, err = o.GetCollectionTraits("unknown")
+	assert.Equal(t, ErrNotFound, err)
+}
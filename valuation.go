@@ -0,0 +1,266 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ValuationSignal is a price signal blended into a valuation.
+type ValuationSignal string
+
+const (
+	SignalLastSale        ValuationSignal = "last_sale"
+	SignalTraitFloor      ValuationSignal = "trait_floor"
+	SignalCollectionFloor ValuationSignal = "collection_floor"
+	SignalComparables     ValuationSignal = "comparables"
+)
+
+// ValuationConfig tunes the heuristics of a Valuator.
+type ValuationConfig struct {
+	// Weights of the signals in the estimate. Signals without weight are not
+	// fetched.
+	Weights map[ValuationSignal]float64
+	// LastSaleHalfLife halves the weight of the last sale every period.
+	LastSaleHalfLife time.Duration
+	// Comparables is the number of recent sales of the collection whose
+	// median is the comparables signal.
+	Comparables int
+	// TraitFloorScan is the number of the cheapest listings searched for the
+	// rarest trait of the token.
+	TraitFloorScan int
+}
+
+func DefaultValuationConfig() ValuationConfig {
+	return ValuationConfig{
+		Weights: map[ValuationSignal]float64{
+			SignalLastSale:        1,
+			SignalTraitFloor:      2,
+			SignalCollectionFloor: 2,
+			SignalComparables:     1,
+		},
+		LastSaleHalfLife: 30 * 24 * time.Hour,
+		Comparables:      50,
+		TraitFloorScan:   10,
+	}
+}
+
+// Valuation is the estimated value of a token, in the native currency of its
+// chain, with the range of its signals.
+type Valuation struct {
+	Estimate float64 `json:"estimate" bson:"estimate"`
+	Low      float64 `json:"low" bson:"low"`
+	High     float64 `json:"high" bson:"high"`
+	// Confidence, from 0 to 1, drops when signals are missing, stale or
+	// disagree.
+	Confidence float64                     `json:"confidence" bson:"confidence"`
+	Signals    map[ValuationSignal]float64 `json:"signals" bson:"signals"`
+	// EstimateUSD is the estimate converted by the oracle of the client,
+	// set when it is configured WithPriceOracle.
+	EstimateUSD float64 `json:"estimate_usd,omitempty" bson:"estimate_usd,omitempty"`
+}
+
+// BlendValuation combines the available signals. The weight of the last
+// sale decays with lastSaleAge.
+func BlendValuation(signals map[ValuationSignal]float64, lastSaleAge time.Duration, cfg ValuationConfig) (*Valuation, error) {
+	v := &Valuation{Signals: map[ValuationSignal]float64{}}
+	var configured, used, sum float64
+	for signal, w := range cfg.Weights {
+		if w <= 0 {
+			continue
+		}
+		configured += w
+		price, ok := signals[signal]
+		if !ok || price <= 0 {
+			continue
+		}
+		if signal == SignalLastSale && cfg.LastSaleHalfLife > 0 {
+			w *= math.Pow(0.5, float64(lastSaleAge)/float64(cfg.LastSaleHalfLife))
+		}
+		v.Signals[signal] = price
+		used += w
+		sum += w * price
+		if v.Low == 0 || price < v.Low {
+			v.Low = price
+		}
+		if price > v.High {
+			v.High = price
+		}
+	}
+	if used == 0 {
+		return nil, fmt.Errorf("No signal to value the token")
+	}
+	v.Estimate = sum / used
+	disagreement := math.Min(1, (v.High-v.Low)/v.Estimate)
+	v.Confidence = used / configured * (1 - disagreement)
+	return v, nil
+}
+
+// Valuator estimates the value of tokens from OpenSea data.
+type Valuator struct {
+	Config ValuationConfig
+
+	o Opensea
+}
+
+func (o Opensea) NewValuator(cfg ValuationConfig) *Valuator {
+	return &Valuator{Config: cfg, o: o}
+}
+
+func (o Opensea) EstimateValue(chain Chain, contract Address, tokenID string) (*Valuation, error) {
+	ctx := context.TODO()
+	return o.EstimateValueWithContext(ctx, chain, contract, tokenID)
+}
+
+// EstimateValueWithContext values a token with DefaultValuationConfig.
+func (o Opensea) EstimateValueWithContext(ctx context.Context, chain Chain, contract Address, tokenID string) (*Valuation, error) {
+	return o.NewValuator(DefaultValuationConfig()).Estimate(ctx, chain, contract, tokenID)
+}
+
+// Estimate fetches the signals of a token and blends them. Signals OpenSea
+// has no data for are skipped.
+func (v *Valuator) Estimate(ctx context.Context, chain Chain, contract Address, tokenID string) (*Valuation, error) {
+	chain, err := v.o.chainFor(chain, CapabilityEvents)
+	if err != nil {
+		return nil, err
+	}
+	ctx = contextWithChain(ctx, chain)
+	nft, err := v.o.GetNFTWithContext(ctx, chain, contract, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	signals := map[ValuationSignal]float64{}
+	var lastSaleAge time.Duration
+	fetch := func(signal ValuationSignal, f func() (float64, error)) error {
+		if v.Config.Weights[signal] <= 0 {
+			return nil
+		}
+		price, err := f()
+		if err == ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		signals[signal] = price
+		return nil
+	}
+
+	err = fetch(SignalLastSale, func() (float64, error) {
+		path := fmt.Sprintf("/api/v2/events/chain/%s/contract/%s/nfts/%s", chain, contract, url.PathEscape(tokenID))
+		sales, err := v.o.getSalesV2(ctx, path, 1)
+		if err != nil || len(sales) == 0 {
+			return 0, err
+		}
+		lastSaleAge = time.Since(sales[0].time())
+		price, _ := sales[0].unitPrice()
+		return price, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = fetch(SignalCollectionFloor, func() (float64, error) {
+		snap, err := v.o.SnapshotFloor(ctx, nft.Collection)
+		if err != nil {
+			return 0, err
+		}
+		return snap.Floor, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = fetch(SignalComparables, func() (float64, error) {
+		sales, err := v.o.getSalesV2(ctx, "/api/v2/events/collection/"+url.PathEscape(nft.Collection), v.Config.Comparables)
+		if err != nil {
+			return 0, err
+		}
+		prices := []float64{}
+		for _, s := range sales {
+			if p, ok := s.unitPrice(); ok {
+				prices = append(prices, p)
+			}
+		}
+		return median(prices), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = fetch(SignalTraitFloor, func() (float64, error) {
+		return v.traitFloor(ctx, chain, nft)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	valuation, err := BlendValuation(signals, lastSaleAge, v.Config)
+	if err != nil {
+		return nil, err
+	}
+	if v.o.oracle != nil {
+		if valuation.EstimateUSD, err = ToUSD(ctx, v.o.oracle, chain, NullAddress, valuation.Estimate); err != nil {
+			return nil, err
+		}
+	}
+	return valuation, nil
+}
+
+// traitFloor returns the cheapest listing, among the cheapest listings of
+// the collection, sharing the rarest trait of nft. It is zero when none
+// does.
+func (v *Valuator) traitFloor(ctx context.Context, chain Chain, nft *NFT) (float64, error) {
+	if len(nft.Traits) == 0 || v.Config.TraitFloorScan <= 0 {
+		return 0, nil
+	}
+	traits, err := v.o.GetCollectionTraitsWithContext(ctx, nft.Collection)
+	if err != nil {
+		return 0, err
+	}
+	var rarest *Trait
+	var rarestCount int64
+	for i, t := range nft.Traits {
+		// numeric traits have ranges rather than counts
+		n := traits.Count(t.TraitType, traitValue(t))
+		if n > 0 && (rarest == nil || n < rarestCount) {
+			rarest, rarestCount = &nft.Traits[i], n
+		}
+	}
+	if rarest == nil {
+		return 0, nil
+	}
+
+	listings, err := v.o.bestListings(ctx, nft.Collection, v.Config.TraitFloorScan)
+	if err != nil {
+		return 0, err
+	}
+	for _, l := range listings {
+		for _, item := range l.ProtocolData.Parameters.Offer {
+			if item.ItemType < ItemERC721 {
+				continue
+			}
+			listed, err := v.o.GetNFTWithContext(ctx, chain, item.Token, string(item.IdentifierOrCriteria))
+			if err != nil {
+				return 0, err
+			}
+			for _, t := range listed.Traits {
+				if strings.EqualFold(t.TraitType, rarest.TraitType) && strings.EqualFold(traitValue(t), traitValue(*rarest)) {
+					return l.unitPrice(true), nil
+				}
+			}
+		}
+	}
+	return 0, nil
+}
+
+// traitValue returns the value of a trait as a string, unquoting strings.
+func traitValue(t Trait) string {
+	var s string
+	if err := json.Unmarshal(t.Value, &s); err == nil {
+		return s
+	}
+	return strings.TrimSpace(string(t.Value))
+}
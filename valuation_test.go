@@ -0,0 +1,108 @@
+package opensea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlendValuation(t *testing.T) {
+	cfg := DefaultValuationConfig()
+
+	v, err := BlendValuation(map[ValuationSignal]float64{
+		SignalCollectionFloor: 1,
+		SignalTraitFloor:      1,
+		SignalComparables:     1,
+		SignalLastSale:        1,
+	}, 0, cfg)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, v.Estimate)
+	assert.Equal(t, 1.0, v.Confidence)
+
+	// a last sale one half-life old weighs half as much
+	v, err = BlendValuation(map[ValuationSignal]float64{
+		SignalCollectionFloor: 1,
+		SignalLastSale:        4,
+	}, cfg.LastSaleHalfLife, cfg)
+	assert.Nil(t, err)
+	assert.InDelta(t, 1.6, v.Estimate, 1e-9)
+	assert.Equal(t, 1.0, v.Low)
+	assert.Equal(t, 4.0, v.High)
+	assert.Equal(t, 0.0, v.Confidence)
+
+	v, err = BlendValuation(map[ValuationSignal]float64{SignalCollectionFloor: 2}, 0, cfg)
+	assert.Nil(t, err)
+	assert.InDelta(t, 2.0/6, v.Confidence, 1e-9)
+
+	_, err = BlendValuation(map[ValuationSignal]float64{SignalLastSale: 0}, 0, cfg)
+	assert.NotNil(t, err)
+}
+
+func TestEstimateValue(t *testing.T) {
+	contract := "0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"
+	nft := func(id, background string) string {
+		return fmt.Sprintf(`{"nft":{"identifier":%q,"collection":"doodles","contract":%q,"traits":[{"trait_type":"background","value":%q},{"trait_type":"face","value":"happy"}]}}`,
+			id, contract, background)
+	}
+	sale := func(price string) string {
+		return fmt.Sprintf(`{"event_type":"sale","closing_date":%d,"quantity":1,"payment":{"quantity":%q,"token_address":"0x0000000000000000000000000000000000000000","decimals":18,"symbol":"ETH"}}`,
+			time.Now().Unix(), price)
+	}
+	listing := func(id, price string) string {
+		return fmt.Sprintf(`{"order_hash":"0x%s","price":{"current":{"currency":"ETH","decimals":18,"value":%q}},"protocol_data":{"parameters":{"offer":[{"itemType":2,"token":%q,"identifierOrCriteria":%q,"startAmount":"1","endAmount":"1"}]}}}`,
+			id, price, contract, id)
+	}
+
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/chain/ethereum/contract/" + contract + "/nfts/1":
+			w.Write([]byte(nft("1", "gold")))
+		case "/api/v2/chain/ethereum/contract/" + contract + "/nfts/2":
+			w.Write([]byte(nft("2", "blue")))
+		case "/api/v2/chain/ethereum/contract/" + contract + "/nfts/3":
+			w.Write([]byte(nft("3", "gold")))
+		case "/api/v2/events/chain/ethereum/contract/" + contract + "/nfts/1":
+			w.Write([]byte(`{"asset_events":[` + sale("3000000000000000000") + `]}`))
+		case "/api/v2/events/collection/doodles":
+			w.Write([]byte(`{"asset_events":[` + sale("1000000000000000000") + `,` + sale("2000000000000000000") + `,` + sale("9000000000000000000") + `]}`))
+		case "/api/v1/collection/doodles/stats":
+			w.Write([]byte(`{"stats":{"floor_price":1}}`))
+		case "/api/v2/traits/doodles":
+			w.Write([]byte(`{"categories":{"background":"string","face":"string","level":"number"},
+				"counts":{"background":{"gold":10,"blue":500},"face":{"happy":2000},"level":{"min":1.5,"max":9.5}}}`))
+		case "/api/v2/listings/collection/doodles/best":
+			assert.Equal(t, "10", r.URL.Query().Get("limit"))
+			w.Write([]byte(`{"listings":[` + listing("2", "1000000000000000000") + `,` + listing("3", "4000000000000000000") + `]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	v, err := o.EstimateValueWithContext(context.Background(), ChainEthereum, Address(contract), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, map[ValuationSignal]float64{
+		SignalLastSale:        3,
+		SignalCollectionFloor: 1,
+		SignalComparables:     2,
+		SignalTraitFloor:      4,
+	}, v.Signals)
+	assert.InDelta(t, (3+2*1+2+2*4)/6.0, v.Estimate, 1e-6)
+	assert.Equal(t, 1.0, v.Low)
+	assert.Equal(t, 4.0, v.High)
+
+	// tokens without sales or listings of their traits are valued from what
+	// is left
+	cfg := DefaultValuationConfig()
+	cfg.TraitFloorScan = 0
+	v, err = o.NewValuator(cfg).Estimate(context.Background(), ChainEthereum, Address(contract), "2")
+	assert.Nil(t, err)
+	assert.Len(t, v.Signals, 2)
+	assert.InDelta(t, 4/3.0, v.Estimate, 1e-6)
+
+	_, err = o.EstimateValueWithContext(context.Background(), ChainEthereum, Address(contract), "4")
+	assert.Equal(t, ErrNotFound, err)
+}
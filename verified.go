@@ -0,0 +1,43 @@
+package opensea
+
+// Values of Collection.SafelistRequestStatus.
+const (
+	SafelistNotRequested        = "not_requested"
+	SafelistRequested           = "requested"
+	SafelistApproved            = "approved"
+	SafelistVerified            = "verified"
+	SafelistDisabledTopTrending = "disabled_top_trending"
+)
+
+// IsVerified reports whether OpenSea verified the collection.
+func (c Collection) IsVerified() bool {
+	return c.SafelistRequestStatus == SafelistVerified || c.SafelistRequestStatus == SafelistApproved
+}
+
+// IsVerified reports whether the collection of the asset is verified. It is
+// false when the collection was not decoded.
+func (a Asset) IsVerified() bool {
+	return a.Collection != nil && a.Collection.IsVerified()
+}
+
+// FilterVerified returns the assets of verified collections.
+func FilterVerified(assets []Asset) []Asset {
+	ret := []Asset{}
+	for _, a := range assets {
+		if a.IsVerified() {
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
+
+// FilterVerifiedCollections returns the verified collections.
+func FilterVerifiedCollections(collections []Collection) []Collection {
+	ret := []Collection{}
+	for _, c := range collections {
+		if c.IsVerified() {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,45 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsVerified(t *testing.T) {
+	assert.True(t, Collection{SafelistRequestStatus: SafelistVerified}.IsVerified())
+	assert.True(t, CollectionSingle{Collection: Collection{SafelistRequestStatus: SafelistApproved}}.IsVerified())
+	assert.False(t, Collection{SafelistRequestStatus: SafelistRequested}.IsVerified())
+	assert.False(t, Asset{}.IsVerified())
+
+	assert.Len(t, FilterVerifiedCollections([]Collection{
+		{SafelistRequestStatus: SafelistVerified},
+		{SafelistRequestStatus: SafelistNotRequested},
+	}), 1)
+}
+
+func TestGetAssetsVerifiedOnly(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"assets":[
+			{"token_id":"1","collection":{"slug":"doodles","safelist_request_status":"verified"}},
+			{"token_id":"2","collection":{"slug":"doodies","safelist_request_status":"not_requested"}}
+		]}`))
+	})
+
+	resp, err := o.GetAssetsWithContext(context.Background(), GetAssetsParams{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Assets, 2)
+
+	resp, err = o.GetAssetsWithContext(context.Background(), GetAssetsParams{VerifiedOnly: true})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Assets, 1)
+	assert.Equal(t, "1", resp.Assets[0].TokenID)
+
+	// the collection is decoded even when not asked for
+	resp, err = o.GetAssetsWithContext(context.Background(), GetAssetsParams{VerifiedOnly: true, Fields: []string{"token_id"}})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Assets, 1)
+	assert.Equal(t, "doodles", resp.Assets[0].Collection.Slug)
+}
@@ -0,0 +1,385 @@
+package opensea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDedupSize bounds the number of event keys an EventDeduper
+// remembers to drop duplicates.
+const DefaultDedupSize = 10000
+
+// AccountWatcher is an EventStream of the activity of a set of accounts,
+// merged from several sources: push streams, such as Stream API
+// subscriptions, and event pollers as a fallback. An event delivered by
+// several sources is emitted once, see EventDeduper.
+type AccountWatcher struct {
+	// OnError is called with the errors of the pollers, which are retried
+	// on their next tick. It must be set before Start.
+	OnError func(err error)
+
+	accounts map[Address]bool
+	streams  []EventStream
+	pollers  []*EventPoller
+	events   chan *Event
+
+	dedup *EventDeduper
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewAccountWatcher returns a watcher of the accounts polling the events of
+// each of them every interval, merged with the given streams. Events of the
+// streams not involving any of the accounts are dropped.
+func (o Opensea) NewAccountWatcher(accounts []Address, interval time.Duration, streams ...EventStream) *AccountWatcher {
+	w := newAccountWatcher(accounts, streams)
+	for a := range w.accounts {
+		w.pollers = append(w.pollers, o.NewEventPoller(watchParams(a), interval))
+	}
+	return w
+}
+
+// WatchAccounts creates and starts an AccountWatcher.
+func (o Opensea) WatchAccounts(ctx context.Context, accounts []Address, interval time.Duration, streams ...EventStream) *AccountWatcher {
+	w := o.NewAccountWatcher(accounts, interval, streams...)
+	w.Start(ctx)
+	return w
+}
+
+// NewAccountWatcher returns a watcher polling the v2 events of the accounts
+// on every chain of the client, as the v1 events only cover Ethereum.
+// Chains without events, such as Solana, are only watched through the
+// streams.
+func (m *MultiChainClient) NewAccountWatcher(accounts []Address, interval time.Duration, streams ...EventStream) *AccountWatcher {
+	w := newAccountWatcher(accounts, streams)
+	for _, chain := range m.Chains() {
+		if !SupportsCapability(chain, CapabilityEvents) {
+			continue
+		}
+		o := m.clients[chain]
+		for a := range w.accounts {
+			w.pollers = append(w.pollers, o.newAccountEventPoller(chain, a, interval))
+		}
+	}
+	return w
+}
+
+// WatchAccounts creates and starts an AccountWatcher on every chain of the
+// client.
+func (m *MultiChainClient) WatchAccounts(ctx context.Context, accounts []Address, interval time.Duration, streams ...EventStream) *AccountWatcher {
+	w := m.NewAccountWatcher(accounts, interval, streams...)
+	w.Start(ctx)
+	return w
+}
+
+func newAccountWatcher(accounts []Address, streams []EventStream) *AccountWatcher {
+	w := &AccountWatcher{
+		accounts: map[Address]bool{},
+		streams:  streams,
+		events:   make(chan *Event),
+		dedup:    NewEventDeduper(DefaultDedupSize),
+	}
+	for _, a := range accounts {
+		w.accounts[Address(strings.ToLower(a.String()))] = true
+	}
+	return w
+}
+
+// newAccountEventPoller returns a poller of the v2 events of account on
+// chain.
+func (o Opensea) newAccountEventPoller(chain Chain, account Address, interval time.Duration) *EventPoller {
+	p := o.NewEventPoller(watchParams(account), interval)
+	p.fetch = func(ctx context.Context, after int64, before int64) ([]*Event, error) {
+		return o.getAccountEventsV2(ctx, chain, account, after, before)
+	}
+	return p
+}
+
+// getAccountEventsV2 reads the events of account on chain between after and
+// before, oldest first.
+func (o Opensea) getAccountEventsV2(ctx context.Context, chain Chain, account Address, after int64, before int64) ([]*Event, error) {
+	path := "/api/v2/events/accounts/" + url.PathEscape(account.String())
+	ret := []*Event{}
+	next := ""
+	for {
+		q := url.Values{}
+		q.Set("chain", chain.String())
+		q.Set("after", strconv.FormatInt(after, 10))
+		q.Set("before", strconv.FormatInt(before, 10))
+		q.Set("limit", "50")
+		if next != "" {
+			q.Set("next", next)
+		}
+		b, err := o.GetPath(ctx, path+"?"+q.Encode())
+		if err != nil {
+			return nil, err
+		}
+		resp := &struct {
+			AssetEvents []eventV2 `json:"asset_events"`
+			Next        string    `json:"next"`
+		}{}
+		if err := json.Unmarshal(b, resp); err != nil {
+			return nil, err
+		}
+		for _, e := range resp.AssetEvents {
+			ret = append(ret, e.event())
+		}
+		if resp.Next == "" || len(resp.AssetEvents) == 0 {
+			break
+		}
+		next = resp.Next
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].EventTimestamp.Time().Before(ret[j].EventTimestamp.Time())
+	})
+	return ret, nil
+}
+
+// eventV2 is an event of the v2 events endpoints.
+type eventV2 struct {
+	EventType      string  `json:"event_type"`
+	OrderType      string  `json:"order_type"`
+	EventTimestamp int64   `json:"event_timestamp"`
+	Transaction    string  `json:"transaction"`
+	Quantity       int64   `json:"quantity"`
+	FromAddress    Address `json:"from_address"`
+	ToAddress      Address `json:"to_address"`
+	Seller         Address `json:"seller"`
+	Buyer          Address `json:"buyer"`
+	Maker          Address `json:"maker"`
+	Taker          Address `json:"taker"`
+	// NFT is the item of sales and transfers, Asset the one of orders.
+	NFT     *NFT `json:"nft"`
+	Asset   *NFT `json:"asset"`
+	Payment *struct {
+		Quantity     Number  `json:"quantity"`
+		TokenAddress Address `json:"token_address"`
+		Decimals     int64   `json:"decimals"`
+		Symbol       string  `json:"symbol"`
+	} `json:"payment"`
+}
+
+// eventAccount returns the account of a, nil if a is empty.
+func eventAccount(a Address) *Account {
+	if a == "" {
+		return nil
+	}
+	return &Account{Address: a}
+}
+
+// event converts e to the v1 model: sales are successful auctions, listings
+// created auctions and offers entered bids.
+func (e eventV2) event() *Event {
+	ret := &Event{
+		EventType:      EventType(e.EventType),
+		EventTimestamp: TimeNano(time.Unix(e.EventTimestamp, 0).UTC()),
+		Quantity:       strconv.FormatInt(e.Quantity, 10),
+		FromAccount:    eventAccount(e.FromAddress),
+		ToAccount:      eventAccount(e.ToAddress),
+	}
+	switch e.EventType {
+	case "sale":
+		ret.EventType = EventTypeSuccessful
+		ret.Seller = eventAccount(e.Seller)
+		ret.WinnerAccount = eventAccount(e.Buyer)
+	case "order":
+		ret.EventType = EventTypeBidEntered
+		if e.OrderType == "listing" {
+			ret.EventType = EventTypeCreated
+		}
+		ret.FromAccount = eventAccount(e.Maker)
+		ret.ToAccount = eventAccount(e.Taker)
+	case "cancel":
+		ret.EventType = EventTypeCancelled
+		ret.FromAccount = eventAccount(e.Maker)
+	}
+	if e.Transaction != "" {
+		ret.Transaction = &Transaction{TransactionHash: e.Transaction}
+	}
+	nft := e.NFT
+	if nft == nil {
+		nft = e.Asset
+	}
+	if nft != nil {
+		ret.Asset = nft.Asset()
+		ret.ContractAddress = Address(nft.Contract)
+		ret.CollectionSlug = nft.Collection
+	}
+	if p := e.Payment; p != nil {
+		ret.TotalPrice = p.Quantity
+		ret.PaymentToken = &PaymentToken{Symbol: p.Symbol, Address: p.TokenAddress, Decimals: p.Decimals}
+		if ret.EventType == EventTypeBidEntered {
+			ret.BidAmount = p.Quantity
+		}
+	}
+	return ret
+}
+
+func watchParams(account Address) *RetrievingEventsParams {
+	params := NewRetrievingEventsParams()
+	params.AccountAddress = account
+	params.OccurredAfter = time.Now().Unix()
+	return params
+}
+
+// Start merges the sources in the background until ctx is done or every
+// source has ended.
+func (w *AccountWatcher) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, p := range w.pollers {
+		p.OnError = w.OnError
+		p.Start(ctx)
+		wg.Add(1)
+		go w.forward(ctx, &wg, p, false)
+	}
+	for _, s := range w.streams {
+		wg.Add(1)
+		go w.forward(ctx, &wg, s, true)
+	}
+	go func() {
+		wg.Wait()
+		if ctx.Err() != nil {
+			w.setErr(ctx.Err())
+		}
+		close(w.events)
+	}()
+}
+
+func (w *AccountWatcher) Events() <-chan *Event {
+	return w.events
+}
+
+// Err reports why the watcher ended: the context error, or else the first
+// error of an ended source.
+func (w *AccountWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *AccountWatcher) forward(ctx context.Context, wg *sync.WaitGroup, s EventStream, filter bool) {
+	defer wg.Done()
+	for e := range s.Events() {
+		if filter && !w.involves(e) {
+			continue
+		}
+		if !w.dedup.First(e) {
+			continue
+		}
+		select {
+		case w.events <- e:
+		case <-ctx.Done():
+			return
+		}
+	}
+	if err := s.Err(); err != nil && ctx.Err() == nil {
+		w.setErr(err)
+	}
+}
+
+// involves reports whether one of the accounts of the event is watched.
+func (w *AccountWatcher) involves(e *Event) bool {
+	for _, a := range []*Account{e.FromAccount, e.ToAccount, e.Seller, e.WinnerAccount, e.OwnerAccount} {
+		if a != nil && w.accounts[Address(strings.ToLower(a.Address.String()))] {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *AccountWatcher) setErr(err error) {
+	w.mu.Lock()
+	if w.err == nil {
+		w.err = err
+	}
+	w.mu.Unlock()
+}
+
+// EventDeduper drops the events already seen from any source. Polled events
+// carry the ID of the events endpoint while pushed ones may only carry
+// their transaction or order, so every event is recorded under all of its
+// keys and is a duplicate if any of them was seen.
+type EventDeduper struct {
+	size int
+
+	mu    sync.Mutex
+	seen  map[string]bool
+	order []string
+}
+
+// NewEventDeduper returns a deduper remembering the last size keys, or
+// DefaultDedupSize if size is 0.
+func NewEventDeduper(size int) *EventDeduper {
+	if size <= 0 {
+		size = DefaultDedupSize
+	}
+	return &EventDeduper{size: size, seen: map[string]bool{}}
+}
+
+// First records the event and reports whether it was not seen before.
+// Events without an ID, a transaction or an order cannot be told apart and
+// always pass.
+func (d *EventDeduper) First(e *Event) bool {
+	keys := eventKeys(e)
+	if len(keys) == 0 {
+		return true
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	first := true
+	for _, key := range keys {
+		if d.seen[key] {
+			first = false
+		}
+	}
+	for _, key := range keys {
+		if d.seen[key] {
+			continue
+		}
+		d.seen[key] = true
+		d.order = append(d.order, key)
+		if len(d.order) > d.size {
+			delete(d.seen, d.order[0])
+			d.order = d.order[1:]
+		}
+	}
+	return first
+}
+
+// eventKeys returns the keys identifying an event across sources: its ID,
+// its transaction and item, and its order. Events with none of them have no
+// keys.
+func eventKeys(e *Event) []string {
+	keys := []string{}
+	if e.ID != 0 {
+		keys = append(keys, fmt.Sprint("id/", e.ID))
+	}
+	if e.Transaction != nil && e.Transaction.TransactionHash != "" {
+		key := "tx/" + string(e.EventType) + "/" + strings.ToLower(e.Transaction.TransactionHash)
+		// a transaction may move tokens of the same ID on several contracts
+		contract := e.ContractAddress
+		if e.Asset != nil && e.Asset.AssetContract != nil && e.Asset.AssetContract.Address != "" {
+			contract = e.Asset.AssetContract.Address
+		}
+		key += "/" + strings.ToLower(contract.String())
+		if e.Asset != nil {
+			key += "/" + e.Asset.TokenID
+		}
+		keys = append(keys, key)
+	}
+	if e.SellOrder != 0 {
+		keys = append(keys, fmt.Sprint("sell/", string(e.EventType), "/", e.SellOrder))
+	}
+	if e.BuyOrder != 0 {
+		keys = append(keys, fmt.Sprint("buy/", string(e.EventType), "/", e.BuyOrder))
+	}
+	return keys
+}
@@ -0,0 +1,176 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStream struct {
+	events chan *Event
+}
+
+func newTestStream(events ...*Event) *testStream {
+	s := &testStream{events: make(chan *Event, len(events))}
+	for _, e := range events {
+		s.events <- e
+	}
+	close(s.events)
+	return s
+}
+
+func (s *testStream) Events() <-chan *Event {
+	return s.events
+}
+
+func (s *testStream) Err() error {
+	return nil
+}
+
+func TestAccountWatcher(t *testing.T) {
+	alice := Address("0x000000000000000000000000000000000000000a")
+	bob := Address("0x000000000000000000000000000000000000000b")
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch Address(r.URL.Query().Get("account_address")) {
+		case alice:
+			w.Write([]byte(`{"asset_events":[
+				{"id":1,"event_type":"transfer","event_timestamp":"2022-05-01T10:00:00"},
+				{"id":2,"event_type":"successful","event_timestamp":"2022-05-01T10:00:01"}
+			]}`))
+		case bob:
+			w.Write([]byte(`{"asset_events":[
+				{"id":2,"event_type":"successful","event_timestamp":"2022-05-01T10:00:01"},
+				{"id":3,"event_type":"transfer","event_timestamp":"2022-05-01T10:00:02"}
+			]}`))
+		}
+	})
+
+	pushed := newTestStream(
+		&Event{ID: 3, EventType: EventTypeTransfer, ToAccount: &Account{Address: bob}},
+		&Event{ID: 4, EventType: EventTypeTransfer, ToAccount: &Account{Address: "0x000000000000000000000000000000000000000c"}},
+		&Event{EventType: EventTypeSuccessful, Seller: &Account{Address: "0x000000000000000000000000000000000000000B"},
+			Transaction: &Transaction{TransactionHash: "0xabc"}},
+		&Event{EventType: EventTypeSuccessful, Seller: &Account{Address: bob},
+			Transaction: &Transaction{TransactionHash: "0xabc"}},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := client.WatchAccounts(ctx, []Address{alice, bob}, 5*time.Millisecond, pushed)
+
+	keys := []string{}
+	for len(keys) < 4 {
+		keys = append(keys, strings.Join(eventKeys(<-w.Events()), " "))
+	}
+	sort.Strings(keys)
+	assert.Equal(t, []string{"id/1", "id/2", "id/3", "tx/successful/0xabc/"}, keys)
+
+	select {
+	case e := <-w.Events():
+		t.Fatalf("unexpected event %v", eventKeys(e))
+	case <-time.After(30 * time.Millisecond):
+	}
+
+	cancel()
+	for range w.Events() {
+	}
+	assert.Equal(t, context.Canceled, w.Err())
+}
+
+func TestMultiChainAccountWatcher(t *testing.T) {
+	alice := Address("0x000000000000000000000000000000000000000a")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/events/accounts/"+alice.String(), r.URL.Path)
+		assert.NotEmpty(t, r.URL.Query().Get("after"))
+		switch r.URL.Query().Get("chain") {
+		case "ethereum":
+			w.Write([]byte(`{"asset_events":[{"event_type":"transfer","event_timestamp":1651399200,"transaction":"0x1",
+				"from_address":"` + alice.String() + `","to_address":"0x000000000000000000000000000000000000000b",
+				"nft":{"identifier":"1","contract":"` + contract + `","collection":"doodles-official"}}]}`))
+		case "matic":
+			w.Write([]byte(`{"asset_events":[
+				{"event_type":"sale","event_timestamp":1651399201,"transaction":"0x2","seller":"` + alice.String() + `","buyer":"0x000000000000000000000000000000000000000b",
+					"nft":{"identifier":"2","contract":"` + contract + `"},"payment":{"quantity":"1000","symbol":"WETH","decimals":18}},
+				{"event_type":"order","order_type":"listing","event_timestamp":1651399202,"maker":"` + alice.String() + `",
+					"asset":{"identifier":"3","contract":"` + contract + `"}}
+			]}`))
+		default:
+			t.Errorf("unexpected chain %s", r.URL.Query().Get("chain"))
+		}
+	}))
+	defer srv.Close()
+	m, err := NewMultiChainClient("key", map[Chain]ChainConfig{
+		ChainEthereum: {API: srv.URL},
+		ChainPolygon:  {API: srv.URL},
+		ChainSolana:   {API: srv.URL},
+	}, WithRetries(0, 0))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := m.WatchAccounts(ctx, []Address{alice}, 5*time.Millisecond)
+
+	byType := map[EventType]*Event{}
+	for len(byType) < 3 {
+		e := <-w.Events()
+		byType[e.EventType] = e
+	}
+	transfer := byType[EventTypeTransfer]
+	assert.Equal(t, alice, transfer.FromAccount.Address)
+	assert.Equal(t, "1", transfer.Asset.TokenID)
+	assert.Equal(t, "doodles-official", transfer.CollectionSlug)
+	sale := byType[EventTypeSuccessful]
+	assert.Equal(t, alice, sale.Seller.Address)
+	assert.Equal(t, Number("1000"), sale.TotalPrice)
+	assert.Equal(t, "0x2", sale.Transaction.TransactionHash)
+	listing := byType[EventTypeCreated]
+	assert.Equal(t, alice, listing.FromAccount.Address)
+	assert.Equal(t, int64(1651399202), listing.EventTimestamp.Time().Unix())
+
+	// polled again, the events are not emitted twice
+	select {
+	case e := <-w.Events():
+		t.Fatalf("unexpected event %v", eventKeys(e))
+	case <-time.After(30 * time.Millisecond):
+	}
+}
+
+func TestEventDeduper(t *testing.T) {
+	d := NewEventDeduper(0)
+	polled := &Event{ID: 5, EventType: EventTypeSuccessful, Transaction: &Transaction{TransactionHash: "0xDEF"}, Asset: &Asset{TokenID: "1"}}
+	pushed := &Event{EventType: EventTypeSuccessful, Transaction: &Transaction{TransactionHash: "0xdef"}, Asset: &Asset{TokenID: "1"}}
+	other := &Event{EventType: EventTypeSuccessful, Transaction: &Transaction{TransactionHash: "0xdef"}, Asset: &Asset{TokenID: "2"}}
+	assert.True(t, d.First(polled))
+	assert.False(t, d.First(pushed))
+	assert.True(t, d.First(other))
+	assert.False(t, d.First(&Event{ID: 5}))
+
+	byOrder := &Event{EventType: EventTypeSuccessful, SellOrder: 9}
+	assert.True(t, d.First(byOrder))
+	assert.False(t, d.First(&Event{ID: 6, EventType: EventTypeSuccessful, SellOrder: 9}))
+	assert.False(t, d.First(&Event{ID: 6}))
+
+	// the same token ID of another contract in the same transaction
+	mint := &Event{EventType: EventTypeTransfer, ContractAddress: Address(contract), Transaction: &Transaction{TransactionHash: "0xabc"}, Asset: &Asset{TokenID: "1"}}
+	otherContract := &Event{EventType: EventTypeTransfer, Transaction: &Transaction{TransactionHash: "0xabc"}, Asset: &Asset{TokenID: "1", AssetContract: &AssetContract{Address: testBidder}}}
+	assert.True(t, d.First(mint))
+	assert.True(t, d.First(otherContract))
+	assert.False(t, d.First(&Event{EventType: EventTypeTransfer, Transaction: &Transaction{TransactionHash: "0xABC"}, Asset: &Asset{TokenID: "1", AssetContract: &AssetContract{Address: Address(strings.ToUpper(contract))}}}))
+
+	// events without keys are not deduped
+	keyless := &Event{EventType: EventTypeSuccessful, Asset: &Asset{TokenID: "3"}}
+	assert.True(t, d.First(keyless))
+	assert.True(t, d.First(keyless))
+
+	small := NewEventDeduper(1)
+	assert.True(t, small.First(&Event{ID: 1}))
+	assert.True(t, small.First(&Event{ID: 2}))
+	assert.True(t, small.First(&Event{ID: 1}))
+}
@@ -0,0 +1,187 @@
+// Package webhook forwards OpenSea events to HTTP endpoints as signed
+// webhooks, for consumers that cannot hold a stream connection open.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+)
+
+const (
+	SignatureHeader = "X-Opensea-Signature"
+	EventTypeHeader = "X-Opensea-Event-Type"
+	DeliveryHeader  = "X-Opensea-Delivery"
+)
+
+// Payload is the body of every webhook.
+type Payload struct {
+	ID        uint64            `json:"id"`
+	EventType opensea.EventType `json:"event_type"`
+	SentAt    time.Time         `json:"sent_at"`
+	Event     *opensea.Event    `json:"event"`
+}
+
+// Subscription is an endpoint and the events it wants. An empty EventTypes
+// selects every event.
+type Subscription struct {
+	URL        string
+	Secret     string
+	EventTypes []opensea.EventType
+	// Filter further narrows the selected events when set.
+	Filter func(e *opensea.Event) bool
+}
+
+func (s Subscription) wants(e *opensea.Event) bool {
+	if len(s.EventTypes) > 0 {
+		ok := false
+		for _, t := range s.EventTypes {
+			if t == e.EventType {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return s.Filter == nil || s.Filter(e)
+}
+
+// Forwarder delivers the events of a stream to its subscriptions.
+type Forwarder struct {
+	Subscriptions []Subscription
+	HTTPClient    *http.Client
+	// MaxAttempts is the number of deliveries tried before giving up.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after each one.
+	Backoff time.Duration
+	// DeadLetter is called with events that could not be delivered.
+	DeadLetter func(sub Subscription, e *opensea.Event, err error)
+}
+
+func NewForwarder(subs ...Subscription) *Forwarder {
+	return &Forwarder{
+		Subscriptions: subs,
+		HTTPClient:    &http.Client{Timeout: 10 * time.Second},
+		MaxAttempts:   5,
+		Backoff:       time.Second,
+	}
+}
+
+// Run forwards events until the stream ends or ctx is done. Deliveries are
+// sequential so that endpoints receive events in stream order.
+func (f *Forwarder) Run(ctx context.Context, stream opensea.EventStream) error {
+	for {
+		select {
+		case e, ok := <-stream.Events():
+			if !ok {
+				return stream.Err()
+			}
+			for _, sub := range f.Subscriptions {
+				if !sub.wants(e) {
+					continue
+				}
+				if err := f.Deliver(ctx, sub, e); err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					if f.DeadLetter != nil {
+						f.DeadLetter(sub, e, err)
+					}
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Deliver posts a single event to sub, retrying with exponential backoff.
+func (f *Forwarder) Deliver(ctx context.Context, sub Subscription, e *opensea.Event) error {
+	body, err := json.Marshal(Payload{
+		ID:        e.ID,
+		EventType: e.EventType,
+		SentAt:    time.Now().UTC(),
+		Event:     e,
+	})
+	if err != nil {
+		return err
+	}
+
+	attempts := f.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := f.Backoff
+	for attempt := 1; ; attempt++ {
+		err = f.post(ctx, sub, e, body)
+		if err == nil || attempt >= attempts {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+func (f *Forwarder) post(ctx context.Context, sub Subscription, e *opensea.Event, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, string(e.EventType))
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(e.ID, 10))
+	if sub.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(time.Now(), body, sub.Secret))
+	}
+
+	client := f.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook %s returns status %d", sub.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the signature header value of body: the timestamp and the
+// hex HMAC-SHA256 of "<timestamp>.<body>", as "t=<unix>,v1=<hex>".
+func Sign(t time.Time, body []byte, secret string) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, signature(ts, body, secret))
+}
+
+func signature(ts string, body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
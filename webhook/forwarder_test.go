@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStream struct {
+	events chan *opensea.Event
+}
+
+func (s testStream) Events() <-chan *opensea.Event {
+	return s.events
+}
+
+func (s testStream) Err() error {
+	return nil
+}
+
+func newTestStream(events ...*opensea.Event) testStream {
+	s := testStream{make(chan *opensea.Event, len(events))}
+	for _, e := range events {
+		s.events <- e
+	}
+	close(s.events)
+	return s
+}
+
+func TestForwarder(t *testing.T) {
+	var mu sync.Mutex
+	var received []Payload
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		parts := strings.Split(r.Header.Get(SignatureHeader), ",")
+		ts := strings.TrimPrefix(parts[0], "t=")
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(ts + "." + string(body)))
+		assert.Equal(t, "v1="+hex.EncodeToString(mac.Sum(nil)), parts[1])
+
+		p := Payload{}
+		assert.Nil(t, json.Unmarshal(body, &p))
+		received = append(received, p)
+	}))
+	defer srv.Close()
+
+	f := NewForwarder(Subscription{
+		URL:        srv.URL,
+		Secret:     "secret",
+		EventTypes: []opensea.EventType{opensea.EventTypeSuccessful},
+	})
+	f.Backoff = time.Millisecond
+
+	stream := newTestStream(
+		&opensea.Event{ID: 1, EventType: opensea.EventTypeSuccessful},
+		&opensea.Event{ID: 2, EventType: opensea.EventTypeTransfer},
+		&opensea.Event{ID: 3, EventType: opensea.EventTypeSuccessful},
+	)
+	assert.Nil(t, f.Run(context.Background(), stream))
+
+	assert.Equal(t, 3, calls)
+	assert.Len(t, received, 2)
+	assert.Equal(t, uint64(1), received[0].ID)
+	assert.Equal(t, uint64(3), received[1].ID)
+}
+
+func TestForwarderDeadLetter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var dead []uint64
+	f := NewForwarder(Subscription{URL: srv.URL})
+	f.MaxAttempts = 2
+	f.Backoff = time.Millisecond
+	f.DeadLetter = func(sub Subscription, e *opensea.Event, err error) {
+		assert.NotNil(t, err)
+		dead = append(dead, e.ID)
+	}
+
+	assert.Nil(t, f.Run(context.Background(), newTestStream(&opensea.Event{ID: 7})))
+	assert.Equal(t, []uint64{7}, dead)
+}
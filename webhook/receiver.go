@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTolerance is how old, or how far in the future, the timestamp of a
+// signature may be before VerifyWebhookSignature rejects it as a replay.
+const DefaultTolerance = 5 * time.Minute
+
+// maxPayloadSize bounds the bodies read by ParseRequest.
+const maxPayloadSize = 1 << 20
+
+var (
+	ErrInvalidSignature = errors.New("Invalid webhook signature")
+	ErrExpiredSignature = errors.New("Expired webhook signature")
+	// ErrEmptySecret is returned when verifying a signature without a
+	// secret, which anyone could sign with.
+	ErrEmptySecret = errors.New("Empty webhook secret")
+)
+
+// VerifyWebhookSignature checks a signature header, as set by Sign, against
+// body and secret. Headers may carry several v1 signatures, e.g. while the
+// secret rotates; one matching is enough.
+func VerifyWebhookSignature(header string, body []byte, secret string) error {
+	return verify(header, body, secret, time.Now(), DefaultTolerance)
+}
+
+func verify(header string, body []byte, secret string, now time.Time, tolerance time.Duration) error {
+	if secret == "" {
+		return ErrEmptySecret
+	}
+	var ts string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			ts = kv[1]
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	expected := signature(ts, body, secret)
+	ok := false
+	for _, s := range signatures {
+		if hmac.Equal([]byte(s), []byte(expected)) {
+			ok = true
+		}
+	}
+	if !ok {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(unix, 0)); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return ErrExpiredSignature
+	}
+	return nil
+}
+
+// DecodePayload decodes the body of a webhook.
+func DecodePayload(body []byte) (*Payload, error) {
+	p := new(Payload)
+	if err := json.Unmarshal(body, p); err != nil {
+		return nil, err
+	}
+	if p.Event == nil {
+		return nil, fmt.Errorf("Webhook payload %d has no event", p.ID)
+	}
+	return p, nil
+}
+
+// ParseRequest verifies the signature of a webhook request and decodes its
+// payload. It returns ErrEmptySecret when secret is empty; unsigned requests
+// are only decoded by ParseRequestInsecure.
+func ParseRequest(r *http.Request, secret string) (*Payload, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyWebhookSignature(r.Header.Get(SignatureHeader), body, secret); err != nil {
+		return nil, err
+	}
+	return DecodePayload(body)
+}
+
+// ParseRequestInsecure decodes the payload of a webhook request without
+// verifying its signature, so anyone reaching the endpoint can forge
+// events. It is meant for local development and for receivers behind a
+// proxy verifying the signatures.
+func ParseRequestInsecure(r *http.Request) (*Payload, error) {
+	body, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return DecodePayload(body)
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	return ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxPayloadSize))
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	opensea "github.com/quintics-io/go-opensea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	now := time.Now()
+	header := Sign(now, body, "secret")
+
+	assert.Nil(t, VerifyWebhookSignature(header, body, "secret"))
+	assert.Equal(t, ErrInvalidSignature, VerifyWebhookSignature(header, body, "other"))
+	assert.Equal(t, ErrInvalidSignature, VerifyWebhookSignature(header, []byte(`{"id":2}`), "secret"))
+	assert.Equal(t, ErrInvalidSignature, VerifyWebhookSignature("", body, "secret"))
+
+	// any of the signatures may match
+	rotated := header + ",v1=" + strings.Repeat("0", 64)
+	assert.Nil(t, verify(rotated, body, "secret", now, DefaultTolerance))
+	rotated = Sign(now, body, "old") + strings.TrimPrefix(header, strings.Split(header, ",")[0])
+	assert.Nil(t, verify(rotated, body, "secret", now, DefaultTolerance))
+
+	assert.Equal(t, ErrExpiredSignature, verify(header, body, "secret", now.Add(time.Hour), DefaultTolerance))
+	assert.Nil(t, verify(header, body, "secret", now.Add(time.Hour), 0))
+}
+
+func TestParseRequest(t *testing.T) {
+	body := `{"id":3,"event_type":"successful","event":{"id":3,"event_type":"successful"}}`
+	r := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	r.Header.Set(SignatureHeader, Sign(time.Now(), []byte(body), "secret"))
+
+	p, err := ParseRequest(r, "secret")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), p.ID)
+	assert.Equal(t, opensea.EventTypeSuccessful, p.Event.EventType)
+
+	r = httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	_, err = ParseRequest(r, "secret")
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	r = httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	r.Header.Set(SignatureHeader, Sign(time.Now(), []byte(body), ""))
+	_, err = ParseRequest(r, "")
+	assert.Equal(t, ErrEmptySecret, err)
+	assert.Equal(t, ErrEmptySecret, VerifyWebhookSignature(r.Header.Get(SignatureHeader), []byte(body), ""))
+
+	r = httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	p, err = ParseRequestInsecure(r)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), p.ID)
+
+	_, err = DecodePayload([]byte(`{"id":4}`))
+	assert.NotNil(t, err)
+}
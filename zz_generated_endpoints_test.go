@@ -0,0 +1,54 @@
+package opensea
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListEventsByCollection(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/events/collection/doodles-official", r.URL.Path)
+		assert.Equal(t, "after=1700000000&event_type=sale&event_type=transfer&next=abc", r.URL.RawQuery)
+		w.Write([]byte(`{"asset_events":[{"event_type":"sale","event_timestamp":1700000100,"chain":"ethereum","quantity":1,
+			"transaction":"0xfeed","seller":"0x1111111111111111111111111111111111111111","buyer":"0x2222222222222222222222222222222222222222",
+			"nft":{"identifier":"7","collection":"doodles-official","contract":"0x8a90cab2b38dba80c64b7734e58ee1db38b8992e"},
+			"payment":{"quantity":"1000000000000000000","token_address":"0x0000000000000000000000000000000000000000","decimals":18,"symbol":"ETH"}}],
+			"next":"def"}`))
+	})
+
+	page, err := o.ListEventsByCollectionWithContext(context.Background(), ListEventsByCollectionParams{
+		CollectionSlug: "doodles-official",
+		After:          1700000000,
+		EventType:      []string{"sale", "transfer"},
+		Next:           "abc",
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "def", page.Next)
+	assert.Len(t, page.AssetEvents, 1)
+	e := page.AssetEvents[0]
+	assert.Equal(t, "0xfeed", e.Transaction)
+	assert.Equal(t, "7", e.NFT.Identifier)
+	assert.Equal(t, int64(18), e.Payment.Decimals)
+}
+
+func TestGetCollectionOffersV2(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/offers/collection/doodles-official", r.URL.Path)
+		w.Write([]byte(`{"offers":[{"order_hash":"0xabc","chain":"ethereum","criteria":{"collection":{"slug":"doodles-official"}},
+			"protocol_address":"0x0000000000000068f116a894984e2db1123eb395",
+			"price":{"currency":"WETH","decimals":18,"value":"1000"}}]}`))
+	})
+
+	offers, err := o.GetCollectionOffersV2(GetCollectionOffersV2Params{CollectionSlug: "doodles-official"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, offers.Offers, 1)
+	assert.Equal(t, "doodles-official", offers.Offers[0].Criteria.Collection.Slug)
+	assert.Equal(t, "1000", offers.Offers[0].Price.Value)
+}